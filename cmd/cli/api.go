@@ -49,22 +49,42 @@ func NewCmdServer() *cobra.Command {
 				return err
 			}
 
+			grpcServer, err := di.ProvideGRPCServer()
+			if err != nil {
+				return err
+			}
+
 			sigCh := make(chan os.Signal, 1)
 			signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+			// A gRPC server that fails to start brings the REST server down
+			// with it, so the process never serves only one of the APIs.
+			grpcErrCh := make(chan error, 1)
 			wg := sync.WaitGroup{}
 			wg.Add(1)
 			go func() {
-				<-sigCh
+				select {
+				case <-sigCh:
+				case <-grpcErrCh:
+				}
 				_ = server.Stop(cmd.Context())
+				_ = grpcServer.Stop(cmd.Context())
 				wg.Done()
 			}()
 
+			var grpcErr error
+			go func() {
+				if err := grpcServer.Start(cmd.Context()); err != nil {
+					grpcErr = err
+					grpcErrCh <- err
+				}
+			}()
+
 			if err = server.Start(cmd.Context()); err != nil && !errors.Is(err, http.ErrServerClosed) {
 				return err
 			}
 
 			wg.Wait()
-			return nil
+			return grpcErr
 		},
 	}
 	return cmd
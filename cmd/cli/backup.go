@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/openfort-xyz/shield/di"
+	"github.com/openfort-xyz/shield/internal/applications/backupapp"
+	"github.com/openfort-xyz/shield/pkg/random"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdBackup() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Backup operations",
+	}
+	cmd.AddCommand(NewCmdBackupKeygen())
+	cmd.AddCommand(NewCmdBackupCreate())
+	cmd.AddCommand(NewCmdBackupVerify())
+	cmd.AddCommand(NewCmdBackupRestore())
+	return cmd
+}
+
+func NewCmdBackupKeygen() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "keygen",
+		Short:   "Generate a backup key",
+		Long:    "Generate a random key to encrypt backups with. Keep it apart from the backups, neither verify nor restore work without it.",
+		Example: "shield backup keygen",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			key, err := random.GenerateRandomString(backupapp.KeySize)
+			if err != nil {
+				return err
+			}
+
+			cmd.Println(key)
+			return nil
+		},
+	}
+	return cmd
+}
+
+func NewCmdBackupCreate() *cobra.Command {
+	var out, key string
+
+	cmd := &cobra.Command{
+		Use:     "create",
+		Short:   "Back up the database",
+		Long:    "Write every Shield table, read in one consistent snapshot, to an encrypted file. Each table is checksummed, the checksums printed are checked again by verify and restore. Shares keep their encryption and secrets encrypted with this deployment's keys stay encrypted, a restored database needs the same PROJECT_SECRET_ENCRYPTION_KEY and PROVIDER_SECRET_ENCRYPTION_KEY.",
+		Example: "shield backup create --out shield.backup",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			app, err := di.ProvideBackupApplication()
+			if err != nil {
+				return err
+			}
+
+			// The backup only shows up under its name once it's complete
+			file, err := os.CreateTemp(filepath.Dir(out), filepath.Base(out)+".*.partial")
+			if err != nil {
+				return err
+			}
+			defer os.Remove(file.Name())
+			defer file.Close()
+
+			manifest, err := app.Create(cmd.Context(), file, key)
+			if err != nil {
+				return err
+			}
+
+			err = file.Close()
+			if err != nil {
+				return err
+			}
+
+			err = os.Rename(file.Name(), out)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("backed up schema version %d at %s\n", manifest.SchemaVersion, manifest.CreatedAt.Format(time.RFC3339))
+			return printManifest(cmd.OutOrStdout(), manifest, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&out, "out", "", "File the backup is written to")
+	cmd.Flags().StringVar(&key, "key", os.Getenv("SHIELD_BACKUP_KEY"), "Backup key from shield backup keygen")
+	_ = cmd.MarkFlagRequired("out")
+	return cmd
+}
+
+func NewCmdBackupVerify() *cobra.Command {
+	var in, key string
+	var parts map[string]string
+	var sample int
+
+	cmd := &cobra.Command{
+		Use:     "verify",
+		Short:   "Verify a backup can be restored",
+		Long:    "Read the whole backup, checking every table against its checksum, then decrypt a sample of the project entropy shares of each project whose encryption part is given, with the key rebuilt from that part and the one in the backup. Fails when any sampled share doesn't decrypt. Nothing is written to the database.",
+		Example: "shield backup verify --in shield.backup --project-part $PROJECT_ID=$ENCRYPTION_PART",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			file, err := os.Open(in)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			app, err := di.ProvideBackupApplication()
+			if err != nil {
+				return err
+			}
+
+			report, err := app.Verify(cmd.Context(), file, key, parts, sample)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("backup of schema version %d taken at %s is intact\n", report.Manifest.SchemaVersion, report.Manifest.CreatedAt.Format(time.RFC3339))
+			err = printManifest(cmd.OutOrStdout(), report.Manifest, nil)
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "\nPROJECT\tSHARES\tSAMPLED\tDECRYPTED\tERROR")
+			for _, p := range report.Projects {
+				_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%s\n", p.ProjectID, p.Shares, p.Sampled, p.Decrypted, p.Error)
+			}
+			err = w.Flush()
+			if err != nil {
+				return err
+			}
+
+			if report.Failed() {
+				return errors.New("sampled shares failed to decrypt")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&in, "in", "", "Backup written by shield backup create")
+	cmd.Flags().StringVar(&key, "key", os.Getenv("SHIELD_BACKUP_KEY"), "Backup key the backup was created with")
+	cmd.Flags().StringToStringVar(&parts, "project-part", nil, "Encryption part of a project as project_id=part, repeat for more projects")
+	cmd.Flags().IntVar(&sample, "sample", 20, "Project entropy shares to decrypt per project")
+	_ = cmd.MarkFlagRequired("in")
+	return cmd
+}
+
+func NewCmdBackupRestore() *cobra.Command {
+	var in, key string
+
+	cmd := &cobra.Command{
+		Use:     "restore",
+		Short:   "Restore a backup into an empty database",
+		Long:    "Load a backup into a database that was migrated to the backup's schema version and holds no data yet, in one transaction. The backup is checked as it's read, nothing is written unless all of it checks out.",
+		Example: "shield db migrate && shield backup restore --in shield.backup",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			file, err := os.Open(in)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			app, err := di.ProvideBackupApplication()
+			if err != nil {
+				return err
+			}
+
+			report, err := app.Restore(cmd.Context(), file, key)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("restored backup of schema version %d taken at %s\n", report.Manifest.SchemaVersion, report.Manifest.CreatedAt.Format(time.RFC3339))
+			return printManifest(cmd.OutOrStdout(), report.Manifest, report.Restored)
+		},
+	}
+
+	cmd.Flags().StringVar(&in, "in", "", "Backup written by shield backup create")
+	cmd.Flags().StringVar(&key, "key", os.Getenv("SHIELD_BACKUP_KEY"), "Backup key the backup was created with")
+	_ = cmd.MarkFlagRequired("in")
+	return cmd
+}
+
+// printManifest lists the tables of a backup, with the rows restored of each
+// when restored isn't nil.
+func printManifest(out io.Writer, manifest *backupapp.Manifest, restored map[string]int64) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if restored != nil {
+		_, _ = fmt.Fprintln(w, "TABLE\tROWS\tRESTORED\tSHA256")
+	} else {
+		_, _ = fmt.Fprintln(w, "TABLE\tROWS\tSHA256")
+	}
+
+	for _, t := range manifest.Tables {
+		if restored != nil {
+			_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%s\n", t.Name, t.Rows, restored[t.Name], t.Digest)
+		} else {
+			_, _ = fmt.Fprintf(w, "%s\t%d\t%s\n", t.Name, t.Rows, t.Digest)
+		}
+	}
+
+	return w.Flush()
+}
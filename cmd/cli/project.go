@@ -0,0 +1,301 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/openfort-xyz/shield/di"
+	"github.com/openfort-xyz/shield/internal/applications/projectapp"
+	"github.com/openfort-xyz/shield/pkg/cypher"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdProject() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "project",
+		Short: "Project operations",
+	}
+	cmd.AddCommand(NewCmdProjectCreate())
+	cmd.AddCommand(NewCmdProjectList())
+	cmd.AddCommand(NewCmdProjectDisable())
+	cmd.AddCommand(NewCmdProjectInvite())
+	cmd.AddCommand(NewCmdProjectKeygen())
+	cmd.AddCommand(NewCmdProjectExport())
+	cmd.AddCommand(NewCmdProjectImport())
+	return cmd
+}
+
+func NewCmdProjectCreate() *cobra.Command {
+	var name string
+	var enable2fa, generateEncryptionKey bool
+
+	cmd := &cobra.Command{
+		Use:     "create",
+		Short:   "Create a project",
+		Long:    "Create a project directly in the database, whatever the registration mode of the server. The API key and secret printed are not shown again, nor is the encryption part if one is generated.",
+		Example: "shield project create --name acme --encryption-key",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			app, err := di.ProvideProjectApplication()
+			if err != nil {
+				return err
+			}
+
+			var opts []projectapp.ProjectOption
+			if generateEncryptionKey {
+				opts = append(opts, projectapp.WithEncryptionKey())
+			}
+
+			proj, err := app.CreateProject(cmd.Context(), name, enable2fa, opts...)
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintf(w, "ID\t%s\n", proj.ID)
+			_, _ = fmt.Fprintf(w, "API KEY\t%s\n", proj.APIKey)
+			_, _ = fmt.Fprintf(w, "API SECRET\t%s\n", proj.APISecret)
+			if proj.EncryptionPart != "" {
+				_, _ = fmt.Fprintf(w, "ENCRYPTION PART\t%s\n", proj.EncryptionPart)
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Name of the project")
+	cmd.Flags().BoolVar(&enable2fa, "2fa", false, "Enable 2FA for the project")
+	cmd.Flags().BoolVar(&generateEncryptionKey, "encryption-key", false, "Generate the project's encryption key and print its external part")
+	_ = cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func NewCmdProjectList() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List projects",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			app, err := di.ProvideProjectApplication()
+			if err != nil {
+				return err
+			}
+
+			projs, err := app.ListProjects(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "ID\tNAME\tAPI KEY\tSTATUS\tCREATED\tDISABLED")
+			for _, proj := range projs {
+				disabled := "-"
+				if proj.DisabledAt != nil {
+					disabled = proj.DisabledAt.UTC().Format(time.RFC3339)
+				}
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", proj.ID, proj.Name, proj.APIKey, proj.Status, proj.CreatedAt.UTC().Format(time.RFC3339), disabled)
+			}
+			return w.Flush()
+		},
+	}
+
+	return cmd
+}
+
+func NewCmdProjectDisable() *cobra.Command {
+	var projectID string
+
+	cmd := &cobra.Command{
+		Use:     "disable",
+		Short:   "Disable a project",
+		Long:    "Stop the project's API key from authenticating. The project's data is kept, it can still be exported or deleted.",
+		Example: "shield project disable --project $PROJECT_ID",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			app, err := di.ProvideProjectApplication()
+			if err != nil {
+				return err
+			}
+
+			err = app.DisableProject(cmd.Context(), projectID)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("disabled project %s\n", projectID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&projectID, "project", "", "ID of the project to disable")
+	_ = cmd.MarkFlagRequired("project")
+	return cmd
+}
+
+func NewCmdProjectInvite() *cobra.Command {
+	var expires time.Duration
+
+	cmd := &cobra.Command{
+		Use:     "invite",
+		Short:   "Issue a registration invite",
+		Long:    "Issue a single-use invite to register a project while the server runs with REGISTRATION_MODE=invite. The token printed is sent in the X-Invite-Token header of the registration and is not shown again.",
+		Example: "shield project invite --expires 48h",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			app, err := di.ProvideProjectApplication()
+			if err != nil {
+				return err
+			}
+
+			token, invite, err := app.CreateInvite(cmd.Context(), expires)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("invite %s expires at %s\n", invite.ID, invite.ExpiresAt.UTC().Format(time.RFC3339))
+			cmd.Println(token)
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVar(&expires, "expires", projectapp.DefaultInviteTTL, "How long the invite stays usable")
+	return cmd
+}
+
+func NewCmdProjectKeygen() *cobra.Command {
+	var out string
+
+	cmd := &cobra.Command{
+		Use:     "keygen",
+		Short:   "Generate a key pair to receive projects with",
+		Long:    "Generate the key pair a destination deployment receives projects with. The private key is written to --out and stays with the destination, the public key printed is handed to shield project export on the source.",
+		Example: "shield project keygen --out transfer.key",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			privateKey, publicKey, err := cypher.GenerateSealingKeyPair()
+			if err != nil {
+				return err
+			}
+
+			file, err := os.OpenFile(out, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			_, err = fmt.Fprintln(file, privateKey)
+			if err != nil {
+				return err
+			}
+
+			cmd.Println(publicKey)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&out, "out", "", "File the private key is written to, it must not exist yet")
+	_ = cmd.MarkFlagRequired("out")
+	return cmd
+}
+
+func NewCmdProjectExport() *cobra.Command {
+	var projectID, recipient, out string
+
+	cmd := &cobra.Command{
+		Use:     "export",
+		Short:   "Export a project for another deployment",
+		Long:    "Export the project with its providers, users, keychains, shares and encryption parts, sealed to the public key of the destination deployment. Secrets encrypted with this deployment's keys are decrypted inside the sealed archive, the destination encrypts them with its own. The digest printed is reported again by the import.",
+		Example: "shield project export --project $PROJECT_ID --recipient $DESTINATION_PUBLIC_KEY --out project.sealed",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			app, err := di.ProvideTransferApplication()
+			if err != nil {
+				return err
+			}
+
+			sealed, digest, err := app.Export(cmd.Context(), projectID, recipient)
+			if err != nil {
+				return err
+			}
+
+			err = os.WriteFile(out, sealed, 0o600)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("exported project %s, digest %s\n", projectID, digest)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&projectID, "project", "", "ID of the project to export")
+	cmd.Flags().StringVar(&recipient, "recipient", "", "Public key printed by shield project keygen on the destination")
+	cmd.Flags().StringVar(&out, "out", "", "File the sealed archive is written to")
+	_ = cmd.MarkFlagRequired("project")
+	_ = cmd.MarkFlagRequired("recipient")
+	_ = cmd.MarkFlagRequired("out")
+	return cmd
+}
+
+func NewCmdProjectImport() *cobra.Command {
+	var in, keyFile string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:     "import",
+		Short:   "Import a project exported by another deployment",
+		Long:    "Open a sealed archive with the private key from shield project keygen, verify it and write the project into this deployment in one transaction. Rows already there are kept, so the import can be run again. With --dry-run nothing is written and the report tells what would be.",
+		Example: "shield project import --in project.sealed --key transfer.key --dry-run",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			sealed, err := os.ReadFile(in)
+			if err != nil {
+				return err
+			}
+
+			privateKey, err := os.ReadFile(keyFile)
+			if err != nil {
+				return err
+			}
+
+			app, err := di.ProvideTransferApplication()
+			if err != nil {
+				return err
+			}
+
+			report, err := app.Import(cmd.Context(), sealed, strings.TrimSpace(string(privateKey)), dryRun)
+			if err != nil {
+				return err
+			}
+
+			verb := "imported"
+			if report.DryRun {
+				verb = "would import"
+			}
+			cmd.Printf("%s project %s, digest %s\n", verb, report.ProjectID, report.Digest)
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "TABLE\tINSERTED\tEXISTING")
+			names := make([]string, 0, len(report.Tables))
+			for name := range report.Tables {
+				names = append(names, name)
+			}
+			slices.Sort(names)
+			for _, name := range names {
+				rows := report.Tables[name]
+				_, _ = fmt.Fprintf(w, "%s\t%d\t%d\n", name, rows.Inserted, rows.Existing)
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().StringVar(&in, "in", "", "Sealed archive written by shield project export")
+	cmd.Flags().StringVar(&keyFile, "key", "", "Private key file written by shield project keygen")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report what would be imported without writing anything")
+	_ = cmd.MarkFlagRequired("in")
+	_ = cmd.MarkFlagRequired("key")
+	return cmd
+}
@@ -10,6 +10,9 @@ func NewCmdRoot() *cobra.Command {
 
 	cmd.AddCommand(NewCmdDB())
 	cmd.AddCommand(NewCmdServer())
+	cmd.AddCommand(NewCmdProject())
+	cmd.AddCommand(NewCmdShares())
+	cmd.AddCommand(NewCmdBackup())
 
 	return cmd
 }
@@ -0,0 +1,424 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/api"
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/authmdw"
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/sharehdl"
+	"github.com/openfort-xyz/shield/internal/applications/shareapp"
+	"github.com/openfort-xyz/shield/pkg/random"
+	"github.com/spf13/cobra"
+)
+
+// checkpointInterval is how many lines are handled between checkpoints.
+const checkpointInterval = 500
+
+type migrationFlags struct {
+	url               string
+	apiKey            string
+	apiSecret         string
+	encryptionPart    string
+	encryptionSession string
+	transferKey       string
+	checkpoint        string
+}
+
+func (f *migrationFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.url, "url", envOr("SHIELD_URL", "http://localhost:8080"), "Shield base URL")
+	cmd.Flags().StringVar(&f.apiKey, "api-key", os.Getenv("SHIELD_API_KEY"), "Project API key")
+	cmd.Flags().StringVar(&f.apiSecret, "api-secret", os.Getenv("SHIELD_API_SECRET"), "Project API secret")
+	cmd.Flags().StringVar(&f.encryptionPart, "encryption-part", os.Getenv("SHIELD_ENCRYPTION_PART"), "Project encryption part, needed to move project entropy shares")
+	cmd.Flags().StringVar(&f.encryptionSession, "encryption-session", "", "Encryption session, instead of the encryption part")
+	cmd.Flags().StringVar(&f.transferKey, "transfer-key", os.Getenv("SHIELD_TRANSFER_KEY"), "Key project entropy shares are re-encrypted to, see shield shares transfer-key")
+	cmd.Flags().StringVar(&f.checkpoint, "checkpoint", "", "File to keep the progress in, an interrupted run resumes from it")
+}
+
+func (f *migrationFlags) newRequest(method, path string, query url.Values, body io.Reader) (*http.Request, error) {
+	endpoint := strings.TrimRight(f.url, "/") + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set(authmdw.APIKeyHeader, f.apiKey)
+	req.Header.Set(authmdw.APISecretHeader, f.apiSecret)
+	if f.encryptionPart != "" {
+		req.Header.Set(sharehdl.EncryptionPartHeader, f.encryptionPart)
+	}
+	if f.encryptionSession != "" {
+		req.Header.Set(sharehdl.EncryptionSessionHeader, f.encryptionSession)
+	}
+	if f.transferKey != "" {
+		req.Header.Set(sharehdl.TransferKeyHeader, f.transferKey)
+	}
+
+	return req, nil
+}
+
+func NewCmdShares() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "shares",
+		Short: "Share migration between Shield instances",
+	}
+	cmd.AddCommand(NewCmdSharesExport())
+	cmd.AddCommand(NewCmdSharesImport())
+	cmd.AddCommand(NewCmdSharesTransferKey())
+	return cmd
+}
+
+func NewCmdSharesExport() *cobra.Command {
+	var flags migrationFlags
+	var out string
+
+	cmd := &cobra.Command{
+		Use:     "export",
+		Short:   "Export the shares of a project",
+		Long:    "Stream every share of the project to an NDJSON file. With a checkpoint file an interrupted export picks up after the last share written, lines that failed to export are kept in the file and reported on stderr.",
+		Example: "shield shares export --out shares.ndjson --checkpoint shares.export.checkpoint --transfer-key $KEY --encryption-part $PART",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			after, err := readCheckpoint(flags.checkpoint)
+			if err != nil {
+				return err
+			}
+
+			file, err := os.OpenFile(out, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			var exported, failed int
+			for {
+				// A stream that broke off ends like a complete one, so keep
+				// asking for what comes after the checkpoint until nothing does
+				n, errs, err := exportShares(&flags, file, &after)
+				exported += n
+				failed += errs
+				if err != nil {
+					return fmt.Errorf("export stopped after %d shares, run it again to resume: %w", exported, err)
+				}
+				if n == 0 {
+					break
+				}
+			}
+
+			cmd.Printf("exported %d shares, %d failed\n", exported, failed)
+			return nil
+		},
+	}
+
+	flags.register(cmd)
+	cmd.Flags().StringVar(&out, "out", "", "NDJSON file the shares are appended to")
+	_ = cmd.MarkFlagRequired("out")
+	return cmd
+}
+
+func exportShares(flags *migrationFlags, file *os.File, after *string) (int, int, error) {
+	query := url.Values{}
+	if *after != "" {
+		query.Set("after", *after)
+	}
+
+	req, err := flags.newRequest(http.MethodGet, "/shares/migration/bulk/export", query, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, 0, responseError(resp)
+	}
+
+	writer := bufio.NewWriter(file)
+	checkpoint := func() error {
+		err := writer.Flush()
+		if err != nil {
+			return err
+		}
+		err = file.Sync()
+		if err != nil {
+			return err
+		}
+		return writeCheckpoint(flags.checkpoint, *after)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), sharehdl.MaxMigrationLineSize)
+
+	var exported, failed int
+	for scanner.Scan() {
+		var line sharehdl.MigratedShare
+		err = json.Unmarshal(scanner.Bytes(), &line)
+		if err != nil {
+			return exported, failed, errors.Join(err, checkpoint())
+		}
+
+		_, err = writer.Write(append(scanner.Bytes(), '\n'))
+		if err != nil {
+			return exported, failed, err
+		}
+
+		if line.Error != nil {
+			failed++
+			fmt.Fprintf(os.Stderr, "share %s: %s (%s)\n", line.ID, line.Error.Message, line.Error.Code)
+		}
+
+		exported++
+		*after = line.ID
+		if exported%checkpointInterval == 0 {
+			err = checkpoint()
+			if err != nil {
+				return exported, failed, err
+			}
+		}
+	}
+
+	return exported, failed, errors.Join(scanner.Err(), checkpoint())
+}
+
+func NewCmdSharesImport() *cobra.Command {
+	var flags migrationFlags
+	var in, errorsOut string
+
+	cmd := &cobra.Command{
+		Use:     "import",
+		Short:   "Import exported shares into a project",
+		Long:    "Stream an NDJSON file from shield shares export into the project. With a checkpoint file an interrupted import picks up after the last line acknowledged, and shares imported before are skipped either way. Failed lines are reported on stderr, or written as NDJSON to --errors.",
+		Example: "shield shares import --in shares.ndjson --checkpoint shares.import.checkpoint --errors shares.errors.ndjson --transfer-key $KEY --encryption-part $PART",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			checkpoint, err := readCheckpoint(flags.checkpoint)
+			if err != nil {
+				return err
+			}
+			offset := 0
+			if checkpoint != "" {
+				offset, err = strconv.Atoi(checkpoint)
+				if err != nil {
+					return fmt.Errorf("invalid checkpoint %q: %w", checkpoint, err)
+				}
+			}
+
+			file, err := os.Open(in)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			report := func(result *sharehdl.ImportResult) error {
+				fmt.Fprintf(os.Stderr, "line %d share %s: %s (%s)\n", result.Line, result.ID, result.Error.Message, result.Error.Code)
+				return nil
+			}
+			if errorsOut != "" {
+				errFile, err := os.OpenFile(errorsOut, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+				if err != nil {
+					return err
+				}
+				defer errFile.Close()
+
+				encoder := json.NewEncoder(errFile)
+				report = func(result *sharehdl.ImportResult) error {
+					return encoder.Encode(result)
+				}
+			}
+
+			counts, err := importShares(&flags, file, offset, report)
+			cmd.Printf("imported %d shares, %d skipped, %d failed\n", counts[sharehdl.ImportStatusImported], counts[sharehdl.ImportStatusSkipped], counts[sharehdl.ImportStatusFailed])
+			if err != nil {
+				return fmt.Errorf("import stopped, run it again to resume: %w", err)
+			}
+			return nil
+		},
+	}
+
+	flags.register(cmd)
+	cmd.Flags().StringVar(&in, "in", "", "NDJSON file written by shield shares export")
+	cmd.Flags().StringVar(&errorsOut, "errors", "", "NDJSON file failed lines are appended to")
+	_ = cmd.MarkFlagRequired("in")
+	return cmd
+}
+
+func importShares(flags *migrationFlags, file *os.File, offset int, report func(*sharehdl.ImportResult) error) (map[sharehdl.ImportStatus]int, error) {
+	counts := make(map[sharehdl.ImportStatus]int)
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), sharehdl.MaxMigrationLineSize)
+	skipped := 0
+	for skipped < offset && scanner.Scan() {
+		skipped++
+	}
+	if err := scanner.Err(); err != nil {
+		return counts, err
+	}
+
+	// The lines are sent as they are read, the results come back while the
+	// rest of the file is still on its way. Blank lines are sent too, the
+	// server numbers the lines the same way this file does.
+	body, pipe := io.Pipe()
+	lastSent := make(chan int, 1)
+	go func() {
+		line, last := offset, offset
+		var err error
+		for scanner.Scan() {
+			line++
+			_, err = pipe.Write(append(scanner.Bytes(), '\n'))
+			if err != nil {
+				break
+			}
+			if len(strings.TrimSpace(scanner.Text())) > 0 {
+				last = line
+			}
+		}
+		if err == nil {
+			err = scanner.Err()
+		}
+		lastSent <- last
+		_ = pipe.CloseWithError(err)
+	}()
+	defer body.Close()
+
+	req, err := flags.newRequest(http.MethodPost, "/shares/migration/bulk/import", nil, body)
+	if err != nil {
+		return counts, err
+	}
+	req.Header.Set("Content-Type", sharehdl.NDJSONContentType)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return counts, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return counts, responseError(resp)
+	}
+
+	acknowledged, handled := offset, 0
+	results := bufio.NewScanner(resp.Body)
+	for results.Scan() {
+		var result sharehdl.ImportResult
+		err = json.Unmarshal(results.Bytes(), &result)
+		if err != nil {
+			return counts, errors.Join(err, writeCheckpoint(flags.checkpoint, strconv.Itoa(acknowledged)))
+		}
+
+		result.Line += offset
+		counts[result.Status]++
+		if result.Status == sharehdl.ImportStatusFailed {
+			err = report(&result)
+			if err != nil {
+				return counts, err
+			}
+		}
+
+		acknowledged = result.Line
+		handled++
+		if handled%checkpointInterval == 0 {
+			err = writeCheckpoint(flags.checkpoint, strconv.Itoa(acknowledged))
+			if err != nil {
+				return counts, err
+			}
+		}
+	}
+
+	err = errors.Join(results.Err(), writeCheckpoint(flags.checkpoint, strconv.Itoa(acknowledged)))
+	if err != nil {
+		return counts, err
+	}
+
+	// Unblocks the sender when the server stopped reading early
+	_ = body.Close()
+	if last := <-lastSent; acknowledged < last {
+		return counts, fmt.Errorf("lines up to %d were acknowledged out of %d", acknowledged, last)
+	}
+
+	return counts, nil
+}
+
+func NewCmdSharesTransferKey() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "transfer-key",
+		Short:   "Generate a transfer key",
+		Long:    "Generate a random key to move project entropy shares with. Pass the same key to the export and to the import, and discard it once the import is done.",
+		Example: "shield shares transfer-key",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			key, err := random.GenerateRandomString(shareapp.TransferKeySize)
+			if err != nil {
+				return err
+			}
+
+			cmd.Println(key)
+			return nil
+		},
+	}
+	return cmd
+}
+
+func responseError(resp *http.Response) error {
+	var apiErr api.Error
+	err := json.NewDecoder(resp.Body).Decode(&apiErr)
+	if err != nil || apiErr.Message == "" {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return fmt.Errorf("%s: %s (%s)", resp.Status, apiErr.Message, apiErr.Code)
+}
+
+func readCheckpoint(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return strings.TrimSpace(string(content)), nil
+}
+
+// writeCheckpoint replaces the checkpoint file in one rename, a crash never
+// leaves it half written.
+func writeCheckpoint(path, value string) error {
+	if path == "" {
+		return nil
+	}
+
+	tmp := path + ".tmp"
+	err := os.WriteFile(tmp, []byte(value+"\n"), 0o600)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -4,6 +4,8 @@ import (
 	"sync"
 
 	"github.com/openfort-xyz/shield/internal/adapters/repositories/sql"
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/sql/invalidationrepo"
+	"github.com/openfort-xyz/shield/internal/core/ports/repositories"
 )
 
 // ProvideSQL returns a process-wide singleton SQL client. It is memoized so the
@@ -29,3 +31,34 @@ var (
 	sqlClient *sql.Client
 	sqlErr    error
 )
+
+// ProvideInvalidationRepository is memoized for the same reason as ProvideSQL,
+// and because publishers only reach subscribers in their own process through
+// the instance they share.
+func ProvideInvalidationRepository() (repositories.InvalidationRepository, error) {
+	invalidationOnce.Do(func() {
+		cfg, err := invalidationrepo.GetConfigFromEnv()
+		if err != nil {
+			invalidationErr = err
+			return
+		}
+		if cfg.Bus == invalidationrepo.BusLocal {
+			invalidationRepo = invalidationrepo.NewLocal()
+			return
+		}
+
+		client, err := ProvideSQL()
+		if err != nil {
+			invalidationErr = err
+			return
+		}
+		invalidationRepo = invalidationrepo.New(client)
+	})
+	return invalidationRepo, invalidationErr
+}
+
+var (
+	invalidationOnce sync.Once
+	invalidationRepo repositories.InvalidationRepository
+	invalidationErr  error
+)
@@ -9,11 +9,18 @@ import (
 	"github.com/google/wire"
 	"github.com/openfort-xyz/shield/internal/adapters/authenticators"
 	"github.com/openfort-xyz/shield/internal/adapters/authenticators/identity"
+	intrspidty "github.com/openfort-xyz/shield/internal/adapters/authenticators/identity/introspection_identity"
 	ofidty "github.com/openfort-xyz/shield/internal/adapters/authenticators/identity/openfort_identity"
+	projauth "github.com/openfort-xyz/shield/internal/adapters/authenticators/project_authenticator"
 	"github.com/openfort-xyz/shield/internal/adapters/encryption"
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/grpc"
 	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest"
 	"github.com/openfort-xyz/shield/internal/adapters/repositories/bunt"
 	"github.com/openfort-xyz/shield/internal/adapters/repositories/bunt/encryptionpartsrepo"
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/bunt/noncerepo"
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/sql/archiverepo"
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/sql/backuprepo"
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/sql/idempotencyrepo"
 	"github.com/openfort-xyz/shield/internal/adapters/repositories/sql/keychainrepo"
 	"github.com/openfort-xyz/shield/internal/adapters/repositories/sql/notificationsrepo"
 	"github.com/openfort-xyz/shield/internal/adapters/repositories/sql/projectrepo"
@@ -21,11 +28,13 @@ import (
 	"github.com/openfort-xyz/shield/internal/adapters/repositories/sql/sharerepo"
 	"github.com/openfort-xyz/shield/internal/adapters/repositories/sql/usercontactrepo"
 	"github.com/openfort-xyz/shield/internal/adapters/repositories/sql/userrepo"
+	"github.com/openfort-xyz/shield/internal/applications/backupapp"
 	"github.com/openfort-xyz/shield/internal/applications/healthzapp"
 	"github.com/openfort-xyz/shield/internal/applications/notificationsapp"
 	"github.com/openfort-xyz/shield/internal/applications/projectapp"
 	"github.com/openfort-xyz/shield/internal/applications/shamirjob"
 	"github.com/openfort-xyz/shield/internal/applications/shareapp"
+	"github.com/openfort-xyz/shield/internal/applications/transferapp"
 	"github.com/openfort-xyz/shield/internal/core/ports/factories"
 	"github.com/openfort-xyz/shield/internal/core/ports/repositories"
 	"github.com/openfort-xyz/shield/internal/core/ports/services"
@@ -56,6 +65,26 @@ func ProvideSQLUserRepository() (r repositories.UserRepository, err error) {
 func ProvideSQLProjectRepository() (r repositories.ProjectRepository, err error) {
 	wire.Build(
 		projectrepo.New,
+		projectrepo.GetConfigFromEnv,
+		ProvideSQL,
+	)
+
+	return
+}
+
+func ProvideSQLArchiveRepository() (r repositories.ArchiveRepository, err error) {
+	wire.Build(
+		archiverepo.New,
+		archiverepo.GetConfigFromEnv,
+		ProvideSQL,
+	)
+
+	return
+}
+
+func ProvideSQLBackupRepository() (r repositories.BackupRepository, err error) {
+	wire.Build(
+		backuprepo.New,
 		ProvideSQL,
 	)
 
@@ -65,6 +94,7 @@ func ProvideSQLProjectRepository() (r repositories.ProjectRepository, err error)
 func ProvideSQLProviderRepository() (r repositories.ProviderRepository, err error) {
 	wire.Build(
 		providerrepo.New,
+		providerrepo.GetConfigFromEnv,
 		ProvideSQL,
 	)
 
@@ -107,6 +137,15 @@ func ProvideSQLUserContactRepository() (r repositories.UserContactRepository, er
 	return
 }
 
+func ProvideSQLIdempotencyRepository() (r repositories.IdempotencyRepository, err error) {
+	wire.Build(
+		idempotencyrepo.New,
+		ProvideSQL,
+	)
+
+	return
+}
+
 func ProvideInMemoryEncryptionPartsRepository() (r repositories.EncryptionPartsRepository, err error) {
 	wire.Build(
 		encryptionpartsrepo.New,
@@ -116,15 +155,21 @@ func ProvideInMemoryEncryptionPartsRepository() (r repositories.EncryptionPartsR
 	return
 }
 
-func ProvideProjectCacheTTL() time.Duration {
-	return 60 * time.Second
+func ProvideInMemoryNonceRepository() (r repositories.NonceRepository, err error) {
+	wire.Build(
+		noncerepo.New,
+		ProvideBuntDB,
+	)
+
+	return
 }
 
 func ProvideProjectService() (s services.ProjectService, err error) {
 	wire.Build(
 		projectsvc.New,
 		ProvideSQLProjectRepository,
-		ProvideProjectCacheTTL,
+		projectsvc.GetConfigFromEnv,
+		ProvideInvalidationRepository,
 	)
 
 	return
@@ -209,6 +254,10 @@ func ProvideProjectApplication() (a *projectapp.ProjectApplication, err error) {
 		ProvideOTPService,
 		ProvideNotificationService,
 		ProvideProjectRateLimiter,
+		ProvideInvalidationRepository,
+		ProvideSQLArchiveRepository,
+		ProvideSQLUserRepository,
+		ProvideSQLKeychainRepository,
 	)
 
 	return
@@ -217,8 +266,11 @@ func ProvideProjectApplication() (a *projectapp.ProjectApplication, err error) {
 func ProvideAuthenticationFactory() (f factories.AuthenticationFactory, err error) {
 	wire.Build(
 		authenticators.NewAuthenticatorFactory,
+		projauth.GetConfigFromEnv,
 		ProvideUserService,
 		ProvideSQLProjectRepository,
+		ProvideInMemoryNonceRepository,
+		ProvideInvalidationRepository,
 	)
 
 	return
@@ -228,12 +280,32 @@ func ProvideIdentityFactory() (f factories.IdentityFactory, err error) {
 	wire.Build(
 		identity.NewIdentityFactory,
 		ofidty.GetConfigFromEnv,
+		intrspidty.GetConfigFromEnv,
 		ProvideSQLProviderRepository,
 	)
 
 	return
 }
 
+func ProvideTransferApplication() (a *transferapp.Application, err error) {
+	wire.Build(
+		transferapp.New,
+		ProvideSQLArchiveRepository,
+	)
+
+	return
+}
+
+func ProvideBackupApplication() (a *backupapp.Application, err error) {
+	wire.Build(
+		backupapp.New,
+		ProvideSQLBackupRepository,
+		ProvideEncryptionFactory,
+	)
+
+	return
+}
+
 func ProvideHealthzApplication() (a *healthzapp.Application, err error) {
 	wire.Build(
 		ProvideSQL,
@@ -323,6 +395,21 @@ func ProvideRESTServer() (s *rest.Server, err error) {
 		ProvideAuthenticationFactory,
 		ProvideIdentityFactory,
 		ProvideProjectService,
+		ProvideSQLIdempotencyRepository,
+	)
+
+	return
+}
+
+func ProvideGRPCServer() (s *grpc.Server, err error) {
+	wire.Build(
+		grpc.New,
+		grpc.GetConfigFromEnv,
+		ProvideShareApplication,
+		ProvideProjectApplication,
+		ProvideAuthenticationFactory,
+		ProvideIdentityFactory,
+		ProvideProjectService,
 	)
 
 	return
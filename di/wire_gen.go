@@ -9,11 +9,18 @@ package di
 import (
 	"github.com/openfort-xyz/shield/internal/adapters/authenticators"
 	"github.com/openfort-xyz/shield/internal/adapters/authenticators/identity"
+	"github.com/openfort-xyz/shield/internal/adapters/authenticators/identity/introspection_identity"
 	"github.com/openfort-xyz/shield/internal/adapters/authenticators/identity/openfort_identity"
+	"github.com/openfort-xyz/shield/internal/adapters/authenticators/project_authenticator"
 	"github.com/openfort-xyz/shield/internal/adapters/encryption"
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/grpc"
 	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest"
 	"github.com/openfort-xyz/shield/internal/adapters/repositories/bunt"
 	"github.com/openfort-xyz/shield/internal/adapters/repositories/bunt/encryptionpartsrepo"
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/bunt/noncerepo"
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/sql/archiverepo"
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/sql/backuprepo"
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/sql/idempotencyrepo"
 	"github.com/openfort-xyz/shield/internal/adapters/repositories/sql/keychainrepo"
 	"github.com/openfort-xyz/shield/internal/adapters/repositories/sql/notificationsrepo"
 	"github.com/openfort-xyz/shield/internal/adapters/repositories/sql/projectrepo"
@@ -21,11 +28,13 @@ import (
 	"github.com/openfort-xyz/shield/internal/adapters/repositories/sql/sharerepo"
 	"github.com/openfort-xyz/shield/internal/adapters/repositories/sql/usercontactrepo"
 	"github.com/openfort-xyz/shield/internal/adapters/repositories/sql/userrepo"
+	"github.com/openfort-xyz/shield/internal/applications/backupapp"
 	"github.com/openfort-xyz/shield/internal/applications/healthzapp"
 	"github.com/openfort-xyz/shield/internal/applications/notificationsapp"
 	"github.com/openfort-xyz/shield/internal/applications/projectapp"
 	"github.com/openfort-xyz/shield/internal/applications/shamirjob"
 	"github.com/openfort-xyz/shield/internal/applications/shareapp"
+	"github.com/openfort-xyz/shield/internal/applications/transferapp"
 	"github.com/openfort-xyz/shield/internal/core/ports/factories"
 	"github.com/openfort-xyz/shield/internal/core/ports/repositories"
 	"github.com/openfort-xyz/shield/internal/core/ports/services"
@@ -61,16 +70,46 @@ func ProvideSQLProjectRepository() (repositories.ProjectRepository, error) {
 	if err != nil {
 		return nil, err
 	}
-	projectRepository := projectrepo.New(client)
+	config, err := projectrepo.GetConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	projectRepository := projectrepo.New(client, config)
 	return projectRepository, nil
 }
 
+func ProvideSQLArchiveRepository() (repositories.ArchiveRepository, error) {
+	client, err := ProvideSQL()
+	if err != nil {
+		return nil, err
+	}
+	config, err := archiverepo.GetConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	archiveRepository := archiverepo.New(client, config)
+	return archiveRepository, nil
+}
+
+func ProvideSQLBackupRepository() (repositories.BackupRepository, error) {
+	client, err := ProvideSQL()
+	if err != nil {
+		return nil, err
+	}
+	backupRepository := backuprepo.New(client)
+	return backupRepository, nil
+}
+
 func ProvideSQLProviderRepository() (repositories.ProviderRepository, error) {
 	client, err := ProvideSQL()
 	if err != nil {
 		return nil, err
 	}
-	providerRepository := providerrepo.New(client)
+	config, err := providerrepo.GetConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	providerRepository := providerrepo.New(client, config)
 	return providerRepository, nil
 }
 
@@ -110,6 +149,15 @@ func ProvideSQLUserContactRepository() (repositories.UserContactRepository, erro
 	return userContactRepository, nil
 }
 
+func ProvideSQLIdempotencyRepository() (repositories.IdempotencyRepository, error) {
+	client, err := ProvideSQL()
+	if err != nil {
+		return nil, err
+	}
+	idempotencyRepository := idempotencyrepo.New(client)
+	return idempotencyRepository, nil
+}
+
 func ProvideInMemoryEncryptionPartsRepository() (repositories.EncryptionPartsRepository, error) {
 	client, err := ProvideBuntDB()
 	if err != nil {
@@ -119,13 +167,29 @@ func ProvideInMemoryEncryptionPartsRepository() (repositories.EncryptionPartsRep
 	return encryptionPartsRepository, nil
 }
 
+func ProvideInMemoryNonceRepository() (repositories.NonceRepository, error) {
+	client, err := ProvideBuntDB()
+	if err != nil {
+		return nil, err
+	}
+	nonceRepository := noncerepo.New(client)
+	return nonceRepository, nil
+}
+
 func ProvideProjectService() (services.ProjectService, error) {
 	projectRepository, err := ProvideSQLProjectRepository()
 	if err != nil {
 		return nil, err
 	}
-	duration := ProvideProjectCacheTTL()
-	projectService := projectsvc.New(projectRepository, duration)
+	config, err := projectsvc.GetConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	invalidationRepository, err := ProvideInvalidationRepository()
+	if err != nil {
+		return nil, err
+	}
+	projectService := projectsvc.New(projectRepository, config, invalidationRepository)
 	return projectService, nil
 }
 
@@ -272,11 +336,31 @@ func ProvideProjectApplication() (*projectapp.ProjectApplication, error) {
 	if err != nil {
 		return nil, err
 	}
-	projectApplication := projectapp.New(projectService, projectRepository, providerService, providerRepository, shareRepository, notificationsRepository, userContactRepository, encryptionFactory, encryptionPartsRepository, inMemoryOTPService, notificationsService, requestTracker)
+	invalidationRepository, err := ProvideInvalidationRepository()
+	if err != nil {
+		return nil, err
+	}
+	archiveRepository, err := ProvideSQLArchiveRepository()
+	if err != nil {
+		return nil, err
+	}
+	userRepository, err := ProvideSQLUserRepository()
+	if err != nil {
+		return nil, err
+	}
+	keychainRepository, err := ProvideSQLKeychainRepository()
+	if err != nil {
+		return nil, err
+	}
+	projectApplication := projectapp.New(projectService, projectRepository, providerService, providerRepository, shareRepository, notificationsRepository, userContactRepository, encryptionFactory, encryptionPartsRepository, inMemoryOTPService, notificationsService, requestTracker, invalidationRepository, archiveRepository, userRepository, keychainRepository)
 	return projectApplication, nil
 }
 
 func ProvideAuthenticationFactory() (factories.AuthenticationFactory, error) {
+	config, err := projauth.GetConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
 	projectRepository, err := ProvideSQLProjectRepository()
 	if err != nil {
 		return nil, err
@@ -285,7 +369,15 @@ func ProvideAuthenticationFactory() (factories.AuthenticationFactory, error) {
 	if err != nil {
 		return nil, err
 	}
-	authenticationFactory := authenticators.NewAuthenticatorFactory(projectRepository, userService)
+	nonceRepository, err := ProvideInMemoryNonceRepository()
+	if err != nil {
+		return nil, err
+	}
+	invalidationRepository, err := ProvideInvalidationRepository()
+	if err != nil {
+		return nil, err
+	}
+	authenticationFactory := authenticators.NewAuthenticatorFactory(config, projectRepository, nonceRepository, invalidationRepository, userService)
 	return authenticationFactory, nil
 }
 
@@ -294,14 +386,40 @@ func ProvideIdentityFactory() (factories.IdentityFactory, error) {
 	if err != nil {
 		return nil, err
 	}
+	intrspidtyConfig, err := intrspidty.GetConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
 	providerRepository, err := ProvideSQLProviderRepository()
 	if err != nil {
 		return nil, err
 	}
-	identityFactory := identity.NewIdentityFactory(config, providerRepository)
+	identityFactory := identity.NewIdentityFactory(config, intrspidtyConfig, providerRepository)
 	return identityFactory, nil
 }
 
+func ProvideTransferApplication() (*transferapp.Application, error) {
+	archiveRepository, err := ProvideSQLArchiveRepository()
+	if err != nil {
+		return nil, err
+	}
+	application := transferapp.New(archiveRepository)
+	return application, nil
+}
+
+func ProvideBackupApplication() (*backupapp.Application, error) {
+	backupRepository, err := ProvideSQLBackupRepository()
+	if err != nil {
+		return nil, err
+	}
+	encryptionFactory, err := ProvideEncryptionFactory()
+	if err != nil {
+		return nil, err
+	}
+	application := backupapp.New(backupRepository, encryptionFactory)
+	return application, nil
+}
+
 func ProvideHealthzApplication() (*healthzapp.Application, error) {
 	client, err := ProvideSQL()
 	if err != nil {
@@ -387,16 +505,45 @@ func ProvideRESTServer() (*rest.Server, error) {
 	if err != nil {
 		return nil, err
 	}
-	server := rest.New(config, projectApplication, shareApplication, authenticationFactory, identityFactory, userService, application, projectService)
+	idempotencyRepository, err := ProvideSQLIdempotencyRepository()
+	if err != nil {
+		return nil, err
+	}
+	server := rest.New(config, projectApplication, shareApplication, authenticationFactory, identityFactory, userService, application, projectService, idempotencyRepository)
+	return server, nil
+}
+
+func ProvideGRPCServer() (*grpc.Server, error) {
+	config, err := grpc.GetConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	projectApplication, err := ProvideProjectApplication()
+	if err != nil {
+		return nil, err
+	}
+	shareApplication, err := ProvideShareApplication()
+	if err != nil {
+		return nil, err
+	}
+	authenticationFactory, err := ProvideAuthenticationFactory()
+	if err != nil {
+		return nil, err
+	}
+	identityFactory, err := ProvideIdentityFactory()
+	if err != nil {
+		return nil, err
+	}
+	projectService, err := ProvideProjectService()
+	if err != nil {
+		return nil, err
+	}
+	server := grpc.New(config, projectApplication, shareApplication, authenticationFactory, identityFactory, projectService)
 	return server, nil
 }
 
 // wire.go:
 
-func ProvideProjectCacheTTL() time.Duration {
-	return 60 * time.Second
-}
-
 type clockImpl struct{}
 
 func (c clockImpl) Now() time.Time {
@@ -1,8 +1,11 @@
 package authenticators
 
 import (
+	"crypto/x509"
+
 	projauth "github.com/openfort-xyz/shield/internal/adapters/authenticators/project_authenticator"
 	usrauth "github.com/openfort-xyz/shield/internal/adapters/authenticators/user_authenticator"
+	"github.com/openfort-xyz/shield/internal/core/domain/authentication"
 	"github.com/openfort-xyz/shield/internal/core/domain/project"
 	"github.com/openfort-xyz/shield/internal/core/ports/factories"
 	"github.com/openfort-xyz/shield/internal/core/ports/repositories"
@@ -10,19 +13,27 @@ import (
 )
 
 type authenticatorFactory struct {
-	projectRepo repositories.ProjectRepository
-	userService services.UserService
+	projectRepo     repositories.ProjectRepository
+	nonceRepo       repositories.NonceRepository
+	userService     services.UserService
+	credentialCache *projauth.CredentialCache
 }
 
-func NewAuthenticatorFactory(projectRepo repositories.ProjectRepository, userService services.UserService) factories.AuthenticationFactory {
+func NewAuthenticatorFactory(cfg *projauth.Config, projectRepo repositories.ProjectRepository, nonceRepo repositories.NonceRepository, invalidationRepo repositories.InvalidationRepository, userService services.UserService) factories.AuthenticationFactory {
 	return &authenticatorFactory{
-		projectRepo: projectRepo,
-		userService: userService,
+		projectRepo:     projectRepo,
+		nonceRepo:       nonceRepo,
+		userService:     userService,
+		credentialCache: projauth.NewCredentialCache(cfg, invalidationRepo),
 	}
 }
 
-func (f *authenticatorFactory) CreateProjectAuthenticator(apiKey, apiSecret string) factories.Authenticator {
-	return projauth.NewProjectAuthenticator(f.projectRepo, apiKey, apiSecret)
+func (f *authenticatorFactory) CreateProjectAuthenticator(apiKey, apiSecret string, signature *authentication.RequestSignature) factories.Authenticator {
+	return projauth.NewProjectAuthenticator(f.projectRepo, f.nonceRepo, f.credentialCache, apiKey, apiSecret, signature)
+}
+
+func (f *authenticatorFactory) CreateCertificateAuthenticator(apiKey, apiSecret string, signature *authentication.RequestSignature, chain []*x509.Certificate) factories.Authenticator {
+	return projauth.NewCertificateAuthenticator(f.projectRepo, f.nonceRepo, apiKey, apiSecret, signature, chain)
 }
 
 func (f *authenticatorFactory) CreateUserAuthenticator(proj *project.Project, token string, identityFactory factories.Identity) factories.Authenticator {
@@ -3,6 +3,7 @@ package cstmidty
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
 	"github.com/openfort-xyz/shield/internal/core/ports/factories"
@@ -38,8 +39,10 @@ func (c *CustomIdentityFactory) Identify(ctx context.Context, token string) (str
 	var externalUserID string
 	var err error
 	switch {
-	case c.config.PEM != "" && c.config.KeyType != provider.KeyTypeUnknown:
+	case len(c.config.Keys) > 0 || (c.config.PEM != "" && c.config.KeyType != provider.KeyTypeUnknown):
 		externalUserID, err = c.validatePEM(token)
+	case c.config.Secret != "" && c.config.KeyType == provider.KeyTypeHMAC:
+		externalUserID, err = c.validateHMAC(token)
 	case c.config.JWK != "":
 		externalUserID, err = jwk.Validate(token, []string{c.config.JWK})
 	default:
@@ -61,13 +64,74 @@ func (c *CustomIdentityFactory) GetCookieFieldName() string {
 }
 
 func (c *CustomIdentityFactory) validatePEM(token string) (string, error) {
-	keyFunc, validMethods, err := getKeyFuncFromPEM([]byte(c.config.PEM), c.config.KeyType)
+	candidates := c.candidateKeys(token, time.Now())
+	if len(candidates) == 0 {
+		return "", domainErrors.ErrInvalidToken
+	}
+
+	var lastErr error
+	for _, key := range candidates {
+		keyFunc, validMethods, err := getKeyFuncFromPEM([]byte(key.PEM), key.KeyType)
+		if err != nil {
+			c.logger.ErrorContext(context.Background(), "failed to parse PEM file", logger.Error(err))
+			lastErr = err
+			continue
+		}
+
+		sub, err := subjectFromToken(token, keyFunc, validMethods)
+		if err == nil {
+			return sub, nil
+		}
+		lastErr = err
+	}
+
+	return "", lastErr
+}
+
+// candidateKeys returns the keys that may have signed the token. When the
+// token's kid matches one of the registered keys only that key is returned,
+// otherwise every key active at now is tried (the legacy single PEM first).
+func (c *CustomIdentityFactory) candidateKeys(token string, now time.Time) []*provider.PEMKey {
+	var active []*provider.PEMKey
+	for _, key := range c.config.PEMKeys() {
+		if key.ActiveAt(now) {
+			active = append(active, key)
+		}
+	}
+
+	kid := tokenKeyID(token)
+	if kid == "" {
+		return active
+	}
 
+	for _, key := range active {
+		if key.KID == kid {
+			return []*provider.PEMKey{key}
+		}
+	}
+	return active
+}
+
+func tokenKeyID(token string) string {
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+	if err != nil {
+		return ""
+	}
+	kid, _ := parsed.Header["kid"].(string)
+	return kid
+}
+
+func (c *CustomIdentityFactory) validateHMAC(token string) (string, error) {
+	keyFunc, validMethods, err := getKeyFuncFromSecret([]byte(c.config.Secret))
 	if err != nil {
-		c.logger.ErrorContext(context.Background(), "failed to parse PEM file", logger.Error(err))
+		c.logger.ErrorContext(context.Background(), "invalid HMAC secret", logger.Error(err))
 		return "", err
 	}
 
+	return subjectFromToken(token, keyFunc, validMethods)
+}
+
+func subjectFromToken(token string, keyFunc jwt.Keyfunc, validMethods []string) (string, error) {
 	parsed, err := jwt.Parse(token, keyFunc, jwt.WithValidMethods(validMethods))
 	if err != nil {
 		return "", err
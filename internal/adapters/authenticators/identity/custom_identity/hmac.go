@@ -0,0 +1,36 @@
+package cstmidty
+
+import (
+	jwt "github.com/golang-jwt/jwt/v5"
+	"github.com/openfort-xyz/shield/internal/core/domain/errors"
+	"github.com/openfort-xyz/shield/internal/core/domain/provider"
+)
+
+// MinHMACSecretLength is the minimum accepted shared secret size in bytes.
+// RFC 7518 section 3.2 requires a key at least as large as the hash output,
+// so anything shorter than HS256's 32 bytes is rejected for every variant.
+const MinHMACSecretLength = 32
+
+func getKeyFuncFromSecret(secret []byte) (jwt.Keyfunc, []string, error) {
+	// Same reasoning as getKeyFuncFromPEM: reject weak secrets up front so a
+	// misconfigured provider fails when it's saved rather than on first use
+	if len(secret) < MinHMACSecretLength {
+		return nil, nil, errors.ErrHMACSecretTooShort
+	}
+
+	allowed := validMethodsForKeyType(provider.KeyTypeHMAC)
+
+	keyfunc := func(token *jwt.Token) (interface{}, error) {
+		if token.Method == nil || !isAllowedMethod(token.Method.Alg(), allowed) {
+			return nil, errors.ErrInvalidToken
+		}
+		return secret, nil
+	}
+
+	return keyfunc, allowed, nil
+}
+
+func CheckHMACSecret(secret []byte) error {
+	_, _, err := getKeyFuncFromSecret(secret)
+	return err
+}
@@ -0,0 +1,94 @@
+package cstmidty
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
+	"github.com/openfort-xyz/shield/internal/core/domain/provider"
+)
+
+var testHMACSecret = strings.Repeat("s", MinHMACSecretLength)
+
+func TestCheckHMACSecret(t *testing.T) {
+	if err := CheckHMACSecret([]byte(testHMACSecret)); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	err := CheckHMACSecret([]byte(testHMACSecret[1:]))
+	if !errors.Is(err, domainErrors.ErrHMACSecretTooShort) {
+		t.Fatalf("expected ErrHMACSecretTooShort, got: %v", err)
+	}
+}
+
+func TestValidateHMAC_AcceptsHSMethods(t *testing.T) {
+	factory := &CustomIdentityFactory{
+		config: &provider.CustomConfig{
+			Secret:  testHMACSecret,
+			KeyType: provider.KeyTypeHMAC,
+		},
+	}
+
+	for _, method := range []jwt.SigningMethod{jwt.SigningMethodHS256, jwt.SigningMethodHS384, jwt.SigningMethodHS512} {
+		t.Run(method.Alg(), func(t *testing.T) {
+			token := signToken(t, method, []byte(testHMACSecret), validClaims())
+			sub, err := factory.validateHMAC(token)
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if sub != "user-123" {
+				t.Fatalf("expected sub=user-123, got: %s", sub)
+			}
+		})
+	}
+}
+
+func TestValidateHMAC_RejectsWrongSecret(t *testing.T) {
+	factory := &CustomIdentityFactory{
+		config: &provider.CustomConfig{
+			Secret:  testHMACSecret,
+			KeyType: provider.KeyTypeHMAC,
+		},
+	}
+
+	token := signToken(t, jwt.SigningMethodHS256, []byte(strings.Repeat("x", MinHMACSecretLength)), validClaims())
+	_, err := factory.validateHMAC(token)
+	if err == nil {
+		t.Fatal("expected error for token signed with a different secret")
+	}
+}
+
+func TestValidateHMAC_RejectsRS256(t *testing.T) {
+	_, priv := generateRSAKeyPEM(t)
+	factory := &CustomIdentityFactory{
+		config: &provider.CustomConfig{
+			Secret:  testHMACSecret,
+			KeyType: provider.KeyTypeHMAC,
+		},
+	}
+
+	token := signToken(t, jwt.SigningMethodRS256, priv, validClaims())
+	_, err := factory.validateHMAC(token)
+	if err == nil {
+		t.Fatal("expected error when using RS256 against HMAC provider")
+	}
+}
+
+func TestValidateHMAC_MissingSub_ReturnsError(t *testing.T) {
+	factory := &CustomIdentityFactory{
+		config: &provider.CustomConfig{
+			Secret:  testHMACSecret,
+			KeyType: provider.KeyTypeHMAC,
+		},
+	}
+
+	claims := validClaims()
+	delete(claims, "sub")
+	token := signToken(t, jwt.SigningMethodHS256, []byte(testHMACSecret), claims)
+	_, err := factory.validateHMAC(token)
+	if !errors.Is(err, domainErrors.ErrInvalidToken) {
+		t.Fatalf("expected ErrInvalidToken, got: %v", err)
+	}
+}
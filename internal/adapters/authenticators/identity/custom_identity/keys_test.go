@@ -0,0 +1,116 @@
+package cstmidty
+
+import (
+	"testing"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+	"github.com/openfort-xyz/shield/internal/core/domain/provider"
+)
+
+func signTokenWithKID(t *testing.T, method jwt.SigningMethod, key interface{}, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestValidatePEM_Keys_SelectsByKID(t *testing.T) {
+	oldPEM, oldPriv := generateRSAKeyPEM(t)
+	newPEM, newPriv := generateECDSAKeyPEM(t)
+	factory := &CustomIdentityFactory{
+		config: &provider.CustomConfig{
+			Keys: []*provider.PEMKey{
+				{KID: "old", PEM: string(oldPEM), KeyType: provider.KeyTypeRSA},
+				{KID: "new", PEM: string(newPEM), KeyType: provider.KeyTypeECDSA},
+			},
+		},
+	}
+
+	for kid, tc := range map[string]struct {
+		method jwt.SigningMethod
+		key    interface{}
+	}{
+		"old": {jwt.SigningMethodRS256, oldPriv},
+		"new": {jwt.SigningMethodES256, newPriv},
+	} {
+		t.Run(kid, func(t *testing.T) {
+			token := signTokenWithKID(t, tc.method, tc.key, kid, validClaims())
+			sub, err := factory.validatePEM(token)
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if sub != "user-123" {
+				t.Fatalf("expected sub=user-123, got: %s", sub)
+			}
+		})
+	}
+}
+
+func TestValidatePEM_Keys_TriesEachKeyWithoutKID(t *testing.T) {
+	legacyPEM, _ := generateRSAKeyPEM(t)
+	rotatedPEM, rotatedPriv := generateRSAKeyPEM(t)
+	factory := &CustomIdentityFactory{
+		config: &provider.CustomConfig{
+			PEM:     string(legacyPEM),
+			KeyType: provider.KeyTypeRSA,
+			Keys: []*provider.PEMKey{
+				{PEM: string(rotatedPEM), KeyType: provider.KeyTypeRSA},
+			},
+		},
+	}
+
+	token := signToken(t, jwt.SigningMethodRS256, rotatedPriv, validClaims())
+	sub, err := factory.validatePEM(token)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if sub != "user-123" {
+		t.Fatalf("expected sub=user-123, got: %s", sub)
+	}
+}
+
+func TestValidatePEM_Keys_UnknownKIDFallsBackToAllKeys(t *testing.T) {
+	pubPEM, priv := generateRSAKeyPEM(t)
+	factory := &CustomIdentityFactory{
+		config: &provider.CustomConfig{
+			Keys: []*provider.PEMKey{
+				{KID: "registered", PEM: string(pubPEM), KeyType: provider.KeyTypeRSA},
+			},
+		},
+	}
+
+	token := signTokenWithKID(t, jwt.SigningMethodRS256, priv, "unregistered", validClaims())
+	if _, err := factory.validatePEM(token); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+}
+
+func TestValidatePEM_Keys_RejectsKeyOutsideValidity(t *testing.T) {
+	pubPEM, priv := generateRSAKeyPEM(t)
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name string
+		key  *provider.PEMKey
+	}{
+		{"expired", &provider.PEMKey{KID: "k", PEM: string(pubPEM), KeyType: provider.KeyTypeRSA, NotAfter: &past}},
+		{"not yet valid", &provider.PEMKey{KID: "k", PEM: string(pubPEM), KeyType: provider.KeyTypeRSA, NotBefore: &future}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory := &CustomIdentityFactory{
+				config: &provider.CustomConfig{Keys: []*provider.PEMKey{tt.key}},
+			}
+			token := signTokenWithKID(t, jwt.SigningMethodRS256, priv, "k", validClaims())
+			if _, err := factory.validatePEM(token); err == nil {
+				t.Fatal("expected error for key outside its validity window")
+			}
+		})
+	}
+}
@@ -15,6 +15,8 @@ func validMethodsForKeyType(keyType provider.KeyType) []string {
 		return []string{"ES256", "ES384", "ES512"}
 	case provider.KeyTypeEd25519:
 		return []string{"EdDSA"}
+	case provider.KeyTypeHMAC:
+		return []string{"HS256", "HS384", "HS512"}
 	default:
 		return nil
 	}
@@ -296,6 +296,7 @@ func TestValidMethodsForKeyType(t *testing.T) {
 		{"RSA", provider.KeyTypeRSA, []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512"}},
 		{"ECDSA", provider.KeyTypeECDSA, []string{"ES256", "ES384", "ES512"}},
 		{"Ed25519", provider.KeyTypeEd25519, []string{"EdDSA"}},
+		{"HMAC", provider.KeyTypeHMAC, []string{"HS256", "HS384", "HS512"}},
 		{"Unknown", provider.KeyTypeUnknown, nil},
 	}
 	for _, tt := range tests {
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 
 	cstmidty "github.com/openfort-xyz/shield/internal/adapters/authenticators/identity/custom_identity"
+	intrspidty "github.com/openfort-xyz/shield/internal/adapters/authenticators/identity/introspection_identity"
 	ofidty "github.com/openfort-xyz/shield/internal/adapters/authenticators/identity/openfort_identity"
 
 	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
@@ -17,16 +18,24 @@ import (
 )
 
 type identityFactory struct {
-	config *ofidty.Config
-	repo   repositories.ProviderRepository
-	logger *slog.Logger
+	config              *ofidty.Config
+	repo                repositories.ProviderRepository
+	openfortCache       *ofidty.Cache
+	openfortBreaker     *ofidty.Breaker
+	introspectionConfig *intrspidty.Config
+	introspectionCache  *intrspidty.Cache
+	logger              *slog.Logger
 }
 
-func NewIdentityFactory(cfg *ofidty.Config, repo repositories.ProviderRepository) factories.IdentityFactory {
+func NewIdentityFactory(cfg *ofidty.Config, introspectionCfg *intrspidty.Config, repo repositories.ProviderRepository) factories.IdentityFactory {
 	return &identityFactory{
-		config: cfg,
-		repo:   repo,
-		logger: logger.New("provider_manager"),
+		config:              cfg,
+		repo:                repo,
+		openfortCache:       ofidty.NewCache(cfg.CacheSize, cfg.CacheMaxTTL),
+		openfortBreaker:     ofidty.NewBreaker(cfg.BreakerThreshold, cfg.BreakerCooldown),
+		introspectionConfig: introspectionCfg,
+		introspectionCache:  intrspidty.NewCache(introspectionCfg.CacheSize, introspectionCfg.CacheMaxTTL),
+		logger:              logger.New("provider_manager"),
 	}
 }
 
@@ -63,5 +72,23 @@ func (p *identityFactory) CreateOpenfortIdentity(ctx context.Context, projectID
 		return nil, domainErrors.ErrProviderConfigMismatch
 	}
 
-	return ofidty.NewOpenfortIdentityFactory(p.config, config, authenticationProvider, tokenType), nil
+	return ofidty.NewOpenfortIdentityFactory(p.config, config, p.openfortCache, p.openfortBreaker, authenticationProvider, tokenType), nil
+}
+
+func (p *identityFactory) CreateIntrospectionIdentity(ctx context.Context, projectID string) (factories.Identity, error) {
+	prov, err := p.repo.GetByProjectAndType(ctx, projectID, provider.TypeIntrospection)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrProviderNotFound) {
+			return nil, domainErrors.ErrProviderNotConfigured
+		}
+		p.logger.ErrorContext(ctx, "failed to get provider", logger.Error(err))
+		return nil, err
+	}
+
+	config, ok := prov.Config.(*provider.IntrospectionConfig)
+	if !ok {
+		return nil, domainErrors.ErrProviderConfigMismatch
+	}
+
+	return intrspidty.NewIntrospectionIdentityFactory(p.introspectionConfig, config, p.introspectionCache), nil
 }
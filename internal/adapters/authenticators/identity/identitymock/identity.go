@@ -0,0 +1,29 @@
+package identitymock
+
+import (
+	"context"
+
+	"github.com/openfort-xyz/shield/internal/core/ports/factories"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockIdentity struct {
+	mock.Mock
+}
+
+var _ factories.Identity = (*MockIdentity)(nil)
+
+func (m *MockIdentity) GetProviderID() string {
+	args := m.Mock.Called()
+	return args.String(0)
+}
+
+func (m *MockIdentity) GetCookieFieldName() string {
+	args := m.Mock.Called()
+	return args.String(0)
+}
+
+func (m *MockIdentity) Identify(ctx context.Context, token string) (string, error) {
+	args := m.Mock.Called(ctx, token)
+	return args.String(0), args.Error(1)
+}
@@ -0,0 +1,62 @@
+package intrspidty
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/openfort-xyz/shield/pkg/lru"
+)
+
+// Cache is a bounded LRU of successful introspection results. It's shared by
+// every introspection provider, entries are keyed by provider and a hash of
+// the token so raw tokens are never held in memory.
+type Cache struct {
+	users  *lru.Cache[string, string]
+	maxTTL time.Duration
+	now    func() time.Time
+}
+
+// NewCache returns nil when size isn't positive, every method treats a nil
+// cache as always empty.
+func NewCache(size int, maxTTL time.Duration) *Cache {
+	if size <= 0 {
+		return nil
+	}
+	return &Cache{
+		users:  lru.New[string, string](size),
+		maxTTL: maxTTL,
+		now:    time.Now,
+	}
+}
+
+func (c *Cache) get(providerID, token string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	return c.users.Get(cacheKey(providerID, token), c.now())
+}
+
+// set caches the result until the max TTL elapses or the token expires,
+// whichever comes first.
+func (c *Cache) set(providerID, token, userID string, tokenExpiresAt *time.Time) {
+	if c == nil {
+		return
+	}
+
+	now := c.now()
+	expiresAt := now.Add(c.maxTTL)
+	if tokenExpiresAt != nil && tokenExpiresAt.Before(expiresAt) {
+		expiresAt = *tokenExpiresAt
+	}
+	if !now.Before(expiresAt) {
+		return
+	}
+
+	c.users.Set(cacheKey(providerID, token), userID, "", expiresAt)
+}
+
+func cacheKey(providerID, token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return providerID + ":" + hex.EncodeToString(sum[:])
+}
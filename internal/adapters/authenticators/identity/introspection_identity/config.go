@@ -0,0 +1,28 @@
+package intrspidty
+
+import (
+	"time"
+
+	env "github.com/caarlos0/env/v10"
+)
+
+type Config struct {
+	// RequestTimeout bounds every call to an introspection endpoint so a slow
+	// issuer can't hold a share request open.
+	RequestTimeout time.Duration `env:"INTROSPECTION_REQUEST_TIMEOUT" envDefault:"10s"`
+
+	// CacheSize is the maximum number of successful introspections
+	// remembered, the least recently used entry is evicted once it's
+	// reached. Zero disables the cache.
+	CacheSize int `env:"INTROSPECTION_CACHE_SIZE" envDefault:"10000"`
+	// CacheMaxTTL bounds how long a successful introspection is trusted
+	// before the endpoint is asked again. It's kept short because a revoked
+	// token stays usable for at most this long.
+	CacheMaxTTL time.Duration `env:"INTROSPECTION_CACHE_MAX_TTL" envDefault:"30s"`
+}
+
+func GetConfigFromEnv() (*Config, error) {
+	cfg := &Config{}
+	err := env.Parse(cfg)
+	return cfg, err
+}
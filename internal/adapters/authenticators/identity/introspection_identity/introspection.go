@@ -0,0 +1,138 @@
+package intrspidty
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
+	"github.com/openfort-xyz/shield/internal/core/domain/provider"
+	"github.com/openfort-xyz/shield/internal/core/ports/factories"
+	"github.com/openfort-xyz/shield/pkg/contexter"
+	"github.com/openfort-xyz/shield/pkg/logger"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// httpTransport wraps the default transport with OTel instrumentation so
+// introspection calls become client spans.
+var httpTransport = otelhttp.NewTransport(http.DefaultTransport)
+
+type IntrospectionIdentityFactory struct {
+	config *provider.IntrospectionConfig
+	cache  *Cache
+	client *http.Client
+	logger *slog.Logger
+}
+
+var _ factories.Identity = (*IntrospectionIdentityFactory)(nil)
+
+func NewIntrospectionIdentityFactory(config *Config, providerConfig *provider.IntrospectionConfig, cache *Cache) factories.Identity {
+	return &IntrospectionIdentityFactory{
+		config: providerConfig,
+		cache:  cache,
+		client: &http.Client{Timeout: config.RequestTimeout, Transport: httpTransport},
+		logger: logger.New("introspection_provider"),
+	}
+}
+
+func (i *IntrospectionIdentityFactory) GetProviderID() string {
+	return i.config.ProviderID
+}
+
+func (i *IntrospectionIdentityFactory) GetCookieFieldName() string {
+	return ""
+}
+
+func (i *IntrospectionIdentityFactory) Identify(ctx context.Context, token string) (string, error) {
+	i.logger.InfoContext(ctx, "identifying user")
+
+	if i.cache != nil {
+		if userID, ok := i.cache.get(i.config.ProviderID, token); ok {
+			return userID, nil
+		}
+	}
+
+	userID, expiresAt, err := i.introspect(ctx, token)
+	if err != nil {
+		i.logger.ErrorContext(ctx, "failed to introspect token", logger.Error(err))
+		return "", err
+	}
+
+	if i.cache != nil {
+		i.cache.set(i.config.ProviderID, token, userID, expiresAt)
+	}
+
+	return userID, nil
+}
+
+// introspect calls the RFC 7662 endpoint, authenticating with the client
+// credentials through HTTP basic auth as recommended by section 2.1.
+func (i *IntrospectionIdentityFactory) introspect(ctx context.Context, token string) (string, *time.Time, error) {
+	form := url.Values{}
+	form.Set("token", token)
+	form.Set("token_type_hint", "access_token")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, i.config.Endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Request-ID", contexter.GetRequestID(ctx))
+	if i.config.ClientID != "" {
+		req.SetBasicAuth(i.config.ClientID, i.config.ClientSecret)
+	}
+
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return "", nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		i.logger.ErrorContext(ctx, "unexpected status code", slog.Int("status_code", resp.StatusCode))
+		return "", nil, domainErrors.ErrUnexpectedStatusCode
+	}
+
+	rawResponse, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(rawResponse, &response); err != nil {
+		return "", nil, err
+	}
+
+	if active, _ := response["active"].(bool); !active {
+		return "", nil, domainErrors.ErrInvalidToken
+	}
+
+	var expiresAt *time.Time
+	if exp, ok := response["exp"].(float64); ok {
+		t := time.Unix(int64(exp), 0)
+		if !time.Now().Before(t) {
+			return "", nil, domainErrors.ErrSessionExpired
+		}
+		expiresAt = &t
+	}
+
+	claim := i.config.UserIDClaim
+	if claim == "" {
+		claim = provider.DefaultUserIDClaim
+	}
+
+	userID, ok := response[claim].(string)
+	if !ok || userID == "" {
+		return "", nil, domainErrors.ErrInvalidToken
+	}
+
+	return userID, expiresAt, nil
+}
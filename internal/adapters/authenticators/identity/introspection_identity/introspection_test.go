@@ -0,0 +1,199 @@
+package intrspidty
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
+	"github.com/openfort-xyz/shield/internal/core/domain/provider"
+)
+
+// clientSecret holds characters a form encoding would alter, the credentials
+// are sent as they are.
+const clientSecret = "s3cr+t/=%"
+
+func newIntrospectionServer(t *testing.T, calls *int32, response map[string]interface{}) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+
+		clientID, secret, ok := r.BasicAuth()
+		if !ok || clientID != "client" || secret != clientSecret {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("token") != "opaque-token" {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"active": false})
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newFactory(endpoint string, cache *Cache) *IntrospectionIdentityFactory {
+	return NewIntrospectionIdentityFactory(&Config{RequestTimeout: time.Second}, &provider.IntrospectionConfig{
+		ProviderID:   "provider-id",
+		Endpoint:     endpoint,
+		ClientID:     "client",
+		ClientSecret: clientSecret,
+	}, cache).(*IntrospectionIdentityFactory)
+}
+
+func TestIdentify_ActiveToken(t *testing.T) {
+	var calls int32
+	srv := newIntrospectionServer(t, &calls, map[string]interface{}{
+		"active": true,
+		"sub":    "user-123",
+		"exp":    time.Now().Add(time.Hour).Unix(),
+	})
+
+	userID, err := newFactory(srv.URL, nil).Identify(context.Background(), "opaque-token")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if userID != "user-123" {
+		t.Fatalf("expected user-123, got: %s", userID)
+	}
+}
+
+func TestIdentify_CustomUserIDClaim(t *testing.T) {
+	var calls int32
+	srv := newIntrospectionServer(t, &calls, map[string]interface{}{
+		"active":   true,
+		"sub":      "user-123",
+		"username": "alice",
+	})
+
+	factory := newFactory(srv.URL, nil)
+	factory.config.UserIDClaim = "username"
+	userID, err := factory.Identify(context.Background(), "opaque-token")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if userID != "alice" {
+		t.Fatalf("expected alice, got: %s", userID)
+	}
+}
+
+func TestIdentify_InactiveToken(t *testing.T) {
+	var calls int32
+	srv := newIntrospectionServer(t, &calls, map[string]interface{}{"active": true, "sub": "user-123"})
+
+	_, err := newFactory(srv.URL, nil).Identify(context.Background(), "revoked-token")
+	if !errors.Is(err, domainErrors.ErrInvalidToken) {
+		t.Fatalf("expected ErrInvalidToken, got: %v", err)
+	}
+}
+
+func TestIdentify_MissingUserIDClaim(t *testing.T) {
+	var calls int32
+	srv := newIntrospectionServer(t, &calls, map[string]interface{}{"active": true})
+
+	_, err := newFactory(srv.URL, nil).Identify(context.Background(), "opaque-token")
+	if !errors.Is(err, domainErrors.ErrInvalidToken) {
+		t.Fatalf("expected ErrInvalidToken, got: %v", err)
+	}
+}
+
+func TestIdentify_ExpiredToken(t *testing.T) {
+	var calls int32
+	srv := newIntrospectionServer(t, &calls, map[string]interface{}{
+		"active": true,
+		"sub":    "user-123",
+		"exp":    time.Now().Add(-time.Minute).Unix(),
+	})
+
+	_, err := newFactory(srv.URL, nil).Identify(context.Background(), "opaque-token")
+	if !errors.Is(err, domainErrors.ErrSessionExpired) {
+		t.Fatalf("expected ErrSessionExpired, got: %v", err)
+	}
+}
+
+func TestIdentify_WrongClientCredentials(t *testing.T) {
+	var calls int32
+	srv := newIntrospectionServer(t, &calls, map[string]interface{}{"active": true, "sub": "user-123"})
+
+	factory := newFactory(srv.URL, nil)
+	factory.config.ClientSecret = "wrong"
+	_, err := factory.Identify(context.Background(), "opaque-token")
+	if !errors.Is(err, domainErrors.ErrUnexpectedStatusCode) {
+		t.Fatalf("expected ErrUnexpectedStatusCode, got: %v", err)
+	}
+}
+
+func TestIdentify_CachesSuccessfulResults(t *testing.T) {
+	var calls int32
+	srv := newIntrospectionServer(t, &calls, map[string]interface{}{"active": true, "sub": "user-123"})
+
+	cache := NewCache(10, time.Minute)
+	factory := newFactory(srv.URL, cache)
+	for n := 0; n < 3; n++ {
+		if _, err := factory.Identify(context.Background(), "opaque-token"); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+	}
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Fatalf("expected a single introspection call, got: %d", calls)
+	}
+
+	if _, err := factory.Identify(context.Background(), "revoked-token"); err == nil {
+		t.Fatal("expected inactive token to be rejected")
+	}
+	if _, err := factory.Identify(context.Background(), "revoked-token"); err == nil {
+		t.Fatal("expected inactive token to be rejected")
+	}
+	if atomic.LoadInt32(&calls) != 3 {
+		t.Fatalf("expected failed introspections not to be cached, got %d calls", calls)
+	}
+}
+
+func TestCache_HonoursTokenExpiry(t *testing.T) {
+	now := time.Now()
+	cache := NewCache(10, time.Minute)
+	cache.now = func() time.Time { return now }
+
+	exp := now.Add(10 * time.Second)
+	cache.set("provider-id", "token", "user-123", &exp)
+
+	if _, ok := cache.get("provider-id", "token"); !ok {
+		t.Fatal("expected cache hit before token expiry")
+	}
+
+	cache.now = func() time.Time { return now.Add(11 * time.Second) }
+	if _, ok := cache.get("provider-id", "token"); ok {
+		t.Fatal("expected cache miss after token expiry")
+	}
+}
+
+func TestCache_IsScopedByProvider(t *testing.T) {
+	cache := NewCache(10, time.Minute)
+	cache.set("provider-a", "token", "user-123", nil)
+
+	if _, ok := cache.get("provider-b", "token"); ok {
+		t.Fatal("expected cache entries to be scoped by provider")
+	}
+}
+
+func TestCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewCache(2, time.Minute)
+	cache.set("provider-id", "token-a", "user-a", nil)
+	cache.set("provider-id", "token-b", "user-b", nil)
+	cache.get("provider-id", "token-a")
+	cache.set("provider-id", "token-c", "user-c", nil)
+
+	if _, ok := cache.get("provider-id", "token-b"); ok {
+		t.Fatal("expected the least recently used entry to be evicted")
+	}
+	if _, ok := cache.get("provider-id", "token-c"); !ok {
+		t.Fatal("expected new entries to be cached once the cache is full")
+	}
+}
@@ -0,0 +1,97 @@
+package ofidty
+
+import (
+	"sync"
+	"time"
+
+	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
+)
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// Breaker stops calling the Openfort API after consecutive failures so an
+// outage fails share requests fast instead of piling them up on timeouts.
+// Once the cooldown elapses a single probe is let through, its outcome
+// decides whether the circuit closes again or stays open for another round.
+type Breaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	state     breakerState
+	failures  int
+	openedAt  time.Time
+	now       func() time.Time
+}
+
+// NewBreaker returns nil when threshold isn't positive, a nil breaker always
+// lets requests through.
+func NewBreaker(threshold int, cooldown time.Duration) *Breaker {
+	if threshold <= 0 {
+		return nil
+	}
+	return &Breaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// allow reports whether a request may be sent upstream, it returns
+// ErrIdentityProviderUnavailable while the circuit is open.
+func (b *Breaker) allow() error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == breakerClosed {
+		return nil
+	}
+
+	// While half-open only the probe goes through. Restarting the cooldown
+	// when it's sent means a probe that never reports back (its caller went
+	// away) doesn't wedge the circuit, another one follows a cooldown later.
+	now := b.now()
+	if now.Sub(b.openedAt) < b.cooldown {
+		breakerRejections.Inc()
+		return domainErrors.ErrIdentityProviderUnavailable
+	}
+	b.state = breakerHalfOpen
+	b.openedAt = now
+	return nil
+}
+
+func (b *Breaker) success() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = breakerClosed
+	b.failures = 0
+}
+
+func (b *Breaker) failure() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		b.state = breakerOpen
+		b.openedAt = b.now()
+	}
+}
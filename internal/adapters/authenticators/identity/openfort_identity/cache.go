@@ -0,0 +1,80 @@
+package ofidty
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/openfort-xyz/shield/pkg/lru"
+)
+
+// Cache is a bounded LRU of tokens Openfort has already validated. It's
+// shared by every Openfort identity, entries are keyed by a hash of the
+// token so raw tokens are never held in memory.
+type Cache struct {
+	users  *lru.Cache[string, string]
+	maxTTL time.Duration
+	now    func() time.Time
+}
+
+// NewCache returns nil when size isn't positive, every method treats a nil
+// cache as always empty.
+func NewCache(size int, maxTTL time.Duration) *Cache {
+	if size <= 0 {
+		return nil
+	}
+	return &Cache{
+		users:  lru.New[string, string](size),
+		maxTTL: maxTTL,
+		now:    time.Now,
+	}
+}
+
+func (c *Cache) get(key string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+
+	userID, ok := c.users.Get(key, c.now())
+	if !ok {
+		cacheLookups.WithLabelValues("miss").Inc()
+		return "", false
+	}
+
+	cacheLookups.WithLabelValues("hit").Inc()
+	return userID, true
+}
+
+// set caches the user until the session expires or the max TTL elapses,
+// whichever comes first.
+func (c *Cache) set(key, userID string, sessionExpiresAt *time.Time) {
+	if c == nil {
+		return
+	}
+
+	now := c.now()
+	expiresAt := now.Add(c.maxTTL)
+	if sessionExpiresAt != nil && sessionExpiresAt.Before(expiresAt) {
+		expiresAt = *sessionExpiresAt
+	}
+	if !now.Before(expiresAt) {
+		return
+	}
+
+	c.users.Set(key, userID, "", expiresAt)
+}
+
+func (c *Cache) len() int {
+	if c == nil {
+		return 0
+	}
+	return c.users.Len()
+}
+
+// cacheKey scopes the token hash so the same token presented to another
+// project, or through another third-party provider, is validated again.
+func cacheKey(scope []string, token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return strings.Join(append(scope, hex.EncodeToString(sum[:])), ":")
+}
@@ -1,9 +1,30 @@
 package ofidty
 
-import env "github.com/caarlos0/env/v10"
+import (
+	"time"
+
+	env "github.com/caarlos0/env/v10"
+)
 
 type Config struct {
 	OpenfortBaseURL string `env:"OPENFORT_BASE_URL" envDefault:"https://api.openfort.io"`
+
+	// RequestTimeout bounds every call to the Openfort API so a slow upstream
+	// can't hold a share request open.
+	RequestTimeout time.Duration `env:"OPENFORT_REQUEST_TIMEOUT" envDefault:"10s"`
+
+	// CacheSize is the maximum number of validated tokens remembered, the
+	// least recently used entry is evicted once it's reached. Zero disables
+	// the cache.
+	CacheSize int `env:"OPENFORT_CACHE_SIZE" envDefault:"10000"`
+	// CacheMaxTTL caps how long a token is trusted without asking Openfort
+	// again, even when its session lives longer, so revocations propagate.
+	CacheMaxTTL time.Duration `env:"OPENFORT_CACHE_MAX_TTL" envDefault:"5m"`
+
+	// BreakerThreshold consecutive upstream failures open the circuit for
+	// BreakerCooldown, after which a single probe request is let through.
+	BreakerThreshold int           `env:"OPENFORT_BREAKER_THRESHOLD" envDefault:"5"`
+	BreakerCooldown  time.Duration `env:"OPENFORT_BREAKER_COOLDOWN" envDefault:"30s"`
 }
 
 func GetConfigFromEnv() (*Config, error) {
@@ -0,0 +1,24 @@
+package ofidty
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	cacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "shield_openfort_identity_cache_lookups_total",
+		Help: "Openfort token cache lookups, by result (hit or miss).",
+	}, []string{"result"})
+
+	upstreamDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "shield_openfort_identity_upstream_duration_seconds",
+		Help:    "Latency of calls to the Openfort API, by endpoint and outcome.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"endpoint", "outcome"})
+
+	breakerRejections = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "shield_openfort_identity_breaker_rejections_total",
+		Help: "Requests rejected without calling Openfort because the circuit was open.",
+	})
+)
@@ -30,6 +30,9 @@ type OpenfortIdentityFactory struct {
 	publishableKey string
 	baseURL        string
 	providerID     string
+	client         *http.Client
+	cache          *Cache
+	breaker        *Breaker
 	logger         *slog.Logger
 
 	authenticationProvider *string
@@ -38,11 +41,17 @@ type OpenfortIdentityFactory struct {
 
 var _ factories.Identity = (*OpenfortIdentityFactory)(nil)
 
-func NewOpenfortIdentityFactory(config *Config, providerConfig *provider.OpenfortConfig, authenticationProvider, tokenType *string) factories.Identity {
+// NewOpenfortIdentityFactory builds an identity for a single request, the
+// cache and breaker are shared across requests and may be nil to disable
+// them.
+func NewOpenfortIdentityFactory(config *Config, providerConfig *provider.OpenfortConfig, cache *Cache, breaker *Breaker, authenticationProvider, tokenType *string) factories.Identity {
 	return &OpenfortIdentityFactory{
 		publishableKey:         providerConfig.PublishableKey,
 		providerID:             providerConfig.ProviderID,
 		baseURL:                config.OpenfortBaseURL,
+		client:                 &http.Client{Timeout: config.RequestTimeout, Transport: httpTransport},
+		cache:                  cache,
+		breaker:                breaker,
 		logger:                 logger.New("openfort_provider"),
 		authenticationProvider: authenticationProvider,
 		tokenType:              tokenType,
@@ -73,6 +82,11 @@ func (o *OpenfortIdentityFactory) GetCookieFieldName() string {
 }
 
 func (o *OpenfortIdentityFactory) accessToken(ctx context.Context, token string) (string, error) {
+	key := cacheKey([]string{"session", o.publishableKey}, token)
+	if userID, ok := o.cache.get(key); ok {
+		return userID, nil
+	}
+
 	url := fmt.Sprintf("%s/iam/v2/auth/get-session", o.baseURL)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -83,20 +97,7 @@ func (o *OpenfortIdentityFactory) accessToken(ctx context.Context, token string)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 	req.Header.Set("x-project-key", o.publishableKey)
-	client := http.Client{Timeout: time.Minute, Transport: httpTransport}
-	resp, err := client.Do(req)
-	if err != nil {
-		return "", err
-	}
-
-	defer resp.Body.Close()
-
-	if resp.StatusCode/100 != 2 {
-		o.logger.ErrorContext(ctx, "unexpected status code", slog.Int("status_code", resp.StatusCode))
-		return "", domainErrors.ErrUnexpectedStatusCode
-	}
-
-	rawResponse, err := io.ReadAll(resp.Body)
+	rawResponse, err := o.do(ctx, "get_session", req)
 	if err != nil {
 		return "", err
 	}
@@ -115,6 +116,7 @@ func (o *OpenfortIdentityFactory) accessToken(ctx context.Context, token string)
 		return "", domainErrors.ErrSessionExpired
 	}
 
+	o.cache.set(key, response.User.ID, &expiresAt)
 	return response.User.ID, nil
 }
 
@@ -125,6 +127,13 @@ func (o *OpenfortIdentityFactory) jwtToken(_ context.Context, token string) (str
 }
 
 func (o *OpenfortIdentityFactory) thirdParty(ctx context.Context, token, authenticationProvider, tokenType string) (string, error) {
+	// The response carries no expiry, so these entries only live for the
+	// cache's max TTL
+	key := cacheKey([]string{"oauth", o.publishableKey, authenticationProvider, tokenType}, token)
+	if userID, ok := o.cache.get(key); ok {
+		return userID, nil
+	}
+
 	url := fmt.Sprintf("%s/iam/v1/oauth/third_party", o.baseURL)
 
 	reqBody := authenticateOauthRequest{
@@ -145,30 +154,76 @@ func (o *OpenfortIdentityFactory) thirdParty(ctx context.Context, token, authent
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", o.publishableKey))
 	req.Header.Set("X-Request-ID", contexter.GetRequestID(ctx))
-	client := http.Client{Timeout: time.Minute, Transport: httpTransport}
-	resp, err := client.Do(req)
+	rawResponse, err := o.do(ctx, "third_party", req)
 	if err != nil {
 		return "", err
 	}
 
+	var response authenticateOauthResponse
+	if err := json.Unmarshal(rawResponse, &response); err != nil {
+		return "", err
+	}
+
+	o.cache.set(key, response.ID, nil)
+	return response.ID, nil
+}
+
+// do sends req through the circuit breaker and returns the body of a 2xx
+// response. Transport errors, timeouts and 5xx/429 responses count against
+// the breaker, any other status means Openfort is up and rejected the token.
+func (o *OpenfortIdentityFactory) do(ctx context.Context, endpoint string, req *http.Request) ([]byte, error) {
+	if err := o.breaker.allow(); err != nil {
+		o.logger.ErrorContext(ctx, "openfort circuit open, skipping request")
+		return nil, err
+	}
+
+	start := time.Now()
+	body, outcome, err := o.send(req)
+	upstreamDuration.WithLabelValues(endpoint, outcome).Observe(time.Since(start).Seconds())
+
+	switch outcome {
+	case outcomeError:
+		o.breaker.failure()
+	case outcomeOK, outcomeRejected:
+		o.breaker.success()
+	}
+
+	return body, err
+}
+
+const (
+	outcomeOK       = "ok"
+	outcomeRejected = "rejected"
+	outcomeError    = "error"
+	outcomeCanceled = "canceled"
+)
+
+func (o *OpenfortIdentityFactory) send(req *http.Request) ([]byte, string, error) {
+	resp, err := o.client.Do(req)
+	if err != nil {
+		// The caller going away says nothing about Openfort's health
+		if req.Context().Err() != nil {
+			return nil, outcomeCanceled, err
+		}
+		return nil, outcomeError, err
+	}
+
 	defer resp.Body.Close()
 
 	if resp.StatusCode/100 != 2 {
-		o.logger.ErrorContext(ctx, "unexpected status code", slog.Int("status_code", resp.StatusCode))
-		return "", domainErrors.ErrUnexpectedStatusCode
+		o.logger.ErrorContext(req.Context(), "unexpected status code", slog.Int("status_code", resp.StatusCode))
+		if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests {
+			return nil, outcomeError, domainErrors.ErrUnexpectedStatusCode
+		}
+		return nil, outcomeRejected, domainErrors.ErrUnexpectedStatusCode
 	}
 
 	rawResponse, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", err
-	}
-
-	var response authenticateOauthResponse
-	if err := json.Unmarshal(rawResponse, &response); err != nil {
-		return "", err
+		return nil, outcomeError, err
 	}
 
-	return response.ID, nil
+	return rawResponse, outcomeOK, nil
 }
 
 type authenticateOauthRequest struct {
@@ -0,0 +1,199 @@
+package ofidty
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
+	"github.com/openfort-xyz/shield/internal/core/domain/provider"
+)
+
+func newSessionServer(t *testing.T, calls *int32, status *int32, expiresAt time.Time) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+
+		if code := atomic.LoadInt32(status); code != http.StatusOK {
+			w.WriteHeader(int(code))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer session-token" || r.Header.Get("x-project-key") != "pk_test" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var response SessionResponse
+		response.Session.ExpiresAt = expiresAt.Format(time.RFC3339)
+		response.User.ID = "user-123"
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newFactory(baseURL string, cache *Cache, breaker *Breaker) *OpenfortIdentityFactory {
+	return NewOpenfortIdentityFactory(
+		&Config{OpenfortBaseURL: baseURL, RequestTimeout: time.Second},
+		&provider.OpenfortConfig{ProviderID: "provider-id", PublishableKey: "pk_test"},
+		cache, breaker, nil, nil,
+	).(*OpenfortIdentityFactory)
+}
+
+func TestAccessToken_CachesUntilSessionExpires(t *testing.T) {
+	var calls int32
+	status := int32(http.StatusOK)
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	srv := newSessionServer(t, &calls, &status, expiresAt)
+
+	cache := NewCache(10, 24*time.Hour)
+	for i := 0; i < 3; i++ {
+		userID, err := newFactory(srv.URL, cache, nil).Identify(context.Background(), "session-token")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if userID != "user-123" {
+			t.Fatalf("expected user-123, got: %s", userID)
+		}
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected a single upstream call, got: %d", got)
+	}
+
+	// Past the session's expiry the entry is dropped and Openfort is asked again
+	cache.now = func() time.Time { return expiresAt.Add(time.Second) }
+	if _, ok := cache.get(cacheKey([]string{"session", "pk_test"}, "session-token")); ok {
+		t.Fatal("expected entry to expire with the session")
+	}
+}
+
+func TestAccessToken_DoesNotCacheRejections(t *testing.T) {
+	var calls int32
+	status := int32(http.StatusOK)
+	srv := newSessionServer(t, &calls, &status, time.Now().Add(time.Hour))
+
+	cache := NewCache(10, time.Minute)
+	for i := 0; i < 2; i++ {
+		_, err := newFactory(srv.URL, cache, nil).Identify(context.Background(), "wrong-token")
+		if !errors.Is(err, domainErrors.ErrUnexpectedStatusCode) {
+			t.Fatalf("expected ErrUnexpectedStatusCode, got: %v", err)
+		}
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("expected every rejection to reach upstream, got: %d calls", got)
+	}
+	if cache.len() != 0 {
+		t.Fatalf("expected empty cache, got %d entries", cache.len())
+	}
+}
+
+func TestAccessToken_BreakerOpensOnUpstreamFailures(t *testing.T) {
+	var calls int32
+	status := int32(http.StatusBadGateway)
+	srv := newSessionServer(t, &calls, &status, time.Now().Add(time.Hour))
+
+	now := time.Now()
+	breaker := NewBreaker(2, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		_, err := newFactory(srv.URL, nil, breaker).Identify(context.Background(), "session-token")
+		if !errors.Is(err, domainErrors.ErrUnexpectedStatusCode) {
+			t.Fatalf("expected ErrUnexpectedStatusCode, got: %v", err)
+		}
+	}
+
+	_, err := newFactory(srv.URL, nil, breaker).Identify(context.Background(), "session-token")
+	if !errors.Is(err, domainErrors.ErrIdentityProviderUnavailable) {
+		t.Fatalf("expected ErrIdentityProviderUnavailable, got: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("expected open circuit to skip upstream, got: %d calls", got)
+	}
+
+	// After the cooldown a probe goes through and its success closes the circuit
+	atomic.StoreInt32(&status, http.StatusOK)
+	now = now.Add(time.Minute)
+	userID, err := newFactory(srv.URL, nil, breaker).Identify(context.Background(), "session-token")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if userID != "user-123" {
+		t.Fatalf("expected user-123, got: %s", userID)
+	}
+	if err := breaker.allow(); err != nil {
+		t.Fatalf("expected closed circuit, got: %v", err)
+	}
+}
+
+func TestAccessToken_RejectionsDoNotTripBreaker(t *testing.T) {
+	var calls int32
+	status := int32(http.StatusOK)
+	srv := newSessionServer(t, &calls, &status, time.Now().Add(time.Hour))
+
+	breaker := NewBreaker(1, time.Minute)
+	for i := 0; i < 3; i++ {
+		_, err := newFactory(srv.URL, nil, breaker).Identify(context.Background(), "wrong-token")
+		if !errors.Is(err, domainErrors.ErrUnexpectedStatusCode) {
+			t.Fatalf("expected ErrUnexpectedStatusCode, got: %v", err)
+		}
+	}
+}
+
+func TestBreaker_FailedProbeReopens(t *testing.T) {
+	now := time.Now()
+	breaker := NewBreaker(1, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	breaker.failure()
+	if err := breaker.allow(); err == nil {
+		t.Fatal("expected open circuit")
+	}
+
+	now = now.Add(time.Minute)
+	if err := breaker.allow(); err != nil {
+		t.Fatalf("expected probe to be allowed, got: %v", err)
+	}
+	if err := breaker.allow(); err == nil {
+		t.Fatal("expected only one probe while half-open")
+	}
+
+	breaker.failure()
+	now = now.Add(time.Second)
+	if err := breaker.allow(); err == nil {
+		t.Fatal("expected failed probe to reopen the circuit")
+	}
+}
+
+func TestCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewCache(2, time.Minute)
+
+	cache.set("a", "user-a", nil)
+	cache.set("b", "user-b", nil)
+	if _, ok := cache.get("a"); !ok {
+		t.Fatal("expected a to be cached")
+	}
+	cache.set("c", "user-c", nil)
+
+	if _, ok := cache.get("b"); ok {
+		t.Fatal("expected b to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := cache.get(key); !ok {
+			t.Fatalf("expected %s to be cached", key)
+		}
+	}
+}
+
+func TestCache_DisabledWhenSizeIsZero(t *testing.T) {
+	cache := NewCache(0, time.Minute)
+	cache.set("a", "user-a", nil)
+	if _, ok := cache.get("a"); ok {
+		t.Fatal("expected disabled cache to miss")
+	}
+}
@@ -0,0 +1,166 @@
+package projauth
+
+import (
+	"context"
+	"crypto/x509"
+	"log/slog"
+	"time"
+
+	"github.com/openfort-xyz/shield/internal/core/domain/authentication"
+	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
+	"github.com/openfort-xyz/shield/internal/core/domain/project"
+	"github.com/openfort-xyz/shield/internal/core/ports/factories"
+	"github.com/openfort-xyz/shield/internal/core/ports/repositories"
+	"github.com/openfort-xyz/shield/pkg/logger"
+)
+
+// CertificateAuthenticator authenticates a project backend that presented a
+// TLS client certificate. The TLS handshake already proved the caller holds
+// the certificate's private key, this checks the certificate against the
+// fingerprints the project registered and applies the project's
+// ClientCertMode to decide whether the API secret is needed as well.
+type CertificateAuthenticator struct {
+	projectRepo repositories.ProjectRepository
+	apiKey      string
+	secret      secretFactor
+	chain       []*x509.Certificate
+	now         func() time.Time
+	logger      *slog.Logger
+}
+
+var _ factories.Authenticator = (*CertificateAuthenticator)(nil)
+
+// NewCertificateAuthenticator expects the presented chain leaf first, as in
+// tls.ConnectionState.PeerCertificates or one of its VerifiedChains. The API
+// secret and signature may both be empty when the project accepts
+// certificates alone.
+func NewCertificateAuthenticator(repository repositories.ProjectRepository, nonceRepo repositories.NonceRepository, apiKey, apiSecret string, signature *authentication.RequestSignature, chain []*x509.Certificate) factories.Authenticator {
+	return &CertificateAuthenticator{
+		projectRepo: repository,
+		apiKey:      apiKey,
+		secret: secretFactor{
+			projectRepo: repository,
+			nonceRepo:   nonceRepo,
+			apiSecret:   apiSecret,
+			signature:   signature,
+			now:         time.Now,
+		},
+		chain:  chain,
+		now:    time.Now,
+		logger: logger.New("certificate_authenticator"),
+	}
+}
+
+func (a *CertificateAuthenticator) Authenticate(ctx context.Context) (*authentication.Authentication, error) {
+	a.logger.InfoContext(ctx, "authenticating client certificate")
+
+	proj, err := a.projectRepo.GetByAPIKey(ctx, a.apiKey)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to authenticate api key", logger.Error(err))
+		return nil, err
+	}
+
+	// Projects that haven't opted in keep authenticating with the secret, a
+	// certificate sent along by a shared client doesn't change that
+	if proj.ClientCertMode != project.ClientCertModeAlternative && proj.ClientCertMode != project.ClientCertModeRequired {
+		return a.authenticateSecret(ctx, proj)
+	}
+
+	certErr := a.verifyChain(ctx, proj.ID)
+	if certErr != nil {
+		a.logger.ErrorContext(ctx, "failed to authenticate client certificate", logger.Error(certErr))
+	}
+
+	if proj.ClientCertMode == project.ClientCertModeAlternative {
+		if certErr == nil {
+			return authenticated(proj.ID, proj.Status)
+		}
+		if !a.secret.present() {
+			return nil, certErr
+		}
+		return a.authenticateSecret(ctx, proj)
+	}
+
+	if certErr != nil {
+		return nil, certErr
+	}
+	return a.authenticateSecret(ctx, proj)
+}
+
+func (a *CertificateAuthenticator) authenticateSecret(ctx context.Context, proj *project.Project) (*authentication.Authentication, error) {
+	err := a.secret.verify(ctx, proj)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to authenticate api secret", logger.Error(err))
+		return nil, err
+	}
+
+	return authenticated(proj.ID, proj.Status)
+}
+
+func (a *CertificateAuthenticator) verifyChain(ctx context.Context, projectID string) error {
+	if len(a.chain) == 0 {
+		return domainErrors.ErrClientCertificateRequired
+	}
+
+	registered, err := a.projectRepo.ListClientCertificates(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	return matchChain(a.chain, registered, a.now())
+}
+
+// matchChain accepts the chain when its leaf is pinned, or when it verifies
+// up to a pinned CA. A pinned CA only needs to appear somewhere in the
+// presented chain, it's used as the sole root so a chain that doesn't
+// actually descend from it fails verification.
+func matchChain(chain []*x509.Certificate, registered []*project.ClientCertificate, now time.Time) error {
+	leaf := chain[0]
+	if now.Before(leaf.NotBefore) || now.After(leaf.NotAfter) {
+		return domainErrors.ErrClientCertificateNotTrusted
+	}
+
+	pinnedCAs := make(map[string]bool)
+	for _, cert := range registered {
+		switch cert.Type {
+		case project.ClientCertificateTypeLeaf:
+			if cert.Fingerprint == project.Fingerprint(leaf.Raw) {
+				return nil
+			}
+		case project.ClientCertificateTypeCA:
+			pinnedCAs[cert.Fingerprint] = true
+		}
+	}
+
+	if len(pinnedCAs) == 0 {
+		return domainErrors.ErrClientCertificateNotTrusted
+	}
+
+	roots := x509.NewCertPool()
+	intermediates := x509.NewCertPool()
+	hasRoot := false
+	for _, cert := range chain[1:] {
+		if cert.IsCA && pinnedCAs[project.Fingerprint(cert.Raw)] {
+			roots.AddCert(cert)
+			hasRoot = true
+			continue
+		}
+		intermediates.AddCert(cert)
+	}
+
+	if !hasRoot {
+		return domainErrors.ErrClientCertificateNotTrusted
+	}
+
+	_, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		return domainErrors.ErrClientCertificateNotTrusted
+	}
+
+	return nil
+}
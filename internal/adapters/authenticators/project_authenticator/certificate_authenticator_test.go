@@ -0,0 +1,186 @@
+package projauth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/mocks/projectmockrepo"
+	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
+	"github.com/openfort-xyz/shield/internal/core/domain/project"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func newCertificate(t *testing.T, cn string, isCA bool, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	if isCA {
+		template.KeyUsage = x509.KeyUsageCertSign
+	} else {
+		template.KeyUsage = x509.KeyUsageDigitalSignature
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	}
+
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+
+	return cert, key
+}
+
+func TestMatchChain(t *testing.T) {
+	ca, caKey := newCertificate(t, "ca", true, nil, nil)
+	leaf, _ := newCertificate(t, "backend", false, ca, caKey)
+	otherCA, otherCAKey := newCertificate(t, "other ca", true, nil, nil)
+	otherLeaf, _ := newCertificate(t, "intruder", false, otherCA, otherCAKey)
+
+	pinnedCA := []*project.ClientCertificate{{Type: project.ClientCertificateTypeCA, Fingerprint: project.Fingerprint(ca.Raw)}}
+	pinnedLeaf := []*project.ClientCertificate{{Type: project.ClientCertificateTypeLeaf, Fingerprint: project.Fingerprint(leaf.Raw)}}
+
+	tc := []struct {
+		name       string
+		chain      []*x509.Certificate
+		registered []*project.ClientCertificate
+		now        time.Time
+		wantErr    error
+	}{
+		{
+			name:       "pinned leaf",
+			chain:      []*x509.Certificate{leaf},
+			registered: pinnedLeaf,
+			now:        time.Now(),
+		},
+		{
+			name:       "leaf issued by pinned CA",
+			chain:      []*x509.Certificate{leaf, ca},
+			registered: pinnedCA,
+			now:        time.Now(),
+		},
+		{
+			name:       "pinned CA missing from chain",
+			chain:      []*x509.Certificate{leaf},
+			registered: pinnedCA,
+			now:        time.Now(),
+			wantErr:    domainErrors.ErrClientCertificateNotTrusted,
+		},
+		{
+			name:       "pinned CA presented next to a leaf it didn't issue",
+			chain:      []*x509.Certificate{otherLeaf, ca},
+			registered: pinnedCA,
+			now:        time.Now(),
+			wantErr:    domainErrors.ErrClientCertificateNotTrusted,
+		},
+		{
+			name:       "unknown leaf",
+			chain:      []*x509.Certificate{otherLeaf, otherCA},
+			registered: append(pinnedLeaf, pinnedCA...),
+			now:        time.Now(),
+			wantErr:    domainErrors.ErrClientCertificateNotTrusted,
+		},
+		{
+			name:       "expired leaf",
+			chain:      []*x509.Certificate{leaf},
+			registered: pinnedLeaf,
+			now:        time.Now().Add(2 * time.Hour),
+			wantErr:    domainErrors.ErrClientCertificateNotTrusted,
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantErr, matchChain(tt.chain, tt.registered, tt.now))
+		})
+	}
+}
+
+func TestCertificateAuthenticator_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	ca, caKey := newCertificate(t, "ca", true, nil, nil)
+	leaf, _ := newCertificate(t, "backend", false, ca, caKey)
+	stranger, _ := newCertificate(t, "stranger", false, nil, nil)
+
+	secret := hex.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	hashedSecret, err := bcrypt.GenerateFromPassword(getAPISecretBytes(secret), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("failed to hash secret: %v", err)
+	}
+
+	registered := []*project.ClientCertificate{{Type: project.ClientCertificateTypeLeaf, Fingerprint: project.Fingerprint(leaf.Raw)}}
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+
+	tc := []struct {
+		name      string
+		mode      project.ClientCertMode
+		apiSecret string
+		chain     []*x509.Certificate
+		wantErr   bool
+	}{
+		{name: "alternative with certificate only", mode: project.ClientCertModeAlternative, chain: []*x509.Certificate{leaf}},
+		{name: "alternative falls back to secret", mode: project.ClientCertModeAlternative, apiSecret: secret, chain: []*x509.Certificate{stranger}},
+		{name: "alternative with untrusted certificate only", mode: project.ClientCertModeAlternative, chain: []*x509.Certificate{stranger}, wantErr: true},
+		{name: "required with both", mode: project.ClientCertModeRequired, apiSecret: secret, chain: []*x509.Certificate{leaf}},
+		{name: "required without secret", mode: project.ClientCertModeRequired, chain: []*x509.Certificate{leaf}, wantErr: true},
+		{name: "required with untrusted certificate", mode: project.ClientCertModeRequired, apiSecret: secret, chain: []*x509.Certificate{stranger}, wantErr: true},
+		{name: "disabled ignores certificate", mode: project.ClientCertModeDisabled, apiSecret: secret, chain: []*x509.Certificate{stranger}},
+		{name: "disabled still needs secret", mode: project.ClientCertModeDisabled, chain: []*x509.Certificate{leaf}, wantErr: true},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			projectRepo.ExpectedCalls = nil
+			projectRepo.On("GetByAPIKey", mock.Anything, "api_key").Return(&project.Project{ID: "project_id", APISecret: string(hashedSecret), ClientCertMode: tt.mode}, nil)
+			projectRepo.On("ListClientCertificates", mock.Anything, "project_id").Return(registered, nil)
+
+			auth, err := NewCertificateAuthenticator(projectRepo, nil, "api_key", tt.apiSecret, nil, tt.chain).Authenticate(ctx)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "project_id", auth.ProjectID)
+		})
+	}
+}
+
+func TestProjectAuthenticator_RequiredModeRejectsSecretOnly(t *testing.T) {
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+	projectRepo.On("GetByAPIKey", mock.Anything, "api_key").Return(&project.Project{ID: "project_id", ClientCertMode: project.ClientCertModeRequired}, nil)
+
+	_, err := NewProjectAuthenticator(projectRepo, nil, nil, "api_key", "secret", nil).Authenticate(context.Background())
+	if !errors.Is(err, domainErrors.ErrClientCertificateRequired) {
+		t.Fatalf("expected ErrClientCertificateRequired, got: %v", err)
+	}
+}
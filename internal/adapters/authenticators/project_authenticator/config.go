@@ -0,0 +1,24 @@
+package projauth
+
+import (
+	"time"
+
+	env "github.com/caarlos0/env/v10"
+)
+
+type Config struct {
+	// CredentialCacheTTL is how long an API key and secret pair that passed
+	// verification is trusted without checking it again. Changes to the
+	// project's credentials are pushed to every replica, the TTL bounds how
+	// stale a replica can be when that push is lost. Zero disables the cache.
+	CredentialCacheTTL time.Duration `env:"PROJECT_AUTH_CACHE_TTL" envDefault:"30s"`
+	// CredentialCacheSize is the maximum number of verified pairs
+	// remembered, the least recently used entry is evicted once it's reached.
+	CredentialCacheSize int `env:"PROJECT_AUTH_CACHE_SIZE" envDefault:"10000"`
+}
+
+func GetConfigFromEnv() (*Config, error) {
+	cfg := &Config{}
+	err := env.Parse(cfg)
+	return cfg, err
+}
@@ -0,0 +1,109 @@
+package projauth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/openfort-xyz/shield/internal/core/domain/project"
+	"github.com/openfort-xyz/shield/internal/core/ports/repositories"
+	"github.com/openfort-xyz/shield/pkg/lru"
+)
+
+// CredentialCache remembers API key and secret pairs that passed the bcrypt
+// check, so a project's backend only pays for it once per TTL instead of on
+// every request. Pairs are keyed by an HMAC under a key generated at
+// startup, neither the secret nor an offline-crackable hash of it is held.
+//
+// Entries are dropped as soon as the project's credentials or
+// authentication settings change on any replica, see
+// project.InvalidationChannel.
+type CredentialCache struct {
+	ttl     time.Duration
+	hashKey []byte
+	entries *lru.Cache[string, credentialEntry]
+	now     func() time.Time
+}
+
+type credentialEntry struct {
+	projectID string
+	status    project.Status
+}
+
+// NewCredentialCache returns nil when the cache is disabled, every method
+// treats a nil cache as always empty.
+func NewCredentialCache(cfg *Config, invalidations repositories.InvalidationRepository) *CredentialCache {
+	if cfg.CredentialCacheSize <= 0 || cfg.CredentialCacheTTL <= 0 {
+		return nil
+	}
+
+	hashKey := make([]byte, 32)
+	_, _ = rand.Read(hashKey)
+
+	c := &CredentialCache{
+		ttl:     cfg.CredentialCacheTTL,
+		hashKey: hashKey,
+		entries: lru.New[string, credentialEntry](cfg.CredentialCacheSize),
+		now:     time.Now,
+	}
+
+	invalidations.Subscribe(project.InvalidationChannel, func(projectID string) {
+		if projectID == "" {
+			c.entries.Purge()
+			return
+		}
+		c.entries.Invalidate(projectID)
+	})
+
+	return c
+}
+
+func (c *CredentialCache) key(apiKey, apiSecret string) string {
+	mac := hmac.New(sha256.New, c.hashKey)
+	mac.Write([]byte(apiKey))
+	mac.Write([]byte{0})
+	mac.Write([]byte(apiSecret))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (c *CredentialCache) get(apiKey, apiSecret string) (string, project.Status, bool) {
+	if c == nil {
+		return "", "", false
+	}
+
+	entry, ok := c.entries.Get(c.key(apiKey, apiSecret), c.now())
+	if !ok {
+		credentialCacheLookups.WithLabelValues("miss").Inc()
+		return "", "", false
+	}
+
+	credentialCacheLookups.WithLabelValues("hit").Inc()
+	return entry.projectID, entry.status, true
+}
+
+// snapshot is taken before the project is loaded and handed back to set, so
+// a verification that raced with an invalidation isn't cached.
+func (c *CredentialCache) snapshot() uint64 {
+	if c == nil {
+		return 0
+	}
+	return c.entries.Generation()
+}
+
+func (c *CredentialCache) set(apiKey, apiSecret, projectID string, status project.Status, snapshot uint64) {
+	if c == nil {
+		return
+	}
+
+	entry := credentialEntry{projectID: projectID, status: status}
+	c.entries.SetIfGeneration(snapshot, c.key(apiKey, apiSecret), entry, projectID, c.now().Add(c.ttl))
+}
+
+func (c *CredentialCache) len() int {
+	if c == nil {
+		return 0
+	}
+	return c.entries.Len()
+}
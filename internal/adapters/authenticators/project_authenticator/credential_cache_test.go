@@ -0,0 +1,157 @@
+package projauth
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/mocks/invalidationmockrepo"
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/mocks/projectmockrepo"
+	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
+	"github.com/openfort-xyz/shield/internal/core/domain/project"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func newTestCredentialCache(t *testing.T, size int) (*CredentialCache, func(string), *time.Time) {
+	t.Helper()
+
+	var notify func(string)
+	invalidationRepo := new(invalidationmockrepo.MockInvalidationRepository)
+	invalidationRepo.On("Subscribe", project.InvalidationChannel, mock.Anything).Run(func(args mock.Arguments) {
+		notify = args.Get(1).(func(string))
+	})
+
+	c := NewCredentialCache(&Config{CredentialCacheTTL: time.Minute, CredentialCacheSize: size}, invalidationRepo)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	return c, notify, &now
+}
+
+func TestCredentialCache(t *testing.T) {
+	t.Run("hit until expiry", func(t *testing.T) {
+		c, _, now := newTestCredentialCache(t, 10)
+		c.set("key", "secret", "project_id", project.StatusActive, c.snapshot())
+
+		projectID, _, ok := c.get("key", "secret")
+		assert.True(t, ok)
+		assert.Equal(t, "project_id", projectID)
+
+		_, _, ok = c.get("key", "other secret")
+		assert.False(t, ok)
+
+		*now = now.Add(time.Minute)
+		_, _, ok = c.get("key", "secret")
+		assert.False(t, ok)
+		assert.Equal(t, 0, c.len())
+	})
+
+	t.Run("invalidation drops the project's entries", func(t *testing.T) {
+		c, notify, _ := newTestCredentialCache(t, 10)
+		c.set("key", "secret", "project_id", project.StatusActive, c.snapshot())
+		c.set("other_key", "secret", "other_project_id", project.StatusActive, c.snapshot())
+
+		notify("project_id")
+		_, _, ok := c.get("key", "secret")
+		assert.False(t, ok)
+		_, _, ok = c.get("other_key", "secret")
+		assert.True(t, ok)
+
+		notify("")
+		assert.Equal(t, 0, c.len())
+	})
+
+	t.Run("verification racing an invalidation isn't cached", func(t *testing.T) {
+		c, notify, _ := newTestCredentialCache(t, 10)
+		snapshot := c.snapshot()
+		notify("project_id")
+		c.set("key", "secret", "project_id", project.StatusActive, snapshot)
+
+		_, _, ok := c.get("key", "secret")
+		assert.False(t, ok)
+	})
+
+	t.Run("least recently used is evicted", func(t *testing.T) {
+		c, _, _ := newTestCredentialCache(t, 2)
+		c.set("a", "secret", "project_a", project.StatusActive, c.snapshot())
+		c.set("b", "secret", "project_b", project.StatusActive, c.snapshot())
+		c.get("a", "secret")
+		c.set("c", "secret", "project_c", project.StatusActive, c.snapshot())
+
+		_, _, ok := c.get("b", "secret")
+		assert.False(t, ok)
+		_, _, ok = c.get("a", "secret")
+		assert.True(t, ok)
+		assert.Equal(t, 2, c.len())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.Nil(t, NewCredentialCache(&Config{CredentialCacheTTL: 0, CredentialCacheSize: 10}, nil))
+
+		var c *CredentialCache
+		c.set("key", "secret", "project_id", project.StatusActive, c.snapshot())
+		_, _, ok := c.get("key", "secret")
+		assert.False(t, ok)
+	})
+}
+
+func TestProjectAuthenticator_CachesVerifiedSecret(t *testing.T) {
+	ctx := context.Background()
+	secret := hex.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	hashedSecret, err := bcrypt.GenerateFromPassword(getAPISecretBytes(secret), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("failed to hash secret: %v", err)
+	}
+
+	c, notify, _ := newTestCredentialCache(t, 10)
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+	projectRepo.On("GetByAPIKey", mock.Anything, "api_key").Return(&project.Project{ID: "project_id", APISecret: string(hashedSecret)}, nil)
+
+	for range 3 {
+		auth, err := NewProjectAuthenticator(projectRepo, nil, c, "api_key", secret, nil).Authenticate(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "project_id", auth.ProjectID)
+	}
+	projectRepo.AssertNumberOfCalls(t, "GetByAPIKey", 1)
+
+	_, err = NewProjectAuthenticator(projectRepo, nil, c, "api_key", "wrong", nil).Authenticate(ctx)
+	assert.Error(t, err)
+	projectRepo.AssertNumberOfCalls(t, "GetByAPIKey", 2)
+
+	notify("project_id")
+	_, err = NewProjectAuthenticator(projectRepo, nil, c, "api_key", secret, nil).Authenticate(ctx)
+	assert.NoError(t, err)
+	projectRepo.AssertNumberOfCalls(t, "GetByAPIKey", 3)
+}
+
+func TestProjectAuthenticator_AppliesStatusAfterInvalidation(t *testing.T) {
+	ctx := context.Background()
+	secret := hex.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	hashedSecret, err := bcrypt.GenerateFromPassword(getAPISecretBytes(secret), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("failed to hash secret: %v", err)
+	}
+
+	c, notify, _ := newTestCredentialCache(t, 10)
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+	projectRepo.On("GetByAPIKey", mock.Anything, "api_key").Return(&project.Project{ID: "project_id", APISecret: string(hashedSecret), Status: project.StatusReadOnly}, nil).Once()
+	projectRepo.On("GetByAPIKey", mock.Anything, "api_key").Return(&project.Project{ID: "project_id", APISecret: string(hashedSecret), Status: project.StatusSuspended}, nil)
+
+	auth, err := NewProjectAuthenticator(projectRepo, nil, c, "api_key", secret, nil).Authenticate(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, project.StatusReadOnly, auth.ProjectStatus)
+
+	auth, err = NewProjectAuthenticator(projectRepo, nil, c, "api_key", secret, nil).Authenticate(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, project.StatusReadOnly, auth.ProjectStatus)
+
+	notify("project_id")
+	_, err = NewProjectAuthenticator(projectRepo, nil, c, "api_key", secret, nil).Authenticate(ctx)
+	assert.ErrorIs(t, err, domainErrors.ErrProjectSuspended)
+
+	_, err = NewProjectAuthenticator(projectRepo, nil, c, "api_key", "wrong", nil).Authenticate(ctx)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, domainErrors.ErrProjectSuspended)
+}
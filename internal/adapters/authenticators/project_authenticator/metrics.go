@@ -0,0 +1,11 @@
+package projauth
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var credentialCacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "shield_project_auth_cache_lookups_total",
+	Help: "Verified API credential cache lookups, by result (hit or miss).",
+}, []string{"result"})
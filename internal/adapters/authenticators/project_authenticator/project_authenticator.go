@@ -2,61 +2,91 @@ package projauth
 
 import (
 	"context"
-	"encoding/hex"
 	"log/slog"
+	"time"
 
 	"github.com/openfort-xyz/shield/internal/core/domain/authentication"
+	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
+	"github.com/openfort-xyz/shield/internal/core/domain/project"
 	"github.com/openfort-xyz/shield/internal/core/ports/factories"
 
 	"github.com/openfort-xyz/shield/internal/core/ports/repositories"
 	"github.com/openfort-xyz/shield/pkg/logger"
-	"golang.org/x/crypto/bcrypt"
 )
 
 type ProjectAuthenticator struct {
-	projectRepo       repositories.ProjectRepository
-	apiKey, apiSecret string
-	logger            *slog.Logger
+	projectRepo repositories.ProjectRepository
+	apiKey      string
+	secret      secretFactor
+	cache       *CredentialCache
+	logger      *slog.Logger
 }
 
 var _ factories.Authenticator = (*ProjectAuthenticator)(nil)
 
-func NewProjectAuthenticator(repository repositories.ProjectRepository, apiKey, apiSecret string) factories.Authenticator {
+// NewProjectAuthenticator authenticates with the API secret, or with a
+// request signature when signature isn't nil. Only secrets are looked up in
+// cache, a signature is bound to a single request.
+func NewProjectAuthenticator(repository repositories.ProjectRepository, nonceRepo repositories.NonceRepository, cache *CredentialCache, apiKey, apiSecret string, signature *authentication.RequestSignature) factories.Authenticator {
 	return &ProjectAuthenticator{
 		projectRepo: repository,
 		apiKey:      apiKey,
-		apiSecret:   apiSecret,
-		logger:      logger.New("api_key_authenticator"),
+		cache:       cache,
+		secret: secretFactor{
+			projectRepo: repository,
+			nonceRepo:   nonceRepo,
+			apiSecret:   apiSecret,
+			signature:   signature,
+			now:         time.Now,
+		},
+		logger: logger.New("api_key_authenticator"),
 	}
 }
 
-func getAPISecretBytes(apiSecret string) []byte {
-	hex32bytes, err := hex.DecodeString(apiSecret)
-	if err != nil {
-		// Old legacy api secrets are UUIDs and new secrets are hex-encoded 32 bytes
-		return []byte(apiSecret)
-	}
-	return hex32bytes
-}
-
 func (a *ProjectAuthenticator) Authenticate(ctx context.Context) (*authentication.Authentication, error) {
 	a.logger.InfoContext(ctx, "authenticating api key")
 
+	cacheable := a.secret.signature == nil && a.secret.apiSecret != ""
+	if cacheable {
+		if projectID, status, ok := a.cache.get(a.apiKey, a.secret.apiSecret); ok {
+			return authenticated(projectID, status)
+		}
+	}
+	snapshot := a.cache.snapshot()
+
 	proj, err := a.projectRepo.GetByAPIKey(ctx, a.apiKey)
 	if err != nil {
 		a.logger.ErrorContext(ctx, "failed to authenticate api key", logger.Error(err))
 		return nil, err
 	}
 
-	apiSecretBytes := getAPISecretBytes(a.apiSecret)
+	if proj.ClientCertMode == project.ClientCertModeRequired {
+		a.logger.ErrorContext(ctx, "project requires a client certificate")
+		return nil, domainErrors.ErrClientCertificateRequired
+	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(proj.APISecret), apiSecretBytes)
+	err = a.secret.verify(ctx, proj)
 	if err != nil {
 		a.logger.ErrorContext(ctx, "failed to authenticate api secret", logger.Error(err))
 		return nil, err
 	}
 
+	if cacheable {
+		a.cache.set(a.apiKey, a.secret.apiSecret, proj.ID, proj.Status, snapshot)
+	}
+
+	return authenticated(proj.ID, proj.Status)
+}
+
+// authenticated is called once the credentials are verified, so a suspended
+// project is only reported to callers that hold its secret.
+func authenticated(projectID string, status project.Status) (*authentication.Authentication, error) {
+	if status == project.StatusSuspended {
+		return nil, domainErrors.ErrProjectSuspended
+	}
+
 	return &authentication.Authentication{
-		ProjectID: proj.ID,
+		ProjectID:     projectID,
+		ProjectStatus: status,
 	}, nil
 }
@@ -0,0 +1,88 @@
+package projauth
+
+import (
+	"context"
+	"encoding/hex"
+	"time"
+
+	"github.com/openfort-xyz/shield/internal/core/domain/authentication"
+	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
+	"github.com/openfort-xyz/shield/internal/core/domain/project"
+	"github.com/openfort-xyz/shield/internal/core/ports/repositories"
+	"github.com/openfort-xyz/shield/pkg/signing"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// SignatureMaxSkew is how far a signed request's timestamp may drift from
+// the server clock. Nonces are remembered for twice as long, which covers
+// every timestamp still accepted.
+const SignatureMaxSkew = 5 * time.Minute
+
+const maxNonceLength = 128
+
+// secretFactor proves the caller knows the project's secret, either by
+// sending the API secret or by signing the request with the project's
+// signing key. A signature is bound to the request and its nonce can only
+// be used once, so unlike the API secret a captured request can't be
+// replayed.
+type secretFactor struct {
+	projectRepo repositories.ProjectRepository
+	nonceRepo   repositories.NonceRepository
+	apiSecret   string
+	signature   *authentication.RequestSignature
+	now         func() time.Time
+}
+
+func (f *secretFactor) present() bool {
+	return f.apiSecret != "" || f.signature != nil
+}
+
+func (f *secretFactor) verify(ctx context.Context, proj *project.Project) error {
+	if f.signature != nil {
+		return f.verifySignature(ctx, proj)
+	}
+
+	if proj.RequireSignedRequests {
+		return domainErrors.ErrRequestSignatureRequired
+	}
+
+	return checkAPISecret(proj, f.apiSecret)
+}
+
+func (f *secretFactor) verifySignature(ctx context.Context, proj *project.Project) error {
+	sig := f.signature
+	if sig.Nonce == "" || len(sig.Nonce) > maxNonceLength {
+		return domainErrors.ErrInvalidRequestSignature
+	}
+
+	skew := f.now().Sub(time.Unix(sig.Timestamp, 0))
+	if skew > SignatureMaxSkew || skew < -SignatureMaxSkew {
+		return domainErrors.ErrRequestSignatureExpired
+	}
+
+	signingKey, err := f.projectRepo.GetSigningKey(ctx, proj.ID)
+	if err != nil {
+		return err
+	}
+
+	if !signing.Verify([]byte(signingKey), sig.Signature, sig.Method, sig.RequestURI, sig.BodyHash, sig.Timestamp, sig.Nonce) {
+		return domainErrors.ErrInvalidRequestSignature
+	}
+
+	// Only claim the nonce once the signature checks out, otherwise anyone
+	// could burn a legitimate client's nonces
+	return f.nonceRepo.Claim(ctx, proj.ID+":"+sig.Nonce, 2*SignatureMaxSkew)
+}
+
+func getAPISecretBytes(apiSecret string) []byte {
+	hex32bytes, err := hex.DecodeString(apiSecret)
+	if err != nil {
+		// Old legacy api secrets are UUIDs and new secrets are hex-encoded 32 bytes
+		return []byte(apiSecret)
+	}
+	return hex32bytes
+}
+
+func checkAPISecret(proj *project.Project, apiSecret string) error {
+	return bcrypt.CompareHashAndPassword([]byte(proj.APISecret), getAPISecretBytes(apiSecret))
+}
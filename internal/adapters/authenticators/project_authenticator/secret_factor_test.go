@@ -0,0 +1,73 @@
+package projauth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/bunt"
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/bunt/noncerepo"
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/mocks/projectmockrepo"
+	"github.com/openfort-xyz/shield/internal/core/domain/authentication"
+	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
+	"github.com/openfort-xyz/shield/internal/core/domain/project"
+	"github.com/openfort-xyz/shield/pkg/signing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSecretFactor_VerifySignature(t *testing.T) {
+	ctx := context.Background()
+	db, err := bunt.New()
+	if err != nil {
+		t.Fatalf("failed to open bunt: %v", err)
+	}
+	nonceRepo := noncerepo.New(db)
+
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+	projectRepo.On("GetSigningKey", mock.Anything, "project_id").Return("signing_key", nil)
+
+	now := time.Unix(1760875200, 0)
+	bodyHash := signing.BodyHash([]byte(`{"reference":"ref"}`))
+	sign := func(key, nonce string, ts int64) *authentication.RequestSignature {
+		return &authentication.RequestSignature{
+			Method:     "POST",
+			RequestURI: "/shares/encryption/reference",
+			BodyHash:   bodyHash,
+			Timestamp:  ts,
+			Nonce:      nonce,
+			Signature:  signing.Sign([]byte(key), "POST", "/shares/encryption/reference", bodyHash, ts, nonce),
+		}
+	}
+	tampered := sign("signing_key", "nonce-tampered", now.Unix())
+	tampered.RequestURI = "/shares"
+
+	tc := []struct {
+		name      string
+		signature *authentication.RequestSignature
+		required  bool
+		wantErr   error
+	}{
+		{name: "valid signature", signature: sign("signing_key", "nonce-1", now.Unix())},
+		{name: "replayed nonce", signature: sign("signing_key", "nonce-1", now.Unix()), wantErr: domainErrors.ErrNonceAlreadyUsed},
+		{name: "wrong key", signature: sign("other_key", "nonce-2", now.Unix()), wantErr: domainErrors.ErrInvalidRequestSignature},
+		{name: "tampered request", signature: tampered, wantErr: domainErrors.ErrInvalidRequestSignature},
+		{name: "stale timestamp", signature: sign("signing_key", "nonce-3", now.Add(-SignatureMaxSkew-time.Second).Unix()), wantErr: domainErrors.ErrRequestSignatureExpired},
+		{name: "missing nonce", signature: sign("signing_key", "", now.Unix()), wantErr: domainErrors.ErrInvalidRequestSignature},
+		{name: "unsigned when required", required: true, wantErr: domainErrors.ErrRequestSignatureRequired},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &secretFactor{
+				projectRepo: projectRepo,
+				nonceRepo:   nonceRepo,
+				apiSecret:   "secret",
+				signature:   tt.signature,
+				now:         func() time.Time { return now },
+			}
+			err := f.verify(ctx, &project.Project{ID: "project_id", RequireSignedRequests: tt.required})
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
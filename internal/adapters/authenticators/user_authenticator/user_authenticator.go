@@ -5,6 +5,7 @@ import (
 	"log/slog"
 
 	"github.com/openfort-xyz/shield/internal/core/domain/authentication"
+	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
 	"github.com/openfort-xyz/shield/internal/core/domain/project"
 	"github.com/openfort-xyz/shield/internal/core/ports/factories"
 
@@ -35,6 +36,12 @@ func NewUserAuthenticator(userService services.UserService, proj *project.Projec
 func (a *UserAuthenticator) Authenticate(ctx context.Context) (*authentication.Authentication, error) {
 	a.logger.InfoContext(ctx, "authenticating api key")
 
+	// A suspended project is refused before its identity provider is called
+	if a.project.Status == project.StatusSuspended {
+		a.logger.ErrorContext(ctx, "project is suspended")
+		return nil, domainErrors.ErrProjectSuspended
+	}
+
 	externalUserID, err := a.identityFactory.Identify(ctx, a.token)
 	if err != nil {
 		a.logger.ErrorContext(ctx, "failed to identify user", logger.Error(err))
@@ -51,5 +58,6 @@ func (a *UserAuthenticator) Authenticate(ctx context.Context) (*authentication.A
 		UserID:         usr.ID,
 		ProjectID:      a.project.ID,
 		ExternalUserID: externalUserID,
+		ProjectStatus:  a.project.Status,
 	}, nil
 }
@@ -0,0 +1,81 @@
+package usrauth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/openfort-xyz/shield/internal/adapters/authenticators/identity/identitymock"
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/mocks/usermockedrepo"
+	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
+	"github.com/openfort-xyz/shield/internal/core/domain/project"
+	"github.com/openfort-xyz/shield/internal/core/domain/user"
+	"github.com/openfort-xyz/shield/internal/core/services/usersvc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUserAuthenticator_Authenticate(t *testing.T) {
+	identity := new(identitymock.MockIdentity)
+	userRepo := new(usermockedrepo.MockUserRepository)
+	userService := usersvc.New(userRepo)
+
+	tc := []struct {
+		name       string
+		status     project.Status
+		wantErr    error
+		wantUserID string
+		mock       func()
+	}{
+		{
+			name:       "success",
+			status:     project.StatusActive,
+			wantUserID: "user_id",
+			mock: func() {
+				identity.ExpectedCalls = nil
+				userRepo.ExpectedCalls = nil
+				identity.On("Identify", mock.Anything, "token").Return("external_user_id", nil)
+				identity.On("GetProviderID").Return("provider_id")
+				userRepo.On("FindUserByExternalID", mock.Anything, "external_user_id", "provider_id").Return(&user.User{ID: "user_id", ProjectID: "project_id"}, nil)
+			},
+		},
+		{
+			name:    "identity provider error",
+			status:  project.StatusActive,
+			wantErr: domainErrors.ErrInvalidToken,
+			mock: func() {
+				identity.ExpectedCalls = nil
+				userRepo.ExpectedCalls = nil
+				identity.On("Identify", mock.Anything, "token").Return("", domainErrors.ErrInvalidToken)
+			},
+		},
+		{
+			name:    "suspended project",
+			status:  project.StatusSuspended,
+			wantErr: domainErrors.ErrProjectSuspended,
+			mock: func() {
+				identity.ExpectedCalls = nil
+				userRepo.ExpectedCalls = nil
+				identity.On("Identify", mock.Anything, "token").Return("", errors.New("identity provider unavailable"))
+			},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			identity.Calls = nil
+			proj := &project.Project{ID: "project_id", Status: tt.status}
+
+			auth, err := NewUserAuthenticator(userService, proj, "token", identity).Authenticate(context.Background())
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.wantUserID, auth.UserID)
+				assert.Equal(t, tt.status, auth.ProjectStatus)
+			}
+			if tt.status == project.StatusSuspended {
+				identity.AssertNotCalled(t, "Identify", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package grpc
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"strings"
+
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/api"
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/authmdw"
+	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
+	"github.com/openfort-xyz/shield/internal/core/ports/factories"
+	"github.com/openfort-xyz/shield/internal/core/ports/services"
+	"github.com/openfort-xyz/shield/pkg/contexter"
+	shieldv1 "github.com/openfort-xyz/shield/pkg/pb/shield/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type authMode int
+
+const (
+	authModeProject authMode = iota
+	authModeUser
+)
+
+// serviceAuthModes maps each service to the way its callers authenticate,
+// the same as the REST routes the service mirrors.
+var serviceAuthModes = map[string]authMode{
+	shieldv1.ShareService_ServiceDesc.ServiceName:    authModeUser,
+	shieldv1.KeychainService_ServiceDesc.ServiceName: authModeUser,
+	shieldv1.ProjectService_ServiceDesc.ServiceName:  authModeProject,
+}
+
+// authenticator is the gRPC counterpart of authmdw.Middleware, credentials
+// are read from the metadata keys named after the REST headers.
+type authenticator struct {
+	authenticationFactory factories.AuthenticationFactory
+	identityFactory       factories.IdentityFactory
+	projectService        services.ProjectService
+}
+
+func newAuthenticator(authenticationFactory factories.AuthenticationFactory, identityFactory factories.IdentityFactory, projectService services.ProjectService) *authenticator {
+	return &authenticator{
+		authenticationFactory: authenticationFactory,
+		identityFactory:       identityFactory,
+		projectService:        projectService,
+	}
+}
+
+func (a *authenticator) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	service, _, _ := strings.Cut(strings.TrimPrefix(info.FullMethod, "/"), "/")
+	mode, ok := serviceAuthModes[service]
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "unknown service %s", service)
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	var err error
+	switch mode {
+	case authModeProject:
+		ctx, err = a.authenticateProject(ctx, md)
+	case authModeUser:
+		ctx, err = a.authenticateUser(ctx, md)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// authenticateProject authenticates with the project's API key and either its
+// secret or a registered client certificate. Signed requests are a REST only
+// scheme, projects requiring them can't use the gRPC API.
+func (a *authenticator) authenticateProject(ctx context.Context, md metadata.MD) (context.Context, error) {
+	apiKey := firstValue(md, authmdw.APIKeyHeader)
+	if apiKey == "" {
+		return nil, toStatus(api.ErrMissingAPIKey)
+	}
+
+	apiSecret := firstValue(md, authmdw.APISecretHeader)
+	chain := peerCertificateChain(ctx)
+	if apiSecret == "" && len(chain) == 0 {
+		return nil, toStatus(api.ErrMissingAPISecret)
+	}
+
+	var projectAuthenticator factories.Authenticator
+	if len(chain) == 0 {
+		projectAuthenticator = a.authenticationFactory.CreateProjectAuthenticator(apiKey, apiSecret, nil)
+	} else {
+		projectAuthenticator = a.authenticationFactory.CreateCertificateAuthenticator(apiKey, apiSecret, nil, chain)
+	}
+	auth, err := projectAuthenticator.Authenticate(ctx)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainErrors.ErrClientCertificateRequired):
+			return nil, toStatus(api.ErrClientCertificateRequired)
+		case errors.Is(err, domainErrors.ErrRequestSignatureRequired):
+			return nil, toStatus(api.ErrRequestSignatureRequired)
+		case errors.Is(err, domainErrors.ErrProjectSuspended):
+			return nil, toStatus(api.ErrProjectSuspended)
+		default:
+			return nil, toStatus(api.ErrInvalidAPICredentials)
+		}
+	}
+
+	ctx = contexter.WithProjectID(ctx, auth.ProjectID)
+	ctx = contexter.WithProjectStatus(ctx, auth.ProjectStatus)
+	return ctx, nil
+}
+
+// authenticateUser authenticates the user's token against the project's
+// identity provider. There are no cookies in gRPC, the token is always read
+// from the authorization metadata, even for providers configured with a
+// cookie field name.
+func (a *authenticator) authenticateUser(ctx context.Context, md metadata.MD) (context.Context, error) {
+	apiKey := firstValue(md, authmdw.APIKeyHeader)
+	if apiKey == "" {
+		return nil, toStatus(api.ErrMissingAPIKey)
+	}
+
+	providerStr := firstValue(md, authmdw.AuthProviderHeader)
+	if providerStr == "" {
+		return nil, toStatus(api.ErrMissingAuthProvider)
+	}
+
+	proj, err := a.projectService.GetByAPIKey(ctx, apiKey)
+	if err != nil {
+		return nil, toStatus(api.ErrInvalidAPICredentials)
+	}
+
+	var identity factories.Identity
+	switch providerStr {
+	case authmdw.AuthenticationTypeCustom:
+		identity, err = a.identityFactory.CreateCustomIdentity(ctx, proj.ID)
+	case authmdw.AuthenticationTypeOpenfort:
+		identity, err = a.identityFactory.CreateOpenfortIdentity(ctx, proj.ID,
+			optionalValue(md, authmdw.OpenfortProviderHeader),
+			optionalValue(md, authmdw.OpenfortTokenTypeHeader))
+	case authmdw.AuthenticationTypeIntrospection:
+		identity, err = a.identityFactory.CreateIntrospectionIdentity(ctx, proj.ID)
+	default:
+		return nil, toStatus(api.ErrInvalidAuthProvider)
+	}
+	if err != nil {
+		return nil, toStatus(api.ErrInvalidAuthProvider)
+	}
+
+	token, apiErr := bearerToken(firstValue(md, authmdw.TokenHeader))
+	if apiErr != nil {
+		return nil, toStatus(apiErr)
+	}
+
+	auth, err := a.authenticationFactory.CreateUserAuthenticator(proj, token, identity).Authenticate(ctx)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainErrors.ErrIdentityProviderUnavailable):
+			return nil, toStatus(api.ErrIdentityUnavailable)
+		case errors.Is(err, domainErrors.ErrProjectSuspended):
+			return nil, toStatus(api.ErrProjectSuspended)
+		default:
+			return nil, toStatus(api.ErrInvalidToken)
+		}
+	}
+
+	ctx = contexter.WithUserID(ctx, auth.UserID)
+	ctx = contexter.WithProjectID(ctx, auth.ProjectID)
+	ctx = contexter.WithExternalUserID(ctx, auth.ExternalUserID)
+	ctx = contexter.WithProject(ctx, proj)
+	ctx = contexter.WithProjectStatus(ctx, auth.ProjectStatus)
+	return ctx, nil
+}
+
+func bearerToken(value string) (string, *api.Error) {
+	if value == "" {
+		return "", api.ErrMissingToken
+	}
+
+	scheme, token, ok := strings.Cut(value, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", api.ErrInvalidToken
+	}
+
+	return token, nil
+}
+
+func optionalValue(md metadata.MD, key string) *string {
+	value := firstValue(md, key)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// peerCertificateChain returns the certificates the client presented during
+// the TLS handshake, leaf first, preferring the verified chain as the REST
+// server does.
+func peerCertificateChain(ctx context.Context) []*x509.Certificate {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return nil
+	}
+
+	if len(info.State.VerifiedChains) > 0 {
+		return info.State.VerifiedChains[0]
+	}
+	return info.State.PeerCertificates
+}
@@ -0,0 +1,25 @@
+package grpc
+
+import (
+	env "github.com/caarlos0/env/v10"
+)
+
+// Config holds the configuration for the gRPC server.
+// The default values are used if the environment variables are not set.
+// The environment variables are:
+// - GRPC_PORT: the port the gRPC server listens on (if 0, the gRPC server is disabled)
+// - TLS_CERT_FILE / TLS_KEY_FILE / TLS_CLIENT_CA_FILE / TLS_REQUIRE_CLIENT_CERT: shared with the REST server, see rest.Config
+type Config struct {
+	Port                 int    `env:"GRPC_PORT" envDefault:"0"`
+	TLSCertFile          string `env:"TLS_CERT_FILE"`
+	TLSKeyFile           string `env:"TLS_KEY_FILE"`
+	TLSClientCAFile      string `env:"TLS_CLIENT_CA_FILE"`
+	TLSRequireClientCert bool   `env:"TLS_REQUIRE_CLIENT_CERT" envDefault:"false"`
+}
+
+// GetConfigFromEnv gets the configuration from the environment variables.
+func GetConfigFromEnv() (*Config, error) {
+	config := &Config{}
+	err := env.Parse(config)
+	return config, err
+}
@@ -0,0 +1,57 @@
+package grpc
+
+import (
+	"net/http"
+
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/api"
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/projecthdl"
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/sharehdl"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain is the domain of the ErrorInfo detail attached to every error,
+// its reason holds the same code the REST API reports.
+const ErrorDomain = "shield"
+
+var codesByHTTPStatus = map[int]codes.Code{
+	http.StatusBadRequest:           codes.InvalidArgument,
+	http.StatusUnauthorized:         codes.Unauthenticated,
+	http.StatusForbidden:            codes.PermissionDenied,
+	http.StatusNotFound:             codes.NotFound,
+	http.StatusConflict:             codes.FailedPrecondition,
+	http.StatusPreconditionFailed:   codes.FailedPrecondition,
+	http.StatusPreconditionRequired: codes.FailedPrecondition,
+	http.StatusUnprocessableEntity:  codes.InvalidArgument,
+	http.StatusTooManyRequests:      codes.ResourceExhausted,
+	http.StatusServiceUnavailable:   codes.Unavailable,
+}
+
+// toStatus converts an API error into a gRPC status error, so both APIs
+// report the same codes for the same failures.
+func toStatus(apiErr *api.Error) error {
+	code, ok := codesByHTTPStatus[apiErr.Status]
+	if !ok {
+		code = codes.Internal
+	}
+
+	st := status.New(code, apiErr.Message)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: apiErr.Code,
+		Domain: ErrorDomain,
+	})
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+func fromShareError(err error) error {
+	return toStatus(sharehdl.FromApplicationError(err))
+}
+
+func fromProjectError(err error) error {
+	return toStatus(projecthdl.FromApplicationError(err))
+}
@@ -0,0 +1,378 @@
+package grpc
+
+import (
+	"time"
+
+	"github.com/openfort-xyz/shield/internal/applications/projectapp"
+	"github.com/openfort-xyz/shield/internal/core/domain/keychain"
+	"github.com/openfort-xyz/shield/internal/core/domain/project"
+	"github.com/openfort-xyz/shield/internal/core/domain/provider"
+	"github.com/openfort-xyz/shield/internal/core/domain/share"
+	shieldv1 "github.com/openfort-xyz/shield/pkg/pb/shield/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type parser struct {
+	mapEntropyDomain        map[shieldv1.Entropy]share.Entropy
+	mapDomainEntropy        map[share.Entropy]shieldv1.Entropy
+	mapStorageMethodDomain  map[shieldv1.StorageMethod]share.StorageMethodID
+	mapDomainStorageMethod  map[share.StorageMethodID]shieldv1.StorageMethod
+	mapKeyTypeDomain        map[shieldv1.KeyType]provider.KeyType
+	mapDomainKeyType        map[provider.KeyType]shieldv1.KeyType
+	mapDomainProviderType   map[provider.Type]shieldv1.ProviderType
+	mapDomainClientCertMode map[project.ClientCertMode]shieldv1.ClientCertMode
+	mapDomainStatus         map[project.Status]shieldv1.ProjectStatus
+}
+
+func newParser() *parser {
+	return &parser{
+		mapEntropyDomain: map[shieldv1.Entropy]share.Entropy{
+			shieldv1.Entropy_ENTROPY_NONE:    share.EntropyNone,
+			shieldv1.Entropy_ENTROPY_USER:    share.EntropyUser,
+			shieldv1.Entropy_ENTROPY_PROJECT: share.EntropyProject,
+			shieldv1.Entropy_ENTROPY_PASSKEY: share.EntropyPasskey,
+		},
+		mapDomainEntropy: map[share.Entropy]shieldv1.Entropy{
+			share.EntropyNone:    shieldv1.Entropy_ENTROPY_NONE,
+			share.EntropyUser:    shieldv1.Entropy_ENTROPY_USER,
+			share.EntropyProject: shieldv1.Entropy_ENTROPY_PROJECT,
+			share.EntropyPasskey: shieldv1.Entropy_ENTROPY_PASSKEY,
+		},
+		mapStorageMethodDomain: map[shieldv1.StorageMethod]share.StorageMethodID{
+			shieldv1.StorageMethod_STORAGE_METHOD_SHIELD:       share.StorageMethodShield,
+			shieldv1.StorageMethod_STORAGE_METHOD_GOOGLE_DRIVE: share.StorageMethodGoogleDrive,
+			shieldv1.StorageMethod_STORAGE_METHOD_ICLOUD:       share.StorageMethodICloud,
+		},
+		mapDomainStorageMethod: map[share.StorageMethodID]shieldv1.StorageMethod{
+			share.StorageMethodShield:      shieldv1.StorageMethod_STORAGE_METHOD_SHIELD,
+			share.StorageMethodGoogleDrive: shieldv1.StorageMethod_STORAGE_METHOD_GOOGLE_DRIVE,
+			share.StorageMethodICloud:      shieldv1.StorageMethod_STORAGE_METHOD_ICLOUD,
+		},
+		mapKeyTypeDomain: map[shieldv1.KeyType]provider.KeyType{
+			shieldv1.KeyType_KEY_TYPE_RSA:     provider.KeyTypeRSA,
+			shieldv1.KeyType_KEY_TYPE_ECDSA:   provider.KeyTypeECDSA,
+			shieldv1.KeyType_KEY_TYPE_ED25519: provider.KeyTypeEd25519,
+			shieldv1.KeyType_KEY_TYPE_HMAC:    provider.KeyTypeHMAC,
+		},
+		mapDomainKeyType: map[provider.KeyType]shieldv1.KeyType{
+			provider.KeyTypeRSA:     shieldv1.KeyType_KEY_TYPE_RSA,
+			provider.KeyTypeECDSA:   shieldv1.KeyType_KEY_TYPE_ECDSA,
+			provider.KeyTypeEd25519: shieldv1.KeyType_KEY_TYPE_ED25519,
+			provider.KeyTypeHMAC:    shieldv1.KeyType_KEY_TYPE_HMAC,
+		},
+		mapDomainProviderType: map[provider.Type]shieldv1.ProviderType{
+			provider.TypeOpenfort:      shieldv1.ProviderType_PROVIDER_TYPE_OPENFORT,
+			provider.TypeCustom:        shieldv1.ProviderType_PROVIDER_TYPE_CUSTOM,
+			provider.TypeIntrospection: shieldv1.ProviderType_PROVIDER_TYPE_INTROSPECTION,
+		},
+		mapDomainClientCertMode: map[project.ClientCertMode]shieldv1.ClientCertMode{
+			project.ClientCertModeDisabled:    shieldv1.ClientCertMode_CLIENT_CERT_MODE_DISABLED,
+			project.ClientCertModeAlternative: shieldv1.ClientCertMode_CLIENT_CERT_MODE_ALTERNATIVE,
+			project.ClientCertModeRequired:    shieldv1.ClientCertMode_CLIENT_CERT_MODE_REQUIRED,
+		},
+		mapDomainStatus: map[project.Status]shieldv1.ProjectStatus{
+			project.StatusActive:    shieldv1.ProjectStatus_PROJECT_STATUS_ACTIVE,
+			project.StatusReadOnly:  shieldv1.ProjectStatus_PROJECT_STATUS_READ_ONLY,
+			project.StatusSuspended: shieldv1.ProjectStatus_PROJECT_STATUS_SUSPENDED,
+		},
+	}
+}
+
+// toDomainShare follows sharehdl's parser: the entropy is inferred from the
+// encryption parameters and the project encryption material when they are set.
+func (p *parser) toDomainShare(s *shieldv1.Share, encryptionPart, encryptionSession string) *share.Share {
+	shr := &share.Share{
+		Secret:               s.GetSecret(),
+		Entropy:              p.mapEntropyDomain[s.GetEntropy()],
+		ShareStorageMethodID: p.mapStorageMethodDomain[s.GetStorageMethod()],
+		Metadata:             s.GetMetadata(),
+	}
+
+	if s.GetKeychainId() != "" {
+		keychainID := s.GetKeychainId()
+		shr.KeychainID = &keychainID
+	}
+
+	if s.GetReference() != "" {
+		reference := s.GetReference()
+		shr.Reference = &reference
+	}
+
+	if encryptionPart != "" || encryptionSession != "" {
+		shr.Entropy = share.EntropyProject
+	}
+
+	if params := s.GetEncryptionParameters(); params != nil && !isEmptyEncryptionParameters(params) {
+		shr.EncryptionParameters = &share.EncryptionParameters{
+			Salt:       params.GetSalt(),
+			Iterations: int(params.GetIterations()),
+			Length:     int(params.GetLength()),
+			Digest:     params.GetDigest(),
+		}
+		shr.Entropy = share.EntropyUser
+	}
+
+	if s.GetEntropy() == shieldv1.Entropy_ENTROPY_PASSKEY && s.GetPasskeyReference() != nil {
+		shr.PasskeyReference = &share.PasskeyReference{
+			PasskeyID: s.GetPasskeyReference().GetPasskeyId(),
+		}
+		if env := s.GetPasskeyReference().GetPasskeyEnv(); env != nil {
+			shr.PasskeyReference.PasskeyEnv = &share.PasskeyEnv{
+				Name:      env.Name,
+				OS:        env.Os,
+				OSVersion: env.OsVersion,
+				Device:    env.Device,
+			}
+		}
+	}
+
+	return shr
+}
+
+func isEmptyEncryptionParameters(params *shieldv1.EncryptionParameters) bool {
+	return params.GetSalt() == "" && params.GetIterations() == 0 && params.GetLength() == 0 && params.GetDigest() == ""
+}
+
+func (p *parser) fromDomainShare(s *share.Share) *shieldv1.Share {
+	shr := &shieldv1.Share{
+		Secret:        s.Secret,
+		Entropy:       p.mapDomainEntropy[s.Entropy],
+		StorageMethod: p.mapDomainStorageMethod[s.ShareStorageMethodID],
+		Metadata:      s.Metadata,
+	}
+
+	if s.KeychainID != nil {
+		shr.KeychainId = *s.KeychainID
+	}
+
+	if s.Reference != nil {
+		shr.Reference = *s.Reference
+	}
+
+	if s.EncryptionParameters != nil {
+		shr.EncryptionParameters = &shieldv1.EncryptionParameters{
+			Salt:       s.EncryptionParameters.Salt,
+			Iterations: int32(s.EncryptionParameters.Iterations), //nolint:gosec // bounded by the share validator
+			Length:     int32(s.EncryptionParameters.Length),     //nolint:gosec // bounded by the share validator
+			Digest:     s.EncryptionParameters.Digest,
+		}
+	}
+
+	if s.PasskeyReference != nil {
+		shr.PasskeyReference = &shieldv1.PasskeyReference{
+			PasskeyId: s.PasskeyReference.PasskeyID,
+		}
+		if s.PasskeyReference.PasskeyEnv != nil {
+			shr.PasskeyReference.PasskeyEnv = &shieldv1.PasskeyEnv{
+				Name:      s.PasskeyReference.PasskeyEnv.Name,
+				Os:        s.PasskeyReference.PasskeyEnv.OS,
+				OsVersion: s.PasskeyReference.PasskeyEnv.OSVersion,
+				Device:    s.PasskeyReference.PasskeyEnv.Device,
+			}
+		}
+	}
+
+	return shr
+}
+
+func (p *parser) fromDomainKeychainMetadata(k *keychain.Keychain, shrs []*share.Share) *shieldv1.GetKeychainMetadataResponse {
+	resp := &shieldv1.GetKeychainMetadataResponse{
+		Id:         k.ID,
+		CreatedAt:  timestamppb.New(k.CreatedAt),
+		UpdatedAt:  timestamppb.New(k.UpdatedAt),
+		References: make([]*shieldv1.ReferenceMetadata, 0, len(shrs)),
+	}
+
+	for _, s := range shrs {
+		ref := &shieldv1.ReferenceMetadata{
+			Entropy:       p.mapDomainEntropy[s.Entropy],
+			StorageMethod: p.mapDomainStorageMethod[s.ShareStorageMethodID],
+			Metadata:      s.Metadata,
+			CreatedAt:     timestamppb.New(s.CreatedAt),
+			UpdatedAt:     timestamppb.New(s.UpdatedAt),
+		}
+		if s.Reference != nil {
+			ref.Reference = *s.Reference
+		}
+		if s.PasskeyReference != nil {
+			ref.PasskeyId = s.PasskeyReference.PasskeyID
+		}
+		resp.References = append(resp.References, ref)
+	}
+
+	return resp
+}
+
+// fromDomainEncryptionTypes returns an entry for every requested key, found
+// or not, as the REST bulk endpoints do.
+func (p *parser) fromDomainEncryptionTypes(requested []string, found map[string]share.RecoveryInfo) map[string]*shieldv1.EncryptionType {
+	types := make(map[string]*shieldv1.EncryptionType, len(requested))
+	for _, key := range requested {
+		info, ok := found[key]
+		if !ok {
+			types[key] = &shieldv1.EncryptionType{}
+			continue
+		}
+		types[key] = &shieldv1.EncryptionType{
+			Found:      true,
+			Entropy:    p.mapDomainEntropy[info.Entropy],
+			PasskeyId:  info.PasskeyID,
+			PasskeyEnv: p.toPasskeyEnv(info.PasskeyEnv),
+			Metadata:   info.Metadata,
+		}
+	}
+	return types
+}
+
+func (p *parser) toPasskeyEnv(s *string) *shieldv1.PasskeyEnv {
+	if s == nil {
+		return nil
+	}
+
+	matches := share.PasskeyEnvPattern.FindStringSubmatch(*s)
+	if matches == nil {
+		return nil
+	}
+
+	return &shieldv1.PasskeyEnv{
+		Name:      &matches[1],
+		Os:        &matches[2],
+		OsVersion: &matches[3],
+		Device:    &matches[4],
+	}
+}
+
+func (p *parser) fromDomainProject(proj *project.Project) *shieldv1.Project {
+	mode, ok := p.mapDomainClientCertMode[proj.ClientCertMode]
+	if !ok {
+		mode = shieldv1.ClientCertMode_CLIENT_CERT_MODE_DISABLED
+	}
+
+	status, ok := p.mapDomainStatus[proj.Status]
+	if !ok {
+		status = shieldv1.ProjectStatus_PROJECT_STATUS_ACTIVE
+	}
+
+	return &shieldv1.Project{
+		Id:                     proj.ID,
+		Name:                   proj.Name,
+		Enabled_2Fa:            proj.Enable2FA,
+		ClientCertMode:         mode,
+		SignedRequestsRequired: proj.RequireSignedRequests,
+		Status:                 status,
+	}
+}
+
+func (p *parser) fromDomainProviders(providers []*provider.Provider) []*shieldv1.Provider {
+	resp := make([]*shieldv1.Provider, 0, len(providers))
+	for _, prov := range providers {
+		resp = append(resp, &shieldv1.Provider{
+			ProviderId: prov.ID,
+			Type:       p.mapDomainProviderType[prov.Type],
+		})
+	}
+	return resp
+}
+
+func (p *parser) fromDomainProviderDetail(prov *provider.Provider) *shieldv1.ProviderDetail {
+	resp := &shieldv1.ProviderDetail{
+		ProviderId: prov.ID,
+		Type:       p.mapDomainProviderType[prov.Type],
+	}
+
+	switch cfg := prov.Config.(type) {
+	case *provider.OpenfortConfig:
+		resp.PublishableKey = cfg.PublishableKey
+	case *provider.CustomConfig:
+		resp.Jwk = cfg.JWK
+		resp.Pem = cfg.PEM
+		resp.CookieFieldName = cfg.CookieFieldName
+		resp.KeyType = p.mapDomainKeyType[cfg.KeyType]
+		for _, key := range cfg.PEMKeys() {
+			resp.Keys = append(resp.Keys, &shieldv1.ProviderKey{
+				KeyId:     key.ID,
+				Kid:       key.KID,
+				Pem:       key.PEM,
+				KeyType:   p.mapDomainKeyType[key.KeyType],
+				NotBefore: optionalTimestamp(key.NotBefore),
+				NotAfter:  optionalTimestamp(key.NotAfter),
+			})
+		}
+	case *provider.IntrospectionConfig:
+		resp.Endpoint = cfg.Endpoint
+		resp.ClientId = cfg.ClientID
+		resp.UserIdClaim = cfg.UserIDClaim
+	}
+
+	return resp
+}
+
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func (p *parser) fromAddProvidersRequest(req *shieldv1.AddProvidersRequest) []projectapp.ProviderOption {
+	var opts []projectapp.ProviderOption
+
+	if req.GetOpenfort().GetPublishableKey() != "" {
+		opts = append(opts, projectapp.WithOpenfort(req.GetOpenfort().GetPublishableKey()))
+	}
+
+	if custom := req.GetCustom(); custom != nil {
+		if custom.GetJwk() != "" {
+			opts = append(opts, projectapp.WithCustomJWK(custom.GetJwk()))
+		}
+		if custom.GetPem() != "" {
+			opts = append(opts, projectapp.WithCustomPEM(custom.GetPem(), p.mapKeyTypeDomain[custom.GetKeyType()]))
+		}
+		if custom.GetSecret() != "" {
+			opts = append(opts, projectapp.WithCustomHMACSecret(custom.GetSecret()))
+		}
+		if custom.CookieFieldName != nil {
+			opts = append(opts, projectapp.WithCustomCookieFieldName(custom.GetCookieFieldName()))
+		}
+	}
+
+	if req.GetIntrospection() != nil {
+		opts = append(opts, fromIntrospectionProvider(req.GetIntrospection()))
+	}
+
+	return opts
+}
+
+func (p *parser) fromUpdateProviderRequest(req *shieldv1.UpdateProviderRequest) []projectapp.ProviderOption {
+	var opts []projectapp.ProviderOption
+
+	if req.GetJwk() != "" {
+		opts = append(opts, projectapp.WithCustomJWK(req.GetJwk()))
+	}
+
+	if req.GetPublishableKey() != "" {
+		opts = append(opts, projectapp.WithOpenfort(req.GetPublishableKey()))
+	}
+
+	if req.GetPem() != "" {
+		opts = append(opts, projectapp.WithCustomPEM(req.GetPem(), p.mapKeyTypeDomain[req.GetKeyType()]))
+	}
+
+	if req.GetSecret() != "" {
+		opts = append(opts, projectapp.WithCustomHMACSecret(req.GetSecret()))
+	}
+
+	if req.CookieFieldName != nil {
+		opts = append(opts, projectapp.WithCustomCookieFieldName(req.GetCookieFieldName()))
+	}
+
+	if req.GetIntrospection() != nil {
+		opts = append(opts, fromIntrospectionProvider(req.GetIntrospection()))
+	}
+
+	return opts
+}
+
+func fromIntrospectionProvider(prov *shieldv1.IntrospectionProvider) projectapp.ProviderOption {
+	return projectapp.WithIntrospection(prov.GetEndpoint(), prov.GetClientId(), prov.GetClientSecret(), prov.GetUserIdClaim())
+}
@@ -0,0 +1,156 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/api"
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/sharehdl"
+	"github.com/openfort-xyz/shield/internal/applications/projectapp"
+	"github.com/openfort-xyz/shield/internal/applications/shareapp"
+	"github.com/openfort-xyz/shield/pkg/logger"
+	shieldv1 "github.com/openfort-xyz/shield/pkg/pb/shield/v1"
+)
+
+type projectServer struct {
+	shieldv1.UnimplementedProjectServiceServer
+	app      *projectapp.ProjectApplication
+	shareApp *shareapp.ShareApplication
+	logger   *slog.Logger
+	parser   *parser
+}
+
+func newProjectServer(app *projectapp.ProjectApplication, shareApp *shareapp.ShareApplication) *projectServer {
+	return &projectServer{
+		app:      app,
+		shareApp: shareApp,
+		logger:   logger.New("grpc_project_handler"),
+		parser:   newParser(),
+	}
+}
+
+func (s *projectServer) GetProject(ctx context.Context, _ *shieldv1.GetProjectRequest) (*shieldv1.GetProjectResponse, error) {
+	s.logger.InfoContext(ctx, "getting project")
+
+	proj, err := s.app.GetProject(ctx)
+	if err != nil {
+		return nil, fromProjectError(err)
+	}
+
+	return &shieldv1.GetProjectResponse{Project: s.parser.fromDomainProject(proj)}, nil
+}
+
+func (s *projectServer) ListProviders(ctx context.Context, _ *shieldv1.ListProvidersRequest) (*shieldv1.ListProvidersResponse, error) {
+	s.logger.InfoContext(ctx, "getting providers")
+
+	providers, err := s.app.GetProviders(ctx)
+	if err != nil {
+		return nil, fromProjectError(err)
+	}
+
+	return &shieldv1.ListProvidersResponse{Providers: s.parser.fromDomainProviders(providers)}, nil
+}
+
+func (s *projectServer) GetProvider(ctx context.Context, req *shieldv1.GetProviderRequest) (*shieldv1.GetProviderResponse, error) {
+	s.logger.InfoContext(ctx, "getting provider")
+
+	if req.GetProviderId() == "" {
+		return nil, toStatus(api.ErrMissingProvider)
+	}
+
+	prov, err := s.app.GetProviderDetail(ctx, req.GetProviderId())
+	if err != nil {
+		return nil, fromProjectError(err)
+	}
+
+	return &shieldv1.GetProviderResponse{Provider: s.parser.fromDomainProviderDetail(prov)}, nil
+}
+
+func (s *projectServer) AddProviders(ctx context.Context, req *shieldv1.AddProvidersRequest) (*shieldv1.AddProvidersResponse, error) {
+	s.logger.InfoContext(ctx, "adding providers")
+
+	providers, err := s.app.AddProviders(ctx, s.parser.fromAddProvidersRequest(req)...)
+	if err != nil {
+		return nil, fromProjectError(err)
+	}
+
+	return &shieldv1.AddProvidersResponse{Providers: s.parser.fromDomainProviders(providers)}, nil
+}
+
+func (s *projectServer) UpdateProvider(ctx context.Context, req *shieldv1.UpdateProviderRequest) (*shieldv1.UpdateProviderResponse, error) {
+	s.logger.InfoContext(ctx, "updating provider")
+
+	if req.GetProviderId() == "" {
+		return nil, toStatus(api.ErrMissingProvider)
+	}
+
+	err := s.app.UpdateProvider(ctx, req.GetProviderId(), s.parser.fromUpdateProviderRequest(req)...)
+	if err != nil {
+		return nil, fromProjectError(err)
+	}
+
+	return &shieldv1.UpdateProviderResponse{}, nil
+}
+
+func (s *projectServer) DeleteProvider(ctx context.Context, req *shieldv1.DeleteProviderRequest) (*shieldv1.DeleteProviderResponse, error) {
+	s.logger.InfoContext(ctx, "deleting provider")
+
+	if req.GetProviderId() == "" {
+		return nil, toStatus(api.ErrMissingProvider)
+	}
+
+	err := s.app.RemoveProvider(ctx, req.GetProviderId())
+	if err != nil {
+		return nil, fromProjectError(err)
+	}
+
+	return &shieldv1.DeleteProviderResponse{}, nil
+}
+
+func (s *projectServer) RegisterEncryptionSession(ctx context.Context, req *shieldv1.RegisterEncryptionSessionRequest) (*shieldv1.RegisterEncryptionSessionResponse, error) {
+	s.logger.InfoContext(ctx, "registering encryption session")
+
+	sessionID, err := s.app.RegisterEncryptionSession(ctx, req.GetEncryptionPart(), req.GetUserId(), req.OtpCode)
+	if err != nil {
+		return nil, fromProjectError(err)
+	}
+
+	return &shieldv1.RegisterEncryptionSessionResponse{SessionId: sessionID}, nil
+}
+
+func (s *projectServer) GetSharesEncryptionForReferences(ctx context.Context, req *shieldv1.GetSharesEncryptionForReferencesRequest) (*shieldv1.GetSharesEncryptionForReferencesResponse, error) {
+	s.logger.InfoContext(ctx, "getting shares encryption for references")
+
+	if len(req.GetReferences()) > sharehdl.MaxBulkSize {
+		return nil, toStatus(api.ErrBadRequestWithMessage(fmt.Sprintf("Requests with more than %d elements are not allowed", sharehdl.MaxBulkSize)))
+	}
+
+	found, err := s.shareApp.GetSharesEncryptionForReferences(ctx, req.GetReferences())
+	if err != nil {
+		// Any error here must be the server's fault (the request is well-formed)
+		return nil, toStatus(api.ErrInternal)
+	}
+
+	return &shieldv1.GetSharesEncryptionForReferencesResponse{
+		EncryptionTypes: s.parser.fromDomainEncryptionTypes(req.GetReferences(), found),
+	}, nil
+}
+
+func (s *projectServer) GetSharesEncryptionForUsers(ctx context.Context, req *shieldv1.GetSharesEncryptionForUsersRequest) (*shieldv1.GetSharesEncryptionForUsersResponse, error) {
+	s.logger.InfoContext(ctx, "getting shares encryption for users")
+
+	if len(req.GetUserIds()) > sharehdl.MaxBulkSize {
+		return nil, toStatus(api.ErrBadRequestWithMessage(fmt.Sprintf("Requests with more than %d elements are not allowed", sharehdl.MaxBulkSize)))
+	}
+
+	found, err := s.shareApp.GetSharesEncryptionForUsers(ctx, req.GetUserIds(), req.Reference)
+	if err != nil {
+		// Any error here must be the server's fault (the request is well-formed)
+		return nil, toStatus(api.ErrInternal)
+	}
+
+	return &shieldv1.GetSharesEncryptionForUsersResponse{
+		EncryptionTypes: s.parser.fromDomainEncryptionTypes(req.GetUserIds(), found),
+	}, nil
+}
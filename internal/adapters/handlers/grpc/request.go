@@ -0,0 +1,38 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/requestmdw"
+	"github.com/openfort-xyz/shield/pkg/contexter"
+	"github.com/openfort-xyz/shield/pkg/random"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// requestIDInterceptor is the gRPC counterpart of
+// requestmdw.RequestIDMiddleware, the request ID is read from and echoed in
+// the x-request-id metadata.
+func requestIDInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	requestID := firstValue(md, requestmdw.RequestIDHeader)
+	if requestID == "" {
+		requestID, _ = random.UUIDv7()
+	}
+
+	ctx = contexter.WithRequestID(ctx, requestID)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(strings.ToLower(requestmdw.RequestIDHeader), requestID))
+
+	return handler(ctx, req)
+}
+
+// firstValue returns the first value of the metadata key, metadata keys are
+// the lower-cased REST header names.
+func firstValue(md metadata.MD, key string) string {
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
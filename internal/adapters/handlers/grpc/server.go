@@ -0,0 +1,127 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"sync"
+
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/servertls"
+	"github.com/openfort-xyz/shield/internal/applications/projectapp"
+	"github.com/openfort-xyz/shield/internal/applications/shareapp"
+	"github.com/openfort-xyz/shield/internal/core/ports/factories"
+	"github.com/openfort-xyz/shield/internal/core/ports/services"
+	"github.com/openfort-xyz/shield/pkg/logger"
+	shieldv1 "github.com/openfort-xyz/shield/pkg/pb/shield/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// Server is the gRPC server for the shield API, it serves the share, keychain
+// and project operations of the REST API from the same applications.
+type Server struct {
+	projectApp            *projectapp.ProjectApplication
+	shareApp              *shareapp.ShareApplication
+	logger                *slog.Logger
+	config                *Config
+	authenticationFactory factories.AuthenticationFactory
+	identityFactory       factories.IdentityFactory
+	projectService        services.ProjectService
+
+	mu      sync.Mutex
+	server  *grpc.Server
+	stopped bool
+}
+
+// New creates a new gRPC server
+func New(cfg *Config,
+	projectApp *projectapp.ProjectApplication,
+	shareApp *shareapp.ShareApplication,
+	authenticationFactory factories.AuthenticationFactory,
+	identityFactory factories.IdentityFactory,
+	projectService services.ProjectService) *Server {
+	return &Server{
+		projectApp:            projectApp,
+		shareApp:              shareApp,
+		logger:                logger.New("grpc_server"),
+		config:                cfg,
+		authenticationFactory: authenticationFactory,
+		identityFactory:       identityFactory,
+		projectService:        projectService,
+	}
+}
+
+// Start starts the gRPC server and blocks until it stops. It returns right
+// away when no port is configured.
+func (s *Server) Start(ctx context.Context) error {
+	if s.config.Port == 0 {
+		s.logger.InfoContext(ctx, "gRPC server disabled")
+		return nil
+	}
+
+	tlsConfig, err := servertls.New(s.config.TLSCertFile, s.config.TLSKeyFile, s.config.TLSClientCAFile, s.config.TLSRequireClientCert)
+	if err != nil {
+		return err
+	}
+
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.config.Port))
+	if err != nil {
+		return err
+	}
+
+	var opts []grpc.ServerOption
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	server := s.newServer(opts...)
+
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		return lis.Close()
+	}
+	s.server = server
+	s.mu.Unlock()
+
+	s.logger.InfoContext(ctx, "starting gRPC server", slog.String("address", lis.Addr().String()), slog.Bool("tls", tlsConfig != nil))
+	return server.Serve(lis)
+}
+
+func (s *Server) newServer(opts ...grpc.ServerOption) *grpc.Server {
+	auth := newAuthenticator(s.authenticationFactory, s.identityFactory, s.projectService)
+	opts = append(opts, grpc.ChainUnaryInterceptor(requestIDInterceptor, auth.unaryInterceptor))
+
+	server := grpc.NewServer(opts...)
+	shieldv1.RegisterShareServiceServer(server, newShareServer(s.shareApp))
+	shieldv1.RegisterKeychainServiceServer(server, newKeychainServer(s.shareApp))
+	shieldv1.RegisterProjectServiceServer(server, newProjectServer(s.projectApp, s.shareApp))
+	return server
+}
+
+// Stop stops the gRPC server gracefully, in-flight calls are cancelled once
+// the context is done.
+func (s *Server) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	s.stopped = true
+	server := s.server
+	s.mu.Unlock()
+
+	if server == nil {
+		return nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		server.Stop()
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package grpc
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/mocks/projectmockrepo"
+	"github.com/openfort-xyz/shield/internal/applications/projectapp"
+	"github.com/openfort-xyz/shield/internal/core/domain/authentication"
+	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
+	"github.com/openfort-xyz/shield/internal/core/domain/project"
+	"github.com/openfort-xyz/shield/internal/core/ports/factories"
+	shieldv1 "github.com/openfort-xyz/shield/pkg/pb/shield/v1"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type stubAuthenticationFactory struct {
+	apiSecret string
+}
+
+func (f *stubAuthenticationFactory) CreateProjectAuthenticator(_, apiSecret string, _ *authentication.RequestSignature) factories.Authenticator {
+	return stubAuthenticator{ok: apiSecret == f.apiSecret}
+}
+
+func (f *stubAuthenticationFactory) CreateCertificateAuthenticator(_, _ string, _ *authentication.RequestSignature, _ []*x509.Certificate) factories.Authenticator {
+	return stubAuthenticator{}
+}
+
+func (f *stubAuthenticationFactory) CreateUserAuthenticator(_ *project.Project, _ string, _ factories.Identity) factories.Authenticator {
+	return stubAuthenticator{}
+}
+
+type stubAuthenticator struct {
+	ok bool
+}
+
+func (a stubAuthenticator) Authenticate(_ context.Context) (*authentication.Authentication, error) {
+	if !a.ok {
+		return nil, errors.New("invalid credentials")
+	}
+	return &authentication.Authentication{ProjectID: "project-id", ProjectStatus: project.StatusActive}, nil
+}
+
+func newTestClient(t *testing.T, projectRepo *projectmockrepo.MockProjectRepository) shieldv1.ProjectServiceClient {
+	t.Helper()
+
+	app := projectapp.New(nil, projectRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	s := New(&Config{}, app, nil, &stubAuthenticationFactory{apiSecret: "api-secret"}, nil, nil)
+
+	lis := bufconn.Listen(1 << 20)
+	server := s.newServer()
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return shieldv1.NewProjectServiceClient(conn)
+}
+
+func errorReason(t *testing.T, err error) string {
+	t.Helper()
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			require.Equal(t, ErrorDomain, info.GetDomain())
+			return info.GetReason()
+		}
+	}
+	return ""
+}
+
+func TestServer_ProjectService(t *testing.T) {
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+	client := newTestClient(t, projectRepo)
+
+	tc := []struct {
+		name       string
+		md         metadata.MD
+		mock       func()
+		call       func(ctx context.Context) error
+		wantCode   codes.Code
+		wantReason string
+	}{
+		{
+			name: "missing api key",
+			md:   metadata.Pairs("x-api-secret", "api-secret"),
+			call: func(ctx context.Context) error {
+				_, err := client.GetProject(ctx, &shieldv1.GetProjectRequest{})
+				return err
+			},
+			wantCode:   codes.Unauthenticated,
+			wantReason: "A_MISSING",
+		},
+		{
+			name: "invalid api secret",
+			md:   metadata.Pairs("x-api-key", "api-key", "x-api-secret", "wrong"),
+			call: func(ctx context.Context) error {
+				_, err := client.GetProject(ctx, &shieldv1.GetProjectRequest{})
+				return err
+			},
+			wantCode:   codes.Unauthenticated,
+			wantReason: "A_INVALID",
+		},
+		{
+			name: "project not found",
+			md:   metadata.Pairs("x-api-key", "api-key", "x-api-secret", "api-secret"),
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				projectRepo.On("Get", mock.Anything, "project-id").Return(nil, domainErrors.ErrProjectNotFound)
+			},
+			call: func(ctx context.Context) error {
+				_, err := client.GetProject(ctx, &shieldv1.GetProjectRequest{})
+				return err
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "bulk request too large",
+			md:   metadata.Pairs("x-api-key", "api-key", "x-api-secret", "api-secret"),
+			call: func(ctx context.Context) error {
+				_, err := client.GetSharesEncryptionForReferences(ctx, &shieldv1.GetSharesEncryptionForReferencesRequest{References: make([]string, 101)})
+				return err
+			},
+			wantCode:   codes.InvalidArgument,
+			wantReason: "BAD_REQUEST",
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mock != nil {
+				tt.mock()
+			}
+			ctx := metadata.NewOutgoingContext(context.Background(), tt.md)
+			err := tt.call(ctx)
+			require.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantReason != "" {
+				require.Equal(t, tt.wantReason, errorReason(t, err))
+			}
+		})
+	}
+}
+
+func TestServer_GetProject(t *testing.T) {
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+	projectRepo.On("Get", mock.Anything, "project-id").Return(&project.Project{
+		ID:             "project-id",
+		Name:           "project name",
+		ClientCertMode: project.ClientCertModeRequired,
+		Status:         project.StatusReadOnly,
+	}, nil)
+	client := newTestClient(t, projectRepo)
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs(
+		"x-api-key", "api-key",
+		"x-api-secret", "api-secret",
+		"x-request-id", "request-id",
+	))
+	var header metadata.MD
+	resp, err := client.GetProject(ctx, &shieldv1.GetProjectRequest{}, grpc.Header(&header))
+	require.NoError(t, err)
+	require.Equal(t, "project-id", resp.GetProject().GetId())
+	require.Equal(t, shieldv1.ClientCertMode_CLIENT_CERT_MODE_REQUIRED, resp.GetProject().GetClientCertMode())
+	require.Equal(t, shieldv1.ProjectStatus_PROJECT_STATUS_READ_ONLY, resp.GetProject().GetStatus())
+	require.Equal(t, []string{"request-id"}, header.Get("x-request-id"))
+}
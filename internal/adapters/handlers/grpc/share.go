@@ -0,0 +1,178 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/api"
+	"github.com/openfort-xyz/shield/internal/applications/shareapp"
+	"github.com/openfort-xyz/shield/internal/core/domain/share"
+	"github.com/openfort-xyz/shield/pkg/logger"
+	shieldv1 "github.com/openfort-xyz/shield/pkg/pb/shield/v1"
+)
+
+type shareServer struct {
+	shieldv1.UnimplementedShareServiceServer
+	app    *shareapp.ShareApplication
+	logger *slog.Logger
+	parser *parser
+}
+
+func newShareServer(app *shareapp.ShareApplication) *shareServer {
+	return &shareServer{
+		app:    app,
+		logger: logger.New("grpc_share_handler"),
+		parser: newParser(),
+	}
+}
+
+func (s *shareServer) GetShare(ctx context.Context, req *shieldv1.GetShareRequest) (*shieldv1.GetShareResponse, error) {
+	s.logger.InfoContext(ctx, "getting share")
+
+	opts := encryptionOptions(req.GetEncryptionPart(), req.GetEncryptionSession())
+
+	var shr *share.Share
+	var err error
+	if req.GetReference() == "" {
+		shr, err = s.app.GetShare(ctx, opts...)
+	} else {
+		shr, err = s.app.GetShareByReference(ctx, req.GetReference(), opts...)
+	}
+	if err != nil {
+		return nil, fromShareError(err)
+	}
+
+	return &shieldv1.GetShareResponse{Share: s.parser.fromDomainShare(shr)}, nil
+}
+
+func (s *shareServer) RegisterShare(ctx context.Context, req *shieldv1.RegisterShareRequest) (*shieldv1.RegisterShareResponse, error) {
+	s.logger.InfoContext(ctx, "registering share")
+
+	if errV := validateShare(req.GetShare(), req.GetEncryptionPart(), req.GetEncryptionSession()); errV != nil {
+		return nil, toStatus(errV)
+	}
+
+	shr := s.parser.toDomainShare(req.GetShare(), req.GetEncryptionPart(), req.GetEncryptionSession())
+	err := s.app.RegisterShare(ctx, shr, encryptionOptions(req.GetEncryptionPart(), req.GetEncryptionSession())...)
+	if err != nil {
+		return nil, fromShareError(err)
+	}
+
+	return &shieldv1.RegisterShareResponse{}, nil
+}
+
+func (s *shareServer) UpdateShare(ctx context.Context, req *shieldv1.UpdateShareRequest) (*shieldv1.UpdateShareResponse, error) {
+	s.logger.InfoContext(ctx, "updating share")
+
+	if errV := validateShare(req.GetShare(), req.GetEncryptionPart(), req.GetEncryptionSession()); errV != nil {
+		return nil, toStatus(errV)
+	}
+
+	shr := s.parser.toDomainShare(req.GetShare(), req.GetEncryptionPart(), req.GetEncryptionSession())
+	updated, err := s.app.UpdateShare(ctx, shr, req.GetShare().GetReference(), encryptionOptions(req.GetEncryptionPart(), req.GetEncryptionSession())...)
+	if err != nil {
+		return nil, fromShareError(err)
+	}
+
+	return &shieldv1.UpdateShareResponse{Share: s.parser.fromDomainShare(updated)}, nil
+}
+
+func (s *shareServer) DeleteShare(ctx context.Context, req *shieldv1.DeleteShareRequest) (*shieldv1.DeleteShareResponse, error) {
+	s.logger.InfoContext(ctx, "deleting share")
+
+	var reference *string
+	if req.GetReference() != "" {
+		ref := req.GetReference()
+		reference = &ref
+	}
+
+	err := s.app.DeleteShare(ctx, reference)
+	if err != nil {
+		return nil, fromShareError(err)
+	}
+
+	return &shieldv1.DeleteShareResponse{}, nil
+}
+
+type keychainServer struct {
+	shieldv1.UnimplementedKeychainServiceServer
+	app    *shareapp.ShareApplication
+	logger *slog.Logger
+	parser *parser
+}
+
+func newKeychainServer(app *shareapp.ShareApplication) *keychainServer {
+	return &keychainServer{
+		app:    app,
+		logger: logger.New("grpc_keychain_handler"),
+		parser: newParser(),
+	}
+}
+
+func (s *keychainServer) GetKeychain(ctx context.Context, req *shieldv1.GetKeychainRequest) (*shieldv1.GetKeychainResponse, error) {
+	s.logger.InfoContext(ctx, "getting keychain")
+
+	var reference *string
+	if req.GetReference() != "" {
+		ref := req.GetReference()
+		reference = &ref
+	}
+
+	opts := encryptionOptions(req.GetEncryptionPart(), req.GetEncryptionSession())
+	if len(req.GetMetadata()) > 0 {
+		opts = append(opts, shareapp.WithMetadataFilter(share.Metadata(req.GetMetadata())))
+	}
+
+	shrs, err := s.app.GetKeychainShares(ctx, reference, opts...)
+	if err != nil {
+		return nil, fromShareError(err)
+	}
+
+	resp := &shieldv1.GetKeychainResponse{Shares: make([]*shieldv1.Share, 0, len(shrs))}
+	for _, shr := range shrs {
+		resp.Shares = append(resp.Shares, s.parser.fromDomainShare(shr))
+	}
+
+	return resp, nil
+}
+
+func (s *keychainServer) GetKeychainMetadata(ctx context.Context, _ *shieldv1.GetKeychainMetadataRequest) (*shieldv1.GetKeychainMetadataResponse, error) {
+	s.logger.InfoContext(ctx, "getting keychain metadata")
+
+	kc, shrs, err := s.app.GetKeychainMetadata(ctx)
+	if err != nil {
+		return nil, fromShareError(err)
+	}
+
+	return s.parser.fromDomainKeychainMetadata(kc, shrs), nil
+}
+
+func (s *keychainServer) RenameReference(ctx context.Context, req *shieldv1.RenameReferenceRequest) (*shieldv1.RenameReferenceResponse, error) {
+	s.logger.InfoContext(ctx, "renaming reference")
+
+	if req.GetReference() == "" {
+		return nil, toStatus(api.ErrBadRequestWithMessage("missing reference"))
+	}
+
+	if req.GetNewReference() == "" {
+		return nil, toStatus(api.ErrBadRequestWithMessage("new_reference is required"))
+	}
+
+	err := s.app.RenameReference(ctx, req.GetReference(), req.GetNewReference())
+	if err != nil {
+		return nil, fromShareError(err)
+	}
+
+	return &shieldv1.RenameReferenceResponse{}, nil
+}
+
+func encryptionOptions(encryptionPart, encryptionSession string) []shareapp.Option {
+	var opts []shareapp.Option
+	if encryptionPart != "" {
+		opts = append(opts, shareapp.WithEncryptionPart(encryptionPart))
+	}
+	if encryptionSession != "" {
+		opts = append(opts, shareapp.WithEncryptionSession(encryptionSession))
+	}
+	return opts
+}
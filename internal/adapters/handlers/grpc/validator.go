@@ -0,0 +1,105 @@
+package grpc
+
+import (
+	"fmt"
+
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/api"
+	"github.com/openfort-xyz/shield/internal/core/domain/share"
+	shieldv1 "github.com/openfort-xyz/shield/pkg/pb/shield/v1"
+)
+
+// validateShare applies the same rules as sharehdl's validator so a share
+// registered through either API is held to the same constraints.
+func validateShare(shr *shieldv1.Share, encryptionPart, encryptionSession string) *api.Error {
+	if shr == nil {
+		return api.ErrBadRequestWithMessage("share is required")
+	}
+
+	if shr.GetSecret() == "" {
+		return api.ErrBadRequestWithMessage("secret is required")
+	}
+
+	if errV := validateMetadata(shr.GetMetadata()); errV != nil {
+		return errV
+	}
+
+	if _, ok := shieldv1.StorageMethod_name[int32(shr.GetStorageMethod())]; !ok {
+		return api.ErrBadRequestWithMessage("invalid storage method")
+	}
+
+	params := shr.GetEncryptionParameters()
+	switch shr.GetEntropy() {
+	case shieldv1.Entropy_ENTROPY_UNSPECIFIED, shieldv1.Entropy_ENTROPY_NONE:
+		return api.ErrBadRequestWithMessage("require share entropy to be set")
+	case shieldv1.Entropy_ENTROPY_USER:
+		if params.GetSalt() == "" {
+			return api.ErrBadRequestWithMessage("salt is required when entropy is user")
+		}
+		if params.GetIterations() == 0 {
+			return api.ErrBadRequestWithMessage("iterations is required when entropy is user")
+		}
+		if params.GetLength() == 0 {
+			return api.ErrBadRequestWithMessage("length is required when entropy is user")
+		}
+		if params.GetDigest() == "" {
+			return api.ErrBadRequestWithMessage("digest is required when entropy is user")
+		}
+	case shieldv1.Entropy_ENTROPY_PROJECT:
+		if shr.GetStorageMethod() != shieldv1.StorageMethod_STORAGE_METHOD_SHIELD {
+			return api.ErrBadRequestWithMessage("storage_method must be Shield if entropy is project")
+		}
+
+		if !isEmptyEncryptionParameters(params) {
+			return api.ErrBadRequestWithMessage("if user entropy is not set, encryption parameters should not be set")
+		}
+
+		if encryptionPart == "" && encryptionSession == "" {
+			return api.ErrBadRequestWithMessage("encryption_part or encryption_session is required when entropy is project")
+		}
+	case shieldv1.Entropy_ENTROPY_PASSKEY:
+		if shr.GetStorageMethod() != shieldv1.StorageMethod_STORAGE_METHOD_SHIELD {
+			return api.ErrBadRequestWithMessage("storage_method must be Shield if entropy is passkey")
+		}
+
+		if shr.GetReference() == "" || shr.GetReference() == share.DefaultReference {
+			return api.ErrBadRequestWithMessage("share needs a valid share reference if entropy is passkey")
+		}
+
+		if shr.GetPasskeyReference() == nil {
+			return api.ErrBadRequestWithMessage("passkey_reference must be set if entropy is passkey")
+		}
+
+		if shr.GetPasskeyReference().GetPasskeyId() == "" {
+			return api.ErrBadRequestWithMessage("passkey_reference must contain passkey_id if entropy is passkey")
+		}
+
+		if !isEmptyEncryptionParameters(params) {
+			return api.ErrBadRequestWithMessage("if user entropy is not set, encryption parameters should not be set")
+		}
+
+		if encryptionPart != "" || encryptionSession != "" {
+			return api.ErrBadRequestWithMessage("encryption parameters should not be set for passkey entropy")
+		}
+	default:
+		return api.ErrBadRequestWithMessage("invalid entropy")
+	}
+
+	return nil
+}
+
+func validateMetadata(metadata map[string]string) *api.Error {
+	if len(metadata) > share.MaxMetadataEntries {
+		return api.ErrBadRequestWithMessage(fmt.Sprintf("metadata can't hold more than %d entries", share.MaxMetadataEntries))
+	}
+
+	for k, val := range metadata {
+		if k == "" || len(k) > share.MaxMetadataKeyLength {
+			return api.ErrBadRequestWithMessage(fmt.Sprintf("metadata keys must be 1 to %d bytes long", share.MaxMetadataKeyLength))
+		}
+		if len(val) > share.MaxMetadataValueLength {
+			return api.ErrBadRequestWithMessage(fmt.Sprintf("metadata values can't be longer than %d bytes", share.MaxMetadataValueLength))
+		}
+	}
+
+	return nil
+}
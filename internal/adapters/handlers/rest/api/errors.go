@@ -1,82 +1,186 @@
 package api
 
 import (
-	"encoding/json"
 	"net/http"
+	"slices"
 )
 
 type Error struct {
 	Message string `json:"message"`
-	Code    string `json:"code,omitempty"`
-	Status  int    `json:"-"`
+	// Code is the code application/json responses report, a few errors
+	// share theirs.
+	Code   string `json:"code,omitempty"`
+	Status int    `json:"-"`
+	// ProblemCode is the code problem details report, unique to each error.
+	ProblemCode string `json:"-"`
+	// Title summarizes errors whose Message describes the occurrence, e.g.
+	// a bad request. Message is the title of the others.
+	Title string `json:"-"`
+	// Fields lists the invalid fields of a request that failed validation.
+	Fields []FieldError `json:"-"`
 }
 
 func (e *Error) Error() string {
 	return e.Message
 }
 
-func RespondWithError(w http.ResponseWriter, err *Error) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(err.Status)
-	_ = json.NewEncoder(w).Encode(err)
+// codes holds every code the API reports, errors register theirs when declared.
+var codes = map[string]struct{}{}
+
+const (
+	codeBadRequest       = "BAD_REQUEST"
+	codeValidationFailed = "VALIDATION_FAILED"
+)
+
+func newError(message, code string, status int) *Error {
+	return newErrorWithLegacyCode(message, code, code, status)
+}
+
+// newErrorWithLegacyCode declares an error that got its own code, application/json
+// responses keep reporting the code it shared with other errors.
+func newErrorWithLegacyCode(message, code, legacyCode string, status int) *Error {
+	codes[code] = struct{}{}
+	codes[legacyCode] = struct{}{}
+	return &Error{Message: message, Code: legacyCode, Status: status, ProblemCode: code}
+}
+
+// Codes returns the codes of every error the API can report, sorted.
+func Codes() []string {
+	all := make([]string, 0, len(codes))
+	for code := range codes {
+		all = append(all, code)
+	}
+	slices.Sort(all)
+	return all
 }
 
 var (
-	ErrProjectNotFound = &Error{"Project not found", "PJ_NOT_FOUND", http.StatusNotFound}
-
-	ErrUnknownProviderType   = &Error{"Unknown provider type", "PV_UNKNOWN", http.StatusBadRequest}
-	ErrMissingProvider       = &Error{"Missing provider", "PV_MISSING", http.StatusBadRequest}
-	ErrProviderNotFound      = &Error{"Provider not found", "PV_NOT_FOUND", http.StatusNotFound}
-	ErrInvalidProviderConfig = &Error{"Invalid provider config", "PV_CFG_INVALID", http.StatusBadRequest}
-	ErrMissingKeyType        = &Error{"Missing key type", "PV_CFG_INVALID", http.StatusBadRequest}
-	ErrProviderAlreadyExists = &Error{"Custom authentication already registered for this project", "PV_EXISTS", http.StatusConflict}
-	ErrMissingUserID         = &Error{"Missing user ID", "US_ID_MISSING", http.StatusBadRequest}
-
-	ErrShareNotFound      = &Error{"Share not found", "SH_NOT_FOUND", http.StatusNotFound}
-	ErrShareAlreadyExists = &Error{"Share already exists", "SH_EXISTS", http.StatusConflict}
-
-	ErrPreRegisterUser = &Error{"Failed to pre-register user", "US_PREREG_FAILED", http.StatusInternalServerError}
-
-	ErrUserNotFound                = &Error{"User not found", "US_NOT_FOUND", http.StatusNotFound}
-	ErrExternalUserNotFound        = &Error{"External user not found", "US_EXT_NOT_FOUND", http.StatusNotFound}
-	ErrExternalUserAlreadyExists   = &Error{"External user already exists", "US_EXT_EXISTS", http.StatusConflict}
-	ErrEncryptionPartRequired      = &Error{"The requested share have project entropy and encryption part is required", "EC_MISSING", http.StatusConflict}
-	ErrEncryptionNotConfigured     = &Error{"Encryption not configured", "EC_MISSING", http.StatusConflict}
-	ErrJWKPemConflict              = &Error{"JWK and PEM cannot be set at the same time", "PV_CFG_INVALID", http.StatusConflict}
-	ErrInvalidPemCertificate       = &Error{"Invalid PEM certificate", "PV_CFG_INVALID", http.StatusBadRequest}
-	ErrInvalidEncryptionPart       = &Error{"Invalid encryption part", "EC_INVALID", http.StatusBadRequest}
-	ErrInvalidEncryptionSession    = &Error{"Invalid encryption session", "EC_INVALID", http.StatusBadRequest}
-	ErrEncryptionPartAlreadyExists = &Error{"Encryption part already exists", "EC_EXISTS", http.StatusConflict}
-
-	ErrMissingAPIKey         = &Error{"Missing API key", "A_MISSING", http.StatusUnauthorized}
-	ErrMissingAPISecret      = &Error{"Missing API secret", "A_MISSING", http.StatusUnauthorized}
-	ErrInvalidAPICredentials = &Error{"Invalid API key or API secret", "A_INVALID", http.StatusUnauthorized}
-	ErrMissingToken          = &Error{"Missing token", "A_MISSING", http.StatusUnauthorized}
-	ErrInvalidToken          = &Error{"Invalid token", "A_INVALID", http.StatusUnauthorized}
-	ErrMissingAuthProvider   = &Error{"Missing auth provider", "A_MISSING", http.StatusUnauthorized}
-	ErrInvalidAuthProvider   = &Error{"Invalid auth provider", "A_INVALID", http.StatusUnauthorized}
-
-	ErrOTPRequired              = &Error{"OTP is required for this request", "OTP_MISSING", http.StatusPreconditionRequired}
-	ErrOTPRateLimitExceeded     = &Error{"Rate limit exceeded to generate OTP", "OTP_RATE_LIMIT", http.StatusTooManyRequests}
-	ErrOTPExpired               = &Error{"OTP is expired", "OTP_EXPIRED", http.StatusUnprocessableEntity}
-	ErrOTPInvalidated           = &Error{"OTP invalidated after max failed attempts", "OTP_INVALIDATED", http.StatusBadRequest}
-	ErrOTPInvalid               = &Error{"Received otp is invalid", "OTP_INVALID", http.StatusBadRequest}
-	ErrOTPUserInfoMissing       = &Error{"Missing user information like email or phone number", "OTP_USER_INFO_MISSING", http.StatusBadRequest}
-	ErrOTPMissing               = &Error{"OTP was requested but not sent", "OTP_REQUESTED_BUT_NOT_SENT", http.StatusBadRequest}
-	ErrProjectDoesntHave2FA     = &Error{"Project doesn't support 2FA", "OTP_NOT_SUPPORTED", http.StatusBadRequest}
-	ErrProject2FAAlreadyEnabled = &Error{"Project already has 2FA enabled", "OTP_ALREADY_ENABLED", http.StatusConflict}
-	ErrOTPRecordNotFound        = &Error{"OTP record not found for user", "OTP_RECORD_NOT_FOUND", http.StatusNotFound}
-
-	ErrUserContactInformationMismatch = &Error{"User contact information mismatch", "USER_CONTACTS_MISMATCH", http.StatusBadRequest}
-
-	ErrEmailIsInvalid       = &Error{"Provided Email is invalid", "EMAIL_INVALID", http.StatusBadRequest}
-	ErrPhoneNumberIsInvalid = &Error{"Provided phone number is invalid", "PHONE_INVALID", http.StatusBadRequest}
-
-	ErrMissingNotificationService = &Error{"Missing notification service", "MISSING_NOTIFICATION_SERV", http.StatusInternalServerError}
-
-	ErrInternal = &Error{"Internal error", "INTERNAL", http.StatusInternalServerError}
+	ErrProjectNotFound = newError("Project not found", "PJ_NOT_FOUND", http.StatusNotFound)
+
+	ErrUnknownProviderType   = newError("Unknown provider type", "PV_UNKNOWN", http.StatusBadRequest)
+	ErrMissingProvider       = newError("Missing provider", "PV_MISSING", http.StatusBadRequest)
+	ErrProviderNotFound      = newError("Provider not found", "PV_NOT_FOUND", http.StatusNotFound)
+	ErrInvalidProviderConfig = newError("Invalid provider config", "PV_CFG_INVALID", http.StatusBadRequest)
+	ErrMissingKeyType        = newErrorWithLegacyCode("Missing key type", "PV_KEY_TYPE_MISSING", "PV_CFG_INVALID", http.StatusBadRequest)
+	ErrProviderAlreadyExists = newError("Custom authentication already registered for this project", "PV_EXISTS", http.StatusConflict)
+	ErrMissingProviderKey    = newError("Missing provider key", "PV_KEY_MISSING", http.StatusBadRequest)
+	ErrProviderKeyNotFound   = newError("Provider key not found", "PV_KEY_NOT_FOUND", http.StatusNotFound)
+	ErrProviderKeyExists     = newError("A key with the same kid is already registered for this provider", "PV_KEY_EXISTS", http.StatusConflict)
+	ErrInvalidKeyValidity    = newErrorWithLegacyCode("Key not_before must be earlier than not_after", "PV_KEY_VALIDITY_INVALID", "PV_CFG_INVALID", http.StatusBadRequest)
+	ErrMissingUserID         = newError("Missing user ID", "US_ID_MISSING", http.StatusBadRequest)
+
+	ErrShareNotFound       = newError("Share not found", "SH_NOT_FOUND", http.StatusNotFound)
+	ErrShareAlreadyExists  = newError("Share already exists", "SH_EXISTS", http.StatusConflict)
+	ErrKeychainNotFound    = newError("Keychain not found", "KC_NOT_FOUND", http.StatusNotFound)
+	ErrTransferKeyRequired = newError("Project entropy shares can only be migrated with a transfer key", "SH_TRANSFER_KEY_MISSING", http.StatusConflict)
+	ErrInvalidTransferKey  = newError("Invalid transfer key, expected 32 base64 encoded bytes", "SH_TRANSFER_KEY_INVALID", http.StatusBadRequest)
+
+	ErrShareVersionMismatch = newError("Share was changed since it was read, get it again and retry", "SH_VERSION_MISMATCH", http.StatusPreconditionFailed)
+
+	ErrPreRegisterUser = newError("Failed to pre-register user", "US_PREREG_FAILED", http.StatusInternalServerError)
+
+	ErrUserNotFound                = newError("User not found", "US_NOT_FOUND", http.StatusNotFound)
+	ErrExternalUserNotFound        = newError("External user not found", "US_EXT_NOT_FOUND", http.StatusNotFound)
+	ErrExternalUserAlreadyExists   = newError("External user already exists", "US_EXT_EXISTS", http.StatusConflict)
+	ErrEncryptionPartRequired      = newError("The requested share have project entropy and encryption part is required", "EC_MISSING", http.StatusConflict)
+	ErrEncryptionNotConfigured     = newErrorWithLegacyCode("Encryption not configured", "EC_NOT_CONFIGURED", "EC_MISSING", http.StatusConflict)
+	ErrJWKPemConflict              = newErrorWithLegacyCode("JWK and PEM cannot be set at the same time", "PV_JWK_PEM_CONFLICT", "PV_CFG_INVALID", http.StatusConflict)
+	ErrInvalidPemCertificate       = newErrorWithLegacyCode("Invalid PEM certificate", "PV_PEM_INVALID", "PV_CFG_INVALID", http.StatusBadRequest)
+	ErrHMACSecretConflict          = newErrorWithLegacyCode("HMAC secret cannot be set together with JWK or PEM", "PV_HMAC_CONFLICT", "PV_CFG_INVALID", http.StatusConflict)
+	ErrInvalidHMACSecret           = newErrorWithLegacyCode("Invalid HMAC secret, it must be at least 32 bytes long", "PV_HMAC_SECRET_INVALID", "PV_CFG_INVALID", http.StatusBadRequest)
+	ErrSecretEncryptionNotSet      = newError("Provider secret encryption is not configured", "PV_SECRET_UNAVAILABLE", http.StatusInternalServerError)
+	ErrInvalidCertFingerprint      = newErrorWithLegacyCode("Invalid certificate fingerprint, expected a hex encoded SHA-256 digest", "CC_FINGERPRINT_INVALID", "CC_INVALID", http.StatusBadRequest)
+	ErrInvalidCertType             = newErrorWithLegacyCode("Invalid certificate type, expected ca or leaf", "CC_TYPE_INVALID", "CC_INVALID", http.StatusBadRequest)
+	ErrClientCertExists            = newError("Client certificate already registered", "CC_EXISTS", http.StatusConflict)
+	ErrClientCertNotFound          = newError("Client certificate not found", "CC_NOT_FOUND", http.StatusNotFound)
+	ErrInvalidClientCertMode       = newErrorWithLegacyCode("Invalid client certificate mode, expected disabled, alternative or required", "CC_MODE_INVALID", "CC_INVALID", http.StatusBadRequest)
+	ErrNoClientCertificates        = newError("At least one client certificate must be registered while certificates are required", "CC_REQUIRED", http.StatusConflict)
+	ErrSigningKeyNotFound          = newError("Generate a signing key before requiring signed requests", "PJ_SIGNING_KEY_MISSING", http.StatusConflict)
+	ErrSigningNotConfigured        = newError("Project secret encryption is not configured", "PJ_SIGNING_UNAVAILABLE", http.StatusInternalServerError)
+	ErrDeletionNotRequested        = newError("Request the project deletion before confirming it", "PJ_DELETION_NOT_REQUESTED", http.StatusConflict)
+	ErrInvalidDeletionToken        = newError("Invalid deletion confirmation token", "PJ_DELETION_TOKEN_INVALID", http.StatusForbidden)
+	ErrDeletionRequestExpired      = newError("Deletion request expired, request the deletion again", "PJ_DELETION_EXPIRED", http.StatusConflict)
+	ErrInvalidDeletionMode         = newError("Deletion mode must be hard_delete or crypto_shred", "PJ_DELETION_MODE_INVALID", http.StatusBadRequest)
+	ErrInvalidPageSize             = newError("Page size must be between 1 and 100", "PG_SIZE_INVALID", http.StatusBadRequest)
+	ErrProjectChangedSinceExport   = newError("Project data changed since the archive was exported, request the deletion again", "PJ_DELETION_STALE_ARCHIVE", http.StatusConflict)
+	ErrProjectReadOnly             = newError("Project is read-only, shares can't be changed", "PJ_READ_ONLY", http.StatusForbidden)
+	ErrInvalidProjectStatus        = newError("Invalid project status, expected active, read_only or suspended", "PJ_STATUS_INVALID", http.StatusBadRequest)
+	ErrInvalidInvite               = newError("Registration invite is invalid, used or expired", "PJ_INVITE_INVALID", http.StatusForbidden)
+	ErrInvalidInviteTTL            = newErrorWithLegacyCode("Invite expiration must be positive", "PJ_INVITE_TTL_INVALID", "PJ_INVITE_INVALID", http.StatusBadRequest)
+	ErrInvalidEncryptionPart       = newErrorWithLegacyCode("Invalid encryption part", "EC_PART_INVALID", "EC_INVALID", http.StatusBadRequest)
+	ErrInvalidEncryptionSession    = newErrorWithLegacyCode("Invalid encryption session", "EC_SESSION_INVALID", "EC_INVALID", http.StatusBadRequest)
+	ErrEncryptionPartAlreadyExists = newError("Encryption part already exists", "EC_EXISTS", http.StatusConflict)
+
+	ErrMissingAPIKey             = newErrorWithLegacyCode("Missing API key", "A_API_KEY_MISSING", "A_MISSING", http.StatusUnauthorized)
+	ErrMissingAPISecret          = newErrorWithLegacyCode("Missing API secret", "A_API_SECRET_MISSING", "A_MISSING", http.StatusUnauthorized)
+	ErrInvalidAPICredentials     = newErrorWithLegacyCode("Invalid API key or API secret", "A_CREDENTIALS_INVALID", "A_INVALID", http.StatusUnauthorized)
+	ErrClientCertificateRequired = newError("A registered client certificate is required for this project", "A_CERT_REQUIRED", http.StatusUnauthorized)
+	ErrRequestSignatureRequired  = newError("A signed request is required for this project", "A_SIGNATURE_REQUIRED", http.StatusUnauthorized)
+	ErrInvalidRequestSignature   = newError("Invalid request signature", "A_SIGNATURE_INVALID", http.StatusUnauthorized)
+	ErrRequestSignatureExpired   = newError("Request signature timestamp is outside the allowed window", "A_SIGNATURE_EXPIRED", http.StatusUnauthorized)
+	ErrRequestReplayed           = newError("Request signature nonce was already used", "A_SIGNATURE_REPLAYED", http.StatusUnauthorized)
+	ErrSignedBodyTooLarge        = newError("Signed request body is too large", "A_SIGNATURE_BODY_TOO_LARGE", http.StatusRequestEntityTooLarge)
+	ErrMissingToken              = newErrorWithLegacyCode("Missing token", "A_TOKEN_MISSING", "A_MISSING", http.StatusUnauthorized)
+	ErrInvalidToken              = newErrorWithLegacyCode("Invalid token", "A_TOKEN_INVALID", "A_INVALID", http.StatusUnauthorized)
+	ErrMissingAuthProvider       = newErrorWithLegacyCode("Missing auth provider", "A_PROVIDER_MISSING", "A_MISSING", http.StatusUnauthorized)
+	ErrInvalidAuthProvider       = newErrorWithLegacyCode("Invalid auth provider", "A_PROVIDER_INVALID", "A_INVALID", http.StatusUnauthorized)
+	ErrIdentityUnavailable       = newError("Identity provider is temporarily unavailable", "A_UNAVAILABLE", http.StatusServiceUnavailable)
+	ErrProjectSuspended          = newError("Project is suspended", "A_PROJECT_SUSPENDED", http.StatusForbidden)
+	ErrMissingOperatorKey        = newErrorWithLegacyCode("Missing operator key", "A_OPERATOR_KEY_MISSING", "A_MISSING", http.StatusUnauthorized)
+	ErrInvalidOperatorKey        = newErrorWithLegacyCode("Invalid operator key", "A_OPERATOR_KEY_INVALID", "A_INVALID", http.StatusUnauthorized)
+	ErrOperatorAPIDisabled       = newError("Operator API is not configured", "A_OPERATOR_DISABLED", http.StatusNotFound)
+	ErrMissingInvite             = newError("A registration invite is required", "REG_INVITE_REQUIRED", http.StatusForbidden)
+	ErrRegistrationOperatorOnly  = newError("Projects can only be registered by the operator", "REG_OPERATOR_ONLY", http.StatusForbidden)
+	ErrTooManyRegistrations      = newError("Too many registrations from this address, try again later", "REG_RATE_LIMIT", http.StatusTooManyRequests)
+
+	ErrInvalidIdempotencyKey    = newError("Idempotency key must be at most 255 characters", "IDEM_KEY_INVALID", http.StatusBadRequest)
+	ErrIdempotencyKeyInProgress = newError("A request with this idempotency key is still in progress", "IDEM_IN_PROGRESS", http.StatusConflict)
+	ErrIdempotencyKeyReused     = newError("Idempotency key was already used for a different request", "IDEM_KEY_REUSED", http.StatusUnprocessableEntity)
+
+	ErrOTPRequired              = newError("OTP is required for this request", "OTP_MISSING", http.StatusPreconditionRequired)
+	ErrOTPRateLimitExceeded     = newError("Rate limit exceeded to generate OTP", "OTP_RATE_LIMIT", http.StatusTooManyRequests)
+	ErrOTPExpired               = newError("OTP is expired", "OTP_EXPIRED", http.StatusUnprocessableEntity)
+	ErrOTPInvalidated           = newError("OTP invalidated after max failed attempts", "OTP_INVALIDATED", http.StatusBadRequest)
+	ErrOTPInvalid               = newError("Received otp is invalid", "OTP_INVALID", http.StatusBadRequest)
+	ErrOTPUserInfoMissing       = newError("Missing user information like email or phone number", "OTP_USER_INFO_MISSING", http.StatusBadRequest)
+	ErrOTPMissing               = newError("OTP was requested but not sent", "OTP_REQUESTED_BUT_NOT_SENT", http.StatusBadRequest)
+	ErrProjectDoesntHave2FA     = newError("Project doesn't support 2FA", "OTP_NOT_SUPPORTED", http.StatusBadRequest)
+	ErrProject2FAAlreadyEnabled = newError("Project already has 2FA enabled", "OTP_ALREADY_ENABLED", http.StatusConflict)
+	ErrOTPRecordNotFound        = newError("OTP record not found for user", "OTP_RECORD_NOT_FOUND", http.StatusNotFound)
+
+	ErrUserContactInformationMismatch = newError("User contact information mismatch", "USER_CONTACTS_MISMATCH", http.StatusBadRequest)
+
+	ErrEmailIsInvalid       = newError("Provided Email is invalid", "EMAIL_INVALID", http.StatusBadRequest)
+	ErrPhoneNumberIsInvalid = newError("Provided phone number is invalid", "PHONE_INVALID", http.StatusBadRequest)
+
+	ErrMissingNotificationService = newError("Missing notification service", "MISSING_NOTIFICATION_SERV", http.StatusInternalServerError)
+
+	ErrInternal = newError("Internal error", "INTERNAL", http.StatusInternalServerError)
+)
+
+var (
+	errBadRequest       = newError("Bad request", codeBadRequest, http.StatusBadRequest)
+	errValidationFailed = newErrorWithLegacyCode("Request validation failed", codeValidationFailed, codeBadRequest, http.StatusBadRequest)
 )
 
 func ErrBadRequestWithMessage(message string) *Error {
-	return &Error{message, "BAD_REQUEST", http.StatusBadRequest}
+	return &Error{Message: message, Code: codeBadRequest, Status: http.StatusBadRequest, ProblemCode: codeBadRequest, Title: errBadRequest.Message}
+}
+
+// ErrValidation reports the invalid fields of a request. application/json
+// responses only carry the message of the first one, as they did when the
+// validation stopped there, problem details list them all.
+func ErrValidation(fields ...FieldError) *Error {
+	var message string
+	if len(fields) > 0 {
+		message = fields[0].Message
+	}
+	return &Error{
+		Message:     message,
+		Code:        errValidationFailed.Code,
+		Status:      errValidationFailed.Status,
+		ProblemCode: errValidationFailed.ProblemCode,
+		Title:       errValidationFailed.Message,
+		Fields:      fields,
+	}
 }
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/openfort-xyz/shield/pkg/contexter"
+)
+
+const (
+	JSONContentType    = "application/json"
+	ProblemContentType = "application/problem+json"
+
+	// ProblemTypePrefix prefixes the code of an error to make the type of
+	// its problem details.
+	ProblemTypePrefix = "urn:openfort:shield:error:"
+)
+
+// Field error codes, they say what's wrong with a field regardless of the field.
+const (
+	FieldRequired   = "required"
+	FieldInvalid    = "invalid"
+	FieldNotAllowed = "not_allowed"
+	FieldTooLarge   = "too_large"
+)
+
+// FieldError is an invalid field of a request, named by its path in the
+// request body, e.g. passkey_reference.passkey_id.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Problem is an error in the RFC 9457 (formerly RFC 7807) problem details
+// format, served to the clients that accept application/problem+json.
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      string       `json:"code"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// Problem returns the problem details of the error for the request.
+func (e *Error) Problem(r *http.Request) *Problem {
+	p := &Problem{
+		Type:      ProblemTypePrefix + e.ProblemCode,
+		Title:     e.Message,
+		Status:    e.Status,
+		Instance:  r.URL.Path,
+		Code:      e.ProblemCode,
+		RequestID: contexter.GetRequestID(r.Context()),
+		Errors:    e.Fields,
+	}
+	if e.Title != "" {
+		p.Title = e.Title
+		p.Detail = e.Message
+	}
+	if len(e.Fields) > 1 {
+		messages := make([]string, 0, len(e.Fields))
+		for _, field := range e.Fields {
+			messages = append(messages, field.Message)
+		}
+		p.Detail = strings.Join(messages, "; ")
+	}
+	return p
+}
+
+// RespondWithError writes the error as problem details when the client
+// accepts them, and in the application/json format otherwise, which clients
+// written before problem details keep getting.
+func RespondWithError(w http.ResponseWriter, r *http.Request, err *Error) {
+	if AcceptsProblem(r) {
+		w.Header().Set("Content-Type", ProblemContentType)
+		w.WriteHeader(err.Status)
+		_ = json.NewEncoder(w).Encode(err.Problem(r))
+		return
+	}
+
+	w.Header().Set("Content-Type", JSONContentType)
+	w.WriteHeader(err.Status)
+	_ = json.NewEncoder(w).Encode(err)
+}
+
+// AcceptsProblem reports whether the Accept header of the request names
+// application/problem+json, wildcards don't count as clients accepting them
+// may still expect the application/json format.
+func AcceptsProblem(r *http.Request) bool {
+	for _, value := range r.Header.Values("Accept") {
+		for _, accepted := range strings.Split(value, ",") {
+			mediaType, params, err := mime.ParseMediaType(accepted)
+			if err != nil || mediaType != ProblemContentType {
+				continue
+			}
+			if q, ok := params["q"]; ok {
+				if weight, err := strconv.ParseFloat(q, 64); err != nil || weight <= 0 {
+					continue
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/openfort-xyz/shield/pkg/contexter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcceptsProblem(t *testing.T) {
+	tc := []struct {
+		name   string
+		accept []string
+		want   bool
+	}{
+		{name: "no accept header", want: false},
+		{name: "json", accept: []string{"application/json"}, want: false},
+		{name: "wildcard", accept: []string{"*/*"}, want: false},
+		{name: "problem", accept: []string{"application/problem+json"}, want: true},
+		{name: "problem among others", accept: []string{"application/json, application/problem+json"}, want: true},
+		{name: "problem in a second header", accept: []string{"application/json", "application/problem+json"}, want: true},
+		{name: "problem with a weight", accept: []string{"application/problem+json;q=0.5"}, want: true},
+		{name: "problem refused", accept: []string{"application/problem+json;q=0"}, want: false},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/shares", nil)
+			for _, value := range tt.accept {
+				r.Header.Add("Accept", value)
+			}
+			assert.Equal(t, tt.want, AcceptsProblem(r))
+		})
+	}
+}
+
+func TestRespondWithError(t *testing.T) {
+	tc := []struct {
+		name        string
+		err         *Error
+		wantMessage string
+		wantCode    string
+		wantProblem Problem
+	}{
+		{
+			name:        "error",
+			err:         ErrShareNotFound,
+			wantMessage: ErrShareNotFound.Message,
+			wantCode:    ErrShareNotFound.Code,
+			wantProblem: Problem{
+				Type:   ProblemTypePrefix + ErrShareNotFound.ProblemCode,
+				Title:  ErrShareNotFound.Message,
+				Status: http.StatusNotFound,
+				Code:   ErrShareNotFound.ProblemCode,
+			},
+		},
+		{
+			name:        "bad request",
+			err:         ErrBadRequestWithMessage("invalid request body"),
+			wantMessage: "invalid request body",
+			wantCode:    codeBadRequest,
+			wantProblem: Problem{
+				Type:   ProblemTypePrefix + codeBadRequest,
+				Title:  "Bad request",
+				Status: http.StatusBadRequest,
+				Detail: "invalid request body",
+				Code:   codeBadRequest,
+			},
+		},
+		{
+			name: "validation",
+			err: ErrValidation(
+				FieldError{Field: "secret", Code: FieldRequired, Message: "secret is required"},
+				FieldError{Field: "salt", Code: FieldRequired, Message: "salt is required when entropy is user"},
+			),
+			// Clients of the former format keep getting the first error only
+			wantMessage: "secret is required",
+			wantCode:    codeBadRequest,
+			wantProblem: Problem{
+				Type:   ProblemTypePrefix + codeValidationFailed,
+				Title:  "Request validation failed",
+				Status: http.StatusBadRequest,
+				Detail: "secret is required; salt is required when entropy is user",
+				Code:   codeValidationFailed,
+				Errors: []FieldError{
+					{Field: "secret", Code: FieldRequired, Message: "secret is required"},
+					{Field: "salt", Code: FieldRequired, Message: "salt is required when entropy is user"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/shares", nil)
+			r = r.WithContext(contexter.WithRequestID(r.Context(), "request-id"))
+
+			w := httptest.NewRecorder()
+			RespondWithError(w, r, tt.err)
+			assert.Equal(t, tt.err.Status, w.Code)
+			assert.Equal(t, JSONContentType, w.Header().Get("Content-Type"))
+			var legacy map[string]any
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&legacy))
+			assert.Equal(t, map[string]any{"message": tt.wantMessage, "code": tt.wantCode}, legacy)
+
+			r.Header.Set("Accept", ProblemContentType)
+			w = httptest.NewRecorder()
+			RespondWithError(w, r, tt.err)
+			assert.Equal(t, tt.err.Status, w.Code)
+			assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+			var problem Problem
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&problem))
+			want := tt.wantProblem
+			want.Instance = "/shares"
+			want.RequestID = "request-id"
+			assert.Equal(t, want, problem)
+		})
+	}
+}
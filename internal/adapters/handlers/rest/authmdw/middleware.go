@@ -1,15 +1,22 @@
 package authmdw
 
 import (
+	"bytes"
+	"crypto/x509"
 	"errors"
+	"io"
 	"net/http"
+	"strconv"
 	"strings"
 
+	"github.com/openfort-xyz/shield/internal/core/domain/authentication"
+	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
 	"github.com/openfort-xyz/shield/internal/core/ports/factories"
 	"github.com/openfort-xyz/shield/internal/core/ports/services"
 
 	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/api"
 	"github.com/openfort-xyz/shield/pkg/contexter"
+	"github.com/openfort-xyz/shield/pkg/signing"
 )
 
 const TokenHeader = "Authorization"                                  //nolint:gosec
@@ -24,8 +31,13 @@ const EncryptionSessionHeader = "X-Encryption-Session"               //nolint:go
 const UserIDHeader = "X-User-ID"                                     //nolint:gosec
 const AuthenticationTypeCustom = "custom"                            //nolint:gosec
 const AuthenticationTypeOpenfort = "openfort"                        //nolint:gosec
+const AuthenticationTypeIntrospection = "introspection"              //nolint:gosec
 const RequestIDHeader = "X-Request-ID"                               //nolint:gosec
 
+// MaxSignedBodySize bounds the body of a signed request, it's read into
+// memory to hash it before the request is authenticated.
+const MaxSignedBodySize = 10 << 20
+
 type Middleware struct {
 	authenticationFactory factories.AuthenticationFactory
 	identityFactory       factories.IdentityFactory
@@ -46,39 +58,117 @@ func (m *Middleware) AuthenticateAPISecret(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		apiKey := r.Header.Get(APIKeyHeader)
 		if apiKey == "" {
-			api.RespondWithError(w, api.ErrMissingAPIKey)
+			api.RespondWithError(w, r, api.ErrMissingAPIKey)
 			return
 		}
 
 		apiSecret := r.Header.Get(APISecretHeader)
-		if apiSecret == "" {
-			api.RespondWithError(w, api.ErrMissingAPISecret)
+		signature, err := requestSignature(w, r)
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				api.RespondWithError(w, r, api.ErrSignedBodyTooLarge)
+				return
+			}
+			api.RespondWithError(w, r, api.ErrInvalidRequestSignature)
 			return
 		}
 
-		authenticator := m.authenticationFactory.CreateProjectAuthenticator(apiKey, apiSecret)
+		chain := clientCertificateChain(r)
+		if apiSecret == "" && signature == nil && len(chain) == 0 {
+			api.RespondWithError(w, r, api.ErrMissingAPISecret)
+			return
+		}
+
+		var authenticator factories.Authenticator
+		if len(chain) == 0 {
+			authenticator = m.authenticationFactory.CreateProjectAuthenticator(apiKey, apiSecret, signature)
+		} else {
+			authenticator = m.authenticationFactory.CreateCertificateAuthenticator(apiKey, apiSecret, signature, chain)
+		}
 		authentication, err := authenticator.Authenticate(r.Context())
 		if err != nil {
-			api.RespondWithError(w, api.ErrInvalidAPICredentials)
+			switch {
+			case errors.Is(err, domainErrors.ErrClientCertificateRequired):
+				api.RespondWithError(w, r, api.ErrClientCertificateRequired)
+			case errors.Is(err, domainErrors.ErrRequestSignatureRequired):
+				api.RespondWithError(w, r, api.ErrRequestSignatureRequired)
+			case errors.Is(err, domainErrors.ErrRequestSignatureExpired):
+				api.RespondWithError(w, r, api.ErrRequestSignatureExpired)
+			case errors.Is(err, domainErrors.ErrNonceAlreadyUsed):
+				api.RespondWithError(w, r, api.ErrRequestReplayed)
+			case errors.Is(err, domainErrors.ErrProjectSuspended):
+				api.RespondWithError(w, r, api.ErrProjectSuspended)
+			default:
+				api.RespondWithError(w, r, api.ErrInvalidAPICredentials)
+			}
 			return
 		}
 
 		ctx := contexter.WithProjectID(r.Context(), authentication.ProjectID)
+		ctx = contexter.WithProjectStatus(ctx, authentication.ProjectStatus)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// requestSignature reads the signing headers, it returns nil when the request
+// isn't signed. The body is read to hash it and put back for the handler, up
+// to MaxSignedBodySize.
+func requestSignature(w http.ResponseWriter, r *http.Request) (*authentication.RequestSignature, error) {
+	sig := r.Header.Get(signing.SignatureHeader)
+	if sig == "" {
+		return nil, nil
+	}
+
+	timestamp, err := strconv.ParseInt(r.Header.Get(signing.TimestampHeader), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	var body []byte
+	if r.Body != nil {
+		body, err = io.ReadAll(http.MaxBytesReader(w, r.Body, MaxSignedBodySize))
+		if err != nil {
+			return nil, err
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	return &authentication.RequestSignature{
+		Method:     r.Method,
+		RequestURI: r.URL.RequestURI(),
+		BodyHash:   signing.BodyHash(body),
+		Timestamp:  timestamp,
+		Nonce:      r.Header.Get(signing.NonceHeader),
+		Signature:  sig,
+	}, nil
+}
+
+// clientCertificateChain returns the certificates the client presented
+// during the TLS handshake, leaf first. When the server verified them against
+// its own CA bundle the verified chain is preferred since it includes the
+// root the client may have left out.
+func clientCertificateChain(r *http.Request) []*x509.Certificate {
+	if r.TLS == nil {
+		return nil
+	}
+	if len(r.TLS.VerifiedChains) > 0 {
+		return r.TLS.VerifiedChains[0]
+	}
+	return r.TLS.PeerCertificates
+}
+
 func (m *Middleware) PreRegisterUser(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		userID := r.Header.Get(UserIDHeader)
 		if userID == "" {
-			api.RespondWithError(w, api.ErrMissingUserID)
+			api.RespondWithError(w, r, api.ErrMissingUserID)
 			return
 		}
 
 		providerStr := r.Header.Get(AuthProviderHeader)
 		if providerStr == "" {
-			api.RespondWithError(w, api.ErrMissingAuthProvider)
+			api.RespondWithError(w, r, api.ErrMissingAuthProvider)
 			return
 		}
 
@@ -91,18 +181,20 @@ func (m *Middleware) PreRegisterUser(next http.Handler) http.Handler {
 			identity, err = m.identityFactory.CreateCustomIdentity(r.Context(), projectID)
 		case AuthenticationTypeOpenfort:
 			identity, err = m.identityFactory.CreateOpenfortIdentity(r.Context(), projectID, nil, nil)
+		case AuthenticationTypeIntrospection:
+			identity, err = m.identityFactory.CreateIntrospectionIdentity(r.Context(), projectID)
 		default:
-			api.RespondWithError(w, api.ErrInvalidAuthProvider)
+			api.RespondWithError(w, r, api.ErrInvalidAuthProvider)
 			return
 		}
 		if err != nil {
-			api.RespondWithError(w, api.ErrInvalidAuthProvider)
+			api.RespondWithError(w, r, api.ErrInvalidAuthProvider)
 			return
 		}
 
 		usr, err := m.userService.GetOrCreate(r.Context(), projectID, userID, identity.GetProviderID())
 		if err != nil {
-			api.RespondWithError(w, api.ErrPreRegisterUser)
+			api.RespondWithError(w, r, api.ErrPreRegisterUser)
 			return
 		}
 
@@ -149,19 +241,19 @@ func (m *Middleware) AuthenticateUser(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		apiKey := r.Header.Get(APIKeyHeader)
 		if apiKey == "" {
-			api.RespondWithError(w, api.ErrMissingAPIKey)
+			api.RespondWithError(w, r, api.ErrMissingAPIKey)
 			return
 		}
 
 		providerStr := r.Header.Get(AuthProviderHeader)
 		if providerStr == "" {
-			api.RespondWithError(w, api.ErrMissingAuthProvider)
+			api.RespondWithError(w, r, api.ErrMissingAuthProvider)
 			return
 		}
 
 		proj, err := m.projectService.GetByAPIKey(r.Context(), apiKey)
 		if err != nil {
-			api.RespondWithError(w, api.ErrInvalidAPICredentials)
+			api.RespondWithError(w, r, api.ErrInvalidAPICredentials)
 			return
 		}
 
@@ -182,12 +274,14 @@ func (m *Middleware) AuthenticateUser(next http.Handler) http.Handler {
 				*openfortTokenType = r.Header.Get(OpenfortTokenTypeHeader)
 			}
 			identity, err = m.identityFactory.CreateOpenfortIdentity(r.Context(), proj.ID, openfortProvider, openfortTokenType)
+		case AuthenticationTypeIntrospection:
+			identity, err = m.identityFactory.CreateIntrospectionIdentity(r.Context(), proj.ID)
 		default:
-			api.RespondWithError(w, api.ErrInvalidAuthProvider)
+			api.RespondWithError(w, r, api.ErrInvalidAuthProvider)
 			return
 		}
 		if err != nil {
-			api.RespondWithError(w, api.ErrInvalidAuthProvider)
+			api.RespondWithError(w, r, api.ErrInvalidAuthProvider)
 			return
 		}
 
@@ -199,7 +293,7 @@ func (m *Middleware) AuthenticateUser(next http.Handler) http.Handler {
 		} else {
 			// Cookie vs header ARE mutually exclusive, otherwise it's not clear which one we should obey
 			if r.Header.Get(TokenHeader) != "" {
-				api.RespondWithError(w, api.ErrInvalidToken)
+				api.RespondWithError(w, r, api.ErrInvalidToken)
 				return
 			}
 			token, err = getTokenFromCookie(r, identity.GetCookieFieldName())
@@ -209,14 +303,22 @@ func (m *Middleware) AuthenticateUser(next http.Handler) http.Handler {
 		}
 
 		if err != nil {
-			api.RespondWithError(w, api.ErrInvalidToken)
+			api.RespondWithError(w, r, api.ErrInvalidToken)
 			return
 		}
 
 		authenticator := m.authenticationFactory.CreateUserAuthenticator(proj, token, identity)
 		authentication, err := authenticator.Authenticate(r.Context())
 		if err != nil {
-			api.RespondWithError(w, api.ErrInvalidToken)
+			if errors.Is(err, domainErrors.ErrIdentityProviderUnavailable) {
+				api.RespondWithError(w, r, api.ErrIdentityUnavailable)
+				return
+			}
+			if errors.Is(err, domainErrors.ErrProjectSuspended) {
+				api.RespondWithError(w, r, api.ErrProjectSuspended)
+				return
+			}
+			api.RespondWithError(w, r, api.ErrInvalidToken)
 			return
 		}
 
@@ -224,6 +326,7 @@ func (m *Middleware) AuthenticateUser(next http.Handler) http.Handler {
 		ctx = contexter.WithProjectID(ctx, authentication.ProjectID)
 		ctx = contexter.WithExternalUserID(ctx, authentication.ExternalUserID)
 		ctx = contexter.WithProject(ctx, proj)
+		ctx = contexter.WithProjectStatus(ctx, authentication.ProjectStatus)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
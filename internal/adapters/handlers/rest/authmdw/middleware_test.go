@@ -0,0 +1,36 @@
+package authmdw
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/api"
+	"github.com/openfort-xyz/shield/pkg/signing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticateAPISecret_SignedBodyTooLarge(t *testing.T) {
+	next := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Fatal("the handler isn't called")
+	})
+
+	r := httptest.NewRequest(http.MethodPost, "/shares", strings.NewReader(strings.Repeat("a", MaxSignedBodySize+1)))
+	r.Header.Set(APIKeyHeader, "api_key")
+	r.Header.Set(signing.SignatureHeader, "signature")
+	r.Header.Set(signing.TimestampHeader, strconv.FormatInt(time.Now().Unix(), 10))
+	r.Header.Set(signing.NonceHeader, "nonce")
+
+	w := httptest.NewRecorder()
+	New(nil, nil, nil, nil).AuthenticateAPISecret(next).ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	var resp api.Error
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, api.ErrSignedBodyTooLarge.Code, resp.Code)
+}
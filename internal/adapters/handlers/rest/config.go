@@ -16,6 +16,14 @@ import (
 // - IDLE_TIMEOUT: the idle timeout for the server (if 0, no timeout is set)
 // - CORS_MAX_AGE: the max age for the CORS header
 // - CORS_EXTRA_ALLOWED_HEADERS: the extra allowed headers for the CORS header (comma separated)
+// - TLS_CERT_FILE / TLS_KEY_FILE: serve HTTPS with this certificate and key instead of plain HTTP
+// - TLS_CLIENT_CA_FILE: CA bundle client certificates are verified against during the handshake (optional)
+// - TLS_REQUIRE_CLIENT_CERT: reject TLS connections that don't present a client certificate
+// - REGISTRATION_MODE: who can register projects through /register, one of open, invite or operator
+// - OPERATOR_API_KEY: key of the operator API, sent in X-Operator-Key (if empty, the operator API is disabled)
+// - REGISTRATIONS_PER_IP: the number of registrations allowed per client address in each window (if 0, registrations are not throttled)
+// - REGISTRATION_WINDOW: the window registrations per client address are counted in
+// - CLIENT_IP_HEADER: header set by a trusted proxy to read the client address from, its last entry is used (if empty, the connection address is used)
 type Config struct {
 	Port                    int           `env:"PORT" envDefault:"8080"`
 	MetricsPort             int           `env:"METRICS_PORT" envDefault:"9090"`
@@ -25,6 +33,15 @@ type Config struct {
 	IdleTimeout             time.Duration `env:"IDLE_TIMEOUT" envDefault:"15s"`
 	CORSMaxAge              int           `env:"CORS_MAX_AGE" envDefault:"86400"`
 	CORSExtraAllowedHeaders string        `env:"CORS_EXTRA_ALLOWED_HEADERS" envDefault:""`
+	TLSCertFile             string        `env:"TLS_CERT_FILE"`
+	TLSKeyFile              string        `env:"TLS_KEY_FILE"`
+	TLSClientCAFile         string        `env:"TLS_CLIENT_CA_FILE"`
+	TLSRequireClientCert    bool          `env:"TLS_REQUIRE_CLIENT_CERT" envDefault:"false"`
+	RegistrationMode        string        `env:"REGISTRATION_MODE" envDefault:"open"`
+	OperatorAPIKey          string        `env:"OPERATOR_API_KEY"`
+	RegistrationsPerIP      int           `env:"REGISTRATIONS_PER_IP" envDefault:"5"`
+	RegistrationWindow      time.Duration `env:"REGISTRATION_WINDOW" envDefault:"1h"`
+	ClientIPHeader          string        `env:"CLIENT_IP_HEADER"`
 }
 
 // GetConfigFromEnv gets the configuration from the environment variables.
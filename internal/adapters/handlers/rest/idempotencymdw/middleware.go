@@ -0,0 +1,246 @@
+package idempotencymdw
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/api"
+	"github.com/openfort-xyz/shield/internal/core/domain/idempotency"
+	"github.com/openfort-xyz/shield/internal/core/ports/repositories"
+	"github.com/openfort-xyz/shield/pkg/contexter"
+	"github.com/openfort-xyz/shield/pkg/cypher"
+	"github.com/openfort-xyz/shield/pkg/logger"
+)
+
+const (
+	KeyHeader      = "Idempotency-Key"
+	ReplayedHeader = "Idempotent-Replayed"
+
+	etagHeader = "ETag"
+)
+
+const (
+	// ttl is how long a response is replayed to retries of its request.
+	ttl = 24 * time.Hour
+	// lockTTL bounds how long a request in flight holds its key, so the key
+	// of a request that died with its replica can be used again.
+	lockTTL = 5 * time.Minute
+	// pruneInterval is how often each replica removes the expired records.
+	pruneInterval = time.Hour
+
+	maxKeyLength = 255
+	// maxResponseSize bounds the responses that are stored, the key of a
+	// larger response is released instead so retries are handled again.
+	maxResponseSize = 1 << 20
+)
+
+// Middleware makes mutating requests sent with an Idempotency-Key safe to
+// retry. The first request with a key is handled and its response stored,
+// retries with the same key and request get the stored response back. Keys
+// are scoped to the authenticated project and user, so it must run after the
+// authentication middleware.
+type Middleware struct {
+	repo   repositories.IdempotencyRepository
+	logger *slog.Logger
+
+	mu      sync.Mutex
+	pruneAt time.Time
+}
+
+func New(repo repositories.IdempotencyRepository) *Middleware {
+	return &Middleware{
+		repo:   repo,
+		logger: logger.New("idempotency_middleware"),
+	}
+}
+
+func (m *Middleware) IdempotencyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(KeyHeader)
+		if key == "" || !mutating(r.Method) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(key) > maxKeyLength {
+			api.RespondWithError(w, r, api.ErrInvalidIdempotencyKey)
+			return
+		}
+
+		ctx := r.Context()
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to read request body"))
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		scope := contexter.GetProjectID(ctx) + "\x00" + contexter.GetUserID(ctx) + "\x00" + key
+		rec := &idempotency.Record{
+			KeyHash:     hash([]byte(scope)),
+			RequestHash: hash([]byte(r.Method+"\x00"+r.URL.RequestURI()+"\x00"), body),
+			ExpiresAt:   time.Now().Add(lockTTL),
+		}
+		bodyKey := responseKey(scope)
+
+		existing, err := m.repo.Claim(ctx, rec)
+		if err != nil {
+			api.RespondWithError(w, r, api.ErrInternal)
+			return
+		}
+		if existing != nil {
+			m.replay(w, r, existing, rec.RequestHash, bodyKey)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		// The client may be gone, it's the one that will retry
+		ctx = context.WithoutCancel(ctx)
+		m.store(ctx, rec, recorder, bodyKey)
+		m.prune(ctx)
+	})
+}
+
+func (m *Middleware) replay(w http.ResponseWriter, r *http.Request, rec *idempotency.Record, requestHash, bodyKey string) {
+	if rec.RequestHash != requestHash {
+		api.RespondWithError(w, r, api.ErrIdempotencyKeyReused)
+		return
+	}
+	if !rec.Completed() {
+		w.Header().Set("Retry-After", "1")
+		api.RespondWithError(w, r, api.ErrIdempotencyKeyInProgress)
+		return
+	}
+
+	body, err := cypher.Decrypt(rec.Body, bodyKey)
+	if err != nil {
+		m.logger.ErrorContext(r.Context(), "error decrypting stored response", logger.Error(err))
+		api.RespondWithError(w, r, api.ErrInternal)
+		return
+	}
+
+	if rec.ContentType != "" {
+		w.Header().Set("Content-Type", rec.ContentType)
+	}
+	if rec.ETag != "" {
+		w.Header().Set(etagHeader, rec.ETag)
+	}
+	w.Header().Set(ReplayedHeader, "true")
+	w.WriteHeader(rec.Status)
+	_, _ = w.Write([]byte(body))
+}
+
+// store keeps the response for the retries of the request. Server errors
+// aren't stored, the request may succeed when it's retried.
+func (m *Middleware) store(ctx context.Context, rec *idempotency.Record, recorder *responseRecorder, bodyKey string) {
+	if recorder.status >= http.StatusInternalServerError || recorder.overflow {
+		if err := m.repo.Release(ctx, rec.KeyHash); err != nil {
+			m.logger.ErrorContext(ctx, "error releasing idempotency key", logger.Error(err))
+		}
+		return
+	}
+
+	body, err := cypher.Encrypt(recorder.body.String(), bodyKey)
+	if err != nil {
+		m.logger.ErrorContext(ctx, "error encrypting response", logger.Error(err))
+		if err := m.repo.Release(ctx, rec.KeyHash); err != nil {
+			m.logger.ErrorContext(ctx, "error releasing idempotency key", logger.Error(err))
+		}
+		return
+	}
+
+	rec.Status = recorder.status
+	rec.ContentType = recorder.Header().Get("Content-Type")
+	rec.ETag = recorder.Header().Get(etagHeader)
+	rec.Body = body
+	rec.ExpiresAt = time.Now().Add(ttl)
+	if err := m.repo.Complete(ctx, rec); err != nil {
+		m.logger.ErrorContext(ctx, "error storing idempotent response", logger.Error(err))
+	}
+}
+
+// prune removes the expired records in the background, at most once per
+// interval on each replica.
+func (m *Middleware) prune(ctx context.Context) {
+	now := time.Now()
+
+	m.mu.Lock()
+	if now.Before(m.pruneAt) {
+		m.mu.Unlock()
+		return
+	}
+	m.pruneAt = now.Add(pruneInterval)
+	m.mu.Unlock()
+
+	go func() {
+		_ = m.repo.DeleteExpired(ctx)
+	}()
+}
+
+func mutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func hash(parts ...[]byte) string {
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write(part)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseKey derives the key stored responses are encrypted with. Only the
+// callers holding the idempotency key can derive it.
+func responseKey(scope string) string {
+	key := sha256.Sum256([]byte("response\x00" + scope))
+	return base64.StdEncoding.EncodeToString(key[:])
+}
+
+// responseRecorder passes the response through while keeping a copy of it.
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+	overflow    bool
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(p []byte) (int, error) {
+	r.wroteHeader = true
+	if !r.overflow {
+		if r.body.Len()+len(p) > maxResponseSize {
+			r.overflow = true
+			r.body.Reset()
+		} else {
+			r.body.Write(p)
+		}
+	}
+	return r.ResponseWriter.Write(p)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,175 @@
+package idempotencymdw
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/openfort-xyz/shield/internal/core/domain/idempotency"
+	"github.com/openfort-xyz/shield/pkg/contexter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryRepository keeps the records in a map, expiry aside it behaves like
+// the SQL repository.
+type memoryRepository struct {
+	mu      sync.Mutex
+	records map[string]*idempotency.Record
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{records: make(map[string]*idempotency.Record)}
+}
+
+func (r *memoryRepository) Claim(_ context.Context, rec *idempotency.Record) (*idempotency.Record, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.records[rec.KeyHash]; ok {
+		stored := *existing
+		return &stored, nil
+	}
+	stored := *rec
+	r.records[rec.KeyHash] = &stored
+	return nil, nil
+}
+
+func (r *memoryRepository) Complete(_ context.Context, rec *idempotency.Record) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *rec
+	r.records[rec.KeyHash] = &stored
+	return nil
+}
+
+func (r *memoryRepository) Release(_ context.Context, keyHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.records, keyHash)
+	return nil
+}
+
+func (r *memoryRepository) DeleteExpired(context.Context) error {
+	return nil
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	var calls int
+	status := http.StatusCreated
+	handler := New(newMemoryRepository()).IdempotencyMiddleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"2"`)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"session":"secret"}`))
+	}))
+
+	send := func(method, key, userID, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/project/encryption-session", strings.NewReader(body))
+		if key != "" {
+			req.Header.Set(KeyHeader, key)
+		}
+		ctx := contexter.WithProjectID(req.Context(), "project-id")
+		ctx = contexter.WithUserID(ctx, userID)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req.WithContext(ctx))
+		return rec
+	}
+
+	t.Run("retries replay the response", func(t *testing.T) {
+		calls = 0
+		first := send(http.MethodPost, "replay", "", `{"part":"a"}`)
+		second := send(http.MethodPost, "replay", "", `{"part":"a"}`)
+
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, http.StatusCreated, second.Code)
+		assert.Equal(t, first.Body.String(), second.Body.String())
+		assert.Equal(t, "application/json", second.Header().Get("Content-Type"))
+		assert.Equal(t, `"2"`, second.Header().Get("ETag"))
+		assert.Empty(t, first.Header().Get(ReplayedHeader))
+		assert.Equal(t, "true", second.Header().Get(ReplayedHeader))
+	})
+
+	t.Run("a different request with the key is refused", func(t *testing.T) {
+		calls = 0
+		send(http.MethodPost, "reused", "", `{"part":"a"}`)
+		rec := send(http.MethodPost, "reused", "", `{"part":"b"}`)
+
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "IDEM_KEY_REUSED")
+	})
+
+	t.Run("keys are scoped to the user", func(t *testing.T) {
+		calls = 0
+		send(http.MethodPost, "scoped", "user-1", `{}`)
+		rec := send(http.MethodPost, "scoped", "user-2", `{}`)
+
+		assert.Equal(t, 2, calls)
+		assert.Empty(t, rec.Header().Get(ReplayedHeader))
+	})
+
+	t.Run("server errors release the key", func(t *testing.T) {
+		calls = 0
+		status = http.StatusInternalServerError
+		send(http.MethodPost, "failed", "", `{}`)
+		status = http.StatusCreated
+		rec := send(http.MethodPost, "failed", "", `{}`)
+
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("requests without a key or reads aren't tracked", func(t *testing.T) {
+		calls = 0
+		send(http.MethodPost, "", "", `{}`)
+		send(http.MethodPost, "", "", `{}`)
+		send(http.MethodGet, "read", "", "")
+		send(http.MethodGet, "read", "", "")
+
+		assert.Equal(t, 4, calls)
+	})
+
+	t.Run("keys are bounded", func(t *testing.T) {
+		rec := send(http.MethodPost, strings.Repeat("k", maxKeyLength+1), "", `{}`)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "IDEM_KEY_INVALID")
+	})
+}
+
+func TestIdempotencyMiddleware_InProgress(t *testing.T) {
+	repo := newMemoryRepository()
+	release := make(chan struct{})
+	started := make(chan struct{})
+	handler := New(repo).IdempotencyMiddleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	send := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/shares", strings.NewReader(`{}`))
+		req.Header.Set(KeyHeader, "in-progress")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		send()
+	}()
+	<-started
+
+	rec := send()
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), "IDEM_IN_PROGRESS")
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+
+	close(release)
+	<-done
+}
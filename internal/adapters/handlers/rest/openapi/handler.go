@@ -0,0 +1,32 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Handler serves the OpenAPI document, it's generated once when the handler
+// is created.
+type Handler struct {
+	document []byte
+}
+
+func New() (*Handler, error) {
+	document, err := json.Marshal(Build())
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{document: document}, nil
+}
+
+// Document returns the OpenAPI document of the API
+// @Summary OpenAPI document
+// @Description Get the OpenAPI 3.1 document of the API
+// @Tags Health
+// @Produce json
+// @Success 200 "Successful response"
+// @Router /openapi.json [get]
+func (h *Handler) Document(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(h.document)
+}
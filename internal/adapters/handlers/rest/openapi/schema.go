@@ -0,0 +1,195 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const schemaRefPrefix = "#/components/schemas/"
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// schemas reflects the handler types into component schemas, so the document
+// follows the request and response types as they change.
+type schemas struct {
+	components map[string]*Schema
+	names      map[reflect.Type]string
+	enums      map[reflect.Type][]any
+}
+
+func newSchemas(enums map[reflect.Type][]any) *schemas {
+	return &schemas{
+		components: make(map[string]*Schema),
+		names:      make(map[reflect.Type]string),
+		enums:      enums,
+	}
+}
+
+// of returns the schema of v's type, named structs and enums are registered as
+// components and referenced.
+func (s *schemas) of(v any) *Schema {
+	return s.schemaOf(reflect.TypeOf(v))
+}
+
+func (s *schemas) schemaOf(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+	if values, ok := s.enums[t]; ok {
+		return s.component(t, func() *Schema {
+			sch := primitive(t)
+			sch.Enum = values
+			return sch
+		})
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.object(t)
+		}
+		return s.component(t, func() *Schema { return s.object(t) })
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: s.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.schemaOf(t.Elem())}
+	case reflect.Interface:
+		return &Schema{}
+	default:
+		return primitive(t)
+	}
+}
+
+// component registers the schema of t under its type name. Handler packages
+// declare types with the same name, identical enums share one component and
+// any other clash is prefixed with the package, e.g. ProjectEntropy.
+func (s *schemas) component(t reflect.Type, build func() *Schema) *Schema {
+	if name, ok := s.names[t]; ok {
+		return ref(name)
+	}
+
+	name := t.Name()
+	if existing, taken := s.components[name]; taken {
+		if t.Kind() != reflect.Struct && sameJSON(build(), existing) {
+			s.names[t] = name
+			return ref(name)
+		}
+		name = packagePrefix(t) + name
+	}
+
+	s.names[t] = name
+	// Reserved before building so recursive types resolve to the reference.
+	s.components[name] = &Schema{}
+	s.components[name] = build()
+	return ref(name)
+}
+
+func (s *schemas) object(t reflect.Type) *Schema {
+	sch := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	s.fields(t, sch)
+	return sch
+}
+
+// fields adds the properties of t following the encoding/json rules, fields
+// that are pointers or omitted when empty aren't required.
+func (s *schemas) fields(t reflect.Type, sch *Schema) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				s.fields(ft, sch)
+				continue
+			}
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		sch.Properties[name] = s.schemaOf(f.Type)
+		if f.Type.Kind() != reflect.Pointer && !hasOption(opts, "omitempty") {
+			sch.Required = append(sch.Required, name)
+		}
+	}
+}
+
+func primitive(t reflect.Type) *Schema {
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int32, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Int8, reflect.Int16, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	default:
+		return &Schema{}
+	}
+}
+
+func ref(name string) *Schema {
+	return &Schema{Ref: schemaRefPrefix + name}
+}
+
+func packagePrefix(t reflect.Type) string {
+	pkg := strings.TrimSuffix(path.Base(t.PkgPath()), "hdl")
+	if pkg == "" {
+		return ""
+	}
+	return strings.ToUpper(pkg[:1]) + pkg[1:]
+}
+
+// sameJSON compares schemas as they're served, enum values of distinct Go types
+// with the same underlying value are equal.
+func sameJSON(a, b *Schema) bool {
+	x, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	y, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(x, y)
+}
+
+func hasOption(opts, option string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,371 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/api"
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/authmdw"
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/healthzhdl"
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/idempotencymdw"
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/operatormdw"
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/projecthdl"
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/sharehdl"
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/usrhdl"
+	"github.com/openfort-xyz/shield/pkg/signing"
+)
+
+const (
+	Version = "3.1.0"
+
+	jsonContentType   = "application/json"
+	ndjsonContentType = "application/x-ndjson"
+)
+
+// auth is how a route authenticates its caller.
+type auth int
+
+const (
+	authNone auth = iota
+	authProject
+	authUser
+	authOperator
+)
+
+type route struct {
+	method      string
+	path        string
+	id          string
+	summary     string
+	description string
+	tag         string
+	auth        auth
+	params      []*Parameter
+	// body and response are values of the types sent on the wire, nil when
+	// there's none. ndjson streams them one per line.
+	body         any
+	bodyOptional bool
+	ndjson       bool
+	status       int
+	response     any
+	// also documents responses other than errors, e.g. the unhealthy status.
+	also map[int]any
+	// etag documents the ETag header of the share on the response.
+	etag   bool
+	errors []int
+}
+
+var (
+	encryptionParams = []*Parameter{
+		header(sharehdl.EncryptionPartHeader, "Project encryption part, for shares with project entropy when the project has no stored encryption part.", false),
+		header(sharehdl.EncryptionSessionHeader, "One-time encryption session registered with POST /project/encryption-session, instead of the encryption part.", false),
+	}
+	migrationParams = append([]*Parameter{
+		header(sharehdl.TransferKeyHeader, "Key the exported secrets are encrypted with and imported secrets are decrypted with.", false),
+	}, encryptionParams...)
+)
+
+// routes lists every route the REST server registers, the router test fails
+// when they drift apart.
+var routes = []route{
+	{method: http.MethodGet, path: "/healthz", id: "healthz", summary: "Health check", tag: "Health", status: http.StatusOK, response: healthzhdl.Status{}, also: map[int]any{http.StatusServiceUnavailable: healthzhdl.Status{}}},
+	{method: http.MethodGet, path: "/openapi.json", id: "getOpenAPI", summary: "OpenAPI document of this API", tag: "Health", status: http.StatusOK, response: map[string]any{}},
+	{method: http.MethodGet, path: "/storage-methods", id: "getShareStorageMethods", summary: "Get share storage methods", tag: "Shares", status: http.StatusOK, response: sharehdl.GetShareStorageMethodsResponse{}},
+	{
+		method: http.MethodPost, path: "/register", id: "createProject", summary: "Create a project", tag: "Project",
+		description: "Depending on the registration mode, registration is open, needs an invite token or is reserved to the operator.",
+		params: []*Parameter{
+			header(operatormdw.InviteTokenHeader, "Single-use invite, required in invite registration mode unless the operator key is sent.", false),
+			header(operatormdw.OperatorKeyHeader, "Operator API key, required in operator registration mode.", false),
+		},
+		body: projecthdl.CreateProjectRequest{}, status: http.StatusCreated, response: projecthdl.CreateProjectResponse{},
+		errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests},
+	},
+
+	{method: http.MethodGet, path: "/project", id: "getProject", summary: "Get a project", tag: "Project", auth: authProject, status: http.StatusOK, response: projecthdl.GetProjectResponse{}, errors: []int{http.StatusNotFound}},
+	{method: http.MethodDelete, path: "/project", id: "deleteProject", summary: "Delete a project", tag: "Project", auth: authProject, body: projecthdl.DeleteProjectRequest{}, status: http.StatusOK, response: projecthdl.DeletionReceiptResponse{}, errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict}},
+	{method: http.MethodPost, path: "/project/deletion", id: "requestProjectDeletion", summary: "Request the deletion of a project", tag: "Project", auth: authProject, status: http.StatusOK, response: projecthdl.RequestDeletionResponse{}, errors: []int{http.StatusNotFound}},
+	{method: http.MethodPost, path: "/project/reset-api-secret", id: "resetAPISecret", summary: "Reset API secret", description: "Disabled, always answers 501.", tag: "Project", auth: authProject, status: http.StatusNotImplemented},
+	{method: http.MethodPost, path: "/project/otp", id: "requestOTP", summary: "Request an OTP for a user", tag: "Project", auth: authProject, body: projecthdl.GenerateOTPRequest{}, status: http.StatusOK, errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusTooManyRequests}},
+	{method: http.MethodGet, path: "/project/providers", id: "getProviders", summary: "Get providers", tag: "Providers", auth: authProject, status: http.StatusOK, response: projecthdl.GetProvidersResponse{}},
+	{method: http.MethodPost, path: "/project/providers", id: "addProviders", summary: "Add providers", tag: "Providers", auth: authProject, body: projecthdl.AddProvidersRequest{}, status: http.StatusOK, response: projecthdl.AddProvidersResponse{}, errors: []int{http.StatusBadRequest, http.StatusConflict}},
+	{method: http.MethodGet, path: "/project/providers/{provider}", id: "getProvider", summary: "Get a provider", tag: "Providers", auth: authProject, status: http.StatusOK, response: projecthdl.GetProviderResponse{}, errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+	{method: http.MethodPut, path: "/project/providers/{provider}", id: "updateProvider", summary: "Update a provider", tag: "Providers", auth: authProject, body: projecthdl.UpdateProviderRequest{}, status: http.StatusOK, errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict}},
+	{method: http.MethodDelete, path: "/project/providers/{provider}", id: "deleteProvider", summary: "Delete a provider", tag: "Providers", auth: authProject, status: http.StatusOK, errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+	{method: http.MethodPost, path: "/project/providers/{provider}/keys", id: "addProviderKey", summary: "Add a key to a custom provider", tag: "Providers", auth: authProject, body: projecthdl.AddProviderKeyRequest{}, status: http.StatusCreated, response: projecthdl.ProviderKeyResponse{}, errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict}},
+	{method: http.MethodDelete, path: "/project/providers/{provider}/keys/{key}", id: "deleteProviderKey", summary: "Delete a key of a custom provider", tag: "Providers", auth: authProject, status: http.StatusOK, errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+	{method: http.MethodGet, path: "/project/client-certificates", id: "getClientCertificates", summary: "Get client certificates", tag: "Project", auth: authProject, status: http.StatusOK, response: projecthdl.GetClientCertificatesResponse{}},
+	{method: http.MethodPost, path: "/project/client-certificates", id: "addClientCertificate", summary: "Add a client certificate", tag: "Project", auth: authProject, body: projecthdl.AddClientCertificateRequest{}, status: http.StatusCreated, response: projecthdl.ClientCertificateResponse{}, errors: []int{http.StatusBadRequest, http.StatusConflict}},
+	{method: http.MethodPut, path: "/project/client-certificates/mode", id: "setClientCertMode", summary: "Set the client certificate mode", tag: "Project", auth: authProject, body: projecthdl.SetClientCertModeRequest{}, status: http.StatusOK, errors: []int{http.StatusBadRequest, http.StatusConflict}},
+	{method: http.MethodDelete, path: "/project/client-certificates/{certificate}", id: "deleteClientCertificate", summary: "Delete a client certificate", tag: "Project", auth: authProject, status: http.StatusOK, errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict}},
+	{
+		method: http.MethodGet, path: "/project/users", id: "listUsers", summary: "List the users of a project", tag: "Users", auth: authProject,
+		params: []*Parameter{
+			query("provider", "Only users signed in with this provider.", &Schema{Type: "string"}),
+			query("entropy", "Only users with a share of this entropy.", &Schema{Ref: schemaRefPrefix + "Entropy"}),
+			query("created_after", "Only users created after this time.", &Schema{Type: "string", Format: "date-time"}),
+			query("created_before", "Only users created before this time.", &Schema{Type: "string", Format: "date-time"}),
+			query("cursor", "Cursor of the next page, as returned by the previous page.", &Schema{Type: "string"}),
+			query("limit", "Maximum number of users in the page.", &Schema{Type: "integer"}),
+		},
+		status: http.StatusOK, response: projecthdl.ListUsersResponse{}, errors: []int{http.StatusBadRequest},
+	},
+	{method: http.MethodGet, path: "/project/users/{externalUserID}", id: "exportUser", summary: "Export the data held on a user", tag: "Users", auth: authProject, status: http.StatusOK, response: projecthdl.UserExportResponse{}, errors: []int{http.StatusNotFound}},
+	{method: http.MethodDelete, path: "/project/users/{externalUserID}", id: "eraseUser", summary: "Erase a user", tag: "Users", auth: authProject, status: http.StatusOK, response: projecthdl.ErasureReceiptResponse{}, errors: []int{http.StatusNotFound}},
+	{method: http.MethodPost, path: "/project/signing-key", id: "rotateSigningKey", summary: "Rotate the request signing key", tag: "Project", auth: authProject, status: http.StatusOK, response: projecthdl.RotateSigningKeyResponse{}},
+	{method: http.MethodPut, path: "/project/signed-requests", id: "setSignedRequests", summary: "Require signed requests", tag: "Project", auth: authProject, body: projecthdl.SetSignedRequestsRequest{}, status: http.StatusOK, errors: []int{http.StatusBadRequest, http.StatusConflict}},
+	{method: http.MethodPost, path: "/project/encrypt", id: "encryptProjectShares", summary: "Encrypt the shares of a project", tag: "Project", auth: authProject, body: projecthdl.EncryptBodyRequest{}, status: http.StatusOK, errors: []int{http.StatusBadRequest, http.StatusConflict}},
+	{method: http.MethodPost, path: "/project/encryption-session", id: "registerEncryptionSession", summary: "Register an encryption session", tag: "Project", auth: authProject, body: projecthdl.RegisterEncryptionSessionRequest{}, status: http.StatusOK, response: projecthdl.RegisterEncryptionSessionResponse{}, errors: []int{http.StatusBadRequest, http.StatusConflict}},
+	{method: http.MethodPost, path: "/project/encryption-key", id: "registerEncryptionKey", summary: "Register an encryption key", tag: "Project", auth: authProject, status: http.StatusOK, response: projecthdl.RegisterEncryptionKeyResponse{}, errors: []int{http.StatusConflict}},
+	{method: http.MethodPost, path: "/project/enable-2fa", id: "enable2FA", summary: "Enable two-factor encryption", tag: "Project", auth: authProject, status: http.StatusOK, errors: []int{http.StatusConflict}},
+	{method: http.MethodPost, path: "/user", id: "createUser", summary: "Create a user", tag: "Users", auth: authProject, body: usrhdl.CreateUserRequest{}, status: http.StatusCreated, response: usrhdl.CreateUserResponse{}, errors: []int{http.StatusBadRequest}},
+
+	{method: http.MethodGet, path: "/shares", id: "getShare", summary: "Get the share of the user", tag: "Shares", auth: authUser, params: encryptionParams, status: http.StatusOK, etag: true, response: sharehdl.GetShareResponse{}, errors: []int{http.StatusNotFound, http.StatusConflict}},
+	{method: http.MethodGet, path: "/shares/{reference}", id: "getShareByReference", summary: "Get a share of the user by reference", tag: "Shares", auth: authUser, params: encryptionParams, status: http.StatusOK, etag: true, response: sharehdl.GetShareResponse{}, errors: []int{http.StatusNotFound, http.StatusConflict}},
+	{method: http.MethodPost, path: "/shares", id: "registerShare", summary: "Register a share", tag: "Shares", auth: authUser, body: sharehdl.RegisterShareRequest{}, status: http.StatusCreated, errors: []int{http.StatusBadRequest, http.StatusConflict}},
+	{method: http.MethodDelete, path: "/shares", id: "deleteShare", summary: "Delete the share of the user", tag: "Shares", auth: authUser, status: http.StatusNoContent, errors: []int{http.StatusNotFound}},
+	{method: http.MethodDelete, path: "/shares/{reference}", id: "deleteShareByReference", summary: "Delete a share of the user by reference", tag: "Shares", auth: authUser, status: http.StatusNoContent, errors: []int{http.StatusNotFound}},
+	{
+		method: http.MethodPut, path: "/shares", id: "updateShare", summary: "Update a share", tag: "Shares", auth: authUser,
+		params: []*Parameter{
+			header(sharehdl.IfMatchHeader, "ETag of the share as it was read, the update is refused with 412 when the share changed since. * or no header updates it whatever its version.", false),
+		},
+		body: sharehdl.UpdateShareRequest{}, status: http.StatusOK, response: sharehdl.UpdateShareResponse{}, etag: true,
+		errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed},
+	},
+	{
+		method: http.MethodGet, path: "/keychain", id: "keychain", summary: "Get the shares of the user", tag: "Keychain", auth: authUser,
+		params: append([]*Parameter{
+			query("reference", "Only the share with this reference. It can't be combined with reference_prefix, entropy, cursor or limit.", &Schema{Type: "string"}),
+			query("reference_prefix", "Only shares whose reference starts with this prefix.", &Schema{Type: "string"}),
+			query("entropy", "Only shares of this entropy.", &Schema{Ref: schemaRefPrefix + "Entropy"}),
+			query("metadata", "Only shares with this metadata label, as key:value. Repeat it to require several labels.", &Schema{Type: "array", Items: &Schema{Type: "string"}}),
+			query("fields", "metadata returns the shares with an empty secret, nothing is decrypted so the encryption headers aren't needed.", &Schema{Type: "string", Enum: []any{sharehdl.KeychainFieldsAll, sharehdl.KeychainFieldsMetadata}}),
+			query("cursor", "Cursor of the next page, as returned by the previous page.", &Schema{Type: "string"}),
+			query("limit", "Maximum number of shares in the page, every share is returned when it's not set.", &Schema{Type: "integer"}),
+		}, encryptionParams...),
+		status: http.StatusOK, response: sharehdl.KeychainResponse{}, errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict},
+	},
+	{method: http.MethodGet, path: "/keychain/metadata", id: "keychainMetadata", summary: "Get the metadata of the shares of the user", tag: "Keychain", auth: authUser, status: http.StatusOK, response: sharehdl.KeychainMetadataResponse{}, errors: []int{http.StatusNotFound}},
+	{method: http.MethodPut, path: "/keychain/references/{reference}", id: "renameReference", summary: "Rename the reference of a share", tag: "Keychain", auth: authUser, body: sharehdl.RenameReferenceRequest{}, status: http.StatusNoContent, errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict}},
+
+	{method: http.MethodGet, path: "/shares/encryption", id: "getShareEncryption", summary: "Get the encryption of a share", tag: "Shares", auth: authProject, status: http.StatusOK, response: sharehdl.GetShareEncryptionResponse{}, errors: []int{http.StatusNotFound}},
+	{method: http.MethodPost, path: "/shares/encryption/reference/bulk", id: "getSharesEncryptionForReferences", summary: "Get the encryption of shares by reference", tag: "Shares", auth: authProject, body: sharehdl.GetSharesEncryptionForReferencesRequest{}, status: http.StatusOK, response: sharehdl.GetSharesEncryptionForReferencesResponse{}, errors: []int{http.StatusBadRequest}},
+	{method: http.MethodPost, path: "/shares/encryption/user/bulk", id: "getSharesEncryptionForUsers", summary: "Get the encryption of shares by user", tag: "Shares", auth: authProject, body: sharehdl.GetSharesEncryptionForUsersRequest{}, status: http.StatusOK, response: sharehdl.GetSharesEncryptionForUsersResponse{}, errors: []int{http.StatusBadRequest}},
+	{method: http.MethodPut, path: "/shares/reassign/{reference}", id: "reassignShare", summary: "Reassign a share to another user", tag: "Shares", auth: authProject, body: sharehdl.ReassignShareRequest{}, status: http.StatusNoContent, errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict}},
+	{method: http.MethodGet, path: "/shares/migration/export/{reference}", id: "exportShare", summary: "Export a share", tag: "Migration", auth: authProject, status: http.StatusOK, response: sharehdl.ExportShareResponse{}, errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+	{method: http.MethodPost, path: "/shares/migration/import", id: "importShare", summary: "Import a share", tag: "Migration", auth: authProject, body: sharehdl.ImportShareRequest{}, status: http.StatusCreated, errors: []int{http.StatusBadRequest, http.StatusConflict}},
+	{
+		method: http.MethodGet, path: "/shares/migration/bulk/export", id: "exportShares", summary: "Export the shares of a project", tag: "Migration", auth: authProject,
+		params: append([]*Parameter{query("after", "Resume the export after this share.", &Schema{Type: "string"})}, migrationParams...),
+		ndjson: true, status: http.StatusOK, response: sharehdl.MigratedShare{}, errors: []int{http.StatusBadRequest, http.StatusConflict},
+	},
+	{method: http.MethodPost, path: "/shares/migration/bulk/import", id: "importShares", summary: "Import shares into a project", tag: "Migration", auth: authProject, params: migrationParams, body: sharehdl.MigratedShare{}, ndjson: true, status: http.StatusOK, response: sharehdl.ImportResult{}, errors: []int{http.StatusBadRequest, http.StatusConflict}},
+
+	{method: http.MethodPost, path: "/operator/invites", id: "createInvite", summary: "Create a registration invite", tag: "Operator", auth: authOperator, body: projecthdl.CreateInviteRequest{}, bodyOptional: true, status: http.StatusCreated, response: projecthdl.CreateInviteResponse{}, errors: []int{http.StatusBadRequest}},
+	{method: http.MethodPut, path: "/operator/projects/{project}/status", id: "setProjectStatus", summary: "Set the status of a project", tag: "Operator", auth: authOperator, body: projecthdl.SetProjectStatusRequest{}, status: http.StatusNoContent, errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+	{
+		method: http.MethodPost, path: "/admin/preregister", id: "preregisterShare", summary: "Register a share on behalf of a user", tag: "Shares", auth: authProject,
+		params: []*Parameter{
+			header(authmdw.UserIDHeader, "User the share is registered for, created when it doesn't exist.", true),
+			header(authmdw.AuthProviderHeader, "Provider the user signs in with.", true),
+		},
+		body: sharehdl.RegisterShareRequest{}, status: http.StatusCreated, errors: []int{http.StatusBadRequest, http.StatusConflict},
+	},
+}
+
+// enums lists the values of the string and integer types the handlers use as
+// enums, reflection can't find them.
+var enums = map[reflect.Type][]any{
+	reflect.TypeFor[sharehdl.Entropy]():                 {sharehdl.EntropyNone, sharehdl.EntropyUser, sharehdl.EntropyProject, sharehdl.EntropyPasskey},
+	reflect.TypeFor[sharehdl.ShareStorageMethodID]():    {sharehdl.StorageMethodShield, sharehdl.StorageMethodGoogleDrive, sharehdl.StorageMethodICloud},
+	reflect.TypeFor[sharehdl.ImportStatus]():            {sharehdl.ImportStatusImported, sharehdl.ImportStatusSkipped, sharehdl.ImportStatusFailed},
+	reflect.TypeFor[sharehdl.EncryptionTypeStatus]():    {sharehdl.EncryptionTypeStatusNotFound, sharehdl.EncryptionTypeStatusFound},
+	reflect.TypeFor[projecthdl.Entropy]():               {projecthdl.EntropyNone, projecthdl.EntropyUser, projecthdl.EntropyProject, projecthdl.EntropyPasskey},
+	reflect.TypeFor[projecthdl.KeyType]():               {projecthdl.KeyTypeRSA, projecthdl.KeyTypeECDSA, projecthdl.KeyTypeEd25519, projecthdl.KeyTypeHMAC},
+	reflect.TypeFor[projecthdl.ClientCertMode]():        {projecthdl.ClientCertModeDisabled, projecthdl.ClientCertModeAlternative, projecthdl.ClientCertModeRequired},
+	reflect.TypeFor[projecthdl.ProjectStatus]():         {projecthdl.ProjectStatusActive, projecthdl.ProjectStatusReadOnly, projecthdl.ProjectStatusSuspended},
+	reflect.TypeFor[projecthdl.ClientCertificateType](): {projecthdl.ClientCertificateTypeCA, projecthdl.ClientCertificateTypeLeaf},
+	reflect.TypeFor[projecthdl.DeletionMode]():          {projecthdl.DeletionModeHardDelete, projecthdl.DeletionModeCryptoShred},
+}
+
+var securitySchemes = map[string]*SecurityScheme{
+	"apiKey":            {Type: "apiKey", In: "header", Name: authmdw.APIKeyHeader, Description: "Publishable key of the project."},
+	"apiSecret":         {Type: "apiKey", In: "header", Name: authmdw.APISecretHeader, Description: "Secret of the project."},
+	"requestSignature":  {Type: "apiKey", In: "header", Name: signing.SignatureHeader, Description: fmt.Sprintf("Signature of the request with the project signing key, sent with the %s and %s headers.", signing.TimestampHeader, signing.NonceHeader)},
+	"clientCertificate": {Type: "mutualTLS", Description: "Client certificate registered on the project."},
+	"bearerAuth":        {Type: "http", Scheme: "bearer", Description: fmt.Sprintf("Token of the user, checked by the provider named in %s.", authmdw.AuthProviderHeader)},
+	"operatorKey":       {Type: "apiKey", In: "header", Name: operatormdw.OperatorKeyHeader, Description: "Operator API key."},
+}
+
+// security maps each kind of auth to the alternative sets of schemes it accepts.
+var security = map[auth][]SecurityRequirement{
+	authProject: {
+		{"apiKey": {}, "apiSecret": {}},
+		{"apiKey": {}, "requestSignature": {}},
+		{"apiKey": {}, "clientCertificate": {}},
+	},
+	authUser:     {{"apiKey": {}, "bearerAuth": {}}},
+	authOperator: {{"operatorKey": {}}},
+}
+
+var (
+	projectAuthParams = []*Parameter{
+		header(signing.TimestampHeader, "Unix time the request was signed at, required with a signature.", false),
+		header(signing.NonceHeader, "Single-use nonce of the signed request, required with a signature.", false),
+	}
+	userAuthParams = []*Parameter{
+		{Name: authmdw.AuthProviderHeader, In: "header", Description: "Provider that checks the user token.", Required: true, Schema: &Schema{Type: "string", Enum: []any{authmdw.AuthenticationTypeOpenfort, authmdw.AuthenticationTypeCustom, authmdw.AuthenticationTypeIntrospection}}},
+		header(authmdw.OpenfortProviderHeader, "Third party provider of an Openfort token.", false),
+		header(authmdw.OpenfortTokenTypeHeader, "Type of a third party Openfort token.", false),
+	}
+	authErrors = map[auth][]int{
+		authProject:  {http.StatusUnauthorized, http.StatusForbidden, http.StatusRequestEntityTooLarge},
+		authUser:     {http.StatusUnauthorized, http.StatusForbidden, http.StatusServiceUnavailable},
+		authOperator: {http.StatusUnauthorized, http.StatusNotFound},
+	}
+)
+
+var (
+	idempotencyParam  = header(idempotencymdw.KeyHeader, fmt.Sprintf("Key that makes the request safe to retry, at most 255 characters and unique per request, e.g. a UUID. Retries with the key get the response of the first request for 24 hours, with the %s header set.", idempotencymdw.ReplayedHeader), false)
+	idempotencyErrors = []int{http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity}
+)
+
+var pathParamRegexp = regexp.MustCompile(`{([^}]+)}`)
+
+// Build generates the OpenAPI document of the REST API.
+func Build() *Document {
+	s := newSchemas(enums)
+	// Errors are problem details for the clients that accept them, and keep
+	// their former format for the others
+	errorContent := map[string]*MediaType{
+		jsonContentType:        {Schema: s.of(api.Error{})},
+		api.ProblemContentType: {Schema: s.of(api.Problem{})},
+	}
+	s.components["Error"].Properties["code"].Enum = codes()
+	s.components["Problem"].Properties["code"].Enum = codes()
+	s.components["FieldError"].Properties["code"].Enum = []any{api.FieldRequired, api.FieldInvalid, api.FieldNotAllowed, api.FieldTooLarge}
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info: Info{
+			Title:       "Shield API",
+			Version:     "1.0.0",
+			Description: "Shield stores the shares of user keys on behalf of Openfort projects.",
+		},
+		Paths: make(map[string]*PathItem),
+		Components: Components{
+			Schemas:         s.components,
+			SecuritySchemes: securitySchemes,
+		},
+	}
+
+	for _, rt := range routes {
+		item, ok := doc.Paths[rt.path]
+		if !ok {
+			item = &PathItem{}
+			doc.Paths[rt.path] = item
+		}
+		(*item)[strings.ToLower(rt.method)] = operation(s, rt, errorContent)
+	}
+
+	return doc
+}
+
+func operation(s *schemas, rt route, errorContent map[string]*MediaType) *Operation {
+	op := &Operation{
+		OperationID: rt.id,
+		Summary:     rt.summary,
+		Description: rt.description,
+		Tags:        []string{rt.tag},
+		Security:    security[rt.auth],
+		Responses:   make(map[string]*Response),
+	}
+
+	for _, match := range pathParamRegexp.FindAllStringSubmatch(rt.path, -1) {
+		op.Parameters = append(op.Parameters, &Parameter{Name: match[1], In: "path", Required: true, Schema: &Schema{Type: "string"}})
+	}
+	switch rt.auth {
+	case authProject:
+		op.Parameters = append(op.Parameters, projectAuthParams...)
+	case authUser:
+		op.Parameters = append(op.Parameters, userAuthParams...)
+	}
+	op.Parameters = append(op.Parameters, rt.params...)
+	// The bulk import streams its body and skips the shares it already
+	// imported, it isn't tracked by idempotency keys
+	idempotent := rt.method != http.MethodGet && !rt.ndjson
+	if idempotent {
+		op.Parameters = append(op.Parameters, idempotencyParam)
+	}
+	op.Parameters = append(op.Parameters, header(authmdw.RequestIDHeader, "Identifier of the request, generated when missing and echoed on the response.", false))
+
+	if rt.body != nil {
+		op.RequestBody = &RequestBody{Required: !rt.bodyOptional, Content: content(s, rt.body, rt.ndjson)}
+	}
+
+	op.Responses[strconv.Itoa(rt.status)] = response(s, rt.status, rt.response, rt.ndjson)
+	if rt.etag {
+		op.Responses[strconv.Itoa(rt.status)].Headers[sharehdl.ETagHeader] = &Header{Description: "Version of the share, to send as If-Match when updating it.", Schema: &Schema{Type: "string"}}
+	}
+	for status, body := range rt.also {
+		op.Responses[strconv.Itoa(status)] = response(s, status, body, false)
+	}
+
+	errs := append(slices.Clone(authErrors[rt.auth]), rt.errors...)
+	if idempotent {
+		errs = append(errs, idempotencyErrors...)
+	}
+	errs = append(errs, http.StatusInternalServerError)
+	for _, status := range errs {
+		op.Responses[strconv.Itoa(status)] = &Response{
+			Description: http.StatusText(status),
+			Headers:     requestIDHeader(),
+			Content:     errorContent,
+		}
+	}
+
+	return op
+}
+
+func response(s *schemas, status int, body any, ndjson bool) *Response {
+	resp := &Response{Description: http.StatusText(status), Headers: requestIDHeader()}
+	if body != nil {
+		resp.Content = content(s, body, ndjson)
+	}
+	return resp
+}
+
+func content(s *schemas, body any, ndjson bool) map[string]*MediaType {
+	if ndjson {
+		return map[string]*MediaType{ndjsonContentType: {Schema: s.of(body)}}
+	}
+	return map[string]*MediaType{jsonContentType: {Schema: s.of(body)}}
+}
+
+func requestIDHeader() map[string]*Header {
+	return map[string]*Header{
+		authmdw.RequestIDHeader: {Description: "Identifier of the request.", Schema: &Schema{Type: "string"}},
+	}
+}
+
+func header(name, description string, required bool) *Parameter {
+	return &Parameter{Name: name, In: "header", Description: description, Required: required, Schema: &Schema{Type: "string"}}
+}
+
+func query(name, description string, schema *Schema) *Parameter {
+	return &Parameter{Name: name, In: "query", Description: description, Schema: schema}
+}
+
+func codes() []any {
+	var values []any
+	for _, code := range api.Codes() {
+		values = append(values, code)
+	}
+	return values
+}
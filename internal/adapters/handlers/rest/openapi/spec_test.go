@@ -0,0 +1,88 @@
+package openapi
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuild_ReferencesResolve(t *testing.T) {
+	doc := Build()
+	raw, err := json.Marshal(doc)
+	require.NoError(t, err)
+
+	var tree any
+	require.NoError(t, json.Unmarshal(raw, &tree))
+
+	var refs []string
+	collectRefs(tree, &refs)
+	require.NotEmpty(t, refs)
+	for _, ref := range refs {
+		name, ok := strings.CutPrefix(ref, schemaRefPrefix)
+		require.True(t, ok, ref)
+		assert.Contains(t, doc.Components.Schemas, name, "unresolved reference %s", ref)
+	}
+}
+
+func TestBuild_Operations(t *testing.T) {
+	doc := Build()
+
+	ids := make(map[string]bool)
+	for path, item := range doc.Paths {
+		for method, op := range *item {
+			assert.False(t, ids[op.OperationID], "duplicate operation id %s", op.OperationID)
+			ids[op.OperationID] = true
+			assert.NotEmpty(t, op.Responses, "%s %s has no responses", method, path)
+			for _, param := range op.Parameters {
+				if param.In == "path" {
+					assert.Contains(t, path, "{"+param.Name+"}")
+				}
+			}
+		}
+	}
+
+	getShare := (*doc.Paths["/shares"])["get"]
+	var headers []string
+	for _, param := range getShare.Parameters {
+		if param.In == "header" {
+			headers = append(headers, param.Name)
+		}
+	}
+	assert.Contains(t, headers, "X-Encryption-Part")
+	assert.Contains(t, headers, "X-Encryption-Session")
+	assert.Contains(t, headers, "X-Auth-Provider")
+
+	for _, schema := range []string{"Error", "Problem"} {
+		code := doc.Components.Schemas[schema].Properties["code"]
+		require.NotNil(t, code, schema)
+		for _, c := range api.Codes() {
+			assert.Contains(t, code.Enum, c)
+		}
+	}
+
+	notFound := getShare.Responses["404"]
+	require.NotNil(t, notFound)
+	assert.Contains(t, notFound.Content, "application/json")
+	assert.Contains(t, notFound.Content, "application/problem+json")
+}
+
+func collectRefs(node any, refs *[]string) {
+	switch n := node.(type) {
+	case map[string]any:
+		for k, v := range n {
+			if s, ok := v.(string); ok && k == "$ref" {
+				*refs = append(*refs, s)
+				continue
+			}
+			collectRefs(v, refs)
+		}
+	case []any:
+		for _, v := range n {
+			collectRefs(v, refs)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package operatormdw
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/api"
+	"github.com/openfort-xyz/shield/internal/core/domain/project"
+)
+
+const OperatorKeyHeader = "X-Operator-Key" //nolint:gosec
+const InviteTokenHeader = "X-Invite-Token" //nolint:gosec
+
+// Middleware guards the endpoints reserved to the operator of the deployment,
+// as opposed to the projects it hosts. With no operator key configured the
+// operator API is disabled altogether.
+type Middleware struct {
+	operatorKey      string
+	registrationMode project.RegistrationMode
+}
+
+func New(operatorKey string, registrationMode project.RegistrationMode) *Middleware {
+	return &Middleware{
+		operatorKey:      operatorKey,
+		registrationMode: registrationMode,
+	}
+}
+
+func (m *Middleware) AuthenticateOperator(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if m.operatorKey == "" {
+			api.RespondWithError(w, r, api.ErrOperatorAPIDisabled)
+			return
+		}
+
+		key := r.Header.Get(OperatorKeyHeader)
+		if key == "" {
+			api.RespondWithError(w, r, api.ErrMissingOperatorKey)
+			return
+		}
+
+		if !m.isOperator(key) {
+			api.RespondWithError(w, r, api.ErrInvalidOperatorKey)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// AuthorizeRegistration applies the registration mode to project registration.
+// The operator can always register projects; in invite mode anyone holding an
+// invite can, the invite itself is consumed by the application.
+func (m *Middleware) AuthorizeRegistration(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if key := r.Header.Get(OperatorKeyHeader); key != "" {
+			if !m.isOperator(key) {
+				api.RespondWithError(w, r, api.ErrInvalidOperatorKey)
+				return
+			}
+			// Operator registrations never consume an invite.
+			r.Header.Del(InviteTokenHeader)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		switch m.registrationMode {
+		case project.RegistrationModeInvite:
+			if r.Header.Get(InviteTokenHeader) == "" {
+				api.RespondWithError(w, r, api.ErrMissingInvite)
+				return
+			}
+		case project.RegistrationModeOperator:
+			api.RespondWithError(w, r, api.ErrRegistrationOperatorOnly)
+			return
+		default:
+			r.Header.Del(InviteTokenHeader)
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (m *Middleware) isOperator(key string) bool {
+	return m.operatorKey != "" && subtle.ConstantTimeCompare([]byte(key), []byte(m.operatorKey)) == 1
+}
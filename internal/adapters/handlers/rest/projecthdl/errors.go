@@ -21,12 +21,37 @@ var applicationErrorMapping = []struct {
 	{projectapp.ErrUnknownProviderType, api.ErrUnknownProviderType},
 	{projectapp.ErrProviderAlreadyExists, api.ErrProviderAlreadyExists},
 	{projectapp.ErrProviderNotFound, api.ErrProviderNotFound},
+	{projectapp.ErrProviderKeyNotFound, api.ErrProviderKeyNotFound},
+	{projectapp.ErrProviderKeyAlreadyExists, api.ErrProviderKeyExists},
+	{projectapp.ErrInvalidKeyValidity, api.ErrInvalidKeyValidity},
 	{projectapp.ErrInvalidEncryptionPart, api.ErrInvalidEncryptionPart},
 	{projectapp.ErrInvalidEncryptionSession, api.ErrInvalidEncryptionSession},
 	{projectapp.ErrEncryptionPartAlreadyExists, api.ErrEncryptionPartAlreadyExists},
 	{projectapp.ErrEncryptionNotConfigured, api.ErrEncryptionNotConfigured},
 	{projectapp.ErrJWKPemConflict, api.ErrJWKPemConflict},
 	{projectapp.ErrInvalidPemCertificate, api.ErrInvalidPemCertificate},
+	{projectapp.ErrHMACSecretConflict, api.ErrHMACSecretConflict},
+	{projectapp.ErrInvalidHMACSecret, api.ErrInvalidHMACSecret},
+	{projectapp.ErrSecretEncryptionNotConfigured, api.ErrSecretEncryptionNotSet},
+	{projectapp.ErrInvalidCertificateFingerprint, api.ErrInvalidCertFingerprint},
+	{projectapp.ErrInvalidCertificateType, api.ErrInvalidCertType},
+	{projectapp.ErrClientCertificateAlreadyExists, api.ErrClientCertExists},
+	{projectapp.ErrClientCertificateNotFound, api.ErrClientCertNotFound},
+	{projectapp.ErrInvalidClientCertMode, api.ErrInvalidClientCertMode},
+	{projectapp.ErrNoClientCertificates, api.ErrNoClientCertificates},
+	{projectapp.ErrSigningKeyNotFound, api.ErrSigningKeyNotFound},
+	{projectapp.ErrSigningNotConfigured, api.ErrSigningNotConfigured},
+	{projectapp.ErrDeletionNotRequested, api.ErrDeletionNotRequested},
+	{projectapp.ErrInvalidDeletionToken, api.ErrInvalidDeletionToken},
+	{projectapp.ErrDeletionRequestExpired, api.ErrDeletionRequestExpired},
+	{projectapp.ErrInvalidDeletionMode, api.ErrInvalidDeletionMode},
+	{projectapp.ErrProjectChangedSinceExport, api.ErrProjectChangedSinceExport},
+	{projectapp.ErrExternalUserNotFound, api.ErrExternalUserNotFound},
+	{projectapp.ErrInvalidPageSize, api.ErrInvalidPageSize},
+	{projectapp.ErrInvalidProjectStatus, api.ErrInvalidProjectStatus},
+	{projectapp.ErrProjectReadOnly, api.ErrProjectReadOnly},
+	{projectapp.ErrInvalidInvite, api.ErrInvalidInvite},
+	{projectapp.ErrInvalidInviteTTL, api.ErrInvalidInviteTTL},
 	{projectapp.ErrOTPRequired, api.ErrOTPRequired},
 	{projectapp.ErrOTPRateLimitExceeded, api.ErrOTPRateLimitExceeded},
 	{projectapp.ErrOTPExpired, api.ErrOTPExpired},
@@ -44,7 +69,9 @@ var applicationErrorMapping = []struct {
 	{projectapp.ErrNoUserContactInformationProvided, api.ErrOTPUserInfoMissing},
 }
 
-func fromApplicationError(err error) *api.Error {
+// FromApplicationError is also used by the gRPC API, so both report the same
+// error codes.
+func FromApplicationError(err error) *api.Error {
 	if err == nil {
 		return nil
 	}
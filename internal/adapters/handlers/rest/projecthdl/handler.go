@@ -8,6 +8,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/api"
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/operatormdw"
 	"github.com/openfort-xyz/shield/internal/applications/projectapp"
 	"github.com/openfort-xyz/shield/pkg/logger"
 )
@@ -34,9 +35,14 @@ func New(app *projectapp.ProjectApplication) *Handler {
 // @Tags Project
 // @Accept json
 // @Produce json
+// @Param X-Invite-Token header string false "Registration invite, required when registration is invite-only"
+// @Param X-Operator-Key header string false "Operator Key, always allowed to register"
 // @Param createProjectRequest body CreateProjectRequest true "Create Project Request"
 // @Success 201 {object} CreateProjectResponse "Project created successfully"
 // @Failure 400 {object} api.Error "Bad Request"
+// @Failure 401 {object} api.Error "Unauthorized"
+// @Failure 403 {object} api.Error "Forbidden"
+// @Failure 429 {object} api.Error "Too Many Requests"
 // @Failure 500 {object} api.Error "Internal Server Error"
 // @Router /register [post]
 func (h *Handler) CreateProject(w http.ResponseWriter, r *http.Request) {
@@ -45,14 +51,14 @@ func (h *Handler) CreateProject(w http.ResponseWriter, r *http.Request) {
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage("failed to read request body"))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to read request body"))
 		return
 	}
 
 	var req CreateProjectRequest
 	err = json.Unmarshal(body, &req)
 	if err != nil {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage("failed to parse request body"))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to parse request body"))
 		return
 	}
 
@@ -60,6 +66,9 @@ func (h *Handler) CreateProject(w http.ResponseWriter, r *http.Request) {
 	if req.GenerateEncryptionKey {
 		opts = append(opts, projectapp.WithEncryptionKey())
 	}
+	if invite := r.Header.Get(operatormdw.InviteTokenHeader); invite != "" {
+		opts = append(opts, projectapp.WithInviteToken(invite))
+	}
 
 	enable2fa := false
 	if req.Enable2FA != nil {
@@ -68,14 +77,14 @@ func (h *Handler) CreateProject(w http.ResponseWriter, r *http.Request) {
 
 	proj, err := h.app.CreateProject(ctx, req.Name, enable2fa, opts...)
 	if err != nil {
-		api.RespondWithError(w, fromApplicationError(err))
+		api.RespondWithError(w, r, FromApplicationError(err))
 		return
 	}
 
 	// gosec G117: api_key/api_secret are intentionally returned to the caller on project creation.
 	resp, err := json.Marshal(h.parser.toCreateProjectResponse(proj)) //nolint:gosec
 	if err != nil {
-		api.RespondWithError(w, api.ErrInternal)
+		api.RespondWithError(w, r, api.ErrInternal)
 		return
 	}
 
@@ -110,7 +119,7 @@ func (h *Handler) ResetAPISecret(w http.ResponseWriter, r *http.Request) {
 	newAPISecret, err := h.app.ResetAPISecret(ctx)
 
 	if err != nil {
-		api.RespondWithError(w, fromApplicationError(err))
+		api.RespondWithError(w, r, FromApplicationError(err))
 		return
 	}
 
@@ -119,7 +128,7 @@ func (h *Handler) ResetAPISecret(w http.ResponseWriter, r *http.Request) {
 		APISecret: newAPISecret,
 	})
 	if err != nil {
-		api.RespondWithError(w, api.ErrInternal)
+		api.RespondWithError(w, r, api.ErrInternal)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
@@ -143,13 +152,13 @@ func (h *Handler) GetProject(w http.ResponseWriter, r *http.Request) {
 
 	proj, err := h.app.GetProject(ctx)
 	if err != nil {
-		api.RespondWithError(w, fromApplicationError(err))
+		api.RespondWithError(w, r, FromApplicationError(err))
 		return
 	}
 
 	resp, err := json.Marshal(h.parser.toGetProjectResponse(proj))
 	if err != nil {
-		api.RespondWithError(w, api.ErrInternal)
+		api.RespondWithError(w, r, api.ErrInternal)
 		return
 	}
 
@@ -176,26 +185,26 @@ func (h *Handler) AddProviders(w http.ResponseWriter, r *http.Request) {
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage("failed to read request body"))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to read request body"))
 		return
 	}
 
 	var req AddProvidersRequest
 	err = json.Unmarshal(body, &req)
 	if err != nil {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage("failed to parse request body"))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to parse request body"))
 		return
 	}
 
 	providers, err := h.app.AddProviders(ctx, h.parser.fromAddProvidersRequest(&req)...)
 	if err != nil {
-		api.RespondWithError(w, fromApplicationError(err))
+		api.RespondWithError(w, r, FromApplicationError(err))
 		return
 	}
 
 	resp, err := json.Marshal(h.parser.toAddProvidersResponse(providers))
 	if err != nil {
-		api.RespondWithError(w, api.ErrInternal)
+		api.RespondWithError(w, r, api.ErrInternal)
 		return
 	}
 
@@ -209,25 +218,25 @@ func (h *Handler) RequestOTP(w http.ResponseWriter, r *http.Request) {
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage("failed to read request body"))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to read request body"))
 		return
 	}
 
 	var req GenerateOTPRequest
 	err = json.Unmarshal(body, &req)
 	if err != nil {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage("failed to parse request body"))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to parse request body"))
 		return
 	}
 
 	if !req.ParametersValid() {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage("invalid parameters were passed"))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("invalid parameters were passed"))
 		return
 	}
 
 	err = h.app.GenerateOTP(ctx, req.UserID, req.DangerouslySkipVerification, req.Email, req.Phone)
 	if err != nil {
-		api.RespondWithError(w, fromApplicationError(err))
+		api.RespondWithError(w, r, FromApplicationError(err))
 		return
 	}
 
@@ -250,13 +259,13 @@ func (h *Handler) GetProviders(w http.ResponseWriter, r *http.Request) {
 
 	providers, err := h.app.GetProviders(ctx)
 	if err != nil {
-		api.RespondWithError(w, fromApplicationError(err))
+		api.RespondWithError(w, r, FromApplicationError(err))
 		return
 	}
 
 	resp, err := json.Marshal(h.parser.toGetProvidersResponse(providers))
 	if err != nil {
-		api.RespondWithError(w, api.ErrInternal)
+		api.RespondWithError(w, r, api.ErrInternal)
 		return
 	}
 
@@ -282,19 +291,19 @@ func (h *Handler) GetProvider(w http.ResponseWriter, r *http.Request) {
 
 	providerID := mux.Vars(r)["provider"]
 	if providerID == "" {
-		api.RespondWithError(w, api.ErrMissingProvider)
+		api.RespondWithError(w, r, api.ErrMissingProvider)
 		return
 	}
 
 	prov, err := h.app.GetProviderDetail(ctx, providerID)
 	if err != nil {
-		api.RespondWithError(w, fromApplicationError(err))
+		api.RespondWithError(w, r, FromApplicationError(err))
 		return
 	}
 
 	resp, err := json.Marshal(h.parser.toGetProviderResponse(prov))
 	if err != nil {
-		api.RespondWithError(w, api.ErrInternal)
+		api.RespondWithError(w, r, api.ErrInternal)
 		return
 	}
 
@@ -321,20 +330,20 @@ func (h *Handler) UpdateProvider(w http.ResponseWriter, r *http.Request) {
 
 	providerID := mux.Vars(r)["provider"]
 	if providerID == "" {
-		api.RespondWithError(w, api.ErrMissingProvider)
+		api.RespondWithError(w, r, api.ErrMissingProvider)
 		return
 	}
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage("failed to read request body"))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to read request body"))
 		return
 	}
 
 	var req UpdateProviderRequest
 	err = json.Unmarshal(body, &req)
 	if err != nil {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage("failed to parse request body"))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to parse request body"))
 		return
 	}
 
@@ -351,13 +360,21 @@ func (h *Handler) UpdateProvider(w http.ResponseWriter, r *http.Request) {
 		opts = append(opts, projectapp.WithCustomPEM(req.PEM, h.parser.mapKeyTypeToDomain[req.KeyType]))
 	}
 
+	if req.Secret != "" {
+		opts = append(opts, projectapp.WithCustomHMACSecret(req.Secret))
+	}
+
 	if req.CookieFieldName != nil {
 		opts = append(opts, projectapp.WithCustomCookieFieldName(*req.CookieFieldName))
 	}
 
+	if req.Introspection != nil {
+		opts = append(opts, h.parser.fromIntrospectionProvider(req.Introspection))
+	}
+
 	err = h.app.UpdateProvider(ctx, providerID, opts...)
 	if err != nil {
-		api.RespondWithError(w, fromApplicationError(err))
+		api.RespondWithError(w, r, FromApplicationError(err))
 		return
 	}
 
@@ -381,13 +398,110 @@ func (h *Handler) DeleteProvider(w http.ResponseWriter, r *http.Request) {
 
 	providerID := mux.Vars(r)["provider"]
 	if providerID == "" {
-		api.RespondWithError(w, api.ErrMissingProvider)
+		api.RespondWithError(w, r, api.ErrMissingProvider)
 		return
 	}
 
 	err := h.app.RemoveProvider(ctx, providerID)
 	if err != nil {
-		api.RespondWithError(w, fromApplicationError(err))
+		api.RespondWithError(w, r, FromApplicationError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// AddProviderKey registers an additional PEM key on a custom provider
+// @Summary Add a provider key
+// @Description Register an additional PEM public key on a custom provider, optionally bound to a kid and a validity window
+// @Tags Project
+// @Accept json
+// @Produce json
+// @Param X-API-Key header string true "API Key"
+// @Param X-API-Secret header string true "API Secret"
+// @Param provider path string true "Provider ID"
+// @Param addProviderKeyRequest body AddProviderKeyRequest true "Add Provider Key Request"
+// @Success 201 {object} ProviderKeyResponse "Key added successfully"
+// @Failure 400 {object} api.Error "Bad Request"
+// @Failure 404 {object} api.Error "Provider not found"
+// @Failure 409 {object} api.Error "Key already exists"
+// @Failure 500 {object} api.Error "Internal Server Error"
+// @Router /project/providers/{provider}/keys [post]
+func (h *Handler) AddProviderKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.InfoContext(ctx, "adding provider key")
+
+	providerID := mux.Vars(r)["provider"]
+	if providerID == "" {
+		api.RespondWithError(w, r, api.ErrMissingProvider)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to read request body"))
+		return
+	}
+
+	var req AddProviderKeyRequest
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to parse request body"))
+		return
+	}
+
+	if req.PEM == "" {
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("pem is required"))
+		return
+	}
+
+	key, err := h.app.AddProviderKey(ctx, providerID, h.parser.fromAddProviderKeyRequest(&req))
+	if err != nil {
+		api.RespondWithError(w, r, FromApplicationError(err))
+		return
+	}
+
+	resp, err := json.Marshal(h.parser.toProviderKeyResponse(key))
+	if err != nil {
+		api.RespondWithError(w, r, api.ErrInternal)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	_, _ = w.Write(resp)
+}
+
+// DeleteProviderKey removes a PEM key from a custom provider
+// @Summary Delete a provider key
+// @Description Remove a single PEM key from a custom provider
+// @Tags Project
+// @Param X-API-Key header string true "API Key"
+// @Param X-API-Secret header string true "API Secret"
+// @Param provider path string true "Provider ID"
+// @Param key path string true "Key ID"
+// @Success 200 "Key deleted successfully"
+// @Failure 404 {object} api.Error "Provider or key not found"
+// @Failure 500 {object} api.Error "Internal Server Error"
+// @Router /project/providers/{provider}/keys/{key} [delete]
+func (h *Handler) DeleteProviderKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.InfoContext(ctx, "deleting provider key")
+
+	providerID := mux.Vars(r)["provider"]
+	if providerID == "" {
+		api.RespondWithError(w, r, api.ErrMissingProvider)
+		return
+	}
+
+	keyID := mux.Vars(r)["key"]
+	if keyID == "" {
+		api.RespondWithError(w, r, api.ErrMissingProviderKey)
+		return
+	}
+
+	err := h.app.RemoveProviderKey(ctx, providerID, keyID)
+	if err != nil {
+		api.RespondWithError(w, r, FromApplicationError(err))
 		return
 	}
 
@@ -410,20 +524,20 @@ func (h *Handler) EncryptProjectShares(w http.ResponseWriter, r *http.Request) {
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage("failed to read request body"))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to read request body"))
 		return
 	}
 
 	var req EncryptBodyRequest
 	err = json.Unmarshal(body, &req)
 	if err != nil {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage("failed to parse request body"))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to parse request body"))
 		return
 	}
 
 	err = h.app.EncryptProjectShares(ctx, req.EncryptionPart)
 	if err != nil {
-		api.RespondWithError(w, fromApplicationError(err))
+		api.RespondWithError(w, r, FromApplicationError(err))
 		return
 	}
 
@@ -449,26 +563,26 @@ func (h *Handler) RegisterEncryptionSession(w http.ResponseWriter, r *http.Reque
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage("failed to read request body"))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to read request body"))
 		return
 	}
 
 	var req RegisterEncryptionSessionRequest
 	err = json.Unmarshal(body, &req)
 	if err != nil {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage("failed to parse request body"))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to parse request body"))
 		return
 	}
 
 	sessionID, err := h.app.RegisterEncryptionSession(ctx, req.EncryptionPart, req.UserID, req.OTPCode)
 	if err != nil {
-		api.RespondWithError(w, fromApplicationError(err))
+		api.RespondWithError(w, r, FromApplicationError(err))
 		return
 	}
 
 	resp, err := json.Marshal(RegisterEncryptionSessionResponse{SessionID: sessionID})
 	if err != nil {
-		api.RespondWithError(w, api.ErrInternal)
+		api.RespondWithError(w, r, api.ErrInternal)
 		return
 	}
 
@@ -494,13 +608,13 @@ func (h *Handler) RegisterEncryptionKey(w http.ResponseWriter, r *http.Request)
 
 	part, err := h.app.RegisterEncryptionKey(ctx)
 	if err != nil {
-		api.RespondWithError(w, fromApplicationError(err))
+		api.RespondWithError(w, r, FromApplicationError(err))
 		return
 	}
 
 	resp, err := json.Marshal(RegisterEncryptionKeyResponse{EncryptionPart: part})
 	if err != nil {
-		api.RespondWithError(w, api.ErrInternal)
+		api.RespondWithError(w, r, api.ErrInternal)
 		return
 	}
 
@@ -525,7 +639,446 @@ func (h *Handler) Enable2FA(w http.ResponseWriter, r *http.Request) {
 
 	err := h.app.Enable2FA(ctx)
 	if err != nil {
-		api.RespondWithError(w, fromApplicationError(err))
+		api.RespondWithError(w, r, FromApplicationError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// GetClientCertificates lists the client certificates registered for a project
+// @Summary List client certificates
+// @Description Get the CA and leaf certificate fingerprints a project's backend can authenticate with
+// @Tags Project
+// @Produce json
+// @Param X-API-Key header string true "API Key"
+// @Param X-API-Secret header string true "API Secret"
+// @Success 200 {object} GetClientCertificatesResponse "Successful response"
+// @Failure 500 {object} api.Error "Internal Server Error"
+// @Router /project/client-certificates [get]
+func (h *Handler) GetClientCertificates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.InfoContext(ctx, "getting client certificates")
+
+	certs, err := h.app.ListClientCertificates(ctx)
+	if err != nil {
+		api.RespondWithError(w, r, FromApplicationError(err))
+		return
+	}
+
+	resp, err := json.Marshal(h.parser.toGetClientCertificatesResponse(certs))
+	if err != nil {
+		api.RespondWithError(w, r, api.ErrInternal)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(resp)
+}
+
+// AddClientCertificate registers a client certificate fingerprint
+// @Summary Add a client certificate
+// @Description Register the SHA-256 fingerprint of a CA or leaf certificate the project's backend presents over mTLS
+// @Tags Project
+// @Accept json
+// @Produce json
+// @Param X-API-Key header string true "API Key"
+// @Param X-API-Secret header string true "API Secret"
+// @Param addClientCertificateRequest body AddClientCertificateRequest true "Add Client Certificate Request"
+// @Success 201 {object} ClientCertificateResponse "Certificate added successfully"
+// @Failure 400 {object} api.Error "Bad Request"
+// @Failure 409 {object} api.Error "Certificate already registered"
+// @Failure 500 {object} api.Error "Internal Server Error"
+// @Router /project/client-certificates [post]
+func (h *Handler) AddClientCertificate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.InfoContext(ctx, "adding client certificate")
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to read request body"))
+		return
+	}
+
+	var req AddClientCertificateRequest
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to parse request body"))
+		return
+	}
+
+	if req.Fingerprint == "" {
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("fingerprint is required"))
+		return
+	}
+
+	cert, err := h.app.AddClientCertificate(ctx, h.parser.fromAddClientCertificateRequest(&req))
+	if err != nil {
+		api.RespondWithError(w, r, FromApplicationError(err))
+		return
+	}
+
+	resp, err := json.Marshal(h.parser.toClientCertificateResponse(cert))
+	if err != nil {
+		api.RespondWithError(w, r, api.ErrInternal)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	_, _ = w.Write(resp)
+}
+
+// DeleteClientCertificate removes a client certificate
+// @Summary Delete a client certificate
+// @Description Stop accepting a CA or leaf certificate for the project's backend
+// @Tags Project
+// @Param X-API-Key header string true "API Key"
+// @Param X-API-Secret header string true "API Secret"
+// @Param certificate path string true "Certificate ID"
+// @Success 200 "Certificate deleted successfully"
+// @Failure 404 {object} api.Error "Certificate not found"
+// @Failure 409 {object} api.Error "Last certificate while certificates are required"
+// @Failure 500 {object} api.Error "Internal Server Error"
+// @Router /project/client-certificates/{certificate} [delete]
+func (h *Handler) DeleteClientCertificate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.InfoContext(ctx, "deleting client certificate")
+
+	certificateID := mux.Vars(r)["certificate"]
+	if certificateID == "" {
+		api.RespondWithError(w, r, api.ErrClientCertNotFound)
+		return
+	}
+
+	err := h.app.RemoveClientCertificate(ctx, certificateID)
+	if err != nil {
+		api.RespondWithError(w, r, FromApplicationError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// SetClientCertMode sets how client certificates authenticate the project
+// @Summary Set the client certificate mode
+// @Description disabled authenticates with the API secret only, alternative accepts a registered certificate instead of the secret and required demands both
+// @Tags Project
+// @Accept json
+// @Param X-API-Key header string true "API Key"
+// @Param X-API-Secret header string true "API Secret"
+// @Param setClientCertModeRequest body SetClientCertModeRequest true "Set Client Certificate Mode Request"
+// @Success 200 "Mode updated successfully"
+// @Failure 400 {object} api.Error "Bad Request"
+// @Failure 409 {object} api.Error "No certificate registered"
+// @Failure 500 {object} api.Error "Internal Server Error"
+// @Router /project/client-certificates/mode [put]
+func (h *Handler) SetClientCertMode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.InfoContext(ctx, "setting client certificate mode")
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to read request body"))
+		return
+	}
+
+	var req SetClientCertModeRequest
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to parse request body"))
+		return
+	}
+
+	mode, ok := h.parser.mapClientCertModeToDomain[req.Mode]
+	if !ok {
+		api.RespondWithError(w, r, api.ErrInvalidClientCertMode)
+		return
+	}
+
+	err = h.app.SetClientCertMode(ctx, mode)
+	if err != nil {
+		api.RespondWithError(w, r, FromApplicationError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// RequestDeletion starts deleting the project
+// @Summary Request the project deletion
+// @Description Export a final archive of everything stored for the project and open a deletion request for it. The confirmation token is only returned once and expires after 15 minutes, a new request replaces any pending one.
+// @Tags Project
+// @Produce json
+// @Param X-API-Key header string true "API Key"
+// @Param X-API-Secret header string true "API Secret"
+// @Success 200 {object} RequestDeletionResponse "Deletion requested successfully"
+// @Failure 500 {object} api.Error "Internal Server Error"
+// @Router /project/deletion [post]
+func (h *Handler) RequestDeletion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.InfoContext(ctx, "requesting project deletion")
+
+	pending, err := h.app.RequestDeletion(ctx)
+	if err != nil {
+		api.RespondWithError(w, r, FromApplicationError(err))
+		return
+	}
+
+	// gosec G117: the confirmation token is the exact payload this endpoint exists to return.
+	resp, err := json.Marshal(h.parser.toRequestDeletionResponse(pending)) //nolint:gosec
+	if err != nil {
+		api.RespondWithError(w, r, api.ErrInternal)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(resp)
+}
+
+// DeleteProject confirms the project deletion
+// @Summary Delete the project
+// @Description Confirm a pending deletion request. hard_delete removes every row stored for the project, crypto_shred destroys the project's encryption part instead of its project entropy shares and removes everything else. The deletion is refused when the project changed after the archive was exported. The receipt is kept in the audit trail.
+// @Tags Project
+// @Accept json
+// @Produce json
+// @Param X-API-Key header string true "API Key"
+// @Param X-API-Secret header string true "API Secret"
+// @Param deleteProjectRequest body DeleteProjectRequest true "Delete Project Request"
+// @Success 200 {object} DeletionReceiptResponse "Project deleted successfully"
+// @Failure 400 {object} api.Error "Bad Request"
+// @Failure 403 {object} api.Error "Invalid confirmation token"
+// @Failure 409 {object} api.Error "Deletion not requested, expired or archive outdated"
+// @Failure 500 {object} api.Error "Internal Server Error"
+// @Router /project [delete]
+func (h *Handler) DeleteProject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.InfoContext(ctx, "deleting project")
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to read request body"))
+		return
+	}
+
+	var req DeleteProjectRequest
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to parse request body"))
+		return
+	}
+
+	if req.ConfirmationToken == "" {
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("confirmation_token is required"))
+		return
+	}
+
+	mode, ok := h.parser.mapDeletionModeToDomain[req.Mode]
+	if !ok {
+		api.RespondWithError(w, r, api.ErrInvalidDeletionMode)
+		return
+	}
+
+	receipt, err := h.app.DeleteProject(ctx, req.ConfirmationToken, mode)
+	if err != nil {
+		api.RespondWithError(w, r, FromApplicationError(err))
+		return
+	}
+
+	resp, err := json.Marshal(h.parser.toDeletionReceiptResponse(receipt))
+	if err != nil {
+		api.RespondWithError(w, r, api.ErrInternal)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(resp)
+}
+
+// ListUsers lists the project's users
+// @Summary List users
+// @Description List the project's users oldest first, with their external user IDs per provider, keychain and share references. Share secrets are never returned.
+// @Tags Project
+// @Produce json
+// @Param X-API-Key header string true "API Key"
+// @Param X-API-Secret header string true "API Secret"
+// @Param provider query string false "Only users with an external user of this provider"
+// @Param entropy query string false "Only users with a share of this entropy" Enums(none, user, project, passkey)
+// @Param created_after query string false "Only users created at or after this RFC 3339 timestamp"
+// @Param created_before query string false "Only users created before this RFC 3339 timestamp"
+// @Param limit query int false "Page size, 50 by default and at most 100"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Success 200 {object} ListUsersResponse "Users listed successfully"
+// @Failure 400 {object} api.Error "Bad Request"
+// @Failure 500 {object} api.Error "Internal Server Error"
+// @Router /project/users [get]
+func (h *Handler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.InfoContext(ctx, "listing users")
+
+	filter, cursor, limit, err := h.parser.fromListUsersQuery(r.URL.Query())
+	if err != nil {
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage(err.Error()))
+		return
+	}
+
+	page, err := h.app.ListUsers(ctx, filter, cursor, limit)
+	if err != nil {
+		api.RespondWithError(w, r, FromApplicationError(err))
+		return
+	}
+
+	resp, err := json.Marshal(h.parser.toListUsersResponse(page))
+	if err != nil {
+		api.RespondWithError(w, r, api.ErrInternal)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(resp)
+}
+
+// ExportUser exports an end user's data
+// @Summary Export an end user's data
+// @Description Return everything stored about an external user of the project, across every provider linking to it, table by table. Shares are returned as stored.
+// @Tags Project
+// @Produce json
+// @Param X-API-Key header string true "API Key"
+// @Param X-API-Secret header string true "API Secret"
+// @Param externalUserID path string true "External User ID"
+// @Success 200 {object} UserExportResponse "User exported successfully"
+// @Failure 404 {object} api.Error "External user not found"
+// @Failure 500 {object} api.Error "Internal Server Error"
+// @Router /project/users/{externalUserID} [get]
+func (h *Handler) ExportUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.InfoContext(ctx, "exporting user")
+
+	externalUserID := mux.Vars(r)["externalUserID"]
+	if externalUserID == "" {
+		api.RespondWithError(w, r, api.ErrExternalUserNotFound)
+		return
+	}
+
+	export, err := h.app.ExportUser(ctx, externalUserID)
+	if err != nil {
+		api.RespondWithError(w, r, FromApplicationError(err))
+		return
+	}
+
+	resp, err := json.Marshal(h.parser.toUserExportResponse(export))
+	if err != nil {
+		api.RespondWithError(w, r, api.ErrInternal)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(resp)
+}
+
+// EraseUser erases an end user's data
+// @Summary Erase an end user's data
+// @Description Remove everything stored about an external user of the project, across every provider linking to it, in a single transaction. Contact details another project still refers to are kept. The receipt is kept in the audit trail with a hash of the external user ID.
+// @Tags Project
+// @Produce json
+// @Param X-API-Key header string true "API Key"
+// @Param X-API-Secret header string true "API Secret"
+// @Param externalUserID path string true "External User ID"
+// @Success 200 {object} ErasureReceiptResponse "User erased successfully"
+// @Failure 404 {object} api.Error "External user not found"
+// @Failure 500 {object} api.Error "Internal Server Error"
+// @Router /project/users/{externalUserID} [delete]
+func (h *Handler) EraseUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.InfoContext(ctx, "erasing user")
+
+	externalUserID := mux.Vars(r)["externalUserID"]
+	if externalUserID == "" {
+		api.RespondWithError(w, r, api.ErrExternalUserNotFound)
+		return
+	}
+
+	receipt, err := h.app.EraseUser(ctx, externalUserID)
+	if err != nil {
+		api.RespondWithError(w, r, FromApplicationError(err))
+		return
+	}
+
+	resp, err := json.Marshal(h.parser.toErasureReceiptResponse(receipt))
+	if err != nil {
+		api.RespondWithError(w, r, api.ErrInternal)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(resp)
+}
+
+// RotateSigningKey generates a new request signing key
+// @Summary Rotate the request signing key
+// @Description Generate a new key for signing API secret requests, the previous key stops working immediately. The key is only returned once.
+// @Tags Project
+// @Produce json
+// @Param X-API-Key header string true "API Key"
+// @Param X-API-Secret header string true "API Secret"
+// @Success 200 {object} RotateSigningKeyResponse "Signing key generated successfully"
+// @Failure 500 {object} api.Error "Internal Server Error"
+// @Router /project/signing-key [post]
+func (h *Handler) RotateSigningKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.InfoContext(ctx, "rotating signing key")
+
+	signingKey, err := h.app.RotateSigningKey(ctx)
+	if err != nil {
+		api.RespondWithError(w, r, FromApplicationError(err))
+		return
+	}
+
+	// gosec G117: signing_key is the exact payload this endpoint exists to return.
+	resp, err := json.Marshal(RotateSigningKeyResponse{ //nolint:gosec
+		SigningKey: signingKey,
+	})
+	if err != nil {
+		api.RespondWithError(w, r, api.ErrInternal)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(resp)
+}
+
+// SetSignedRequests sets whether API secret requests must be signed
+// @Summary Require signed requests
+// @Description When required, requests authenticated with the API key must carry an X-Signature instead of the API secret
+// @Tags Project
+// @Accept json
+// @Param X-API-Key header string true "API Key"
+// @Param X-API-Secret header string true "API Secret"
+// @Param setSignedRequestsRequest body SetSignedRequestsRequest true "Set Signed Requests Request"
+// @Success 200 "Requirement updated successfully"
+// @Failure 400 {object} api.Error "Bad Request"
+// @Failure 409 {object} api.Error "No signing key generated"
+// @Failure 500 {object} api.Error "Internal Server Error"
+// @Router /project/signed-requests [put]
+func (h *Handler) SetSignedRequests(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.InfoContext(ctx, "setting signed requests requirement")
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to read request body"))
+		return
+	}
+
+	var req SetSignedRequestsRequest
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to parse request body"))
+		return
+	}
+
+	err = h.app.SetRequireSignedRequests(ctx, req.Required)
+	if err != nil {
+		api.RespondWithError(w, r, FromApplicationError(err))
 		return
 	}
 
@@ -0,0 +1,121 @@
+package projecthdl
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/api"
+	"github.com/openfort-xyz/shield/internal/applications/projectapp"
+)
+
+// CreateInvite issues a registration invite
+// @Summary Create registration invite
+// @Description Issue a single-use invite to register a project. The token is only returned once, it has to be sent in the X-Invite-Token header of the registration.
+// @Tags Operator
+// @Accept json
+// @Produce json
+// @Param X-Operator-Key header string true "Operator Key"
+// @Param createInviteRequest body CreateInviteRequest false "Create Invite Request"
+// @Success 201 {object} CreateInviteResponse "Invite created successfully"
+// @Failure 400 {object} api.Error "Bad Request"
+// @Failure 401 {object} api.Error "Unauthorized"
+// @Failure 500 {object} api.Error "Internal Server Error"
+// @Router /operator/invites [post]
+func (h *Handler) CreateInvite(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.InfoContext(ctx, "creating registration invite")
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to read request body"))
+		return
+	}
+
+	var req CreateInviteRequest
+	if len(body) > 0 {
+		err = json.Unmarshal(body, &req)
+		if err != nil {
+			api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to parse request body"))
+			return
+		}
+	}
+
+	ttl := projectapp.DefaultInviteTTL
+	if req.ExpiresIn != 0 {
+		ttl = time.Duration(req.ExpiresIn) * time.Second
+	}
+
+	token, invite, err := h.app.CreateInvite(ctx, ttl)
+	if err != nil {
+		api.RespondWithError(w, r, FromApplicationError(err))
+		return
+	}
+
+	resp, err := json.Marshal(&CreateInviteResponse{
+		ID:        invite.ID,
+		Token:     token,
+		ExpiresAt: invite.ExpiresAt,
+	})
+	if err != nil {
+		api.RespondWithError(w, r, api.ErrInternal)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	_, _ = w.Write(resp)
+}
+
+// SetProjectStatus sets what a project's credentials can do
+// @Summary Set project status
+// @Description Set the status of a project. A read_only project can still read its shares but not register, update or delete them, a suspended project is refused every request.
+// @Tags Operator
+// @Accept json
+// @Param X-Operator-Key header string true "Operator Key"
+// @Param project path string true "Project ID"
+// @Param setProjectStatusRequest body SetProjectStatusRequest true "Set Project Status Request"
+// @Success 204 "Description: Project status set successfully"
+// @Failure 400 {object} api.Error "Bad Request"
+// @Failure 401 {object} api.Error "Unauthorized"
+// @Failure 404 {object} api.Error "Not Found"
+// @Failure 500 {object} api.Error "Internal Server Error"
+// @Router /operator/projects/{project}/status [put]
+func (h *Handler) SetProjectStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.InfoContext(ctx, "setting project status")
+
+	projectID := mux.Vars(r)["project"]
+	if projectID == "" {
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("missing project"))
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to read request body"))
+		return
+	}
+
+	var req SetProjectStatusRequest
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to parse request body"))
+		return
+	}
+
+	status, ok := h.parser.mapStatusToDomain[req.Status]
+	if !ok {
+		api.RespondWithError(w, r, api.ErrInvalidProjectStatus)
+		return
+	}
+
+	err = h.app.SetProjectStatus(ctx, projectID, status)
+	if err != nil {
+		api.RespondWithError(w, r, FromApplicationError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
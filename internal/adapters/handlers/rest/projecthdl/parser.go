@@ -1,14 +1,40 @@
 package projecthdl
 
 import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/openfort-xyz/shield/internal/applications/projectapp"
 	"github.com/openfort-xyz/shield/internal/core/domain/project"
 	"github.com/openfort-xyz/shield/internal/core/domain/provider"
+	"github.com/openfort-xyz/shield/internal/core/domain/share"
+	"github.com/openfort-xyz/shield/internal/core/domain/user"
+)
+
+var (
+	errInvalidEntropyFilter = errors.New("entropy must be none, user, project or passkey")
+	errInvalidCursor        = errors.New("invalid cursor")
+	errInvalidLimit         = errors.New("limit must be a positive integer")
 )
 
 type parser struct {
-	mapKeyTypeToDomain   map[KeyType]provider.KeyType
-	mapKeyTypeToResponse map[provider.KeyType]KeyType
+	mapKeyTypeToDomain          map[KeyType]provider.KeyType
+	mapKeyTypeToResponse        map[provider.KeyType]KeyType
+	mapCertTypeToDomain         map[ClientCertificateType]project.ClientCertificateType
+	mapCertTypeToResponse       map[project.ClientCertificateType]ClientCertificateType
+	mapClientCertModeToDomain   map[ClientCertMode]project.ClientCertMode
+	mapClientCertModeToResponse map[project.ClientCertMode]ClientCertMode
+	mapStatusToDomain           map[ProjectStatus]project.Status
+	mapStatusToResponse         map[project.Status]ProjectStatus
+	mapDeletionModeToDomain     map[DeletionMode]project.DeletionMode
+	mapDeletionModeToResponse   map[project.DeletionMode]DeletionMode
+	mapEntropyToDomain          map[Entropy]share.Entropy
+	mapEntropyToResponse        map[share.Entropy]Entropy
 }
 
 func newParser() *parser {
@@ -17,11 +43,61 @@ func newParser() *parser {
 			KeyTypeRSA:     provider.KeyTypeRSA,
 			KeyTypeECDSA:   provider.KeyTypeECDSA,
 			KeyTypeEd25519: provider.KeyTypeEd25519,
+			KeyTypeHMAC:    provider.KeyTypeHMAC,
 		},
 		mapKeyTypeToResponse: map[provider.KeyType]KeyType{
 			provider.KeyTypeRSA:     KeyTypeRSA,
 			provider.KeyTypeECDSA:   KeyTypeECDSA,
 			provider.KeyTypeEd25519: KeyTypeEd25519,
+			provider.KeyTypeHMAC:    KeyTypeHMAC,
+		},
+		mapCertTypeToDomain: map[ClientCertificateType]project.ClientCertificateType{
+			ClientCertificateTypeCA:   project.ClientCertificateTypeCA,
+			ClientCertificateTypeLeaf: project.ClientCertificateTypeLeaf,
+		},
+		mapCertTypeToResponse: map[project.ClientCertificateType]ClientCertificateType{
+			project.ClientCertificateTypeCA:   ClientCertificateTypeCA,
+			project.ClientCertificateTypeLeaf: ClientCertificateTypeLeaf,
+		},
+		mapClientCertModeToDomain: map[ClientCertMode]project.ClientCertMode{
+			ClientCertModeDisabled:    project.ClientCertModeDisabled,
+			ClientCertModeAlternative: project.ClientCertModeAlternative,
+			ClientCertModeRequired:    project.ClientCertModeRequired,
+		},
+		mapClientCertModeToResponse: map[project.ClientCertMode]ClientCertMode{
+			project.ClientCertModeDisabled:    ClientCertModeDisabled,
+			project.ClientCertModeAlternative: ClientCertModeAlternative,
+			project.ClientCertModeRequired:    ClientCertModeRequired,
+		},
+		mapStatusToDomain: map[ProjectStatus]project.Status{
+			ProjectStatusActive:    project.StatusActive,
+			ProjectStatusReadOnly:  project.StatusReadOnly,
+			ProjectStatusSuspended: project.StatusSuspended,
+		},
+		mapStatusToResponse: map[project.Status]ProjectStatus{
+			project.StatusActive:    ProjectStatusActive,
+			project.StatusReadOnly:  ProjectStatusReadOnly,
+			project.StatusSuspended: ProjectStatusSuspended,
+		},
+		mapDeletionModeToDomain: map[DeletionMode]project.DeletionMode{
+			DeletionModeHardDelete:  project.DeletionModeHardDelete,
+			DeletionModeCryptoShred: project.DeletionModeCryptoShred,
+		},
+		mapDeletionModeToResponse: map[project.DeletionMode]DeletionMode{
+			project.DeletionModeHardDelete:  DeletionModeHardDelete,
+			project.DeletionModeCryptoShred: DeletionModeCryptoShred,
+		},
+		mapEntropyToDomain: map[Entropy]share.Entropy{
+			EntropyNone:    share.EntropyNone,
+			EntropyUser:    share.EntropyUser,
+			EntropyProject: share.EntropyProject,
+			EntropyPasskey: share.EntropyPasskey,
+		},
+		mapEntropyToResponse: map[share.Entropy]Entropy{
+			share.EntropyNone:    EntropyNone,
+			share.EntropyUser:    EntropyUser,
+			share.EntropyProject: EntropyProject,
+			share.EntropyPasskey: EntropyPasskey,
 		},
 	}
 }
@@ -38,13 +114,186 @@ func (p *parser) toCreateProjectResponse(proj *project.Project) *CreateProjectRe
 }
 
 func (p *parser) toGetProjectResponse(proj *project.Project) *GetProjectResponse {
+	mode, ok := p.mapClientCertModeToResponse[proj.ClientCertMode]
+	if !ok {
+		mode = ClientCertModeDisabled
+	}
+
+	status, ok := p.mapStatusToResponse[proj.Status]
+	if !ok {
+		status = ProjectStatusActive
+	}
+
 	return &GetProjectResponse{
-		ID:         proj.ID,
-		Name:       proj.Name,
-		Enabled2FA: proj.Enable2FA,
+		ID:                     proj.ID,
+		Name:                   proj.Name,
+		Enabled2FA:             proj.Enable2FA,
+		ClientCertMode:         mode,
+		SignedRequestsRequired: proj.RequireSignedRequests,
+		Status:                 status,
 	}
 }
 
+func (p *parser) toRequestDeletionResponse(pending *projectapp.PendingDeletion) *RequestDeletionResponse {
+	return &RequestDeletionResponse{
+		ConfirmationToken: pending.ConfirmationToken,
+		ExpiresAt:         pending.ExpiresAt.Unix(),
+		ArchiveDigest:     pending.ArchiveDigest,
+		Archive: &ProjectArchive{
+			ProjectID:  pending.Archive.ProjectID,
+			ExportedAt: pending.Archive.ExportedAt.Unix(),
+			Tables:     pending.Archive.Tables,
+		},
+	}
+}
+
+func (p *parser) toDeletionReceiptResponse(receipt *project.DeletionReceipt) *DeletionReceiptResponse {
+	return &DeletionReceiptResponse{
+		ID:            receipt.ID,
+		ProjectID:     receipt.ProjectID,
+		ProjectName:   receipt.ProjectName,
+		Mode:          p.mapDeletionModeToResponse[receipt.Mode],
+		ArchiveDigest: receipt.ArchiveDigest,
+		DeletedRows:   receipt.DeletedRows,
+		DeletedAt:     receipt.DeletedAt.Unix(),
+	}
+}
+
+func (p *parser) toUserExportResponse(export *user.Export) *UserExportResponse {
+	return &UserExportResponse{
+		ProjectID:      export.ProjectID,
+		ExternalUserID: export.ExternalUserID,
+		ExportedAt:     export.ExportedAt.Unix(),
+		Tables:         export.Tables,
+	}
+}
+
+func (p *parser) toErasureReceiptResponse(receipt *user.ErasureReceipt) *ErasureReceiptResponse {
+	return &ErasureReceiptResponse{
+		ID:                 receipt.ID,
+		ProjectID:          receipt.ProjectID,
+		ExternalUserIDHash: receipt.ExternalUserIDHash,
+		DeletedRows:        receipt.DeletedRows,
+		ErasedAt:           receipt.ErasedAt.Unix(),
+	}
+}
+
+func (p *parser) toListUsersResponse(page *projectapp.UsersPage) *ListUsersResponse {
+	resp := &ListUsersResponse{Users: make([]*UserSummary, 0, len(page.Users))}
+	for _, summary := range page.Users {
+		usr := &UserSummary{
+			UserID:        summary.User.ID,
+			CreatedAt:     summary.User.CreatedAt.Unix(),
+			ExternalUsers: make([]*ExternalUser, 0, len(summary.ExternalUsers)),
+			KeychainID:    summary.KeychainID,
+			ShareCount:    len(summary.Shares),
+			Shares:        make([]*ShareSummary, 0, len(summary.Shares)),
+		}
+		for _, extUsr := range summary.ExternalUsers {
+			usr.ExternalUsers = append(usr.ExternalUsers, &ExternalUser{
+				ProviderID:     extUsr.ProviderID,
+				ExternalUserID: extUsr.ExternalUserID,
+			})
+		}
+		for _, shr := range summary.Shares {
+			reference := share.DefaultReference
+			if shr.Reference != nil {
+				reference = *shr.Reference
+			}
+			usr.Shares = append(usr.Shares, &ShareSummary{
+				Reference: reference,
+				Entropy:   p.mapEntropyToResponse[shr.Entropy],
+			})
+		}
+		resp.Users = append(resp.Users, usr)
+	}
+
+	if page.Next != nil {
+		resp.NextCursor = encodeCursor(page.Next)
+	}
+
+	return resp
+}
+
+func (p *parser) fromListUsersQuery(query url.Values) (*user.ListFilter, *user.Cursor, int, error) {
+	filter := &user.ListFilter{ProviderID: query.Get("provider")}
+
+	if raw := query.Get("entropy"); raw != "" {
+		entropy, ok := p.mapEntropyToDomain[Entropy(raw)]
+		if !ok {
+			return nil, nil, 0, errInvalidEntropyFilter
+		}
+		filter.Entropy = entropy
+	}
+
+	var err error
+	filter.CreatedAfter, err = parseTimeParam(query, "created_after")
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	filter.CreatedBefore, err = parseTimeParam(query, "created_before")
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	var after *user.Cursor
+	if raw := query.Get("cursor"); raw != "" {
+		cursor, err := decodeCursor(raw)
+		if err != nil {
+			return nil, nil, 0, errInvalidCursor
+		}
+		after = cursor
+	}
+
+	var limit int
+	if raw := query.Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			return nil, nil, 0, errInvalidLimit
+		}
+		limit = n
+	}
+
+	return filter, after, limit, nil
+}
+
+func parseTimeParam(query url.Values, param string) (*time.Time, error) {
+	raw := query.Get(param)
+	if raw == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an RFC 3339 timestamp", param)
+	}
+	return &t, nil
+}
+
+// Cursors are opaque to clients, they encode the creation time in unix
+// nanoseconds and the ID of the last user of a page.
+func encodeCursor(cursor *user.Cursor) string {
+	raw := strconv.FormatInt(cursor.CreatedAt.UnixNano(), 10) + ":" + cursor.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(encoded string) (*user.Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok || id == "" {
+		return nil, errInvalidCursor
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user.Cursor{CreatedAt: time.Unix(0, n).UTC(), ID: id}, nil
+}
+
 func (p *parser) fromAddProvidersRequest(req *AddProvidersRequest) []projectapp.ProviderOption {
 	opts := make([]projectapp.ProviderOption, 0)
 
@@ -60,13 +309,25 @@ func (p *parser) fromAddProvidersRequest(req *AddProvidersRequest) []projectapp.
 		opts = append(opts, projectapp.WithCustomPEM(req.Providers.Custom.PEM, p.mapKeyTypeToDomain[req.Providers.Custom.KeyType]))
 	}
 
+	if req.Providers.Custom != nil && req.Providers.Custom.Secret != "" {
+		opts = append(opts, projectapp.WithCustomHMACSecret(req.Providers.Custom.Secret))
+	}
+
 	if req.Providers.Custom != nil && req.Providers.Custom.CookieFieldName != nil {
 		opts = append(opts, projectapp.WithCustomCookieFieldName(*req.Providers.Custom.CookieFieldName))
 	}
 
+	if req.Providers.Introspection != nil {
+		opts = append(opts, p.fromIntrospectionProvider(req.Providers.Introspection))
+	}
+
 	return opts
 }
 
+func (p *parser) fromIntrospectionProvider(prov *IntrospectionProvider) projectapp.ProviderOption {
+	return projectapp.WithIntrospection(prov.Endpoint, prov.ClientID, prov.ClientSecret, prov.UserIDClaim)
+}
+
 func (p *parser) toAddProvidersResponse(providers []*provider.Provider) *AddProvidersResponse {
 	resp := &AddProvidersResponse{
 		Providers: make([]*ProviderResponse, 0, len(providers)),
@@ -111,8 +372,64 @@ func (p *parser) toGetProviderResponse(prov *provider.Provider) *GetProviderResp
 		resp.PEM = prov.Config.(*provider.CustomConfig).PEM
 		resp.CookieFieldName = prov.Config.(*provider.CustomConfig).CookieFieldName
 		resp.KeyType = p.mapKeyTypeToResponse[prov.Config.(*provider.CustomConfig).KeyType]
+		for _, key := range prov.Config.(*provider.CustomConfig).PEMKeys() {
+			resp.Keys = append(resp.Keys, p.toProviderKeyResponse(key))
+		}
+	case provider.TypeIntrospection:
+		resp.Endpoint = prov.Config.(*provider.IntrospectionConfig).Endpoint
+		resp.ClientID = prov.Config.(*provider.IntrospectionConfig).ClientID
+		resp.UserIDClaim = prov.Config.(*provider.IntrospectionConfig).UserIDClaim
 	case provider.TypeUnknown:
 	}
 
 	return resp
 }
+
+func (p *parser) fromAddProviderKeyRequest(req *AddProviderKeyRequest) *provider.PEMKey {
+	return &provider.PEMKey{
+		KID:       req.KID,
+		PEM:       req.PEM,
+		KeyType:   p.mapKeyTypeToDomain[req.KeyType],
+		NotBefore: req.NotBefore,
+		NotAfter:  req.NotAfter,
+	}
+}
+
+func (p *parser) toProviderKeyResponse(key *provider.PEMKey) *ProviderKeyResponse {
+	return &ProviderKeyResponse{
+		KeyID:     key.ID,
+		KID:       key.KID,
+		PEM:       key.PEM,
+		KeyType:   p.mapKeyTypeToResponse[key.KeyType],
+		NotBefore: key.NotBefore,
+		NotAfter:  key.NotAfter,
+	}
+}
+
+func (p *parser) fromAddClientCertificateRequest(req *AddClientCertificateRequest) *project.ClientCertificate {
+	return &project.ClientCertificate{
+		Name:        req.Name,
+		Fingerprint: req.Fingerprint,
+		Type:        p.mapCertTypeToDomain[req.Type],
+	}
+}
+
+func (p *parser) toClientCertificateResponse(cert *project.ClientCertificate) *ClientCertificateResponse {
+	return &ClientCertificateResponse{
+		CertificateID: cert.ID,
+		Name:          cert.Name,
+		Fingerprint:   cert.Fingerprint,
+		Type:          p.mapCertTypeToResponse[cert.Type],
+		CreatedAt:     cert.CreatedAt,
+	}
+}
+
+func (p *parser) toGetClientCertificatesResponse(certs []*project.ClientCertificate) *GetClientCertificatesResponse {
+	resp := &GetClientCertificatesResponse{
+		Certificates: make([]*ClientCertificateResponse, 0, len(certs)),
+	}
+	for _, cert := range certs {
+		resp.Certificates = append(resp.Certificates, p.toClientCertificateResponse(cert))
+	}
+	return resp
+}
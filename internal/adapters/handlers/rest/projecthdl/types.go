@@ -1,5 +1,7 @@
 package projecthdl
 
+import "time"
+
 type CreateProjectRequest struct {
 	Name                  string `json:"name"`
 	GenerateEncryptionKey bool   `json:"generate_encryption_key,omitempty"`
@@ -39,9 +41,12 @@ type CreateProjectResponse struct {
 }
 
 type GetProjectResponse struct {
-	ID         string `json:"id"`
-	Name       string `json:"name"`
-	Enabled2FA bool   `json:"enabled_2fa"`
+	ID                     string         `json:"id"`
+	Name                   string         `json:"name"`
+	Enabled2FA             bool           `json:"enabled_2fa"`
+	ClientCertMode         ClientCertMode `json:"client_cert_mode"`
+	SignedRequestsRequired bool           `json:"signed_requests_required"`
+	Status                 ProjectStatus  `json:"status"`
 }
 
 type AddProvidersRequest struct {
@@ -49,8 +54,9 @@ type AddProvidersRequest struct {
 }
 
 type ProvidersRequest struct {
-	Openfort *OpenfortProvider `json:"openfort,omitempty"`
-	Custom   *CustomProvider   `json:"custom,omitempty"`
+	Openfort      *OpenfortProvider      `json:"openfort,omitempty"`
+	Custom        *CustomProvider        `json:"custom,omitempty"`
+	Introspection *IntrospectionProvider `json:"introspection,omitempty"`
 }
 
 type OpenfortProvider struct {
@@ -58,10 +64,19 @@ type OpenfortProvider struct {
 	PublishableKey string `json:"publishable_key,omitempty"`
 }
 
+type IntrospectionProvider struct {
+	ProviderID   string `json:"provider_id,omitempty"`
+	Endpoint     string `json:"endpoint,omitempty"`
+	ClientID     string `json:"client_id,omitempty"`
+	ClientSecret string `json:"client_secret,omitempty"`
+	UserIDClaim  string `json:"user_id_claim,omitempty"`
+}
+
 type CustomProvider struct {
 	ProviderID      string  `json:"provider_id,omitempty"`
 	JWK             string  `json:"jwk,omitempty"`
 	PEM             string  `json:"pem,omitempty"`
+	Secret          string  `json:"secret,omitempty"`
 	CookieFieldName *string `json:"cookie_field_name,omitempty"`
 	KeyType         KeyType `json:"key_type,omitempty"`
 }
@@ -72,6 +87,7 @@ const (
 	KeyTypeRSA     KeyType = "rsa"
 	KeyTypeECDSA   KeyType = "ecdsa"
 	KeyTypeEd25519 KeyType = "ed25519"
+	KeyTypeHMAC    KeyType = "hmac"
 )
 
 type AddProvidersResponse struct {
@@ -95,6 +111,28 @@ type GetProviderResponse struct {
 	PEM             string  `json:"pem,omitempty"`
 	CookieFieldName *string `json:"cookie_field_name,omitempty"`
 	KeyType         KeyType `json:"key_type,omitempty"`
+	Endpoint        string  `json:"endpoint,omitempty"`
+	ClientID        string  `json:"client_id,omitempty"`
+	UserIDClaim     string  `json:"user_id_claim,omitempty"`
+
+	Keys []*ProviderKeyResponse `json:"keys,omitempty"`
+}
+
+type AddProviderKeyRequest struct {
+	KID       string     `json:"kid,omitempty"`
+	PEM       string     `json:"pem"`
+	KeyType   KeyType    `json:"key_type"`
+	NotBefore *time.Time `json:"not_before,omitempty"`
+	NotAfter  *time.Time `json:"not_after,omitempty"`
+}
+
+type ProviderKeyResponse struct {
+	KeyID     string     `json:"key_id"`
+	KID       string     `json:"kid,omitempty"`
+	PEM       string     `json:"pem"`
+	KeyType   KeyType    `json:"key_type"`
+	NotBefore *time.Time `json:"not_before,omitempty"`
+	NotAfter  *time.Time `json:"not_after,omitempty"`
 }
 
 type ResetAPISecretResponse struct {
@@ -105,8 +143,11 @@ type UpdateProviderRequest struct {
 	PublishableKey  string  `json:"publishable_key,omitempty"`
 	JWK             string  `json:"jwk,omitempty"`
 	PEM             string  `json:"pem,omitempty"`
+	Secret          string  `json:"secret,omitempty"`
 	CookieFieldName *string `json:"cookie_field_name,omitempty"`
 	KeyType         KeyType `json:"key_type,omitempty"`
+
+	Introspection *IntrospectionProvider `json:"introspection,omitempty"`
 }
 
 type EncryptBodyRequest struct {
@@ -126,3 +167,155 @@ type RegisterEncryptionSessionRequest struct {
 type RegisterEncryptionSessionResponse struct {
 	SessionID string `json:"session_id"`
 }
+
+type ClientCertMode string
+
+const (
+	ClientCertModeDisabled    ClientCertMode = "disabled"
+	ClientCertModeAlternative ClientCertMode = "alternative"
+	ClientCertModeRequired    ClientCertMode = "required"
+)
+
+type ProjectStatus string
+
+const (
+	ProjectStatusActive    ProjectStatus = "active"
+	ProjectStatusReadOnly  ProjectStatus = "read_only"
+	ProjectStatusSuspended ProjectStatus = "suspended"
+)
+
+type SetProjectStatusRequest struct {
+	Status ProjectStatus `json:"status"`
+}
+
+type ClientCertificateType string
+
+const (
+	ClientCertificateTypeCA   ClientCertificateType = "ca"
+	ClientCertificateTypeLeaf ClientCertificateType = "leaf"
+)
+
+type AddClientCertificateRequest struct {
+	Name        string                `json:"name,omitempty"`
+	Fingerprint string                `json:"fingerprint"`
+	Type        ClientCertificateType `json:"type"`
+}
+
+type ClientCertificateResponse struct {
+	CertificateID string                `json:"certificate_id"`
+	Name          string                `json:"name,omitempty"`
+	Fingerprint   string                `json:"fingerprint"`
+	Type          ClientCertificateType `json:"type"`
+	CreatedAt     time.Time             `json:"created_at"`
+}
+
+type GetClientCertificatesResponse struct {
+	Certificates []*ClientCertificateResponse `json:"certificates"`
+}
+
+type SetClientCertModeRequest struct {
+	Mode ClientCertMode `json:"mode"`
+}
+
+type RotateSigningKeyResponse struct {
+	SigningKey string `json:"signing_key"`
+}
+
+type SetSignedRequestsRequest struct {
+	Required bool `json:"required"`
+}
+
+type DeletionMode string
+
+const (
+	DeletionModeHardDelete  DeletionMode = "hard_delete"
+	DeletionModeCryptoShred DeletionMode = "crypto_shred"
+)
+
+type ProjectArchive struct {
+	ProjectID  string                      `json:"project_id"`
+	ExportedAt int64                       `json:"exported_at"`
+	Tables     map[string][]map[string]any `json:"tables"`
+}
+
+type RequestDeletionResponse struct {
+	ConfirmationToken string          `json:"confirmation_token"`
+	ExpiresAt         int64           `json:"expires_at"`
+	ArchiveDigest     string          `json:"archive_digest"`
+	Archive           *ProjectArchive `json:"archive"`
+}
+
+type DeleteProjectRequest struct {
+	ConfirmationToken string       `json:"confirmation_token"`
+	Mode              DeletionMode `json:"mode"`
+}
+
+type DeletionReceiptResponse struct {
+	ID            string           `json:"id"`
+	ProjectID     string           `json:"project_id"`
+	ProjectName   string           `json:"project_name"`
+	Mode          DeletionMode     `json:"mode"`
+	ArchiveDigest string           `json:"archive_digest"`
+	DeletedRows   map[string]int64 `json:"deleted_rows"`
+	DeletedAt     int64            `json:"deleted_at"`
+}
+
+type UserExportResponse struct {
+	ProjectID      string                      `json:"project_id"`
+	ExternalUserID string                      `json:"external_user_id"`
+	ExportedAt     int64                       `json:"exported_at"`
+	Tables         map[string][]map[string]any `json:"tables"`
+}
+
+type ErasureReceiptResponse struct {
+	ID                 string           `json:"id"`
+	ProjectID          string           `json:"project_id"`
+	ExternalUserIDHash string           `json:"external_user_id_hash"`
+	DeletedRows        map[string]int64 `json:"deleted_rows"`
+	ErasedAt           int64            `json:"erased_at"`
+}
+
+type Entropy string
+
+const (
+	EntropyNone    Entropy = "none"
+	EntropyUser    Entropy = "user"
+	EntropyProject Entropy = "project"
+	EntropyPasskey Entropy = "passkey"
+)
+
+type ListUsersResponse struct {
+	Users []*UserSummary `json:"users"`
+	// NextCursor is omitted on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type UserSummary struct {
+	UserID        string          `json:"user_id"`
+	CreatedAt     int64           `json:"created_at"`
+	ExternalUsers []*ExternalUser `json:"external_users"`
+	KeychainID    *string         `json:"keychain_id,omitempty"`
+	ShareCount    int             `json:"share_count"`
+	Shares        []*ShareSummary `json:"shares"`
+}
+
+type ExternalUser struct {
+	ProviderID     string `json:"provider_id"`
+	ExternalUserID string `json:"external_user_id"`
+}
+
+type ShareSummary struct {
+	Reference string  `json:"reference"`
+	Entropy   Entropy `json:"entropy"`
+}
+
+type CreateInviteRequest struct {
+	// ExpiresIn is the number of seconds the invite stays usable.
+	ExpiresIn int64 `json:"expires_in,omitempty"`
+}
+
+type CreateInviteResponse struct {
+	ID        string    `json:"id"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
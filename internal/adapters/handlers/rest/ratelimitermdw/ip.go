@@ -0,0 +1,110 @@
+package ratelimitermdw
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/api"
+)
+
+// IPMiddleware allows a fixed number of requests per client address in each
+// window. Counters are kept in memory, so with several replicas the effective
+// limit is multiplied by the number of replicas behind the load balancer.
+type IPMiddleware struct {
+	limit    int
+	window   time.Duration
+	ipHeader string
+
+	mu      sync.Mutex
+	windows map[string]*ipWindow
+	pruneAt time.Time
+}
+
+type ipWindow struct {
+	start time.Time
+	count int
+}
+
+// NewIP creates a per-address limiter. If ipHeader is set, the client address
+// is read from the last entry of that header, which must then be set by a
+// trusted proxy; otherwise the address of the connection is used. A limit of 0
+// disables the limiter.
+func NewIP(limit int, window time.Duration, ipHeader string) *IPMiddleware {
+	return &IPMiddleware{
+		limit:    limit,
+		window:   window,
+		ipHeader: ipHeader,
+		windows:  make(map[string]*ipWindow),
+	}
+}
+
+func (m *IPMiddleware) RateLimitMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if m.limit <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		retryAfter, ok := m.take(m.clientIP(r))
+		if !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			api.RespondWithError(w, r, api.ErrTooManyRegistrations)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// take counts a request from ip and reports whether it is allowed, or how long
+// until the address can be served again.
+func (m *IPMiddleware) take(ip string) (time.Duration, bool) {
+	now := time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if now.After(m.pruneAt) {
+		for k, win := range m.windows {
+			if now.Sub(win.start) >= m.window {
+				delete(m.windows, k)
+			}
+		}
+		m.pruneAt = now.Add(m.window)
+	}
+
+	win, ok := m.windows[ip]
+	if !ok || now.Sub(win.start) >= m.window {
+		win = &ipWindow{start: now}
+		m.windows[ip] = win
+	}
+
+	if win.count >= m.limit {
+		return win.start.Add(m.window).Sub(now), false
+	}
+
+	win.count++
+	return 0, true
+}
+
+func (m *IPMiddleware) clientIP(r *http.Request) string {
+	if m.ipHeader != "" {
+		if values := r.Header.Values(m.ipHeader); len(values) > 0 {
+			entries := strings.Split(values[len(values)-1], ",")
+			if ip := strings.TrimSpace(entries[len(entries)-1]); ip != "" {
+				return ip
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -9,11 +9,14 @@ import (
 	"strings"
 
 	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/healthzhdl"
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/idempotencymdw"
 	"github.com/openfort-xyz/shield/internal/applications/healthzapp"
 
 	"github.com/gorilla/mux"
 	metrics "github.com/openfort-xyz/metrics"
 	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/authmdw"
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/openapi"
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/operatormdw"
 	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/projecthdl"
 	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/ratelimitermdw"
 	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/requestmdw"
@@ -23,7 +26,9 @@ import (
 	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/usrhdl"
 	"github.com/openfort-xyz/shield/internal/applications/projectapp"
 	"github.com/openfort-xyz/shield/internal/applications/shareapp"
+	"github.com/openfort-xyz/shield/internal/core/domain/project"
 	"github.com/openfort-xyz/shield/internal/core/ports/factories"
+	"github.com/openfort-xyz/shield/internal/core/ports/repositories"
 	"github.com/openfort-xyz/shield/internal/core/ports/services"
 	"github.com/openfort-xyz/shield/pkg/logger"
 	"github.com/rs/cors"
@@ -43,6 +48,7 @@ type Server struct {
 	identityFactory       factories.IdentityFactory
 	userService           services.UserService
 	projectService        services.ProjectService
+	idempotencyRepo       repositories.IdempotencyRepository
 }
 
 // New creates a new REST server
@@ -53,7 +59,8 @@ func New(cfg *Config,
 	identityFactory factories.IdentityFactory,
 	userService services.UserService,
 	healthzApp *healthzapp.Application,
-	projectService services.ProjectService) *Server {
+	projectService services.ProjectService,
+	idempotencyRepo repositories.IdempotencyRepository) *Server {
 	return &Server{
 		projectApp:            projectApp,
 		shareApp:              shareApp,
@@ -66,17 +73,117 @@ func New(cfg *Config,
 		identityFactory:       identityFactory,
 		userService:           userService,
 		projectService:        projectService,
+		idempotencyRepo:       idempotencyRepo,
 	}
 }
 
 // Start starts the REST server
 func (s *Server) Start(ctx context.Context) error {
+	handler, err := s.Handler(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.server.Addr = fmt.Sprintf(":%d", s.config.Port)
+	s.server.Handler = handler
+	s.server.ReadTimeout = s.config.ReadTimeout
+	s.server.WriteTimeout = s.config.WriteTimeout
+	s.server.IdleTimeout = s.config.IdleTimeout
+
+	tlsConfig, err := s.config.tlsConfig()
+	if err != nil {
+		return err
+	}
+
+	// Start the metrics server
+	// Ideally, this server is not meant to be exposed to the public internet
+	// and its /metrics endpoint must only be consumed by prometheus
+	// or any other monitoring system
+	// so no authz is required
+	// Default port is 9100 and can be configured via METRICS_PORT env var
+	// (look how Config is defined in config.go and used when instantiating the server)
+	go func() {
+		s.logger.InfoContext(ctx, "starting metrics server", slog.Int("port", s.config.MetricsPort))
+		if err := s.metricsServer.Start(ctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.ErrorContext(ctx, "failed to start metrics server", slog.Any("error", err))
+		}
+	}()
+
+	if tlsConfig != nil {
+		s.server.TLSConfig = tlsConfig
+		s.logger.InfoContext(ctx, "starting TLS server", slog.String("address", s.server.Addr))
+		return s.server.ListenAndServeTLS("", "")
+	}
+
+	s.logger.InfoContext(ctx, "starting server", slog.String("address", s.server.Addr))
+	return s.server.ListenAndServe()
+}
+
+// Handler returns the handler serving the API, its routes behind CORS.
+func (s *Server) Handler(ctx context.Context) (http.Handler, error) {
+	r, err := s.router(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	extraHeaders := strings.Split(s.config.CORSExtraAllowedHeaders, ",")
+	return cors.New(cors.Options{
+		AllowedOrigins: []string{"*"},
+		AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions},
+		AllowedHeaders: append([]string{
+			authmdw.AccessControlAllowOriginHeader,
+			authmdw.TokenHeader,
+			responsemdw.ContentTypeHeader,
+			authmdw.APIKeyHeader,
+			authmdw.APISecretHeader,
+			authmdw.AuthProviderHeader,
+			authmdw.OpenfortProviderHeader,
+			authmdw.OpenfortTokenTypeHeader,
+			authmdw.EncryptionPartHeader,
+			authmdw.EncryptionSessionHeader,
+			authmdw.RequestIDHeader,
+			operatormdw.InviteTokenHeader,
+			idempotencymdw.KeyHeader,
+			sharehdl.IfMatchHeader,
+			// W3C Trace Context — sent by the iFrame so shield-side spans
+			// join the same trace as the api/castle path of the flow.
+			"traceparent",
+			// Human-readable flow name (e.g. "embedded.create") used to
+			// rename the server root span — see tracingmdw.FlowNameMiddleware.
+			tracingmdw.FlowNameHeader,
+			// Flow attributes attached to the iframe-root span by the api.
+			// Shield doesn't consume them but must allow-list them for CORS.
+			tracingmdw.UserIDHeader,
+			tracingmdw.ChainIDHeader,
+		}, extraHeaders...),
+		ExposedHeaders: []string{sharehdl.ETagHeader},
+		MaxAge:         s.config.CORSMaxAge,
+	}).Handler(r), nil
+}
+
+// router registers the routes of the API, openapi.Build documents each of them.
+func (s *Server) router(ctx context.Context) (*mux.Router, error) {
 	healthzHdl := healthzhdl.New(s.healthzApp)
 	projectHdl := projecthdl.New(s.projectApp)
 	shareHdl := sharehdl.New(s.shareApp)
 	userHdl := usrhdl.New(s.userService)
 	authMdw := authmdw.New(s.authenticationFactory, s.identityFactory, s.userService, s.projectService)
 	rateLimiterMdw := ratelimitermdw.New(s.config.RPS)
+	idempotencyMdw := idempotencymdw.New(s.idempotencyRepo)
+
+	registrationMode := project.RegistrationMode(s.config.RegistrationMode)
+	if !registrationMode.Valid() {
+		return nil, fmt.Errorf("invalid registration mode %q", s.config.RegistrationMode)
+	}
+	if registrationMode == project.RegistrationModeInvite && s.config.OperatorAPIKey == "" {
+		s.logger.WarnContext(ctx, "invite-only registration without an operator API key, invites can only be issued from the CLI")
+	}
+	operatorMdw := operatormdw.New(s.config.OperatorAPIKey, registrationMode)
+	openapiHdl, err := openapi.New()
+	if err != nil {
+		return nil, err
+	}
+	registrationLimiterMdw := ratelimitermdw.NewIP(s.config.RegistrationsPerIP, s.config.RegistrationWindow, s.config.ClientIPHeader)
 
 	r := mux.NewRouter()
 	// Tracing first so the span wraps rate-limit/metrics/request-id/response work,
@@ -92,12 +199,22 @@ func (s *Server) Start(ctx context.Context) error {
 	r.Use(requestmdw.RequestIDMiddleware)
 	r.Use(responsemdw.ResponseMiddleware)
 	r.HandleFunc("/healthz", healthzHdl.Healthz).Methods(http.MethodGet)
-	r.HandleFunc("/register", projectHdl.CreateProject).Methods(http.MethodPost)
+	r.HandleFunc("/openapi.json", openapiHdl.Document).Methods(http.MethodGet)
+	reg := r.Path("/register").Subrouter()
+	reg.Use(registrationLimiterMdw.RateLimitMiddleware)
+	reg.Use(operatorMdw.AuthorizeRegistration)
+	// Registration takes no Idempotency-Key, its callers aren't authenticated
+	// so a stored response, with the credentials of the project, can't be
+	// scoped to the caller it belongs to
+	reg.HandleFunc("", projectHdl.CreateProject).Methods(http.MethodPost)
 	// This endpoint only lists the available share storage methods, so it does not require authentication
 	r.HandleFunc("/storage-methods", shareHdl.GetShareStorageMethods).Methods(http.MethodGet)
 	p := r.PathPrefix("/project").Subrouter()
 	p.Use(authMdw.AuthenticateAPISecret)
+	p.Use(idempotencyMdw.IdempotencyMiddleware)
 	p.HandleFunc("", projectHdl.GetProject).Methods(http.MethodGet)
+	p.HandleFunc("", projectHdl.DeleteProject).Methods(http.MethodDelete)
+	p.HandleFunc("/deletion", projectHdl.RequestDeletion).Methods(http.MethodPost)
 	p.HandleFunc("/reset-api-secret", projectHdl.ResetAPISecret).Methods(http.MethodPost)
 	p.HandleFunc("/otp", projectHdl.RequestOTP).Methods(http.MethodPost)
 	p.HandleFunc("/providers", projectHdl.GetProviders).Methods(http.MethodGet)
@@ -105,6 +222,17 @@ func (s *Server) Start(ctx context.Context) error {
 	p.HandleFunc("/providers/{provider}", projectHdl.GetProvider).Methods(http.MethodGet)
 	p.HandleFunc("/providers/{provider}", projectHdl.UpdateProvider).Methods(http.MethodPut)
 	p.HandleFunc("/providers/{provider}", projectHdl.DeleteProvider).Methods(http.MethodDelete)
+	p.HandleFunc("/providers/{provider}/keys", projectHdl.AddProviderKey).Methods(http.MethodPost)
+	p.HandleFunc("/providers/{provider}/keys/{key}", projectHdl.DeleteProviderKey).Methods(http.MethodDelete)
+	p.HandleFunc("/client-certificates", projectHdl.GetClientCertificates).Methods(http.MethodGet)
+	p.HandleFunc("/client-certificates", projectHdl.AddClientCertificate).Methods(http.MethodPost)
+	p.HandleFunc("/client-certificates/mode", projectHdl.SetClientCertMode).Methods(http.MethodPut)
+	p.HandleFunc("/client-certificates/{certificate}", projectHdl.DeleteClientCertificate).Methods(http.MethodDelete)
+	p.HandleFunc("/users", projectHdl.ListUsers).Methods(http.MethodGet)
+	p.HandleFunc("/users/{externalUserID}", projectHdl.ExportUser).Methods(http.MethodGet)
+	p.HandleFunc("/users/{externalUserID}", projectHdl.EraseUser).Methods(http.MethodDelete)
+	p.HandleFunc("/signing-key", projectHdl.RotateSigningKey).Methods(http.MethodPost)
+	p.HandleFunc("/signed-requests", projectHdl.SetSignedRequests).Methods(http.MethodPut)
 	p.HandleFunc("/encrypt", projectHdl.EncryptProjectShares).Methods(http.MethodPost)
 	p.HandleFunc("/encryption-session", projectHdl.RegisterEncryptionSession).Methods(http.MethodPost)
 	p.HandleFunc("/encryption-key", projectHdl.RegisterEncryptionKey).Methods(http.MethodPost)
@@ -112,10 +240,12 @@ func (s *Server) Start(ctx context.Context) error {
 
 	usr := r.PathPrefix("/user").Subrouter()
 	usr.Use(authMdw.AuthenticateAPISecret)
+	usr.Use(idempotencyMdw.IdempotencyMiddleware)
 	usr.HandleFunc("", userHdl.CreateUser).Methods(http.MethodPost)
 
 	u := r.PathPrefix("/shares").Subrouter()
 	u.Use(authMdw.AuthenticateUser)
+	u.Use(idempotencyMdw.IdempotencyMiddleware)
 	u.HandleFunc("", shareHdl.GetShare).Methods(http.MethodGet)
 	u.HandleFunc("/{reference}", shareHdl.GetShareByReference).Methods(http.MethodGet)
 
@@ -125,76 +255,45 @@ func (s *Server) Start(ctx context.Context) error {
 	u.HandleFunc("", shareHdl.UpdateShare).Methods(http.MethodPut)
 	k := r.PathPrefix("/keychain").Subrouter()
 	k.Use(authMdw.AuthenticateUser)
+	k.Use(idempotencyMdw.IdempotencyMiddleware)
 	k.HandleFunc("", shareHdl.Keychain).Methods(http.MethodGet)
+	k.HandleFunc("/metadata", shareHdl.KeychainMetadata).Methods(http.MethodGet)
+	k.HandleFunc("/references/{reference}", shareHdl.RenameReference).Methods(http.MethodPut)
 
 	e := r.PathPrefix("/shares/encryption").Subrouter()
 	e.Use(authMdw.AuthenticateAPISecret)
+	e.Use(idempotencyMdw.IdempotencyMiddleware)
 	e.HandleFunc("", shareHdl.GetShareEncryption).Methods(http.MethodGet)
 	e.HandleFunc("/reference/bulk", shareHdl.GetSharesEncryptionForReferences).Methods(http.MethodPost)
 	e.HandleFunc("/user/bulk", shareHdl.GetSharesEncryptionForUsers).Methods(http.MethodPost)
 
+	rs := r.PathPrefix("/shares/reassign").Subrouter()
+	rs.Use(authMdw.AuthenticateAPISecret)
+	rs.Use(idempotencyMdw.IdempotencyMiddleware)
+	rs.HandleFunc("/{reference}", shareHdl.ReassignShare).Methods(http.MethodPut)
+
 	m := r.PathPrefix("/shares/migration").Subrouter()
 	m.Use(authMdw.AuthenticateAPISecret)
 	m.HandleFunc("/export/{reference}", shareHdl.ExportShare).Methods(http.MethodGet)
-	m.HandleFunc("/import", shareHdl.ImportShare).Methods(http.MethodPost)
+	m.Handle("/import", idempotencyMdw.IdempotencyMiddleware(http.HandlerFunc(shareHdl.ImportShare))).Methods(http.MethodPost)
+	m.HandleFunc("/bulk/export", shareHdl.ExportShares).Methods(http.MethodGet)
+	// The bulk import streams its body and is idempotent itself, imported
+	// shares are skipped, so it doesn't go through the idempotency middleware
+	m.HandleFunc("/bulk/import", shareHdl.ImportShares).Methods(http.MethodPost)
+
+	o := r.PathPrefix("/operator").Subrouter()
+	o.Use(operatorMdw.AuthenticateOperator)
+	o.Use(idempotencyMdw.IdempotencyMiddleware)
+	o.HandleFunc("/invites", projectHdl.CreateInvite).Methods(http.MethodPost)
+	o.HandleFunc("/projects/{project}/status", projectHdl.SetProjectStatus).Methods(http.MethodPut)
 
 	a := r.PathPrefix("/admin").Subrouter()
 	a.Use(authMdw.AuthenticateAPISecret)
 	a.Use(authMdw.PreRegisterUser)
+	a.Use(idempotencyMdw.IdempotencyMiddleware)
 	a.HandleFunc("/preregister", shareHdl.RegisterShare).Methods(http.MethodPost)
 
-	extraHeaders := strings.Split(s.config.CORSExtraAllowedHeaders, ",")
-	c := cors.New(cors.Options{
-		AllowedOrigins: []string{"*"},
-		AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions},
-		AllowedHeaders: append([]string{
-			authmdw.AccessControlAllowOriginHeader,
-			authmdw.TokenHeader,
-			responsemdw.ContentTypeHeader,
-			authmdw.APIKeyHeader,
-			authmdw.APISecretHeader,
-			authmdw.AuthProviderHeader,
-			authmdw.OpenfortProviderHeader,
-			authmdw.OpenfortTokenTypeHeader,
-			authmdw.EncryptionPartHeader,
-			authmdw.EncryptionSessionHeader,
-			authmdw.RequestIDHeader,
-			// W3C Trace Context — sent by the iFrame so shield-side spans
-			// join the same trace as the api/castle path of the flow.
-			"traceparent",
-			// Human-readable flow name (e.g. "embedded.create") used to
-			// rename the server root span — see tracingmdw.FlowNameMiddleware.
-			tracingmdw.FlowNameHeader,
-			// Flow attributes attached to the iframe-root span by the api.
-			// Shield doesn't consume them but must allow-list them for CORS.
-			tracingmdw.UserIDHeader,
-			tracingmdw.ChainIDHeader,
-		}, extraHeaders...),
-		MaxAge: s.config.CORSMaxAge,
-	}).Handler(r)
-
-	s.server.Addr = fmt.Sprintf(":%d", s.config.Port)
-	s.server.Handler = c
-	s.server.ReadTimeout = s.config.ReadTimeout
-	s.server.WriteTimeout = s.config.WriteTimeout
-	s.server.IdleTimeout = s.config.IdleTimeout
-
-	// Start the metrics server
-	// Ideally, this server is not meant to be exposed to the public internet
-	// and its /metrics endpoint must only be consumed by prometheus
-	// or any other monitoring system
-	// so no authz is required
-	// Default port is 9100 and can be configured via METRICS_PORT env var
-	// (look how Config is defined in config.go and used when instantiating the server)
-	go func() {
-		s.logger.InfoContext(ctx, "starting metrics server", slog.Int("port", s.config.MetricsPort))
-		if err := s.metricsServer.Start(ctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			s.logger.ErrorContext(ctx, "failed to start metrics server", slog.Any("error", err))
-		}
-	}()
-
-	s.logger.InfoContext(ctx, "starting server", slog.String("address", s.server.Addr))
-	return s.server.ListenAndServe()
+	return r, nil
 }
 
 // Stop stops the REST server gracefully
@@ -0,0 +1,49 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/openapi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_RouterMatchesOpenAPI(t *testing.T) {
+	s := New(&Config{RegistrationMode: "open", RPS: 100}, nil, nil, nil, nil, nil, nil, nil, nil)
+	r, err := s.router(context.Background())
+	require.NoError(t, err)
+
+	var registered []string
+	err = r.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			// Subrouters and their prefixes have no methods.
+			return nil
+		}
+		for _, method := range methods {
+			registered = append(registered, method+" "+path)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	var documented []string
+	for path, item := range openapi.Build().Paths {
+		for method := range *item {
+			documented = append(documented, strings.ToUpper(method)+" "+path)
+		}
+	}
+
+	slices.Sort(registered)
+	slices.Sort(documented)
+	require.Equal(t, registered, documented, "routes registered by the server and documented in openapi diverge")
+	require.Contains(t, registered, http.MethodGet+" /openapi.json")
+}
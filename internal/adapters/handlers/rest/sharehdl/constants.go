@@ -1,3 +1,8 @@
 package sharehdl
 
 const MaxBulkSize = 100
+
+// MaxMigrationLineSize bounds a single line of a bulk share import.
+const MaxMigrationLineSize = 1 << 20
+
+const NDJSONContentType = "application/x-ndjson"
@@ -7,7 +7,9 @@ import (
 	"github.com/openfort-xyz/shield/internal/applications/shareapp"
 )
 
-func fromApplicationError(err error) *api.Error {
+// FromApplicationError is also used by the gRPC API, so both report the same
+// error codes.
+func FromApplicationError(err error) *api.Error {
 	if err == nil {
 		return nil
 	}
@@ -16,6 +18,8 @@ func fromApplicationError(err error) *api.Error {
 		return api.ErrShareNotFound
 	case errors.Is(err, shareapp.ErrShareAlreadyExists):
 		return api.ErrShareAlreadyExists
+	case errors.Is(err, shareapp.ErrKeychainNotFound):
+		return api.ErrKeychainNotFound
 	case errors.Is(err, shareapp.ErrUserNotFound):
 		return api.ErrUserNotFound
 	case errors.Is(err, shareapp.ErrExternalUserNotFound):
@@ -32,6 +36,16 @@ func fromApplicationError(err error) *api.Error {
 		return api.ErrInvalidEncryptionSession
 	case errors.Is(err, shareapp.ErrOTPVerificationRequired):
 		return api.ErrOTPRequired
+	case errors.Is(err, shareapp.ErrTransferKeyRequired):
+		return api.ErrTransferKeyRequired
+	case errors.Is(err, shareapp.ErrInvalidTransferKey):
+		return api.ErrInvalidTransferKey
+	case errors.Is(err, shareapp.ErrProjectReadOnly):
+		return api.ErrProjectReadOnly
+	case errors.Is(err, shareapp.ErrShareVersionMismatch):
+		return api.ErrShareVersionMismatch
+	case errors.Is(err, shareapp.ErrInvalidPageSize):
+		return api.ErrInvalidPageSize
 	default:
 		return api.ErrInternal
 	}
@@ -11,6 +11,7 @@ import (
 	ua "github.com/mileusna/useragent"
 	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/api"
 	"github.com/openfort-xyz/shield/internal/applications/shareapp"
+	"github.com/openfort-xyz/shield/internal/core/domain/share"
 	"github.com/openfort-xyz/shield/pkg/logger"
 )
 
@@ -69,7 +70,7 @@ func parseUserAgent(r *http.Request) (*PasskeyEnv, *api.Error) {
 
 // Keychain gets the keychain
 // @Summary Get keychain
-// @Description Get the keychain for the user
+// @Description Get the keychain for the user, every share at once or a page of them when limit is set
 // @Tags Share
 // @Accept json
 // @Produce json
@@ -77,44 +78,83 @@ func parseUserAgent(r *http.Request) (*PasskeyEnv, *api.Error) {
 // @Param Authorization header string true "
 // @Param X-Auth-Provider header string true "Auth Provider"
 // @Param reference query string false "Reference"
+// @Param reference_prefix query string false "Only shares whose reference starts with this prefix"
+// @Param entropy query string false "Only shares of this entropy" Enums(none, user, project, passkey)
+// @Param metadata query []string false "Metadata entries as key:value the shares must hold, all of them" collectionFormat(multi)
+// @Param fields query string false "metadata returns the shares without their secrets, nothing is decrypted" Enums(all, metadata)
+// @Param limit query int false "Page size, at most 100, every share is returned when omitted"
+// @Param cursor query string false "next_cursor of the previous page"
 // @Success 200 {object} KeychainResponse "Successful response"
+// @Failure 400 {object} api.Error "Bad Request"
 // @Failure 404 "Description: Not Found"
 // @Failure 500 "Description: Internal Server Error"
 // @Router /shares/keychain [get]
 func (h *Handler) Keychain(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	h.logger.InfoContext(ctx, "getting keychain")
-	referenceQuery := r.URL.Query().Get("reference")
-	var reference *string
-	if referenceQuery != "" {
-		reference = &referenceQuery
-	}
+	query := r.URL.Query()
 
 	var opts []shareapp.Option
-	encryptionPart := r.Header.Get(EncryptionPartHeader)
-	if encryptionPart != "" {
-		opts = append(opts, shareapp.WithEncryptionPart(encryptionPart))
-	}
-
-	encryptionSession := r.Header.Get(EncryptionSessionHeader)
-	if encryptionSession != "" {
-		opts = append(opts, shareapp.WithEncryptionSession(encryptionSession))
-	}
+	switch query.Get("fields") {
+	case "", KeychainFieldsAll:
+		encryptionPart := r.Header.Get(EncryptionPartHeader)
+		if encryptionPart != "" {
+			opts = append(opts, shareapp.WithEncryptionPart(encryptionPart))
+		}
 
-	keychain, err := h.app.GetKeychainShares(ctx, reference, opts...)
-	if err != nil {
-		api.RespondWithError(w, fromApplicationError(err))
+		encryptionSession := r.Header.Get(EncryptionSessionHeader)
+		if encryptionSession != "" {
+			opts = append(opts, shareapp.WithEncryptionSession(encryptionSession))
+		}
+	case KeychainFieldsMetadata:
+		opts = append(opts, shareapp.WithoutSecrets())
+	default:
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("fields must be all or metadata"))
 		return
 	}
 
 	var response KeychainResponse
-	for _, share := range keychain {
-		response.Shares = append(response.Shares, h.parser.fromDomain(share))
+	var keychain []*share.Share
+	if reference := query.Get("reference"); reference != "" {
+		for _, param := range []string{"reference_prefix", "entropy", "limit", "cursor"} {
+			if query.Has(param) {
+				api.RespondWithError(w, r, api.ErrBadRequestWithMessage("reference can't be combined with "+param))
+				return
+			}
+		}
+
+		var err error
+		keychain, err = h.app.GetKeychainShares(ctx, &reference, opts...)
+		if err != nil {
+			api.RespondWithError(w, r, FromApplicationError(err))
+			return
+		}
+	} else {
+		filter, after, limit, errV := h.parser.toKeychainListQuery(query)
+		if errV != nil {
+			api.RespondWithError(w, r, errV)
+			return
+		}
+
+		page, err := h.app.ListKeychainShares(ctx, filter, after, limit, opts...)
+		if err != nil {
+			api.RespondWithError(w, r, FromApplicationError(err))
+			return
+		}
+
+		keychain = page.Shares
+		if page.Next != "" {
+			response.NextCursor = encodeKeychainCursor(page.Next)
+		}
+	}
+
+	for _, shr := range keychain {
+		response.Shares = append(response.Shares, &KeychainShare{Share: h.parser.fromDomain(shr), ETag: ETag(shr.Version)})
 	}
 
 	resp, err := json.Marshal(response)
 	if err != nil {
-		api.RespondWithError(w, api.ErrInternal)
+		api.RespondWithError(w, r, api.ErrInternal)
 		return
 	}
 
@@ -159,17 +199,18 @@ func (h *Handler) GetShareByReference(w http.ResponseWriter, r *http.Request) {
 
 	share, err := h.app.GetShareByReference(ctx, reference, opts...)
 	if err != nil {
-		api.RespondWithError(w, fromApplicationError(err))
+		api.RespondWithError(w, r, FromApplicationError(err))
 		return
 	}
 
 	// gosec G117: encrypted share secret is the resource this endpoint returns to the authenticated owner.
 	resp, err := json.Marshal(GetShareResponse(*h.parser.fromDomain(share))) //nolint:gosec
 	if err != nil {
-		api.RespondWithError(w, api.ErrInternal)
+		api.RespondWithError(w, r, api.ErrInternal)
 		return
 	}
 
+	w.Header().Set(ETagHeader, ETag(share.Version))
 	w.WriteHeader(http.StatusOK)
 	_, _ = w.Write(resp)
 }
@@ -197,26 +238,26 @@ func (h *Handler) RegisterShare(w http.ResponseWriter, r *http.Request) {
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage("failed to read request body"))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to read request body"))
 		return
 	}
 
 	var req RegisterShareRequest
 	err = json.Unmarshal(body, &req)
 	if err != nil {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage("failed to parse request body"))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to parse request body"))
 		return
 	}
 
 	if errV := h.validator.validateShare((*Share)(&req)); errV != nil {
-		api.RespondWithError(w, errV)
+		api.RespondWithError(w, r, errV)
 		return
 	}
 
 	if req.PasskeyReference != nil && req.PasskeyReference.PasskeyEnv == nil {
 		sourceEnv, apiErr := parseUserAgent(r)
 		if apiErr != nil {
-			api.RespondWithError(w, apiErr)
+			api.RespondWithError(w, r, apiErr)
 			return
 		}
 		req.PasskeyReference.PasskeyEnv = sourceEnv
@@ -232,7 +273,7 @@ func (h *Handler) RegisterShare(w http.ResponseWriter, r *http.Request) {
 	}
 	err = h.app.RegisterShare(ctx, share, opts...)
 	if err != nil {
-		api.RespondWithError(w, fromApplicationError(err))
+		api.RespondWithError(w, r, FromApplicationError(err))
 		return
 	}
 
@@ -250,10 +291,12 @@ func (h *Handler) RegisterShare(w http.ResponseWriter, r *http.Request) {
 // @Param X-Auth-Provider header string true "Auth Provider"
 // @Param X-Openfort-Provider header string false "Openfort Provider"
 // @Param X-Openfort-Token-Type header string false "Openfort Token Type"
+// @Param If-Match header string false "ETag of the share the update applies to"
 // @Param updateShareRequest body UpdateShareRequest true "Update Share Request"
 // @Success 200 {object} UpdateShareResponse "Successful response"
 // @Failure 400 {object} api.Error "Bad Request"
 // @Failure 404 {object} api.Error "Not Found"
+// @Failure 412 {object} api.Error "Share was changed since it was read"
 // @Failure 500 {object} api.Error "Internal Server Error"
 // @Router /shares [put]
 func (h *Handler) UpdateShare(w http.ResponseWriter, r *http.Request) {
@@ -262,26 +305,26 @@ func (h *Handler) UpdateShare(w http.ResponseWriter, r *http.Request) {
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage("failed to read request body"))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to read request body"))
 		return
 	}
 
 	var req UpdateShareRequest
 	err = json.Unmarshal(body, &req)
 	if err != nil {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage("failed to parse request body"))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to parse request body"))
 		return
 	}
 
 	if errV := h.validator.validateShare((*Share)(&req)); errV != nil {
-		api.RespondWithError(w, errV)
+		api.RespondWithError(w, r, errV)
 		return
 	}
 
 	if req.PasskeyReference != nil && req.PasskeyReference.PasskeyEnv == nil {
 		sourceEnv, apiErr := parseUserAgent(r)
 		if apiErr != nil {
-			api.RespondWithError(w, apiErr)
+			api.RespondWithError(w, r, apiErr)
 			return
 		}
 		req.PasskeyReference.PasskeyEnv = sourceEnv
@@ -295,19 +338,23 @@ func (h *Handler) UpdateShare(w http.ResponseWriter, r *http.Request) {
 	if req.EncryptionSession != "" {
 		opts = append(opts, shareapp.WithEncryptionSession(req.EncryptionSession))
 	}
+	if versions := parseIfMatch(r.Header.Get(IfMatchHeader)); versions != nil {
+		opts = append(opts, shareapp.WithIfMatch(versions))
+	}
 	shr, err := h.app.UpdateShare(ctx, share, req.Reference, opts...)
 	if err != nil {
-		api.RespondWithError(w, fromApplicationError(err))
+		api.RespondWithError(w, r, FromApplicationError(err))
 		return
 	}
 
 	// gosec G117: encrypted share secret is the resource this endpoint returns to the authenticated owner.
 	resp, err := json.Marshal(UpdateShareResponse(*h.parser.fromDomain(shr))) //nolint:gosec
 	if err != nil {
-		api.RespondWithError(w, api.ErrInternal)
+		api.RespondWithError(w, r, api.ErrInternal)
 		return
 	}
 
+	w.Header().Set(ETagHeader, ETag(shr.Version))
 	w.WriteHeader(http.StatusOK)
 	_, _ = w.Write(resp)
 }
@@ -340,7 +387,7 @@ func (h *Handler) DeleteShare(w http.ResponseWriter, r *http.Request) {
 
 	err := h.app.DeleteShare(ctx, reference)
 	if err != nil {
-		api.RespondWithError(w, fromApplicationError(err))
+		api.RespondWithError(w, r, FromApplicationError(err))
 		return
 	}
 
@@ -380,17 +427,18 @@ func (h *Handler) GetShare(w http.ResponseWriter, r *http.Request) {
 
 	shr, err := h.app.GetShare(ctx, opts...)
 	if err != nil {
-		api.RespondWithError(w, fromApplicationError(err))
+		api.RespondWithError(w, r, FromApplicationError(err))
 		return
 	}
 
 	// gosec G117: encrypted share secret is the resource this endpoint returns to the authenticated owner.
 	resp, err := json.Marshal(GetShareResponse(*h.parser.fromDomain(shr))) //nolint:gosec
 	if err != nil {
-		api.RespondWithError(w, api.ErrInternal)
+		api.RespondWithError(w, r, api.ErrInternal)
 		return
 	}
 
+	w.Header().Set(ETagHeader, ETag(shr.Version))
 	w.WriteHeader(http.StatusOK)
 	_, _ = w.Write(resp)
 }
@@ -416,7 +464,7 @@ func (h *Handler) GetShareEncryption(w http.ResponseWriter, r *http.Request) {
 
 	shareEntropy, encryptionParameters, err := h.app.GetShareEncryption(ctx)
 	if err != nil {
-		api.RespondWithError(w, fromApplicationError(err))
+		api.RespondWithError(w, r, FromApplicationError(err))
 		return
 	}
 
@@ -434,7 +482,7 @@ func (h *Handler) GetShareEncryption(w http.ResponseWriter, r *http.Request) {
 
 	resp, err := json.Marshal(encryptionResponse)
 	if err != nil {
-		api.RespondWithError(w, api.ErrInternal)
+		api.RespondWithError(w, r, api.ErrInternal)
 		return
 	}
 
@@ -457,19 +505,19 @@ func (h *Handler) GetSharesEncryptionForReferences(w http.ResponseWriter, r *htt
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage("failed to read request body"))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to read request body"))
 		return
 	}
 
 	var requestedReferences GetSharesEncryptionForReferencesRequest
 	err = json.Unmarshal(body, &requestedReferences)
 	if err != nil {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage("failed to parse request body"))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to parse request body"))
 		return
 	}
 
 	if len(requestedReferences.References) > MaxBulkSize {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage(fmt.Sprintf("Requests with more than %d elements are not allowed", MaxBulkSize)))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage(fmt.Sprintf("Requests with more than %d elements are not allowed", MaxBulkSize)))
 		return
 	}
 
@@ -483,7 +531,7 @@ func (h *Handler) GetSharesEncryptionForReferences(w http.ResponseWriter, r *htt
 
 	if err != nil {
 		// Any error here must be the server's fault (the request is well-formed)
-		api.RespondWithError(w, api.ErrInternal)
+		api.RespondWithError(w, r, api.ErrInternal)
 		return
 	}
 
@@ -503,6 +551,7 @@ func (h *Handler) GetSharesEncryptionForReferences(w http.ResponseWriter, r *htt
 				EncryptionType: &encryptionType,
 				PasskeyID:      val.PasskeyID,
 				PasskeyEnv:     h.parser.toPasskeyEnv(val.PasskeyEnv),
+				Metadata:       val.Metadata,
 			}
 		} else {
 			responseBody.EncryptionTypes[requestedReference] = EncryptionTypeResponse{
@@ -535,19 +584,19 @@ func (h *Handler) GetSharesEncryptionForUsers(w http.ResponseWriter, r *http.Req
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage("failed to read request body"))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to read request body"))
 		return
 	}
 
 	var requestedUsers GetSharesEncryptionForUsersRequest
 	err = json.Unmarshal(body, &requestedUsers)
 	if err != nil {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage("failed to parse request body"))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to parse request body"))
 		return
 	}
 
 	if len(requestedUsers.UserIDs) > MaxBulkSize {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage(fmt.Sprintf("Requests with more than %d elements are not allowed", MaxBulkSize)))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage(fmt.Sprintf("Requests with more than %d elements are not allowed", MaxBulkSize)))
 		return
 	}
 
@@ -561,7 +610,7 @@ func (h *Handler) GetSharesEncryptionForUsers(w http.ResponseWriter, r *http.Req
 
 	if err != nil {
 		// Any error here must be the server's fault (the request is well-formed)
-		api.RespondWithError(w, api.ErrInternal)
+		api.RespondWithError(w, r, api.ErrInternal)
 		return
 	}
 
@@ -581,6 +630,7 @@ func (h *Handler) GetSharesEncryptionForUsers(w http.ResponseWriter, r *http.Req
 				EncryptionType: &encryptionType,
 				PasskeyID:      val.PasskeyID,
 				PasskeyEnv:     h.parser.toPasskeyEnv(val.PasskeyEnv),
+				Metadata:       val.Metadata,
 			}
 		} else {
 			responseBody.EncryptionTypes[requestedUser] = EncryptionTypeResponse{
@@ -613,20 +663,20 @@ func (h *Handler) ExportShare(w http.ResponseWriter, r *http.Request) {
 
 	reference := mux.Vars(r)["reference"]
 	if reference == "" {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage("missing reference"))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("missing reference"))
 		return
 	}
 
 	shr, err := h.app.ExportShare(ctx, reference)
 	if err != nil {
-		api.RespondWithError(w, fromApplicationError(err))
+		api.RespondWithError(w, r, FromApplicationError(err))
 		return
 	}
 
 	// gosec G117: encrypted share secret is exported by design on this migration endpoint.
 	resp, err := json.Marshal(h.parser.fromDomainExport(shr)) //nolint:gosec
 	if err != nil {
-		api.RespondWithError(w, api.ErrInternal)
+		api.RespondWithError(w, r, api.ErrInternal)
 		return
 	}
 
@@ -653,26 +703,31 @@ func (h *Handler) ImportShare(w http.ResponseWriter, r *http.Request) {
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage("failed to read request body"))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to read request body"))
 		return
 	}
 
 	var req ImportShareRequest
 	err = json.Unmarshal(body, &req)
 	if err != nil {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage("failed to parse request body"))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to parse request body"))
 		return
 	}
 
 	if req.Secret == "" {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage("secret is required"))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("secret is required"))
+		return
+	}
+
+	if errV := h.validator.validateMetadata(req.Metadata); errV != nil {
+		api.RespondWithError(w, r, errV)
 		return
 	}
 
 	shr := h.parser.toImportDomain(&req)
 	err = h.app.ImportShare(ctx, shr)
 	if err != nil {
-		api.RespondWithError(w, fromApplicationError(err))
+		api.RespondWithError(w, r, FromApplicationError(err))
 		return
 	}
 
@@ -693,7 +748,7 @@ func (h *Handler) GetShareStorageMethods(w http.ResponseWriter, r *http.Request)
 
 	storageMethods, err := h.app.GetShareStorageMethods(ctx)
 	if err != nil {
-		api.RespondWithError(w, fromApplicationError(err))
+		api.RespondWithError(w, r, FromApplicationError(err))
 		return
 	}
 
@@ -708,7 +763,7 @@ func (h *Handler) GetShareStorageMethods(w http.ResponseWriter, r *http.Request)
 
 	resp, err := json.Marshal(response)
 	if err != nil {
-		api.RespondWithError(w, api.ErrInternal)
+		api.RespondWithError(w, r, api.ErrInternal)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
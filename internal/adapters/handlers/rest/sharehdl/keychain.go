@@ -0,0 +1,150 @@
+package sharehdl
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/api"
+)
+
+// KeychainMetadata lists the references of the keychain
+// @Summary Get keychain metadata
+// @Description Get the user's keychain and the metadata of each of its shares, without their secrets
+// @Tags Share
+// @Produce json
+// @Param X-API-Key header string true "API Key"
+// @Param Authorization header string true "Bearer token"
+// @Param X-Auth-Provider header string true "Auth Provider"
+// @Param X-Openfort-Provider header string false "Openfort Provider"
+// @Param X-Openfort-Token-Type header string false "Openfort Token Type"
+// @Success 200 {object} KeychainMetadataResponse "Successful response"
+// @Failure 404 {object} api.Error "Not Found"
+// @Failure 500 {object} api.Error "Internal Server Error"
+// @Router /keychain/metadata [get]
+func (h *Handler) KeychainMetadata(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.InfoContext(ctx, "getting keychain metadata")
+
+	kc, shrs, err := h.app.GetKeychainMetadata(ctx)
+	if err != nil {
+		api.RespondWithError(w, r, FromApplicationError(err))
+		return
+	}
+
+	resp, err := json.Marshal(h.parser.fromDomainKeychainMetadata(kc, shrs))
+	if err != nil {
+		api.RespondWithError(w, r, api.ErrInternal)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(resp)
+}
+
+// RenameReference renames a reference of the keychain
+// @Summary Rename reference
+// @Description Rename one of the references in the user's keychain. The new reference must not be taken by any other share.
+// @Tags Share
+// @Accept json
+// @Param X-API-Key header string true "API Key"
+// @Param Authorization header string true "Bearer token"
+// @Param X-Auth-Provider header string true "Auth Provider"
+// @Param X-Openfort-Provider header string false "Openfort Provider"
+// @Param X-Openfort-Token-Type header string false "Openfort Token Type"
+// @Param reference path string true "Share Reference"
+// @Param renameReferenceRequest body RenameReferenceRequest true "Rename Reference Request"
+// @Success 204 "Description: Reference renamed successfully"
+// @Failure 400 {object} api.Error "Bad Request"
+// @Failure 404 {object} api.Error "Not Found"
+// @Failure 409 {object} api.Error "Conflict"
+// @Failure 500 {object} api.Error "Internal Server Error"
+// @Router /keychain/references/{reference} [put]
+func (h *Handler) RenameReference(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.InfoContext(ctx, "renaming reference")
+
+	reference := mux.Vars(r)["reference"]
+	if reference == "" {
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("missing reference"))
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to read request body"))
+		return
+	}
+
+	var req RenameReferenceRequest
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to parse request body"))
+		return
+	}
+
+	if req.Reference == "" {
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("reference is required"))
+		return
+	}
+
+	err = h.app.RenameReference(ctx, reference, req.Reference)
+	if err != nil {
+		api.RespondWithError(w, r, FromApplicationError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ReassignShare moves a share to another keychain
+// @Summary Reassign share
+// @Description Move a share of the project to another keychain of the same project, the share then belongs to that keychain's user
+// @Tags Share
+// @Accept json
+// @Param X-API-Key header string true "API Key"
+// @Param X-API-Secret header string true "API Secret"
+// @Param reference path string true "Share Reference"
+// @Param reassignShareRequest body ReassignShareRequest true "Reassign Share Request"
+// @Success 204 "Description: Share reassigned successfully"
+// @Failure 400 {object} api.Error "Bad Request"
+// @Failure 404 {object} api.Error "Not Found"
+// @Failure 500 {object} api.Error "Internal Server Error"
+// @Router /shares/reassign/{reference} [put]
+func (h *Handler) ReassignShare(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.InfoContext(ctx, "reassigning share")
+
+	reference := mux.Vars(r)["reference"]
+	if reference == "" {
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("missing reference"))
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to read request body"))
+		return
+	}
+
+	var req ReassignShareRequest
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to parse request body"))
+		return
+	}
+
+	if req.KeychainID == "" {
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("keychain_id is required"))
+		return
+	}
+
+	err = h.app.ReassignShare(ctx, reference, req.KeychainID)
+	if err != nil {
+		api.RespondWithError(w, r, FromApplicationError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,207 @@
+package sharehdl
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/api"
+	"github.com/openfort-xyz/shield/internal/applications/shareapp"
+	"github.com/openfort-xyz/shield/pkg/logger"
+)
+
+// migrationStreamDeadline is pushed forward as a migration stream makes
+// progress, the server wide timeouts would cut a large project short.
+const migrationStreamDeadline = time.Minute
+
+// migrationFlushInterval is how many lines are written between flushes.
+const migrationFlushInterval = 100
+
+// ExportShares streams every share of the project as NDJSON
+// @Summary Export shares
+// @Description Stream the project's shares ordered by ID, one JSON object per line. Resume an interrupted export by passing the ID of the last line received as `after`. Project entropy shares are re-encrypted to the transfer key when one is given along with the encryption part or session, otherwise their line carries an error instead of the secret.
+// @Tags Share Migration
+// @Produce application/x-ndjson
+// @Param X-API-Key header string true "API Key"
+// @Param X-API-Secret header string true "API Secret"
+// @Param X-Transfer-Key header string false "Base64 encoded 32 byte key to re-encrypt project entropy shares to"
+// @Param X-Encryption-Part header string false "Encryption Part"
+// @Param X-Encryption-Session header string false "Encryption Session"
+// @Param after query string false "Share ID to resume after"
+// @Success 200 {object} MigratedShare "One line per share"
+// @Failure 400 {object} api.Error "Bad Request"
+// @Failure 409 {object} api.Error "Conflict"
+// @Failure 500 {object} api.Error "Internal Server Error"
+// @Router /shares/migration/bulk/export [get]
+func (h *Handler) ExportShares(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.InfoContext(ctx, "exporting shares")
+
+	rc := http.NewResponseController(w)
+	encoder := json.NewEncoder(w)
+	written := 0
+	emit := func(m *shareapp.MigratedShare) error {
+		if written == 0 {
+			w.Header().Set("Content-Type", NDJSONContentType)
+			w.WriteHeader(http.StatusOK)
+		}
+
+		// gosec G117: share secrets are exported by design on this migration endpoint.
+		err := encoder.Encode(h.parser.fromDomainMigrated(m)) //nolint:gosec
+		if err != nil {
+			return err
+		}
+
+		written++
+		if written%migrationFlushInterval == 0 {
+			_ = rc.SetWriteDeadline(time.Now().Add(migrationStreamDeadline))
+			_ = rc.Flush()
+		}
+		return nil
+	}
+
+	err := h.app.ExportShares(ctx, r.URL.Query().Get("after"), emit, migrationOptions(r)...)
+	if err != nil {
+		if written == 0 {
+			api.RespondWithError(w, r, FromApplicationError(err))
+			return
+		}
+		// The status line is gone already, the client notices the stream
+		// ended early and resumes after the last line it got
+		h.logger.ErrorContext(ctx, "share export interrupted", logger.Error(err))
+		return
+	}
+
+	if written == 0 {
+		w.Header().Set("Content-Type", NDJSONContentType)
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// ImportShares imports shares streamed as NDJSON
+// @Summary Import shares
+// @Description Import shares from the lines of a bulk export. Every line is answered with one result line, in order, reporting whether the share was imported, skipped because it was already imported or failed. Shares keep their ID so a stream can be replayed safely. Transfer encrypted shares need the same transfer key they were exported with, along with the encryption part or session of this project.
+// @Tags Share Migration
+// @Accept application/x-ndjson
+// @Produce application/x-ndjson
+// @Param X-API-Key header string true "API Key"
+// @Param X-API-Secret header string true "API Secret"
+// @Param X-Transfer-Key header string false "Key the project entropy shares were exported with"
+// @Param X-Encryption-Part header string false "Encryption Part"
+// @Param X-Encryption-Session header string false "Encryption Session"
+// @Param body body MigratedShare true "One share per line"
+// @Success 200 {object} ImportResult "One line per imported line"
+// @Failure 400 {object} api.Error "Bad Request"
+// @Failure 409 {object} api.Error "Conflict"
+// @Failure 500 {object} api.Error "Internal Server Error"
+// @Router /shares/migration/bulk/import [post]
+func (h *Handler) ImportShares(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.InfoContext(ctx, "importing shares")
+
+	importer, err := h.app.NewShareImporter(ctx, migrationOptions(r)...)
+	if err != nil {
+		api.RespondWithError(w, r, FromApplicationError(err))
+		return
+	}
+
+	// Results are written while the body is still being read
+	rc := http.NewResponseController(w)
+	_ = rc.EnableFullDuplex()
+
+	w.Header().Set("Content-Type", NDJSONContentType)
+	w.WriteHeader(http.StatusOK)
+
+	scanner := bufio.NewScanner(r.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxMigrationLineSize)
+	encoder := json.NewEncoder(w)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		if line%migrationFlushInterval == 0 {
+			_ = rc.SetReadDeadline(time.Now().Add(migrationStreamDeadline))
+			_ = rc.SetWriteDeadline(time.Now().Add(migrationStreamDeadline))
+			_ = rc.Flush()
+		}
+
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+
+		err = encoder.Encode(h.importLine(ctx, importer, line, raw))
+		if err != nil {
+			h.logger.ErrorContext(ctx, "share import interrupted", logger.Error(err))
+			return
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		h.logger.ErrorContext(ctx, "failed to read share import", logger.Error(err))
+		_ = encoder.Encode(&ImportResult{
+			Line:   line + 1,
+			Status: ImportStatusFailed,
+			Error:  api.ErrBadRequestWithMessage("failed to read line"),
+		})
+	}
+}
+
+func (h *Handler) importLine(ctx context.Context, importer *shareapp.ShareImporter, line int, raw []byte) *ImportResult {
+	var req MigratedShare
+	err := json.Unmarshal(raw, &req)
+	if err != nil {
+		return &ImportResult{Line: line, Status: ImportStatusFailed, Error: api.ErrBadRequestWithMessage("failed to parse line")}
+	}
+
+	result := &ImportResult{Line: line, ID: req.ID, Status: ImportStatusFailed}
+	switch {
+	case req.Error != nil:
+		// The share never left the source project
+		result.Error = req.Error
+		return result
+	case req.ID == "" || req.UserID == "" || req.Secret == "":
+		result.Error = api.ErrBadRequestWithMessage("id, user_id and secret are required")
+		return result
+	case !req.ShareStorageMethodID.IsValid():
+		result.Error = api.ErrBadRequestWithMessage("invalid storage method")
+		return result
+	}
+
+	if errV := h.validator.validateMetadata(req.Metadata); errV != nil {
+		result.Error = errV
+		return result
+	}
+
+	status, err := importer.Import(ctx, h.parser.toMigratedDomain(&req))
+	if err != nil {
+		result.Error = FromApplicationError(err)
+		return result
+	}
+
+	result.Status = h.parser.fromDomainImportStatus(status)
+	return result
+}
+
+func migrationOptions(r *http.Request) []shareapp.Option {
+	var opts []shareapp.Option
+	encryptionPart := r.Header.Get(EncryptionPartHeader)
+	if encryptionPart != "" {
+		opts = append(opts, shareapp.WithEncryptionPart(encryptionPart))
+	}
+
+	encryptionSession := r.Header.Get(EncryptionSessionHeader)
+	if encryptionSession != "" {
+		opts = append(opts, shareapp.WithEncryptionSession(encryptionSession))
+	}
+
+	transferKey := r.Header.Get(TransferKeyHeader)
+	if transferKey != "" {
+		opts = append(opts, shareapp.WithTransferKey(transferKey))
+	}
+
+	return opts
+}
@@ -1,7 +1,15 @@
 package sharehdl
 
 import (
+	"encoding/base64"
+	"net/url"
+	"strconv"
+	"strings"
+
 	"github.com/google/uuid"
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/api"
+	"github.com/openfort-xyz/shield/internal/applications/shareapp"
+	"github.com/openfort-xyz/shield/internal/core/domain/keychain"
 	"github.com/openfort-xyz/shield/internal/core/domain/share"
 )
 
@@ -60,6 +68,7 @@ func (p *parser) toDomain(s *Share) *share.Share {
 		Secret:               s.Secret,
 		Entropy:              p.mapEntropyDomain[s.Entropy],
 		ShareStorageMethodID: p.mapStorageMethodDomain[s.ShareStorageMethodID],
+		Metadata:             s.Metadata,
 	}
 
 	if s.KeychainID != "" {
@@ -128,6 +137,7 @@ func (p *parser) fromDomain(s *share.Share) *Share {
 		Secret:               s.Secret,
 		Entropy:              p.mapDomainEntropy[s.Entropy],
 		ShareStorageMethodID: p.mapDomainStorageMethod[s.ShareStorageMethodID],
+		Metadata:             s.Metadata,
 	}
 
 	if s.KeychainID != nil {
@@ -170,11 +180,40 @@ func (p *parser) fromDomain(s *share.Share) *Share {
 	return shr
 }
 
+func (p *parser) fromDomainKeychainMetadata(k *keychain.Keychain, shrs []*share.Share) *KeychainMetadataResponse {
+	resp := &KeychainMetadataResponse{
+		ID:         k.ID,
+		CreatedAt:  k.CreatedAt,
+		UpdatedAt:  k.UpdatedAt,
+		References: make([]*ReferenceMetadata, 0, len(shrs)),
+	}
+
+	for _, s := range shrs {
+		ref := &ReferenceMetadata{
+			Entropy:              p.mapDomainEntropy[s.Entropy],
+			ShareStorageMethodID: p.mapDomainStorageMethod[s.ShareStorageMethodID],
+			Metadata:             s.Metadata,
+			CreatedAt:            s.CreatedAt,
+			UpdatedAt:            s.UpdatedAt,
+		}
+		if s.Reference != nil {
+			ref.Reference = *s.Reference
+		}
+		if s.PasskeyReference != nil {
+			ref.PasskeyID = s.PasskeyReference.PasskeyID
+		}
+		resp.References = append(resp.References, ref)
+	}
+
+	return resp
+}
+
 func (p *parser) fromDomainExport(s *share.Share) *ExportShareResponse {
 	resp := &ExportShareResponse{
 		Secret:               s.Secret,
 		Entropy:              p.mapDomainEntropy[s.Entropy],
 		ShareStorageMethodID: p.mapDomainStorageMethod[s.ShareStorageMethodID],
+		Metadata:             s.Metadata,
 	}
 
 	if s.Reference != nil {
@@ -211,6 +250,7 @@ func (p *parser) toImportDomain(s *ImportShareRequest) *share.Share {
 		Secret:               s.Secret,
 		Entropy:              p.mapEntropyDomain[s.Entropy],
 		ShareStorageMethodID: p.mapStorageMethodDomain[s.ShareStorageMethodID],
+		Metadata:             s.Metadata,
 	}
 
 	if s.Reference != "" {
@@ -241,9 +281,169 @@ func (p *parser) toImportDomain(s *ImportShareRequest) *share.Share {
 	return shr
 }
 
+func (p *parser) fromDomainMigrated(m *shareapp.MigratedShare) *MigratedShare {
+	s := m.Share
+	resp := &MigratedShare{
+		ID:                   s.ID,
+		UserID:               s.UserID,
+		Secret:               s.Secret,
+		Entropy:              p.mapDomainEntropy[s.Entropy],
+		ShareStorageMethodID: p.mapDomainStorageMethod[s.ShareStorageMethodID],
+		TransferEncrypted:    m.TransferEncrypted,
+		Metadata:             s.Metadata,
+	}
+
+	if s.Reference != nil {
+		resp.Reference = *s.Reference
+	}
+
+	if s.EncryptionParameters != nil {
+		resp.Salt = s.EncryptionParameters.Salt
+		resp.Iterations = s.EncryptionParameters.Iterations
+		resp.Length = s.EncryptionParameters.Length
+		resp.Digest = s.EncryptionParameters.Digest
+	}
+
+	if s.PasskeyReference != nil {
+		resp.PasskeyReference = &PasskeyReference{
+			PasskeyID: &s.PasskeyReference.PasskeyID,
+		}
+		if s.PasskeyReference.PasskeyEnv != nil {
+			resp.PasskeyReference.PasskeyEnv = &PasskeyEnv{
+				Name:      s.PasskeyReference.PasskeyEnv.Name,
+				OS:        s.PasskeyReference.PasskeyEnv.OS,
+				OSVersion: s.PasskeyReference.PasskeyEnv.OSVersion,
+				Device:    s.PasskeyReference.PasskeyEnv.Device,
+			}
+		}
+	}
+
+	if m.Err != nil {
+		resp.Error = FromApplicationError(m.Err)
+	}
+
+	return resp
+}
+
+func (p *parser) toMigratedDomain(s *MigratedShare) *shareapp.MigratedShare {
+	shr := &share.Share{
+		ID:                   s.ID,
+		UserID:               s.UserID,
+		Secret:               s.Secret,
+		Entropy:              p.mapEntropyDomain[s.Entropy],
+		ShareStorageMethodID: p.mapStorageMethodDomain[s.ShareStorageMethodID],
+		Metadata:             s.Metadata,
+	}
+
+	if s.Reference != "" {
+		shr.Reference = &s.Reference
+	}
+
+	if s.Salt != "" || s.Iterations != 0 || s.Length != 0 || s.Digest != "" {
+		shr.EncryptionParameters = &share.EncryptionParameters{
+			Salt:       s.Salt,
+			Iterations: s.Iterations,
+			Length:     s.Length,
+			Digest:     s.Digest,
+		}
+	}
+
+	if s.Entropy == EntropyPasskey && s.PasskeyReference != nil {
+		shr.PasskeyReference = &share.PasskeyReference{
+			PasskeyID: uuid.NewString(),
+		}
+		if s.PasskeyReference.PasskeyID != nil && *s.PasskeyReference.PasskeyID != "" {
+			shr.PasskeyReference.PasskeyID = *s.PasskeyReference.PasskeyID
+		}
+		if s.PasskeyReference.PasskeyEnv != nil {
+			shr.PasskeyReference.PasskeyEnv = &share.PasskeyEnv{
+				Name:      s.PasskeyReference.PasskeyEnv.Name,
+				OS:        s.PasskeyReference.PasskeyEnv.OS,
+				OSVersion: s.PasskeyReference.PasskeyEnv.OSVersion,
+				Device:    s.PasskeyReference.PasskeyEnv.Device,
+			}
+		}
+	}
+
+	return &shareapp.MigratedShare{
+		Share:             shr,
+		TransferEncrypted: s.TransferEncrypted,
+	}
+}
+
+func (p *parser) fromDomainImportStatus(status shareapp.ImportStatus) ImportStatus {
+	if status == shareapp.ImportStatusSkipped {
+		return ImportStatusSkipped
+	}
+	return ImportStatusImported
+}
+
 func (p *parser) fromDomainShareStorageMethod(s *share.StorageMethod) *ShareStorageMethod {
 	return &ShareStorageMethod{
 		ID:   s.ID,
 		Name: s.Name,
 	}
 }
+
+// toMetadataFilter reads the metadata query parameters, each a key:value entry
+// the shares listed must hold.
+func (p *parser) toMetadataFilter(entries []string) (share.Metadata, *api.Error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	filter := make(share.Metadata, len(entries))
+	for _, entry := range entries {
+		key, value, ok := strings.Cut(entry, ":")
+		if !ok || key == "" {
+			return nil, api.ErrBadRequestWithMessage("metadata filters must be key:value")
+		}
+		filter[key] = value
+	}
+
+	return filter, nil
+}
+
+// toKeychainListQuery reads the filter, cursor and page size of a keychain
+// listing.
+func (p *parser) toKeychainListQuery(query url.Values) (*share.ListFilter, string, int, *api.Error) {
+	metadata, errV := p.toMetadataFilter(query["metadata"])
+	if errV != nil {
+		return nil, "", 0, errV
+	}
+	filter := &share.ListFilter{ReferencePrefix: query.Get("reference_prefix"), Metadata: metadata}
+
+	if raw := query.Get("entropy"); raw != "" {
+		entropy, ok := p.mapEntropyDomain[Entropy(raw)]
+		if !ok {
+			return nil, "", 0, api.ErrBadRequestWithMessage("entropy must be none, user, project or passkey")
+		}
+		filter.Entropy = entropy
+	}
+
+	var after string
+	if raw := query.Get("cursor"); raw != "" {
+		id, err := base64.RawURLEncoding.DecodeString(raw)
+		if err != nil || len(id) == 0 {
+			return nil, "", 0, api.ErrBadRequestWithMessage("invalid cursor")
+		}
+		after = string(id)
+	}
+
+	var limit int
+	if raw := query.Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			return nil, "", 0, api.ErrBadRequestWithMessage("limit must be a positive integer")
+		}
+		limit = n
+	}
+
+	return filter, after, limit, nil
+}
+
+// Cursors are opaque to clients, they encode the ID of the last share of a
+// page.
+func encodeKeychainCursor(shareID string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(shareID))
+}
@@ -1,7 +1,14 @@
 package sharehdl
 
+import (
+	"time"
+
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/api"
+)
+
 const EncryptionPartHeader = "X-Encryption-Part"
 const EncryptionSessionHeader = "X-Encryption-Session"
+const TransferKeyHeader = "X-Transfer-Key"
 
 type Share struct {
 	Secret               string               `json:"secret"`
@@ -16,6 +23,7 @@ type Share struct {
 	ShareStorageMethodID ShareStorageMethodID `json:"storage_method_id,omitempty"`
 	PasskeyReference     *PasskeyReference    `json:"passkey_reference,omitempty"`
 	KeychainID           string               `json:"keychain_id,omitempty"`
+	Metadata             map[string]string    `json:"metadata,omitempty"`
 }
 
 type RegisterShareRequest Share
@@ -66,6 +74,7 @@ type ExportShareResponse struct {
 	Reference            string               `json:"reference,omitempty"`
 	ShareStorageMethodID ShareStorageMethodID `json:"storage_method_id"`
 	PasskeyReference     *PasskeyReference    `json:"passkey_reference,omitempty"`
+	Metadata             map[string]string    `json:"metadata,omitempty"`
 }
 
 type ImportShareRequest struct {
@@ -79,6 +88,43 @@ type ImportShareRequest struct {
 	Reference            string               `json:"reference,omitempty"`
 	ShareStorageMethodID ShareStorageMethodID `json:"storage_method_id"`
 	PasskeyReference     *PasskeyEnv          `json:"passkey_reference,omitempty"`
+	Metadata             map[string]string    `json:"metadata,omitempty"`
+}
+
+// MigratedShare is one line of a bulk export, and of the bulk import that
+// takes it back in.
+type MigratedShare struct {
+	ID                   string               `json:"id"`
+	UserID               string               `json:"user_id"`
+	Secret               string               `json:"secret,omitempty"`
+	Entropy              Entropy              `json:"entropy"`
+	Salt                 string               `json:"salt,omitempty"`
+	Iterations           int                  `json:"iterations,omitempty"`
+	Length               int                  `json:"length,omitempty"`
+	Digest               string               `json:"digest,omitempty"`
+	Reference            string               `json:"reference,omitempty"`
+	ShareStorageMethodID ShareStorageMethodID `json:"storage_method_id"`
+	PasskeyReference     *PasskeyReference    `json:"passkey_reference,omitempty"`
+	Metadata             map[string]string    `json:"metadata,omitempty"`
+	TransferEncrypted    bool                 `json:"transfer_encrypted,omitempty"`
+	Error                *api.Error           `json:"error,omitempty"`
+}
+
+type ImportStatus string
+
+const (
+	ImportStatusImported ImportStatus = "imported"
+	ImportStatusSkipped  ImportStatus = "skipped"
+	ImportStatusFailed   ImportStatus = "failed"
+)
+
+// ImportResult reports the outcome of one line of a bulk import, Line counts
+// from 1 and includes blank lines.
+type ImportResult struct {
+	Line   int          `json:"line"`
+	ID     string       `json:"id,omitempty"`
+	Status ImportStatus `json:"status"`
+	Error  *api.Error   `json:"error,omitempty"`
 }
 
 type GetShareEncryptionResponse struct {
@@ -90,7 +136,48 @@ type GetShareEncryptionResponse struct {
 }
 
 type KeychainResponse struct {
-	Shares []*Share `json:"shares"`
+	Shares []*KeychainShare `json:"shares"`
+	// NextCursor is omitted on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// Fields of the keychain shares to return, metadata leaves their secrets
+// empty.
+const (
+	KeychainFieldsAll      = "all"
+	KeychainFieldsMetadata = "metadata"
+)
+
+// KeychainShare is a share of the keychain with its entity tag, to update it
+// with If-Match.
+type KeychainShare struct {
+	*Share
+	ETag string `json:"etag"`
+}
+
+type KeychainMetadataResponse struct {
+	ID         string               `json:"id"`
+	CreatedAt  time.Time            `json:"created_at"`
+	UpdatedAt  time.Time            `json:"updated_at"`
+	References []*ReferenceMetadata `json:"references"`
+}
+
+type ReferenceMetadata struct {
+	Reference            string               `json:"reference"`
+	Entropy              Entropy              `json:"entropy"`
+	ShareStorageMethodID ShareStorageMethodID `json:"storage_method_id"`
+	PasskeyID            string               `json:"passkey_id,omitempty"`
+	Metadata             map[string]string    `json:"metadata,omitempty"`
+	CreatedAt            time.Time            `json:"created_at"`
+	UpdatedAt            time.Time            `json:"updated_at"`
+}
+
+type RenameReferenceRequest struct {
+	Reference string `json:"reference"`
+}
+
+type ReassignShareRequest struct {
+	KeychainID string `json:"keychain_id"`
 }
 
 type ShareStorageMethod struct {
@@ -118,6 +205,7 @@ type EncryptionTypeResponse struct {
 	EncryptionType *Entropy             `json:"encryption_type,omitempty"`
 	PasskeyID      *string              `json:"passkey_id,omitempty"`
 	PasskeyEnv     *PasskeyEnv          `json:"passkey_env,omitempty"`
+	Metadata       map[string]string    `json:"metadata,omitempty"`
 }
 
 type GetSharesEncryptionForReferencesResponse struct {
@@ -1,6 +1,11 @@
 package sharehdl
 
-import "github.com/openfort-xyz/shield/internal/adapters/handlers/rest/api"
+import (
+	"fmt"
+
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/api"
+	"github.com/openfort-xyz/shield/internal/core/domain/share"
+)
 
 type validator struct {
 }
@@ -9,78 +14,129 @@ func newValidator() *validator {
 	return &validator{}
 }
 
-func shareHasUserEntropyParams(share *Share) bool {
-	return share.Salt != "" || share.Iterations != 0 || share.Length != 0 || share.Digest != ""
+// userEntropyParamErrors reports the user entropy parameters set on a share
+// with another entropy.
+func userEntropyParamErrors(share *Share) []api.FieldError {
+	var fields []api.FieldError
+	if share.Salt != "" {
+		fields = append(fields, notAllowed("salt"))
+	}
+	if share.Iterations != 0 {
+		fields = append(fields, notAllowed("iterations"))
+	}
+	if share.Length != 0 {
+		fields = append(fields, notAllowed("length"))
+	}
+	if share.Digest != "" {
+		fields = append(fields, notAllowed("digest"))
+	}
+	return fields
+}
+
+func notAllowed(field string) api.FieldError {
+	return api.FieldError{Field: field, Code: api.FieldNotAllowed, Message: "if user entropy is not set, encryption parameters should not be set"}
+}
+
+func (v *validator) validateMetadata(metadata map[string]string) *api.Error {
+	if fields := v.metadataErrors(metadata); len(fields) > 0 {
+		return api.ErrValidation(fields...)
+	}
+	return nil
+}
+
+func (v *validator) metadataErrors(metadata map[string]string) []api.FieldError {
+	var fields []api.FieldError
+	if len(metadata) > share.MaxMetadataEntries {
+		fields = append(fields, api.FieldError{Field: "metadata", Code: api.FieldTooLarge, Message: fmt.Sprintf("metadata can't hold more than %d entries", share.MaxMetadataEntries)})
+	}
+
+	var invalidKey, largeValue bool
+	for k, val := range metadata {
+		invalidKey = invalidKey || k == "" || len(k) > share.MaxMetadataKeyLength
+		largeValue = largeValue || len(val) > share.MaxMetadataValueLength
+	}
+	if invalidKey {
+		fields = append(fields, api.FieldError{Field: "metadata", Code: api.FieldInvalid, Message: fmt.Sprintf("metadata keys must be 1 to %d bytes long", share.MaxMetadataKeyLength)})
+	}
+	if largeValue {
+		fields = append(fields, api.FieldError{Field: "metadata", Code: api.FieldTooLarge, Message: fmt.Sprintf("metadata values can't be longer than %d bytes", share.MaxMetadataValueLength)})
+	}
+
+	return fields
 }
 
+// validateShare reports every invalid field of the share at once.
 func (v *validator) validateShare(share *Share) *api.Error {
+	var fields []api.FieldError
 	if share.Secret == "" {
-		return api.ErrBadRequestWithMessage("secret is required")
+		fields = append(fields, api.FieldError{Field: "secret", Code: api.FieldRequired, Message: "secret is required"})
 	}
 
+	fields = append(fields, v.metadataErrors(share.Metadata)...)
+
 	if !share.ShareStorageMethodID.IsValid() {
-		return api.ErrBadRequestWithMessage("invalid storage method")
+		fields = append(fields, api.FieldError{Field: "storage_method_id", Code: api.FieldInvalid, Message: "invalid storage method"})
 	}
 
 	switch share.Entropy {
 	case "", EntropyNone:
-		return api.ErrBadRequestWithMessage("require share entropy to be set")
+		fields = append(fields, api.FieldError{Field: "entropy", Code: api.FieldRequired, Message: "require share entropy to be set"})
 	case EntropyUser:
 		if share.Salt == "" {
-			return api.ErrBadRequestWithMessage("salt is required when entropy is user")
+			fields = append(fields, api.FieldError{Field: "salt", Code: api.FieldRequired, Message: "salt is required when entropy is user"})
 		}
 		if share.Iterations == 0 {
-			return api.ErrBadRequestWithMessage("iterations is required when entropy is user")
+			fields = append(fields, api.FieldError{Field: "iterations", Code: api.FieldRequired, Message: "iterations is required when entropy is user"})
 		}
 		if share.Length == 0 {
-			return api.ErrBadRequestWithMessage("length is required when entropy is user")
+			fields = append(fields, api.FieldError{Field: "length", Code: api.FieldRequired, Message: "length is required when entropy is user"})
 		}
 		if share.Digest == "" {
-			return api.ErrBadRequestWithMessage("digest is required when entropy is user")
+			fields = append(fields, api.FieldError{Field: "digest", Code: api.FieldRequired, Message: "digest is required when entropy is user"})
 		}
 	case EntropyProject:
 		if share.ShareStorageMethodID != StorageMethodShield {
-			return api.ErrBadRequestWithMessage("storage_method must be Shield if entropy is project")
+			fields = append(fields, api.FieldError{Field: "storage_method_id", Code: api.FieldInvalid, Message: "storage_method must be Shield if entropy is project"})
 		}
 
-		if shareHasUserEntropyParams(share) {
-			return api.ErrBadRequestWithMessage("if user entropy is not set, encryption parameters should not be set")
-		}
+		fields = append(fields, userEntropyParamErrors(share)...)
 
 		if share.EncryptionPart == "" && share.EncryptionSession == "" {
-			return api.ErrBadRequestWithMessage("encryption_part or encryption_session is required when entropy is project")
+			fields = append(fields, api.FieldError{Field: "encryption_part", Code: api.FieldRequired, Message: "encryption_part or encryption_session is required when entropy is project"})
 		}
 	case EntropyPasskey:
 		if share.ShareStorageMethodID != StorageMethodShield {
-			return api.ErrBadRequestWithMessage("storage_method must be Shield if entropy is passkey")
+			fields = append(fields, api.FieldError{Field: "storage_method_id", Code: api.FieldInvalid, Message: "storage_method must be Shield if entropy is passkey"})
 		}
 
 		if share.Reference == "" || share.Reference == "default" {
-			return api.ErrBadRequestWithMessage("share needs a valid share reference if entropy is passkey")
+			fields = append(fields, api.FieldError{Field: "reference", Code: api.FieldInvalid, Message: "share needs a valid share reference if entropy is passkey"})
 		}
 
 		if share.PasskeyReference == nil {
-			return api.ErrBadRequestWithMessage("passkey_reference must be set if entropy is passkey")
-		}
-
-		if share.PasskeyReference.PasskeyID == nil {
-			return api.ErrBadRequestWithMessage("passkey_reference must contain passkey_id if entropy is passkey")
+			fields = append(fields, api.FieldError{Field: "passkey_reference", Code: api.FieldRequired, Message: "passkey_reference must be set if entropy is passkey"})
+		} else if share.PasskeyReference.PasskeyID == nil {
+			fields = append(fields, api.FieldError{Field: "passkey_reference.passkey_id", Code: api.FieldRequired, Message: "passkey_reference must contain passkey_id if entropy is passkey"})
 		}
 
 		// User entroy parameters should not be set for passkey entropy
-		if shareHasUserEntropyParams(share) {
-			return api.ErrBadRequestWithMessage("if user entropy is not set, encryption parameters should not be set")
-		}
+		fields = append(fields, userEntropyParamErrors(share)...)
 
 		// Encryption part/encryption session belong to project entropy use case
 		// Here we're only storing an encrypted share and hinting how it's encrypted, like we're doing for user entropy
 		// Except we rely on proper user authentication for correct passkey retrieval and decryption
-		if share.EncryptionPart != "" || share.EncryptionSession != "" {
-			return api.ErrBadRequestWithMessage("encryption parameters should not be set for passkey entropy")
+		if share.EncryptionPart != "" {
+			fields = append(fields, api.FieldError{Field: "encryption_part", Code: api.FieldNotAllowed, Message: "encryption parameters should not be set for passkey entropy"})
+		}
+		if share.EncryptionSession != "" {
+			fields = append(fields, api.FieldError{Field: "encryption_session", Code: api.FieldNotAllowed, Message: "encryption parameters should not be set for passkey entropy"})
 		}
 	default:
-		return api.ErrBadRequestWithMessage("invalid entropy")
+		fields = append(fields, api.FieldError{Field: "entropy", Code: api.FieldInvalid, Message: "invalid entropy"})
 	}
 
+	if len(fields) > 0 {
+		return api.ErrValidation(fields...)
+	}
 	return nil
 }
@@ -0,0 +1,13 @@
+package rest
+
+import (
+	"crypto/tls"
+
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/servertls"
+)
+
+// tlsConfig returns nil when the server should keep serving plain HTTP, see
+// servertls.New.
+func (c *Config) tlsConfig() (*tls.Config, error) {
+	return servertls.New(c.TLSCertFile, c.TLSKeyFile, c.TLSClientCAFile, c.TLSRequireClientCert)
+}
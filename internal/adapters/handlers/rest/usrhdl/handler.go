@@ -39,23 +39,23 @@ func (h *Handler) CreateUser(w http.ResponseWriter, r *http.Request) {
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage("failed to read request body"))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to read request body"))
 		return
 	}
 
 	var req CreateUserRequest
 	if err := json.Unmarshal(body, &req); err != nil {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage("failed to parse request body"))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("failed to parse request body"))
 		return
 	}
 
 	if req.ExternalUserID == "" {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage("external_user_id is required"))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("external_user_id is required"))
 		return
 	}
 
 	if req.ProviderID == "" {
-		api.RespondWithError(w, api.ErrBadRequestWithMessage("provider_id is required"))
+		api.RespondWithError(w, r, api.ErrBadRequestWithMessage("provider_id is required"))
 		return
 	}
 
@@ -64,13 +64,13 @@ func (h *Handler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	usr, err := h.userService.GetOrCreate(ctx, projectID, req.ExternalUserID, req.ProviderID)
 	if err != nil {
 		h.logger.ErrorContext(ctx, "failed to create user", slog.String("error", err.Error()))
-		api.RespondWithError(w, api.ErrInternal)
+		api.RespondWithError(w, r, api.ErrInternal)
 		return
 	}
 
 	resp, err := json.Marshal(CreateUserResponse{UserID: usr.ID})
 	if err != nil {
-		api.RespondWithError(w, api.ErrInternal)
+		api.RespondWithError(w, r, api.ErrInternal)
 		return
 	}
 
@@ -0,0 +1,65 @@
+package servertls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+var (
+	ErrIncompleteTLSConfig = errors.New("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	ErrInvalidClientCA     = errors.New("no certificates found in TLS_CLIENT_CA_FILE")
+)
+
+// New builds the TLS configuration shared by the REST and gRPC servers, it
+// returns nil when they should keep serving plain text, as they do behind a
+// TLS terminating load balancer.
+//
+// Client certificates are always requested. Without a client CA file they
+// aren't chain-verified during the handshake (Go still checks the client owns
+// the private key), it's left to the project authenticator to match them
+// against the fingerprints each project registered.
+func New(certFile, keyFile, clientCAFile string, requireClientCert bool) (*tls.Config, error) {
+	if certFile == "" && keyFile == "" {
+		return nil, nil
+	}
+	if certFile == "" || keyFile == "" {
+		return nil, ErrIncompleteTLSConfig
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %w", err)
+	}
+
+	cfg := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequestClientCert,
+	}
+	if requireClientCert {
+		cfg.ClientAuth = tls.RequireAnyClientCert
+	}
+
+	if clientCAFile != "" {
+		raw, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(raw) {
+			return nil, ErrInvalidClientCA
+		}
+
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.VerifyClientCertIfGiven
+		if requireClientCert {
+			cfg.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,53 @@
+package noncerepo
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/bunt"
+	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
+	"github.com/openfort-xyz/shield/internal/core/ports/repositories"
+	"github.com/openfort-xyz/shield/pkg/logger"
+	"github.com/tidwall/buntdb"
+)
+
+const keyPrefix = "nonce:"
+
+type repository struct {
+	db     *bunt.Client
+	logger *slog.Logger
+}
+
+var _ repositories.NonceRepository = &repository{}
+
+func New(db *bunt.Client) repositories.NonceRepository {
+	return &repository{
+		db:     db,
+		logger: logger.New("nonce_repository"),
+	}
+}
+
+func (r *repository) Claim(ctx context.Context, key string, ttl time.Duration) error {
+	// The lookup and the write share one transaction so two requests racing
+	// with the same nonce can't both claim it
+	return r.db.Update(func(tx *buntdb.Tx) error {
+		_, err := tx.Get(keyPrefix + key)
+		if err == nil {
+			return domainErrors.ErrNonceAlreadyUsed
+		}
+		if !errors.Is(err, buntdb.ErrNotFound) {
+			r.logger.ErrorContext(ctx, "error reading nonce", logger.Error(err))
+			return err
+		}
+
+		_, _, err = tx.Set(keyPrefix+key, "", &buntdb.SetOptions{Expires: true, TTL: ttl})
+		if err != nil {
+			r.logger.ErrorContext(ctx, "error claiming nonce", logger.Error(err))
+			return err
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,64 @@
+package archivemockrepo
+
+import (
+	"context"
+
+	"github.com/openfort-xyz/shield/internal/core/domain/project"
+	"github.com/openfort-xyz/shield/internal/core/domain/user"
+	"github.com/openfort-xyz/shield/internal/core/ports/repositories"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockArchiveRepository struct {
+	mock.Mock
+}
+
+var _ repositories.ArchiveRepository = (*MockArchiveRepository)(nil)
+
+func (m *MockArchiveRepository) ExportProject(ctx context.Context, projectID string) (*project.Archive, error) {
+	args := m.Mock.Called(ctx, projectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*project.Archive), args.Error(1)
+}
+
+func (m *MockArchiveRepository) DeleteProject(ctx context.Context, projectID string, mode project.DeletionMode, archiveDigest string) (*project.DeletionReceipt, error) {
+	args := m.Mock.Called(ctx, projectID, mode, archiveDigest)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*project.DeletionReceipt), args.Error(1)
+}
+
+func (m *MockArchiveRepository) ExportUser(ctx context.Context, projectID, externalUserID string) (*user.Export, error) {
+	args := m.Mock.Called(ctx, projectID, externalUserID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.Export), args.Error(1)
+}
+
+func (m *MockArchiveRepository) EraseUser(ctx context.Context, projectID, externalUserID string) (*user.ErasureReceipt, error) {
+	args := m.Mock.Called(ctx, projectID, externalUserID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.ErasureReceipt), args.Error(1)
+}
+
+func (m *MockArchiveRepository) ExportProjectForTransfer(ctx context.Context, projectID string) (*project.Archive, error) {
+	args := m.Mock.Called(ctx, projectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*project.Archive), args.Error(1)
+}
+
+func (m *MockArchiveRepository) ImportProject(ctx context.Context, archive *project.Archive, dryRun bool) (*project.ImportReport, error) {
+	args := m.Mock.Called(ctx, archive, dryRun)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*project.ImportReport), args.Error(1)
+}
@@ -0,0 +1,29 @@
+package backupmockrepo
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/openfort-xyz/shield/internal/core/domain/backup"
+	"github.com/openfort-xyz/shield/internal/core/ports/repositories"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBackupRepository struct {
+	mock.Mock
+}
+
+var _ repositories.BackupRepository = (*MockBackupRepository)(nil)
+
+func (m *MockBackupRepository) Snapshot(ctx context.Context, begin func(*backup.Snapshot) error, emit func(string, json.RawMessage) error) error {
+	args := m.Mock.Called(ctx, begin, emit)
+	return args.Error(0)
+}
+
+func (m *MockBackupRepository) Restore(ctx context.Context, snapshot *backup.Snapshot, next func() (string, json.RawMessage, error)) (map[string]int64, error) {
+	args := m.Mock.Called(ctx, snapshot, next)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int64), args.Error(1)
+}
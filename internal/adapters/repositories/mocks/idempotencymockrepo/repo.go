@@ -0,0 +1,38 @@
+package idempotencymockrepo
+
+import (
+	"context"
+
+	"github.com/openfort-xyz/shield/internal/core/domain/idempotency"
+	"github.com/openfort-xyz/shield/internal/core/ports/repositories"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockIdempotencyRepository struct {
+	mock.Mock
+}
+
+var _ repositories.IdempotencyRepository = (*MockIdempotencyRepository)(nil)
+
+func (m *MockIdempotencyRepository) Claim(ctx context.Context, rec *idempotency.Record) (*idempotency.Record, error) {
+	args := m.Mock.Called(ctx, rec)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*idempotency.Record), args.Error(1)
+}
+
+func (m *MockIdempotencyRepository) Complete(ctx context.Context, rec *idempotency.Record) error {
+	args := m.Mock.Called(ctx, rec)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepository) Release(ctx context.Context, keyHash string) error {
+	args := m.Mock.Called(ctx, keyHash)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepository) DeleteExpired(ctx context.Context) error {
+	args := m.Mock.Called(ctx)
+	return args.Error(0)
+}
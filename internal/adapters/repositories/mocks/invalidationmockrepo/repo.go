@@ -0,0 +1,23 @@
+package invalidationmockrepo
+
+import (
+	"context"
+
+	"github.com/openfort-xyz/shield/internal/core/ports/repositories"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockInvalidationRepository struct {
+	mock.Mock
+}
+
+var _ repositories.InvalidationRepository = (*MockInvalidationRepository)(nil)
+
+func (m *MockInvalidationRepository) Publish(ctx context.Context, channel, key string) error {
+	args := m.Mock.Called(ctx, channel, key)
+	return args.Error(0)
+}
+
+func (m *MockInvalidationRepository) Subscribe(channel string, fn func(key string)) {
+	m.Mock.Called(channel, fn)
+}
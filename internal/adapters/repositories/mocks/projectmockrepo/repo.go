@@ -50,6 +50,62 @@ func (m *MockProjectRepository) Update2FA(ctx context.Context, projectID string,
 	return args.Error(0)
 }
 
+func (m *MockProjectRepository) UpdateStatus(ctx context.Context, projectID string, status project.Status) error {
+	args := m.Mock.Called(ctx, projectID, status)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) UpdateClientCertMode(ctx context.Context, projectID string, mode project.ClientCertMode) error {
+	args := m.Mock.Called(ctx, projectID, mode)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) UpdateRequireSignedRequests(ctx context.Context, projectID string, required bool) error {
+	args := m.Mock.Called(ctx, projectID, required)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) SetSigningKey(ctx context.Context, projectID, signingKey string) error {
+	args := m.Mock.Called(ctx, projectID, signingKey)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) GetSigningKey(ctx context.Context, projectID string) (string, error) {
+	args := m.Mock.Called(ctx, projectID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockProjectRepository) SaveDeletionRequest(ctx context.Context, req *project.DeletionRequest) error {
+	args := m.Mock.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) GetDeletionRequest(ctx context.Context, projectID string) (*project.DeletionRequest, error) {
+	args := m.Mock.Called(ctx, projectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*project.DeletionRequest), args.Error(1)
+}
+
+func (m *MockProjectRepository) ListClientCertificates(ctx context.Context, projectID string) ([]*project.ClientCertificate, error) {
+	args := m.Mock.Called(ctx, projectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*project.ClientCertificate), args.Error(1)
+}
+
+func (m *MockProjectRepository) AddClientCertificate(ctx context.Context, cert *project.ClientCertificate) error {
+	args := m.Mock.Called(ctx, cert)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) DeleteClientCertificate(ctx context.Context, projectID, certificateID string) error {
+	args := m.Mock.Called(ctx, projectID, certificateID)
+	return args.Error(0)
+}
+
 func (m *MockProjectRepository) GetByAPIKey(ctx context.Context, apiKey string) (*project.Project, error) {
 	args := m.Mock.Called(ctx, apiKey)
 	if args.Get(0) == nil {
@@ -63,6 +119,34 @@ func (m *MockProjectRepository) Delete(ctx context.Context, projectID string) er
 	return args.Error(0)
 }
 
+func (m *MockProjectRepository) List(ctx context.Context) ([]*project.Project, error) {
+	args := m.Mock.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*project.Project), args.Error(1)
+}
+
+func (m *MockProjectRepository) Disable(ctx context.Context, projectID string) error {
+	args := m.Mock.Called(ctx, projectID)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) CreateInvite(ctx context.Context, invite *project.Invite) error {
+	args := m.Mock.Called(ctx, invite)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) ConsumeInvite(ctx context.Context, tokenHash string) error {
+	args := m.Mock.Called(ctx, tokenHash)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) ReleaseInvite(ctx context.Context, tokenHash string) error {
+	args := m.Mock.Called(ctx, tokenHash)
+	return args.Error(0)
+}
+
 func (m *MockProjectRepository) GetEncryptionPart(ctx context.Context, projectID string) (string, error) {
 	args := m.Mock.Called(ctx, projectID)
 	if args.Get(0) == nil {
@@ -66,6 +66,16 @@ func (m *MockProviderRepository) UpdateCustom(ctx context.Context, prov *provide
 	return args.Error(0)
 }
 
+func (m *MockProviderRepository) AddCustomKey(ctx context.Context, key *provider.PEMKey) error {
+	args := m.Mock.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockProviderRepository) DeleteCustomKey(ctx context.Context, providerID, keyID string) error {
+	args := m.Mock.Called(ctx, providerID, keyID)
+	return args.Error(0)
+}
+
 func (m *MockProviderRepository) CreateOpenfort(ctx context.Context, prov *provider.OpenfortConfig) error {
 	args := m.Mock.Called(ctx, prov)
 	return args.Error(0)
@@ -83,3 +93,21 @@ func (m *MockProviderRepository) UpdateOpenfort(ctx context.Context, prov *provi
 	args := m.Mock.Called(ctx, prov)
 	return args.Error(0)
 }
+
+func (m *MockProviderRepository) CreateIntrospection(ctx context.Context, prov *provider.IntrospectionConfig) error {
+	args := m.Mock.Called(ctx, prov)
+	return args.Error(0)
+}
+
+func (m *MockProviderRepository) GetIntrospection(ctx context.Context, providerID string) (*provider.IntrospectionConfig, error) {
+	args := m.Mock.Called(ctx, providerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*provider.IntrospectionConfig), args.Error(1)
+}
+
+func (m *MockProviderRepository) UpdateIntrospection(ctx context.Context, prov *provider.IntrospectionConfig) error {
+	args := m.Mock.Called(ctx, prov)
+	return args.Error(0)
+}
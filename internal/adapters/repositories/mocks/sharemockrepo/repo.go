@@ -80,6 +80,32 @@ func (m *MockShareRepository) ListProjectIDAndEntropy(ctx context.Context, proje
 	return args.Get(0).([]*share.Share), args.Error(1)
 }
 
+func (m *MockShareRepository) ListByProject(ctx context.Context, projectID string, afterID string, limit int) ([]*share.Share, error) {
+	args := m.Mock.Called(ctx, projectID, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*share.Share), args.Error(1)
+}
+
+func (m *MockShareRepository) ListPageByKeychainID(ctx context.Context, keychainID string, filter *share.ListFilter, afterID string, limit int) ([]*share.Share, error) {
+	args := m.Mock.Called(ctx, keychainID, filter, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*share.Share), args.Error(1)
+}
+
+func (m *MockShareRepository) UpdateReference(ctx context.Context, keychainID, reference, newReference string) error {
+	args := m.Mock.Called(ctx, keychainID, reference, newReference)
+	return args.Error(0)
+}
+
+func (m *MockShareRepository) UpdateKeychain(ctx context.Context, shareID, keychainID, userID string) error {
+	args := m.Mock.Called(ctx, shareID, keychainID, userID)
+	return args.Error(0)
+}
+
 func (m *MockShareRepository) UpdateProjectEncryption(ctx context.Context, shareID string, encrypted string) error {
 	args := m.Mock.Called(ctx, shareID, encrypted)
 	return args.Error(0)
@@ -69,3 +69,11 @@ func (m *MockUserRepository) GetUserIDsByExternalID(ctx context.Context, externa
 	}
 	return args.Get(0).([]string), args.Error(1)
 }
+
+func (m *MockUserRepository) ListByProject(ctx context.Context, projectID string, filter *user.ListFilter, after *user.Cursor, limit int) ([]*user.User, error) {
+	args := m.Mock.Called(ctx, projectID, filter, after, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*user.User), args.Error(1)
+}
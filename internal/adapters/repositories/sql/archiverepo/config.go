@@ -0,0 +1,17 @@
+package archiverepo
+
+import env "github.com/caarlos0/env/v10"
+
+// Config holds the keys of this deployment that the project and provider
+// repositories encrypt secret columns with, transfers decrypt and encrypt
+// those columns again.
+type Config struct {
+	ProjectSecretEncryptionKey  string `env:"PROJECT_SECRET_ENCRYPTION_KEY"`
+	ProviderSecretEncryptionKey string `env:"PROVIDER_SECRET_ENCRYPTION_KEY"`
+}
+
+func GetConfigFromEnv() (*Config, error) {
+	cfg := &Config{}
+	err := env.Parse(cfg)
+	return cfg, err
+}
@@ -0,0 +1,276 @@
+package archiverepo
+
+import (
+	"context"
+	dbsql "database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/sql"
+	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
+	"github.com/openfort-xyz/shield/internal/core/domain/project"
+	"github.com/openfort-xyz/shield/internal/core/ports/repositories"
+	"github.com/openfort-xyz/shield/pkg/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type repository struct {
+	db     *sql.Client
+	cfg    *Config
+	logger *slog.Logger
+}
+
+var _ repositories.ArchiveRepository = (*repository)(nil)
+
+func New(db *sql.Client, cfg *Config) repositories.ArchiveRepository {
+	return &repository{
+		db:     db,
+		cfg:    cfg,
+		logger: logger.New("archive_repository"),
+	}
+}
+
+func (r *repository) ExportProject(ctx context.Context, projectID string) (*project.Archive, error) {
+	r.logger.InfoContext(ctx, "exporting project", slog.String("project_id", projectID))
+
+	archive, err := r.export(r.db.DB, projectID)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error exporting project", logger.Error(err))
+		return nil, err
+	}
+
+	return archive, nil
+}
+
+func (r *repository) export(tx *gorm.DB, projectID string) (*project.Archive, error) {
+	tables, err := exportTables(tx, projectTables, dbsql.Named("project", projectID))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tables["shld_projects"]) == 0 {
+		return nil, domainErrors.ErrProjectNotFound
+	}
+
+	return &project.Archive{
+		ProjectID:  projectID,
+		ExportedAt: time.Now().UTC(),
+		Tables:     tables,
+	}, nil
+}
+
+func exportTables(tx *gorm.DB, tables []table, args ...any) (map[string][]map[string]any, error) {
+	return queryTables(tx, tables, func(t table, row map[string]any) error {
+		for _, column := range t.redact {
+			delete(row, column)
+		}
+		return nil
+	}, args...)
+}
+
+// queryTables reads the scoped rows of every table, handing each row to
+// prepare before it's added.
+func queryTables(tx *gorm.DB, tables []table, prepare func(table, map[string]any) error, args ...any) (map[string][]map[string]any, error) {
+	exported := make(map[string][]map[string]any, len(tables))
+	for _, t := range tables {
+		rows := make([]map[string]any, 0)
+		err := tx.Table(t.name).Where(t.scope, args...).Order(t.order).Find(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			err = prepare(t, row)
+			if err != nil {
+				return nil, err
+			}
+		}
+		exported[t.name] = rows
+	}
+
+	return exported, nil
+}
+
+func (r *repository) DeleteProject(ctx context.Context, projectID string, mode project.DeletionMode, archiveDigest string) (*project.DeletionReceipt, error) {
+	r.logger.InfoContext(ctx, "deleting project", slog.String("project_id", projectID), slog.String("mode", string(mode)))
+
+	var receipt *project.DeletionReceipt
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var name string
+		err := tx.Table("shld_projects").Select("name").
+			Where("id = ? AND deleted_at IS NULL", projectID).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Take(&name).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domainErrors.ErrProjectNotFound
+			}
+			return err
+		}
+
+		// With the project row locked nothing can be registered under it
+		// anymore, so this is the data the deletion is about to remove
+		archive, err := r.export(tx, projectID)
+		if err != nil {
+			return err
+		}
+		digest, err := archive.Digest()
+		if err != nil {
+			return err
+		}
+		if digest != archiveDigest {
+			return domainErrors.ErrProjectChangedSinceExport
+		}
+
+		var deleted map[string]int64
+		switch mode {
+		case project.DeletionModeCryptoShred:
+			deleted, err = cryptoShred(tx, projectID)
+		default:
+			deleted, err = hardDelete(tx, projectID)
+		}
+		if err != nil {
+			return err
+		}
+
+		receipt = &project.DeletionReceipt{
+			ID:            uuid.NewString(),
+			ProjectID:     projectID,
+			ProjectName:   name,
+			Mode:          mode,
+			ArchiveDigest: archiveDigest,
+			DeletedRows:   deleted,
+			DeletedAt:     time.Now().UTC(),
+		}
+
+		return recordDeletionReceipt(tx, receipt)
+	})
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error deleting project", logger.Error(err))
+		return nil, err
+	}
+
+	return receipt, nil
+}
+
+type deletion struct {
+	table string
+	scope string
+	// soft sets deleted_at instead of removing the rows
+	soft bool
+}
+
+// Contacts go first and shares before their keychains and users, the scopes
+// of later steps look the project's rows up through the earlier ones.
+var hardDeletions = []deletion{
+	{table: "shld_user_contacts", scope: exclusiveContacts},
+	{table: "shld_passkey_references", scope: "share_reference IN (" + projectShares + ")"},
+	{table: "shld_shares", scope: "id IN (" + projectShares + ")"},
+	{table: "shld_keychains", scope: "user_id IN (" + projectUsers + ")"},
+	{table: "shld_external_users", scope: "user_id IN (" + projectUsers + ")"},
+	{table: "shld_users", scope: "project_id = @project"},
+	{table: "shld_custom_provider_keys", scope: "provider_id IN (" + projectProviders + ")"},
+	{table: "shld_openfort_providers", scope: "provider_id IN (" + projectProviders + ")"},
+	{table: "shld_custom_providers", scope: "provider_id IN (" + projectProviders + ")"},
+	{table: "shld_introspection_providers", scope: "provider_id IN (" + projectProviders + ")"},
+	{table: "shld_providers", scope: "project_id = @project"},
+	{table: "shld_notifications", scope: "project_id = @project"},
+	{table: "shld_encryption_parts", scope: "project_id = @project"},
+	{table: "shld_project_client_certificates", scope: "project_id = @project"},
+	{table: "shld_rate_limit", scope: "project_id = @project"},
+	{table: "shld_shamir_migrations", scope: "project_id = @project"},
+	{table: "shld_project_deletion_requests", scope: "project_id = @project"},
+	{table: "shld_projects", scope: "id = @project"},
+}
+
+// cryptoShredDeletions keeps the project entropy shares, now undecryptable,
+// along with the keychains, users and project they belong to.
+var cryptoShredDeletions = []deletion{
+	{table: "shld_user_contacts", scope: exclusiveContacts},
+	{table: "shld_passkey_references", scope: "share_reference IN (SELECT id FROM shld_shares WHERE " + unprotectedShares + ")"},
+	{table: "shld_shares", scope: unprotectedShares},
+	{table: "shld_shares", scope: "id IN (" + projectShares + ") AND deleted_at IS NULL", soft: true},
+	{table: "shld_keychains", scope: "user_id IN (" + projectUsers + ") AND deleted_at IS NULL", soft: true},
+	{table: "shld_external_users", scope: "user_id IN (" + projectUsers + ")"},
+	{table: "shld_users", scope: "project_id = @project AND deleted_at IS NULL", soft: true},
+	{table: "shld_custom_provider_keys", scope: "provider_id IN (" + projectProviders + ")"},
+	{table: "shld_openfort_providers", scope: "provider_id IN (" + projectProviders + ")"},
+	{table: "shld_custom_providers", scope: "provider_id IN (" + projectProviders + ")"},
+	{table: "shld_introspection_providers", scope: "provider_id IN (" + projectProviders + ")"},
+	{table: "shld_providers", scope: "project_id = @project"},
+	{table: "shld_notifications", scope: "project_id = @project"},
+	{table: "shld_encryption_parts", scope: "project_id = @project"},
+	{table: "shld_project_client_certificates", scope: "project_id = @project"},
+	{table: "shld_rate_limit", scope: "project_id = @project"},
+	{table: "shld_shamir_migrations", scope: "project_id = @project"},
+	{table: "shld_project_deletion_requests", scope: "project_id = @project"},
+}
+
+func hardDelete(tx *gorm.DB, projectID string) (map[string]int64, error) {
+	return runDeletions(tx, hardDeletions, dbsql.Named("project", projectID))
+}
+
+func cryptoShred(tx *gorm.DB, projectID string) (map[string]int64, error) {
+	deleted, err := runDeletions(tx, cryptoShredDeletions, dbsql.Named("project", projectID))
+	if err != nil {
+		return nil, err
+	}
+
+	// The project row stays behind for the soft-deleted rows to point at,
+	// without anything that could still authenticate as it
+	res := tx.Table("shld_projects").Where("id = ?", projectID).Updates(map[string]any{
+		"api_secret":  "",
+		"signing_key": nil,
+		"deleted_at":  time.Now().UTC(),
+	})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	deleted["shld_projects"] += res.RowsAffected
+
+	return deleted, nil
+}
+
+func runDeletions(tx *gorm.DB, deletions []deletion, args ...any) (map[string]int64, error) {
+	deleted := make(map[string]int64)
+	args = append(args, dbsql.Named("now", time.Now().UTC()))
+
+	for _, d := range deletions {
+		statement := "DELETE FROM " + d.table + " WHERE " + d.scope
+		if d.soft {
+			statement = "UPDATE " + d.table + " SET deleted_at = @now WHERE " + d.scope
+		}
+
+		res := tx.Exec(statement, args...)
+		if res.Error != nil {
+			return nil, res.Error
+		}
+		deleted[d.table] += res.RowsAffected
+	}
+
+	return deleted, nil
+}
+
+func recordDeletionReceipt(tx *gorm.DB, receipt *project.DeletionReceipt) error {
+	details, err := json.Marshal(DeletionReceiptDetails{
+		ProjectName:   receipt.ProjectName,
+		Mode:          string(receipt.Mode),
+		ArchiveDigest: receipt.ArchiveDigest,
+		DeletedRows:   receipt.DeletedRows,
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Create(&AuditEvent{
+		ID:        receipt.ID,
+		ProjectID: receipt.ProjectID,
+		Action:    ActionProjectDeleted,
+		Details:   string(details),
+		CreatedAt: receipt.DeletedAt,
+	}).Error
+}
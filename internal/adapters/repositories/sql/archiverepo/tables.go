@@ -0,0 +1,92 @@
+package archiverepo
+
+// Scopes select a table's rows for the project bound to @project. They're
+// written against the parent tables, so rows have to be deleted children
+// first for the scopes to keep matching.
+const (
+	projectUsers     = "SELECT id FROM shld_users WHERE project_id = @project"
+	projectProviders = "SELECT id FROM shld_providers WHERE project_id = @project"
+	projectKeychains = "SELECT id FROM shld_keychains WHERE user_id IN (" + projectUsers + ")"
+	projectShares    = "SELECT id FROM shld_shares WHERE user_id IN (" + projectUsers + ") OR keychain_id IN (" + projectKeychains + ")"
+
+	// Contacts are keyed by external user ID alone, not by project
+	projectExternalUserIDs = "SELECT external_user_id FROM shld_external_users WHERE user_id IN (" + projectUsers + ")" +
+		" UNION SELECT external_user_id FROM shld_notifications WHERE project_id = @project"
+	otherProjectsExternalUserIDs = "SELECT eu.external_user_id FROM shld_external_users eu JOIN shld_users u ON u.id = eu.user_id WHERE u.project_id <> @project" +
+		" UNION SELECT external_user_id FROM shld_notifications WHERE project_id <> @project"
+)
+
+type table struct {
+	name  string
+	order string
+	scope string
+	// redact lists columns encrypted with a key of this deployment
+	redact []string
+	// key is the deployment key the redacted columns are encrypted with
+	key secretKey
+	// serial tables take their id from a sequence, another deployment's ids
+	// mean nothing here
+	serial bool
+}
+
+type secretKey int
+
+const (
+	projectSecretKey secretKey = iota + 1
+	providerSecretKey
+)
+
+// projectTables is every table holding project data, in export order.
+var projectTables = []table{
+	{name: "shld_projects", order: "id", scope: "id = @project", redact: []string{"signing_key"}, key: projectSecretKey},
+	{name: "shld_rate_limit", order: "id", scope: "project_id = @project", serial: true},
+	{name: "shld_encryption_parts", order: "id", scope: "project_id = @project"},
+	{name: "shld_project_client_certificates", order: "id", scope: "project_id = @project"},
+	{name: "shld_shamir_migrations", order: "id", scope: "project_id = @project"},
+	{name: "shld_providers", order: "id", scope: "project_id = @project"},
+	{name: "shld_openfort_providers", order: "provider_id", scope: "provider_id IN (" + projectProviders + ")"},
+	{name: "shld_custom_providers", order: "provider_id", scope: "provider_id IN (" + projectProviders + ")", redact: []string{"hmac_secret"}, key: providerSecretKey},
+	{name: "shld_custom_provider_keys", order: "id", scope: "provider_id IN (" + projectProviders + ")"},
+	{name: "shld_introspection_providers", order: "provider_id", scope: "provider_id IN (" + projectProviders + ")", redact: []string{"client_secret"}, key: providerSecretKey},
+	{name: "shld_users", order: "id", scope: "project_id = @project"},
+	{name: "shld_external_users", order: "id", scope: "user_id IN (" + projectUsers + ")"},
+	{name: "shld_keychains", order: "id", scope: "user_id IN (" + projectUsers + ")"},
+	{name: "shld_shares", order: "id", scope: "id IN (" + projectShares + ")"},
+	{name: "shld_passkey_references", order: "share_reference", scope: "share_reference IN (" + projectShares + ")"},
+	{name: "shld_notifications", order: "id", scope: "project_id = @project", serial: true},
+	{name: "shld_user_contacts", order: "id", scope: "external_user_id IN (" + projectExternalUserIDs + ")", serial: true},
+}
+
+const (
+	// Shares that aren't encrypted with the project's key survive the loss of
+	// its encryption part, crypto-shredding has to remove them
+	unprotectedShares = "id IN (" + projectShares + ") AND entropy IS DISTINCT FROM 'project'"
+	// A contact another project still refers to isn't this project's to delete
+	exclusiveContacts = "external_user_id IN (" + projectExternalUserIDs + ") AND external_user_id NOT IN (" + otherProjectsExternalUserIDs + ")"
+)
+
+// User scopes select the rows of the users bound to @users and of the
+// external user IDs bound to @externals. Both are resolved before anything is
+// deleted, only shares still have to go before the keychains they're in.
+const (
+	userKeychains = "SELECT id FROM shld_keychains WHERE user_id IN @users"
+	userShares    = "SELECT id FROM shld_shares WHERE user_id IN @users OR keychain_id IN (" + userKeychains + ")"
+
+	// A contact stays while a user that isn't being erased, in this project
+	// or another, or another project's notifications still refer to it.
+	// IS NOT TRUE keeps the check right when @users is empty
+	exclusiveUserContacts = "external_user_id IN @externals" +
+		" AND NOT EXISTS (SELECT 1 FROM shld_external_users o WHERE o.external_user_id = shld_user_contacts.external_user_id AND (o.user_id IN @users) IS NOT TRUE)" +
+		" AND NOT EXISTS (SELECT 1 FROM shld_notifications n WHERE n.external_user_id = shld_user_contacts.external_user_id AND n.project_id <> @project)"
+)
+
+// userTables is every table holding an external user's data, in export order.
+var userTables = []table{
+	{name: "shld_users", order: "id", scope: "id IN @users"},
+	{name: "shld_external_users", order: "id", scope: "user_id IN @users"},
+	{name: "shld_keychains", order: "id", scope: "user_id IN @users"},
+	{name: "shld_shares", order: "id", scope: "id IN (" + userShares + ")"},
+	{name: "shld_passkey_references", order: "share_reference", scope: "share_reference IN (" + userShares + ")"},
+	{name: "shld_notifications", order: "id", scope: "project_id = @project AND external_user_id IN @externals"},
+	{name: "shld_user_contacts", order: "id", scope: "external_user_id IN @externals"},
+}
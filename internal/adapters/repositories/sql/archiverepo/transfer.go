@@ -0,0 +1,202 @@
+package archiverepo
+
+import (
+	"context"
+	dbsql "database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
+	"github.com/openfort-xyz/shield/internal/core/domain/project"
+	"github.com/openfort-xyz/shield/pkg/cypher"
+	"github.com/openfort-xyz/shield/pkg/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const importBatchSize = 500
+
+// errDryRun rolls a dry run import back once its report is complete.
+var errDryRun = errors.New("dry run")
+
+func (r *repository) ExportProjectForTransfer(ctx context.Context, projectID string) (*project.Archive, error) {
+	r.logger.InfoContext(ctx, "exporting project for transfer", slog.String("project_id", projectID))
+
+	var archive *project.Archive
+	// One snapshot, rows written meanwhile can't refer to rows left out
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		tables, err := queryTables(tx, projectTables, r.decryptSecrets, dbsql.Named("project", projectID))
+		if err != nil {
+			return err
+		}
+
+		if len(tables["shld_projects"]) == 0 {
+			return domainErrors.ErrProjectNotFound
+		}
+
+		archive = &project.Archive{
+			ProjectID:  projectID,
+			ExportedAt: time.Now().UTC(),
+			Tables:     tables,
+		}
+		return nil
+	}, &dbsql.TxOptions{ReadOnly: true, Isolation: dbsql.LevelRepeatableRead})
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error exporting project for transfer", logger.Error(err))
+		return nil, err
+	}
+
+	return archive, nil
+}
+
+func (r *repository) ImportProject(ctx context.Context, archive *project.Archive, dryRun bool) (*project.ImportReport, error) {
+	r.logger.InfoContext(ctx, "importing project", slog.String("project_id", archive.ProjectID), slog.Bool("dry_run", dryRun))
+
+	err := validateArchive(archive)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "invalid project archive", logger.Error(err))
+		return nil, err
+	}
+
+	report := &project.ImportReport{
+		ProjectID: archive.ProjectID,
+		DryRun:    dryRun,
+		Tables:    make(map[string]project.ImportedRows, len(projectTables)),
+	}
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		// Parents come first in projectTables, the rows that refer to them
+		// find them already written
+		for _, t := range projectTables {
+			rows := archive.Tables[t.name]
+			for _, row := range rows {
+				err := r.encryptSecrets(t, row)
+				if err != nil {
+					return err
+				}
+			}
+
+			var inserted int64
+			var err error
+			switch {
+			case len(rows) == 0:
+			case t.serial:
+				inserted, err = importSerialRows(tx, t, rows)
+			default:
+				res := tx.Table(t.name).Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(rows, importBatchSize)
+				inserted, err = res.RowsAffected, res.Error
+			}
+			if err != nil {
+				return err
+			}
+
+			report.Tables[t.name] = project.ImportedRows{
+				Inserted: inserted,
+				Existing: int64(len(rows)) - inserted,
+			}
+		}
+
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		r.logger.ErrorContext(ctx, "error importing project", logger.Error(err))
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// importSerialRows writes rows without their id, the sequence here hands out
+// new ones. A row with the same values already there stands for it, an
+// import run again doesn't write it twice.
+func importSerialRows(tx *gorm.DB, t table, rows []map[string]any) (int64, error) {
+	var inserted int64
+	for _, row := range rows {
+		delete(row, "id")
+
+		var existing int64
+		err := tx.Table(t.name).Where(row).Count(&existing).Error
+		if err != nil {
+			return 0, err
+		}
+		if existing > 0 {
+			continue
+		}
+
+		res := tx.Table(t.name).Clauses(clause.OnConflict{DoNothing: true}).Create(row)
+		if res.Error != nil {
+			return 0, res.Error
+		}
+		inserted += res.RowsAffected
+	}
+
+	return inserted, nil
+}
+
+// validateArchive refuses archives holding anything but the one project's
+// rows in the tables an export writes.
+func validateArchive(archive *project.Archive) error {
+	known := make(map[string]bool, len(projectTables))
+	for _, t := range projectTables {
+		known[t.name] = true
+	}
+	for name := range archive.Tables {
+		if !known[name] {
+			return domainErrors.ErrInvalidProjectArchive
+		}
+	}
+
+	projects := archive.Tables["shld_projects"]
+	if len(projects) != 1 || projects[0]["id"] != archive.ProjectID {
+		return domainErrors.ErrInvalidProjectArchive
+	}
+
+	return nil
+}
+
+func (r *repository) decryptSecrets(t table, row map[string]any) error {
+	return r.convertSecrets(t, row, cypher.Decrypt)
+}
+
+func (r *repository) encryptSecrets(t table, row map[string]any) error {
+	return r.convertSecrets(t, row, cypher.Encrypt)
+}
+
+func (r *repository) convertSecrets(t table, row map[string]any, convert func(string, string) (string, error)) error {
+	for _, column := range t.redact {
+		value, ok := row[column].(string)
+		if !ok || value == "" {
+			continue
+		}
+
+		key, err := r.secretKey(t.key)
+		if err != nil {
+			return err
+		}
+
+		row[column], err = convert(value, key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *repository) secretKey(key secretKey) (string, error) {
+	switch key {
+	case projectSecretKey:
+		if r.cfg.ProjectSecretEncryptionKey == "" {
+			return "", domainErrors.ErrProjectSecretEncryptionNotSet
+		}
+		return r.cfg.ProjectSecretEncryptionKey, nil
+	default:
+		if r.cfg.ProviderSecretEncryptionKey == "" {
+			return "", domainErrors.ErrSecretEncryptionNotSet
+		}
+		return r.cfg.ProviderSecretEncryptionKey, nil
+	}
+}
@@ -0,0 +1,32 @@
+package archiverepo
+
+import "time"
+
+const (
+	ActionProjectDeleted = "project.deleted"
+	ActionUserErased     = "user.erased"
+)
+
+type AuditEvent struct {
+	ID        string    `gorm:"column:id;primaryKey"`
+	ProjectID string    `gorm:"column:project_id"`
+	Action    string    `gorm:"column:action"`
+	Details   string    `gorm:"column:details"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+func (AuditEvent) TableName() string {
+	return "shld_audit_events"
+}
+
+type DeletionReceiptDetails struct {
+	ProjectName   string           `json:"project_name"`
+	Mode          string           `json:"mode"`
+	ArchiveDigest string           `json:"archive_digest"`
+	DeletedRows   map[string]int64 `json:"deleted_rows"`
+}
+
+type ErasureReceiptDetails struct {
+	ExternalUserIDHash string           `json:"external_user_id_hash"`
+	DeletedRows        map[string]int64 `json:"deleted_rows"`
+}
@@ -0,0 +1,164 @@
+package archiverepo
+
+import (
+	"context"
+	dbsql "database/sql"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
+	"github.com/openfort-xyz/shield/internal/core/domain/user"
+	"github.com/openfort-xyz/shield/pkg/logger"
+	"gorm.io/gorm"
+)
+
+var userErasures = []deletion{
+	{table: "shld_user_contacts", scope: exclusiveUserContacts},
+	{table: "shld_notifications", scope: "project_id = @project AND external_user_id IN @externals"},
+	{table: "shld_passkey_references", scope: "share_reference IN (" + userShares + ")"},
+	{table: "shld_shares", scope: "id IN (" + userShares + ")"},
+	{table: "shld_keychains", scope: "user_id IN @users"},
+	{table: "shld_external_users", scope: "user_id IN @users"},
+	{table: "shld_users", scope: "id IN @users"},
+}
+
+func (r *repository) ExportUser(ctx context.Context, projectID, externalUserID string) (*user.Export, error) {
+	r.logger.InfoContext(ctx, "exporting user", slog.String("project_id", projectID))
+
+	var export *user.Export
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		args, err := resolveUser(tx, projectID, externalUserID, false)
+		if err != nil {
+			return err
+		}
+
+		tables, err := exportTables(tx, userTables, args...)
+		if err != nil {
+			return err
+		}
+
+		export = &user.Export{
+			ProjectID:      projectID,
+			ExternalUserID: externalUserID,
+			ExportedAt:     time.Now().UTC(),
+			Tables:         tables,
+		}
+		return nil
+	}, &dbsql.TxOptions{ReadOnly: true, Isolation: dbsql.LevelRepeatableRead})
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error exporting user", logger.Error(err))
+		return nil, err
+	}
+
+	return export, nil
+}
+
+func (r *repository) EraseUser(ctx context.Context, projectID, externalUserID string) (*user.ErasureReceipt, error) {
+	r.logger.InfoContext(ctx, "erasing user", slog.String("project_id", projectID))
+
+	var receipt *user.ErasureReceipt
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		args, err := resolveUser(tx, projectID, externalUserID, true)
+		if err != nil {
+			return err
+		}
+
+		deleted, err := runDeletions(tx, userErasures, args...)
+		if err != nil {
+			return err
+		}
+
+		receipt = &user.ErasureReceipt{
+			ID:                 uuid.NewString(),
+			ProjectID:          projectID,
+			ExternalUserIDHash: user.HashExternalUserID(externalUserID),
+			DeletedRows:        deleted,
+			ErasedAt:           time.Now().UTC(),
+		}
+
+		return recordErasureReceipt(tx, receipt)
+	})
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error erasing user", logger.Error(err))
+		return nil, err
+	}
+
+	return receipt, nil
+}
+
+// resolveUser finds every user of the project an external user ID links to,
+// through any provider, and every external user ID linked to those users.
+// Notifications are kept by external user ID alone, an ID that only ever
+// received an OTP still has data to export.
+func resolveUser(tx *gorm.DB, projectID, externalUserID string, lock bool) ([]any, error) {
+	var userIDs []string
+	err := tx.Table("shld_external_users eu").
+		Joins("JOIN shld_users u ON u.id = eu.user_id").
+		Where("u.project_id = ? AND eu.external_user_id = ?", projectID, externalUserID).
+		Distinct().
+		Pluck("eu.user_id", &userIDs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if lock && len(userIDs) > 0 {
+		// Keeps shares and keychains from being registered for the users
+		// while they're erased
+		err = tx.Exec("SELECT id FROM shld_users WHERE id IN ? FOR UPDATE", userIDs).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	externalUserIDs := []string{externalUserID}
+	if len(userIDs) > 0 {
+		var linked []string
+		err = tx.Table("shld_external_users").Where("user_id IN ?", userIDs).Distinct().Pluck("external_user_id", &linked).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range linked {
+			if !slices.Contains(externalUserIDs, id) {
+				externalUserIDs = append(externalUserIDs, id)
+			}
+		}
+	}
+
+	if len(userIDs) == 0 {
+		var notifications int64
+		err = tx.Table("shld_notifications").Where("project_id = ? AND external_user_id = ?", projectID, externalUserID).Count(&notifications).Error
+		if err != nil {
+			return nil, err
+		}
+		if notifications == 0 {
+			return nil, domainErrors.ErrExternalUserNotFound
+		}
+	}
+
+	return []any{
+		dbsql.Named("project", projectID),
+		dbsql.Named("users", userIDs),
+		dbsql.Named("externals", externalUserIDs),
+	}, nil
+}
+
+func recordErasureReceipt(tx *gorm.DB, receipt *user.ErasureReceipt) error {
+	details, err := json.Marshal(ErasureReceiptDetails{
+		ExternalUserIDHash: receipt.ExternalUserIDHash,
+		DeletedRows:        receipt.DeletedRows,
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Create(&AuditEvent{
+		ID:        receipt.ID,
+		ProjectID: receipt.ProjectID,
+		Action:    ActionUserErased,
+		Details:   string(details),
+		CreatedAt: receipt.ErasedAt,
+	}).Error
+}
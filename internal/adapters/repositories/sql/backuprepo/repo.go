@@ -0,0 +1,245 @@
+package backuprepo
+
+import (
+	"context"
+	dbsql "database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/sql"
+	"github.com/openfort-xyz/shield/internal/core/domain/backup"
+	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
+	"github.com/openfort-xyz/shield/internal/core/ports/repositories"
+	"github.com/openfort-xyz/shield/pkg/logger"
+	"gorm.io/gorm"
+)
+
+const restoreBatchSize = 500
+
+// seededTables are filled by the migrations themselves, a freshly migrated
+// database already holds their rows.
+var seededTables = []string{"shld_share_storage_methods"}
+
+type repository struct {
+	db     *sql.Client
+	logger *slog.Logger
+}
+
+var _ repositories.BackupRepository = (*repository)(nil)
+
+func New(db *sql.Client) repositories.BackupRepository {
+	return &repository{
+		db:     db,
+		logger: logger.New("backup_repository"),
+	}
+}
+
+func (r *repository) Snapshot(ctx context.Context, begin func(*backup.Snapshot) error, emit func(string, json.RawMessage) error) error {
+	r.logger.InfoContext(ctx, "taking snapshot")
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		snapshot, err := describe(tx)
+		if err != nil {
+			return err
+		}
+
+		err = begin(snapshot)
+		if err != nil {
+			return err
+		}
+
+		for _, table := range snapshot.Tables {
+			err = snapshotTable(tx, table, emit)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, &dbsql.TxOptions{ReadOnly: true, Isolation: dbsql.LevelRepeatableRead})
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error taking snapshot", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// snapshotTable has Postgres write each row as JSON, the values come back in
+// the same form when the rows are restored.
+func snapshotTable(tx *gorm.DB, table string, emit func(string, json.RawMessage) error) error {
+	rows, err := tx.Raw(fmt.Sprintf("SELECT to_jsonb(t)::text FROM %s t", quote(table))).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row string
+		err = rows.Scan(&row)
+		if err != nil {
+			return err
+		}
+
+		err = emit(table, json.RawMessage(row))
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (r *repository) Restore(ctx context.Context, snapshot *backup.Snapshot, next func() (string, json.RawMessage, error)) (map[string]int64, error) {
+	r.logger.InfoContext(ctx, "restoring snapshot", slog.Int64("schema_version", snapshot.SchemaVersion))
+
+	restored := make(map[string]int64, len(snapshot.Tables))
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := checkRestorable(tx, snapshot)
+		if err != nil {
+			return err
+		}
+
+		var batch []json.RawMessage
+		table, position := "", -1
+		flush := func() error {
+			if len(batch) == 0 {
+				return nil
+			}
+			inserted, err := insertRows(tx, table, batch)
+			if err != nil {
+				return err
+			}
+			restored[table] += inserted
+			batch = batch[:0]
+			return nil
+		}
+
+		for {
+			rowTable, row, err := next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return err
+			}
+
+			if rowTable != table {
+				// Tables come one after the other, never back
+				index := slices.Index(snapshot.Tables, rowTable)
+				if index <= position {
+					return domainErrors.ErrInvalidBackup
+				}
+				err = flush()
+				if err != nil {
+					return err
+				}
+				table, position = rowTable, index
+			}
+
+			batch = append(batch, row)
+			if len(batch) == restoreBatchSize {
+				err = flush()
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		err = flush()
+		if err != nil {
+			return err
+		}
+
+		return resetSequences(tx)
+	})
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error restoring snapshot", logger.Error(err))
+		return nil, err
+	}
+
+	return restored, nil
+}
+
+// checkRestorable refuses a database at another schema version than the
+// snapshot, or one that already holds data restoring would mix with.
+func checkRestorable(tx *gorm.DB, snapshot *backup.Snapshot) error {
+	current, err := describe(tx)
+	if err != nil {
+		return err
+	}
+
+	if current.SchemaVersion != snapshot.SchemaVersion {
+		return domainErrors.ErrBackupSchemaMismatch
+	}
+
+	for _, table := range snapshot.Tables {
+		if !slices.Contains(current.Tables, table) {
+			return domainErrors.ErrInvalidBackup
+		}
+	}
+
+	for _, table := range current.Tables {
+		if slices.Contains(seededTables, table) {
+			continue
+		}
+
+		var exists bool
+		err = tx.Raw(fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s)", quote(table))).Scan(&exists).Error
+		if err != nil {
+			return err
+		}
+		if exists {
+			return domainErrors.ErrRestoreDatabaseNotEmpty
+		}
+	}
+
+	return nil
+}
+
+// insertRows has Postgres read the rows back from the JSON it wrote them
+// as. Seeded rows are already there and are left alone.
+func insertRows(tx *gorm.DB, table string, rows []json.RawMessage) (int64, error) {
+	values := make([]string, len(rows))
+	for i, row := range rows {
+		values[i] = string(row)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %[1]s SELECT * FROM jsonb_populate_recordset(NULL::%[1]s, ?::jsonb) ON CONFLICT DO NOTHING", quote(table))
+	res := tx.Exec(query, "["+strings.Join(values, ",")+"]")
+	return res.RowsAffected, res.Error
+}
+
+// resetSequences moves every sequence past the ids restored, rows inserted
+// afterwards would collide with them otherwise.
+func resetSequences(tx *gorm.DB) error {
+	var columns []struct {
+		TableName  string
+		ColumnName string
+	}
+	err := tx.Raw(`SELECT table_name, column_name FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name LIKE 'shld\_%' AND column_default LIKE 'nextval(%'`).
+		Scan(&columns).Error
+	if err != nil {
+		return err
+	}
+
+	for _, c := range columns {
+		query := fmt.Sprintf("SELECT setval(pg_get_serial_sequence(?, ?), COALESCE(MAX(%s), 0) + 1, false) FROM %s", quote(c.ColumnName), quote(c.TableName))
+		err = tx.Exec(query, c.TableName, c.ColumnName).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func quote(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
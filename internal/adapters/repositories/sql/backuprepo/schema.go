@@ -0,0 +1,85 @@
+package backuprepo
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/openfort-xyz/shield/internal/core/domain/backup"
+	"gorm.io/gorm"
+)
+
+// describe lists the Shield tables of the database, parents first, along
+// with the migration it's at.
+func describe(tx *gorm.DB) (*backup.Snapshot, error) {
+	// goose records a rollback as another row for the same version, the
+	// latest row of each version tells whether it's applied
+	var version int64
+	err := tx.Raw(`SELECT COALESCE(MAX(version_id), 0) FROM (
+			SELECT DISTINCT ON (version_id) version_id, is_applied FROM goose_db_version ORDER BY version_id, id DESC
+		) v WHERE is_applied`).Scan(&version).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []string
+	err = tx.Raw(`SELECT table_name FROM information_schema.tables
+		WHERE table_schema = current_schema() AND table_type = 'BASE TABLE' AND table_name LIKE 'shld\_%'`).
+		Scan(&tables).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var references []reference
+	err = tx.Raw(`SELECT c.conrelid::regclass::text AS child, c.confrelid::regclass::text AS parent FROM pg_constraint c
+		WHERE c.contype = 'f' AND c.connamespace = current_schema()::regnamespace`).
+		Scan(&references).Error
+	if err != nil {
+		return nil, err
+	}
+
+	ordered, err := parentsFirst(tables, references)
+	if err != nil {
+		return nil, err
+	}
+
+	return &backup.Snapshot{SchemaVersion: version, Tables: ordered}, nil
+}
+
+type reference struct {
+	Child  string
+	Parent string
+}
+
+// parentsFirst orders tables so every table comes after the tables its
+// foreign keys refer to, by name where that leaves a choice.
+func parentsFirst(tables []string, references []reference) ([]string, error) {
+	parents := make(map[string][]string, len(tables))
+	for _, ref := range references {
+		if ref.Child == ref.Parent || !slices.Contains(tables, ref.Child) || !slices.Contains(tables, ref.Parent) {
+			continue
+		}
+		parents[ref.Child] = append(parents[ref.Child], ref.Parent)
+	}
+
+	remaining := slices.Clone(tables)
+	slices.Sort(remaining)
+	ordered := make([]string, 0, len(tables))
+	for len(remaining) > 0 {
+		next := slices.IndexFunc(remaining, func(table string) bool {
+			for _, parent := range parents[table] {
+				if !slices.Contains(ordered, parent) {
+					return false
+				}
+			}
+			return true
+		})
+		if next < 0 {
+			return nil, fmt.Errorf("foreign keys between %v form a cycle", remaining)
+		}
+
+		ordered = append(ordered, remaining[next])
+		remaining = slices.Delete(remaining, next, next+1)
+	}
+
+	return ordered, nil
+}
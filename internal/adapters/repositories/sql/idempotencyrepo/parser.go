@@ -0,0 +1,34 @@
+package idempotencyrepo
+
+import "github.com/openfort-xyz/shield/internal/core/domain/idempotency"
+
+type parser struct {
+}
+
+func newParser() *parser {
+	return &parser{}
+}
+
+func (p *parser) toDomain(rec *Record) *idempotency.Record {
+	return &idempotency.Record{
+		KeyHash:     rec.KeyHash,
+		RequestHash: rec.RequestHash,
+		Status:      rec.Status,
+		ContentType: rec.ContentType,
+		ETag:        rec.ETag,
+		Body:        rec.Body,
+		ExpiresAt:   rec.ExpiresAt,
+	}
+}
+
+func (p *parser) toDatabase(rec *idempotency.Record) *Record {
+	return &Record{
+		KeyHash:     rec.KeyHash,
+		RequestHash: rec.RequestHash,
+		Status:      rec.Status,
+		ContentType: rec.ContentType,
+		ETag:        rec.ETag,
+		Body:        rec.Body,
+		ExpiresAt:   rec.ExpiresAt,
+	}
+}
@@ -0,0 +1,99 @@
+package idempotencyrepo
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/sql"
+	"github.com/openfort-xyz/shield/internal/core/domain/idempotency"
+	"github.com/openfort-xyz/shield/internal/core/ports/repositories"
+	"github.com/openfort-xyz/shield/pkg/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type repository struct {
+	db     *sql.Client
+	logger *slog.Logger
+	parser *parser
+}
+
+var _ repositories.IdempotencyRepository = &repository{}
+
+func New(db *sql.Client) repositories.IdempotencyRepository {
+	return &repository{
+		db:     db,
+		logger: logger.New("idempotency_repository"),
+		parser: newParser(),
+	}
+}
+
+func (r *repository) Claim(ctx context.Context, rec *idempotency.Record) (*idempotency.Record, error) {
+	var existing *idempotency.Record
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// An expired record no longer holds the key, the new request takes it
+		err := tx.Where("key_hash = ? AND expires_at <= ?", rec.KeyHash, time.Now()).Delete(&Record{}).Error
+		if err != nil {
+			return err
+		}
+
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(r.parser.toDatabase(rec))
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 1 {
+			return nil
+		}
+
+		dbRec := &Record{}
+		if err := tx.Where("key_hash = ?", rec.KeyHash).Take(dbRec).Error; err != nil {
+			return err
+		}
+		existing = r.parser.toDomain(dbRec)
+		return nil
+	})
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error claiming idempotency key", logger.Error(err))
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+func (r *repository) Complete(ctx context.Context, rec *idempotency.Record) error {
+	err := r.db.Model(&Record{}).Where("key_hash = ?", rec.KeyHash).Updates(map[string]any{
+		"status":       rec.Status,
+		"content_type": rec.ContentType,
+		"etag":         rec.ETag,
+		"body":         rec.Body,
+		"expires_at":   rec.ExpiresAt,
+	}).Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error completing idempotency key", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) Release(ctx context.Context, keyHash string) error {
+	err := r.db.Where("key_hash = ?", keyHash).Delete(&Record{}).Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error releasing idempotency key", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) DeleteExpired(ctx context.Context) error {
+	res := r.db.Where("expires_at <= ?", time.Now()).Delete(&Record{})
+	if res.Error != nil {
+		r.logger.ErrorContext(ctx, "error deleting expired idempotency keys", logger.Error(res.Error))
+		return res.Error
+	}
+
+	r.logger.InfoContext(ctx, "deleted expired idempotency keys", slog.Int64("count", res.RowsAffected))
+	return nil
+}
@@ -0,0 +1,18 @@
+package idempotencyrepo
+
+import "time"
+
+type Record struct {
+	KeyHash     string    `gorm:"column:key_hash;primaryKey"`
+	RequestHash string    `gorm:"column:request_hash"`
+	Status      int       `gorm:"column:status"`
+	ContentType string    `gorm:"column:content_type"`
+	ETag        string    `gorm:"column:etag"`
+	Body        string    `gorm:"column:body"`
+	ExpiresAt   time.Time `gorm:"column:expires_at"`
+	CreatedAt   time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (Record) TableName() string {
+	return "shld_idempotency_keys"
+}
@@ -0,0 +1,30 @@
+package invalidationrepo
+
+import (
+	"fmt"
+
+	env "github.com/caarlos0/env/v10"
+)
+
+const (
+	BusPostgres = "postgres"
+	BusLocal    = "local"
+)
+
+type Config struct {
+	// Bus selects how invalidations reach the other replicas.
+	// Valid values: postgres (LISTEN/NOTIFY), local (this process only).
+	Bus string `env:"INVALIDATION_BUS" envDefault:"postgres"`
+}
+
+func GetConfigFromEnv() (*Config, error) {
+	cfg := &Config{}
+	if err := env.Parse(cfg); err != nil {
+		return nil, err
+	}
+
+	if cfg.Bus != BusPostgres && cfg.Bus != BusLocal {
+		return nil, fmt.Errorf("invalid invalidation bus %q", cfg.Bus)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,147 @@
+package invalidationrepo
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/sql"
+	"github.com/openfort-xyz/shield/internal/core/ports/repositories"
+	"github.com/openfort-xyz/shield/pkg/logger"
+)
+
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
+var errUnexpectedDriver = errors.New("database connection is not a pgx connection")
+
+// repository relays invalidations through Postgres LISTEN/NOTIFY. Every
+// channel someone subscribed to holds one pooled connection for as long as
+// the process runs.
+//
+// Subscribers in the publishing process are called directly, so a replica
+// never depends on its own notification making the round trip, and keeps
+// invalidating locally while Postgres is unreachable. Without a database only
+// those local subscribers are called, see NewLocal.
+type repository struct {
+	db     *sql.Client
+	logger *slog.Logger
+
+	mu          sync.RWMutex
+	subscribers map[string][]func(key string)
+}
+
+var _ repositories.InvalidationRepository = (*repository)(nil)
+
+func New(db *sql.Client) repositories.InvalidationRepository {
+	return &repository{
+		db:          db,
+		logger:      logger.New("invalidation_repository"),
+		subscribers: make(map[string][]func(key string)),
+	}
+}
+
+// NewLocal keeps invalidations within the process, for a single replica or a
+// database behind a pooler that doesn't support LISTEN. Other replicas then
+// only pick up changes once their cached entries expire.
+func NewLocal() repositories.InvalidationRepository {
+	return New(nil)
+}
+
+func (r *repository) Publish(ctx context.Context, channel, key string) error {
+	r.dispatch(channel, key)
+	if r.db == nil {
+		return nil
+	}
+
+	err := r.db.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", channel, key).Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error publishing invalidation", slog.String("channel", channel), logger.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) Subscribe(channel string, fn func(key string)) {
+	r.mu.Lock()
+	first := len(r.subscribers[channel]) == 0
+	r.subscribers[channel] = append(r.subscribers[channel], fn)
+	r.mu.Unlock()
+
+	if first && r.db != nil {
+		go r.listen(channel)
+	}
+}
+
+func (r *repository) dispatch(channel, key string) {
+	r.mu.RLock()
+	subscribers := r.subscribers[channel]
+	r.mu.RUnlock()
+
+	for _, fn := range subscribers {
+		fn(key)
+	}
+}
+
+func (r *repository) listen(channel string) {
+	ctx := context.Background()
+	delay := minReconnectDelay
+	for {
+		listening := false
+		err := r.listenOnce(ctx, channel, func() {
+			listening = true
+			delay = minReconnectDelay
+			// Anything published while the connection was down is lost
+			r.dispatch(channel, "")
+		})
+		r.logger.ErrorContext(ctx, "invalidation listener stopped", slog.String("channel", channel), logger.Error(err))
+
+		if !listening {
+			delay = min(delay*2, maxReconnectDelay)
+		}
+		time.Sleep(delay)
+	}
+}
+
+func (r *repository) listenOnce(ctx context.Context, channel string, onListen func()) error {
+	sqlDB, err := r.db.DB.DB()
+	if err != nil {
+		return err
+	}
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn any) error {
+		pgxConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return errUnexpectedDriver
+		}
+
+		_, err := pgxConn.Conn().Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize())
+		if err != nil {
+			return err
+		}
+		onListen()
+
+		for {
+			notification, err := pgxConn.Conn().WaitForNotification(ctx)
+			if err != nil {
+				// The connection is in an unknown state, keep it out of the pool
+				return errors.Join(err, driver.ErrBadConn)
+			}
+			r.dispatch(channel, notification.Payload)
+		}
+	})
+}
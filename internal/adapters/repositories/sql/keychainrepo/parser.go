@@ -13,8 +13,10 @@ func newParser() *parser {
 
 func (p *parser) toDomain(k *Keychain) *keychain.Keychain {
 	return &keychain.Keychain{
-		ID:     k.ID,
-		UserID: k.UserID,
+		ID:        k.ID,
+		UserID:    k.UserID,
+		CreatedAt: k.CreatedAt,
+		UpdatedAt: k.UpdatedAt,
 	}
 }
 
@@ -1,12 +1,16 @@
 package keychainrepo
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 )
 
 type Keychain struct {
 	ID        string         `gorm:"column:id;primary_key"`
 	UserID    string         `gorm:"column:user_id;not null"`
+	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt time.Time      `gorm:"column:updated_at;autoUpdateTime"`
 	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at"`
 }
 
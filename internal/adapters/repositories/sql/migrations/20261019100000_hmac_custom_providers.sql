@@ -0,0 +1,13 @@
+-- +goose Up
+ALTER TABLE shld_custom_providers ADD COLUMN hmac_secret TEXT DEFAULT NULL;
+ALTER TABLE shld_custom_providers DROP CONSTRAINT IF EXISTS shld_custom_providers_key_type_check;
+ALTER TABLE shld_custom_providers ADD CONSTRAINT shld_custom_providers_key_type_check CHECK (key_type IN ('RSA', 'ECDSA', 'ED25519', 'HMAC'));
+-- +goose StatementBegin
+-- +goose StatementEnd
+
+-- +goose Down
+ALTER TABLE shld_custom_providers DROP CONSTRAINT IF EXISTS shld_custom_providers_key_type_check;
+ALTER TABLE shld_custom_providers ADD CONSTRAINT shld_custom_providers_key_type_check CHECK (key_type IN ('RSA', 'ECDSA', 'ED25519'));
+ALTER TABLE shld_custom_providers DROP COLUMN hmac_secret;
+-- +goose StatementBegin
+-- +goose StatementEnd
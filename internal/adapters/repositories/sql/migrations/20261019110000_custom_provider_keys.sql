@@ -0,0 +1,23 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS shld_custom_provider_keys (
+    id VARCHAR(36) PRIMARY KEY,
+    provider_id VARCHAR(36) NOT NULL,
+    kid VARCHAR(255) DEFAULT NULL,
+    pem_cert TEXT NOT NULL,
+    key_type VARCHAR(16) NOT NULL CHECK (key_type IN ('RSA', 'ECDSA', 'ED25519')),
+    not_before TIMESTAMP DEFAULT NULL,
+    not_after TIMESTAMP DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE shld_custom_provider_keys ADD CONSTRAINT fk_custom_provider_keys_provider FOREIGN KEY (provider_id) REFERENCES shld_custom_providers(provider_id) ON DELETE CASCADE;
+CREATE UNIQUE INDEX idx_shld_custom_provider_keys_provider_kid ON shld_custom_provider_keys(provider_id, kid);
+
+-- +goose StatementBegin
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TABLE IF EXISTS shld_custom_provider_keys;
+
+-- +goose StatementBegin
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+ALTER TABLE shld_providers DROP CONSTRAINT IF EXISTS shld_providers_type_check;
+ALTER TABLE shld_providers ADD CONSTRAINT shld_providers_type_check CHECK (type IN ('OPENFORT', 'CUSTOM', 'INTROSPECTION'));
+
+CREATE TABLE IF NOT EXISTS shld_introspection_providers (
+    provider_id VARCHAR(36) PRIMARY KEY,
+    endpoint VARCHAR(255) NOT NULL,
+    client_id VARCHAR(255) DEFAULT NULL,
+    client_secret TEXT DEFAULT NULL,
+    user_id_claim VARCHAR(255) NOT NULL DEFAULT 'sub'
+);
+ALTER TABLE shld_introspection_providers ADD CONSTRAINT fk_introspection_provider FOREIGN KEY (provider_id) REFERENCES shld_providers(id) ON DELETE CASCADE;
+
+-- +goose StatementBegin
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TABLE IF EXISTS shld_introspection_providers;
+ALTER TABLE shld_providers DROP CONSTRAINT IF EXISTS shld_providers_type_check;
+ALTER TABLE shld_providers ADD CONSTRAINT shld_providers_type_check CHECK (type IN ('OPENFORT', 'CUSTOM'));
+
+-- +goose StatementBegin
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+ALTER TABLE shld_projects ADD COLUMN client_cert_mode VARCHAR(16) NOT NULL DEFAULT 'DISABLED' CHECK (client_cert_mode IN ('DISABLED', 'ALTERNATIVE', 'REQUIRED'));
+
+CREATE TABLE IF NOT EXISTS shld_project_client_certificates (
+    id VARCHAR(36) PRIMARY KEY,
+    project_id VARCHAR(36) NOT NULL,
+    name VARCHAR(255) DEFAULT NULL,
+    fingerprint CHAR(64) NOT NULL,
+    type VARCHAR(8) NOT NULL CHECK (type IN ('CA', 'LEAF')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE shld_project_client_certificates ADD CONSTRAINT fk_project_client_certificates_project FOREIGN KEY (project_id) REFERENCES shld_projects(id) ON DELETE CASCADE;
+CREATE UNIQUE INDEX idx_shld_project_client_certificates_project_fingerprint ON shld_project_client_certificates(project_id, fingerprint);
+
+-- +goose StatementBegin
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TABLE IF EXISTS shld_project_client_certificates;
+ALTER TABLE shld_projects DROP COLUMN IF EXISTS client_cert_mode;
+
+-- +goose StatementBegin
+-- +goose StatementEnd
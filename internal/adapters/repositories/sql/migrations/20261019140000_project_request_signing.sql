@@ -0,0 +1,13 @@
+-- +goose Up
+ALTER TABLE shld_projects ADD COLUMN signing_key TEXT DEFAULT NULL;
+ALTER TABLE shld_projects ADD COLUMN require_signed_requests BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose StatementBegin
+-- +goose StatementEnd
+
+-- +goose Down
+ALTER TABLE shld_projects DROP COLUMN IF EXISTS require_signed_requests;
+ALTER TABLE shld_projects DROP COLUMN IF EXISTS signing_key;
+
+-- +goose StatementBegin
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS shld_project_deletion_requests (
+    project_id VARCHAR(36) PRIMARY KEY,
+    token_hash CHAR(64) NOT NULL,
+    archive_digest CHAR(64) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+ALTER TABLE shld_project_deletion_requests ADD CONSTRAINT fk_project_deletion_requests_project FOREIGN KEY (project_id) REFERENCES shld_projects(id) ON DELETE CASCADE;
+
+-- Audit events outlive the project they're about, so there's no foreign key
+CREATE TABLE IF NOT EXISTS shld_audit_events (
+    id VARCHAR(36) PRIMARY KEY,
+    project_id VARCHAR(36) NOT NULL,
+    action VARCHAR(64) NOT NULL,
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_shld_audit_events_project_created_at ON shld_audit_events(project_id, created_at);
+-- +goose StatementBegin
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TABLE IF EXISTS shld_audit_events;
+DROP TABLE IF EXISTS shld_project_deletion_requests;
+-- +goose StatementBegin
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+CREATE INDEX IF NOT EXISTS idx_shld_users_project_created_at ON shld_users(project_id, created_at, id);
+-- +goose StatementBegin
+-- +goose StatementEnd
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_shld_users_project_created_at;
+-- +goose StatementBegin
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE shld_shares ADD COLUMN IF NOT EXISTS metadata JSONB DEFAULT NULL;
+-- +goose StatementBegin
+-- +goose StatementEnd
+
+-- +goose Down
+ALTER TABLE shld_shares DROP COLUMN IF EXISTS metadata;
+-- +goose StatementBegin
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+ALTER TABLE shld_projects ADD COLUMN IF NOT EXISTS disabled_at TIMESTAMP DEFAULT NULL;
+
+CREATE TABLE IF NOT EXISTS shld_registration_invites (
+    id VARCHAR(36) PRIMARY KEY,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementBegin
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TABLE IF EXISTS shld_registration_invites;
+ALTER TABLE shld_projects DROP COLUMN IF EXISTS disabled_at;
+-- +goose StatementBegin
+-- +goose StatementEnd
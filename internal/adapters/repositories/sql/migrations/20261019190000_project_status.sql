@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE shld_projects ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'ACTIVE' CHECK (status IN ('ACTIVE', 'READ_ONLY', 'SUSPENDED'));
+-- +goose StatementBegin
+-- +goose StatementEnd
+
+-- +goose Down
+ALTER TABLE shld_projects DROP COLUMN IF EXISTS status;
+-- +goose StatementBegin
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE shld_shares ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+-- +goose StatementBegin
+-- +goose StatementEnd
+
+-- +goose Down
+ALTER TABLE shld_shares DROP COLUMN IF EXISTS version;
+-- +goose StatementBegin
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE shld_idempotency_keys ADD COLUMN IF NOT EXISTS etag VARCHAR(255) NOT NULL DEFAULT '';
+-- +goose StatementBegin
+-- +goose StatementEnd
+
+-- +goose Down
+ALTER TABLE shld_idempotency_keys DROP COLUMN IF EXISTS etag;
+-- +goose StatementBegin
+-- +goose StatementEnd
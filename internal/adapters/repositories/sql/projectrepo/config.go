@@ -0,0 +1,15 @@
+package projectrepo
+
+import env "github.com/caarlos0/env/v10"
+
+type Config struct {
+	// SecretEncryptionKey is a base64 encoded AES key used to encrypt
+	// project request signing keys before they're persisted.
+	SecretEncryptionKey string `env:"PROJECT_SECRET_ENCRYPTION_KEY"`
+}
+
+func GetConfigFromEnv() (*Config, error) {
+	cfg := &Config{}
+	err := env.Parse(cfg)
+	return cfg, err
+}
@@ -11,33 +11,53 @@ func newParser() *parser {
 
 func (p *parser) toDomain(proj *Project) *project.Project {
 	return &project.Project{
-		ID:        proj.ID,
-		Name:      proj.Name,
-		APIKey:    proj.APIKey,
-		APISecret: proj.APISecret,
-		Enable2FA: proj.Enable2FA,
+		ID:                    proj.ID,
+		Name:                  proj.Name,
+		APIKey:                proj.APIKey,
+		APISecret:             proj.APISecret,
+		Enable2FA:             proj.Enable2FA,
+		ClientCertMode:        project.ClientCertMode(proj.ClientCertMode),
+		RequireSignedRequests: proj.RequireSignedRequests,
+		Status:                project.Status(proj.Status),
+		CreatedAt:             proj.CreatedAt,
+		DisabledAt:            proj.DisabledAt,
 	}
 }
 
 func (p *parser) toDomainWithRateLimit(proj *ProjectWithRateLimit) *project.WithRateLimit {
 	return &project.WithRateLimit{
-		ID:             proj.ID,
-		Name:           proj.Name,
-		APIKey:         proj.APIKey,
-		APISecret:      proj.APISecret,
-		Enable2FA:      proj.Enable2FA,
-		SMSRateLimit:   proj.SMSRequestsPerHour,
-		EmailRateLimit: proj.EmailRequestsPerHour,
+		ID:                    proj.ID,
+		Name:                  proj.Name,
+		APIKey:                proj.APIKey,
+		APISecret:             proj.APISecret,
+		Enable2FA:             proj.Enable2FA,
+		ClientCertMode:        project.ClientCertMode(proj.ClientCertMode),
+		RequireSignedRequests: proj.RequireSignedRequests,
+		SMSRateLimit:          proj.SMSRequestsPerHour,
+		EmailRateLimit:        proj.EmailRequestsPerHour,
 	}
 }
 
 func (p *parser) toDatabase(proj *project.Project) *Project {
+	mode := proj.ClientCertMode
+	if mode == "" {
+		mode = project.ClientCertModeDisabled
+	}
+
+	status := proj.Status
+	if status == "" {
+		status = project.StatusActive
+	}
+
 	return &Project{
-		ID:        proj.ID,
-		Name:      proj.Name,
-		APIKey:    proj.APIKey,
-		APISecret: proj.APISecret,
-		Enable2FA: proj.Enable2FA,
+		ID:                    proj.ID,
+		Name:                  proj.Name,
+		APIKey:                proj.APIKey,
+		APISecret:             proj.APISecret,
+		Enable2FA:             proj.Enable2FA,
+		ClientCertMode:        string(mode),
+		RequireSignedRequests: proj.RequireSignedRequests,
+		Status:                string(status),
 	}
 }
 
@@ -48,3 +68,51 @@ func (p *parser) toDatabaseRateLimits(rateLimits *project.RateLimit) *RateLimit
 		EmailRequestsPerHour: rateLimits.EmailRequestsPerHour,
 	}
 }
+
+func (p *parser) toDomainClientCertificate(cert *ClientCertificate) *project.ClientCertificate {
+	return &project.ClientCertificate{
+		ID:          cert.ID,
+		ProjectID:   cert.ProjectID,
+		Name:        cert.Name,
+		Fingerprint: cert.Fingerprint,
+		Type:        project.ClientCertificateType(cert.Type),
+		CreatedAt:   cert.CreatedAt,
+	}
+}
+
+func (p *parser) toDatabaseClientCertificate(cert *project.ClientCertificate) *ClientCertificate {
+	return &ClientCertificate{
+		ID:          cert.ID,
+		ProjectID:   cert.ProjectID,
+		Name:        cert.Name,
+		Fingerprint: cert.Fingerprint,
+		Type:        string(cert.Type),
+	}
+}
+
+func (p *parser) toDomainDeletionRequest(req *DeletionRequest) *project.DeletionRequest {
+	return &project.DeletionRequest{
+		ProjectID:     req.ProjectID,
+		TokenHash:     req.TokenHash,
+		ArchiveDigest: req.ArchiveDigest,
+		ExpiresAt:     req.ExpiresAt,
+	}
+}
+
+func (p *parser) toDatabaseDeletionRequest(req *project.DeletionRequest) *DeletionRequest {
+	return &DeletionRequest{
+		ProjectID:     req.ProjectID,
+		TokenHash:     req.TokenHash,
+		ArchiveDigest: req.ArchiveDigest,
+		ExpiresAt:     req.ExpiresAt,
+	}
+}
+
+func (p *parser) toDatabaseInvite(invite *project.Invite) *Invite {
+	return &Invite{
+		ID:        invite.ID,
+		TokenHash: invite.TokenHash,
+		ExpiresAt: invite.ExpiresAt,
+		UsedAt:    invite.UsedAt,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"time"
 
 	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
 
@@ -13,12 +14,15 @@ import (
 	"github.com/openfort-xyz/shield/internal/adapters/repositories/sql"
 	"github.com/openfort-xyz/shield/internal/core/domain/project"
 	"github.com/openfort-xyz/shield/internal/core/ports/repositories"
+	"github.com/openfort-xyz/shield/pkg/cypher"
 	"github.com/openfort-xyz/shield/pkg/logger"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type repository struct {
 	db             *sql.Client
+	secretKey      string
 	logger         *slog.Logger
 	parser         *parser
 	migrationCache map[string]bool
@@ -27,9 +31,10 @@ type repository struct {
 
 var _ repositories.ProjectRepository = &repository{}
 
-func New(db *sql.Client) repositories.ProjectRepository {
+func New(db *sql.Client, cfg *Config) repositories.ProjectRepository {
 	return &repository{
 		db:             db,
+		secretKey:      cfg.SecretEncryptionKey,
 		logger:         logger.New("project_repository"),
 		parser:         newParser(),
 		migrationCache: make(map[string]bool),
@@ -107,6 +112,180 @@ func (r *repository) Update2FA(ctx context.Context, projectID string, enable2FA
 	return nil
 }
 
+func (r *repository) UpdateClientCertMode(ctx context.Context, projectID string, mode project.ClientCertMode) error {
+	r.logger.InfoContext(ctx, "updating client certificate mode", slog.String("project_id", projectID), slog.String("mode", string(mode)))
+
+	err := r.db.Model(&Project{}).Where("id = ?", projectID).Update("client_cert_mode", string(mode)).Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error updating client certificate mode", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) UpdateRequireSignedRequests(ctx context.Context, projectID string, required bool) error {
+	r.logger.InfoContext(ctx, "updating signed requests requirement", slog.String("project_id", projectID), slog.Bool("required", required))
+
+	err := r.db.Model(&Project{}).Where("id = ?", projectID).Update("require_signed_requests", required).Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error updating signed requests requirement", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) UpdateStatus(ctx context.Context, projectID string, status project.Status) error {
+	r.logger.InfoContext(ctx, "updating project status", slog.String("project_id", projectID), slog.String("status", string(status)))
+
+	res := r.db.Model(&Project{}).Where("id = ?", projectID).Update("status", string(status))
+	if res.Error != nil {
+		r.logger.ErrorContext(ctx, "error updating project status", logger.Error(res.Error))
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domainErrors.ErrProjectNotFound
+	}
+
+	return nil
+}
+
+func (r *repository) SetSigningKey(ctx context.Context, projectID, signingKey string) error {
+	r.logger.InfoContext(ctx, "setting signing key", slog.String("project_id", projectID))
+
+	if r.secretKey == "" {
+		return domainErrors.ErrProjectSecretEncryptionNotSet
+	}
+
+	encrypted, err := cypher.Encrypt(signingKey, r.secretKey)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error encrypting signing key", logger.Error(err))
+		return err
+	}
+
+	err = r.db.Model(&Project{}).Where("id = ?", projectID).Update("signing_key", encrypted).Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error setting signing key", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// GetSigningKey is kept apart from Get so the key is only loaded, and
+// decrypted, for the requests that are actually signed.
+func (r *repository) GetSigningKey(ctx context.Context, projectID string) (string, error) {
+	r.logger.InfoContext(ctx, "getting signing key", slog.String("project_id", projectID))
+
+	var row struct {
+		SigningKey *string `gorm:"column:signing_key"`
+	}
+	err := r.db.Model(&Project{}).Select("signing_key").Where("id = ?", projectID).Take(&row).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", domainErrors.ErrProjectNotFound
+		}
+		r.logger.ErrorContext(ctx, "error getting signing key", logger.Error(err))
+		return "", err
+	}
+
+	if row.SigningKey == nil || *row.SigningKey == "" {
+		return "", domainErrors.ErrSigningKeyNotFound
+	}
+
+	if r.secretKey == "" {
+		return "", domainErrors.ErrProjectSecretEncryptionNotSet
+	}
+
+	return cypher.Decrypt(*row.SigningKey, r.secretKey)
+}
+
+// SaveDeletionRequest replaces any pending request, only the latest
+// confirmation token is valid.
+func (r *repository) SaveDeletionRequest(ctx context.Context, req *project.DeletionRequest) error {
+	r.logger.InfoContext(ctx, "saving deletion request", slog.String("project_id", req.ProjectID))
+
+	dbReq := r.parser.toDatabaseDeletionRequest(req)
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "project_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"token_hash", "archive_digest", "expires_at", "created_at"}),
+	}).Create(dbReq).Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error saving deletion request", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) GetDeletionRequest(ctx context.Context, projectID string) (*project.DeletionRequest, error) {
+	r.logger.InfoContext(ctx, "getting deletion request", slog.String("project_id", projectID))
+
+	dbReq := &DeletionRequest{}
+	err := r.db.Where("project_id = ?", projectID).First(dbReq).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrDeletionRequestNotFound
+		}
+		r.logger.ErrorContext(ctx, "error getting deletion request", logger.Error(err))
+		return nil, err
+	}
+
+	return r.parser.toDomainDeletionRequest(dbReq), nil
+}
+
+func (r *repository) ListClientCertificates(ctx context.Context, projectID string) ([]*project.ClientCertificate, error) {
+	r.logger.InfoContext(ctx, "listing client certificates", slog.String("project_id", projectID))
+
+	var dbCerts []ClientCertificate
+	err := r.db.Where("project_id = ?", projectID).Order("created_at").Find(&dbCerts).Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error listing client certificates", logger.Error(err))
+		return nil, err
+	}
+
+	certs := make([]*project.ClientCertificate, 0, len(dbCerts))
+	for i := range dbCerts {
+		certs = append(certs, r.parser.toDomainClientCertificate(&dbCerts[i]))
+	}
+
+	return certs, nil
+}
+
+func (r *repository) AddClientCertificate(ctx context.Context, cert *project.ClientCertificate) error {
+	r.logger.InfoContext(ctx, "adding client certificate", slog.String("project_id", cert.ProjectID))
+	if cert.ID == "" {
+		cert.ID = uuid.NewString()
+	}
+
+	dbCert := r.parser.toDatabaseClientCertificate(cert)
+	err := r.db.Create(dbCert).Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error adding client certificate", logger.Error(err))
+		return err
+	}
+
+	cert.CreatedAt = dbCert.CreatedAt
+	return nil
+}
+
+func (r *repository) DeleteClientCertificate(ctx context.Context, projectID, certificateID string) error {
+	r.logger.InfoContext(ctx, "deleting client certificate", slog.String("project_id", projectID), slog.String("certificate_id", certificateID))
+
+	result := r.db.Where("project_id = ? AND id = ?", projectID, certificateID).Delete(&ClientCertificate{})
+	if result.Error != nil {
+		r.logger.ErrorContext(ctx, "error deleting client certificate", logger.Error(result.Error))
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domainErrors.ErrClientCertificateNotFound
+	}
+
+	return nil
+}
+
 func (r *repository) GetWithRateLimit(ctx context.Context, projectID string) (*project.WithRateLimit, error) {
 	r.logger.InfoContext(ctx, "getting project with rate limit")
 
@@ -131,7 +310,7 @@ func (r *repository) GetByAPIKey(ctx context.Context, apiKey string) (*project.P
 	r.logger.InfoContext(ctx, "getting project by API key")
 
 	dbProj := &Project{}
-	err := r.db.Where("api_key = ?", apiKey).First(dbProj).Error
+	err := r.db.Where("api_key = ? AND disabled_at IS NULL", apiKey).First(dbProj).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domainErrors.ErrProjectNotFound
@@ -143,6 +322,85 @@ func (r *repository) GetByAPIKey(ctx context.Context, apiKey string) (*project.P
 	return r.parser.toDomain(dbProj), nil
 }
 
+func (r *repository) List(ctx context.Context) ([]*project.Project, error) {
+	r.logger.InfoContext(ctx, "listing projects")
+
+	var dbProjs []Project
+	err := r.db.Order("created_at, id").Find(&dbProjs).Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error listing projects", logger.Error(err))
+		return nil, err
+	}
+
+	projs := make([]*project.Project, 0, len(dbProjs))
+	for i := range dbProjs {
+		projs = append(projs, r.parser.toDomain(&dbProjs[i]))
+	}
+
+	return projs, nil
+}
+
+// Disable keeps the time the project was first disabled.
+func (r *repository) Disable(ctx context.Context, projectID string) error {
+	r.logger.InfoContext(ctx, "disabling project", slog.String("project_id", projectID))
+
+	res := r.db.Model(&Project{}).Where("id = ?", projectID).Update("disabled_at", gorm.Expr("COALESCE(disabled_at, ?)", time.Now()))
+	if res.Error != nil {
+		r.logger.ErrorContext(ctx, "error disabling project", logger.Error(res.Error))
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domainErrors.ErrProjectNotFound
+	}
+
+	return nil
+}
+
+func (r *repository) CreateInvite(ctx context.Context, invite *project.Invite) error {
+	r.logger.InfoContext(ctx, "creating registration invite")
+	if invite.ID == "" {
+		invite.ID = uuid.NewString()
+	}
+
+	err := r.db.Create(r.parser.toDatabaseInvite(invite)).Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error creating registration invite", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) ConsumeInvite(ctx context.Context, tokenHash string) error {
+	r.logger.InfoContext(ctx, "consuming registration invite")
+
+	now := time.Now()
+	res := r.db.Model(&Invite{}).
+		Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, now).
+		Update("used_at", now)
+	if res.Error != nil {
+		r.logger.ErrorContext(ctx, "error consuming registration invite", logger.Error(res.Error))
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domainErrors.ErrInviteNotFound
+	}
+
+	return nil
+}
+
+func (r *repository) ReleaseInvite(ctx context.Context, tokenHash string) error {
+	r.logger.InfoContext(ctx, "releasing registration invite")
+
+	err := r.db.Model(&Invite{}).Where("token_hash = ?", tokenHash).Update("used_at", nil).Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error releasing registration invite", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
+
 func (r *repository) Delete(ctx context.Context, projectID string) error {
 	r.logger.InfoContext(ctx, "deleting project")
 
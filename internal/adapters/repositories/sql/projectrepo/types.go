@@ -7,27 +7,33 @@ import (
 )
 
 type Project struct {
-	ID        string         `gorm:"column:id;primaryKey"`
-	Name      string         `gorm:"column:name"`
-	APIKey    string         `gorm:"column:api_key"`
-	APISecret string         `gorm:"column:api_secret"`
-	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime"`
-	UpdatedAt time.Time      `gorm:"column:updated_at;autoUpdateTime"`
-	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at"`
-	Enable2FA bool           `gorm:"column:enable_2fa"`
+	ID                    string         `gorm:"column:id;primaryKey"`
+	Name                  string         `gorm:"column:name"`
+	APIKey                string         `gorm:"column:api_key"`
+	APISecret             string         `gorm:"column:api_secret"`
+	CreatedAt             time.Time      `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt             time.Time      `gorm:"column:updated_at;autoUpdateTime"`
+	DeletedAt             gorm.DeletedAt `gorm:"column:deleted_at"`
+	Enable2FA             bool           `gorm:"column:enable_2fa"`
+	ClientCertMode        string         `gorm:"column:client_cert_mode"`
+	RequireSignedRequests bool           `gorm:"column:require_signed_requests"`
+	Status                string         `gorm:"column:status"`
+	DisabledAt            *time.Time     `gorm:"column:disabled_at"`
 }
 
 type ProjectWithRateLimit struct {
-	ID                   string         `gorm:"column:id;primaryKey"`
-	Name                 string         `gorm:"column:name"`
-	APIKey               string         `gorm:"column:api_key"`
-	APISecret            string         `gorm:"column:api_secret"`
-	CreatedAt            time.Time      `gorm:"column:created_at;autoCreateTime"`
-	UpdatedAt            time.Time      `gorm:"column:updated_at;autoUpdateTime"`
-	DeletedAt            gorm.DeletedAt `gorm:"column:deleted_at"`
-	Enable2FA            bool           `gorm:"column:enable_2fa"`
-	SMSRequestsPerHour   int64          `gorm:"column:sms_requests_per_hour"`
-	EmailRequestsPerHour int64          `gorm:"column:email_requests_per_hour"`
+	ID                    string         `gorm:"column:id;primaryKey"`
+	Name                  string         `gorm:"column:name"`
+	APIKey                string         `gorm:"column:api_key"`
+	APISecret             string         `gorm:"column:api_secret"`
+	CreatedAt             time.Time      `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt             time.Time      `gorm:"column:updated_at;autoUpdateTime"`
+	DeletedAt             gorm.DeletedAt `gorm:"column:deleted_at"`
+	Enable2FA             bool           `gorm:"column:enable_2fa"`
+	ClientCertMode        string         `gorm:"column:client_cert_mode"`
+	RequireSignedRequests bool           `gorm:"column:require_signed_requests"`
+	SMSRequestsPerHour    int64          `gorm:"column:sms_requests_per_hour"`
+	EmailRequestsPerHour  int64          `gorm:"column:email_requests_per_hour"`
 }
 
 func (Project) TableName() string {
@@ -65,3 +71,40 @@ type Migration struct {
 func (Migration) TableName() string {
 	return "shld_shamir_migrations"
 }
+
+type ClientCertificate struct {
+	ID          string    `gorm:"column:id;primaryKey"`
+	ProjectID   string    `gorm:"column:project_id"`
+	Name        string    `gorm:"column:name"`
+	Fingerprint string    `gorm:"column:fingerprint"`
+	Type        string    `gorm:"column:type"`
+	CreatedAt   time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (ClientCertificate) TableName() string {
+	return "shld_project_client_certificates"
+}
+
+type DeletionRequest struct {
+	ProjectID     string    `gorm:"column:project_id;primaryKey"`
+	TokenHash     string    `gorm:"column:token_hash"`
+	ArchiveDigest string    `gorm:"column:archive_digest"`
+	ExpiresAt     time.Time `gorm:"column:expires_at"`
+	CreatedAt     time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (DeletionRequest) TableName() string {
+	return "shld_project_deletion_requests"
+}
+
+type Invite struct {
+	ID        string     `gorm:"column:id;primaryKey"`
+	TokenHash string     `gorm:"column:token_hash"`
+	ExpiresAt time.Time  `gorm:"column:expires_at"`
+	UsedAt    *time.Time `gorm:"column:used_at"`
+	CreatedAt time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (Invite) TableName() string {
+	return "shld_registration_invites"
+}
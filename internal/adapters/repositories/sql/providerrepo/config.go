@@ -0,0 +1,15 @@
+package providerrepo
+
+import env "github.com/caarlos0/env/v10"
+
+type Config struct {
+	// SecretEncryptionKey is a base64 encoded AES key used to encrypt
+	// provider shared secrets (e.g. HMAC keys) before they're persisted.
+	SecretEncryptionKey string `env:"PROVIDER_SECRET_ENCRYPTION_KEY"`
+}
+
+func GetConfigFromEnv() (*Config, error) {
+	cfg := &Config{}
+	err := env.Parse(cfg)
+	return cfg, err
+}
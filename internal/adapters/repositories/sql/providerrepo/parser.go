@@ -12,22 +12,26 @@ type parser struct {
 func newParser() *parser {
 	return &parser{
 		mapProviderTypeToDatabase: map[provider.Type]Type{
-			provider.TypeCustom:   TypeCustom,
-			provider.TypeOpenfort: TypeOpenfort,
+			provider.TypeCustom:        TypeCustom,
+			provider.TypeOpenfort:      TypeOpenfort,
+			provider.TypeIntrospection: TypeIntrospection,
 		},
 		mapProviderTypeToDomain: map[Type]provider.Type{
-			TypeCustom:   provider.TypeCustom,
-			TypeOpenfort: provider.TypeOpenfort,
+			TypeCustom:        provider.TypeCustom,
+			TypeOpenfort:      provider.TypeOpenfort,
+			TypeIntrospection: provider.TypeIntrospection,
 		},
 		mapKeyTypeToDomain: map[KeyType]provider.KeyType{
 			KeyTypeRSA: provider.KeyTypeRSA,
 			KeyTypeEC:  provider.KeyTypeECDSA,
 			KeyTypeEd:  provider.KeyTypeEd25519,
+			KeyTypeHS:  provider.KeyTypeHMAC,
 		},
 		mapKeyTypeToDatabase: map[provider.KeyType]KeyType{
 			provider.KeyTypeRSA:     KeyTypeRSA,
 			provider.KeyTypeECDSA:   KeyTypeEC,
 			provider.KeyTypeEd25519: KeyTypeEd,
+			provider.KeyTypeHMAC:    KeyTypeHS,
 		},
 	}
 }
@@ -55,6 +59,10 @@ func (p *parser) toDomainProvider(prov Provider) *provider.Provider {
 		domainProv.Config = p.toDomainOpenfortProvider(prov.Openfort)
 	}
 
+	if prov.Introspection != nil {
+		domainProv.Config = p.toDomainIntrospectionProvider(prov.Introspection)
+	}
+
 	return domainProv
 }
 
@@ -72,6 +80,72 @@ func (p *parser) toDomainOpenfortProvider(prov *ProviderOpenfort) *provider.Open
 	}
 }
 
+func (p *parser) toDatabaseIntrospectionProvider(prov *provider.IntrospectionConfig) *ProviderIntrospection {
+	var clientID *string
+	if prov.ClientID != "" {
+		clientID = &prov.ClientID
+	}
+
+	var clientSecret *string
+	if prov.ClientSecret != "" {
+		clientSecret = &prov.ClientSecret
+	}
+
+	userIDClaim := prov.UserIDClaim
+	if userIDClaim == "" {
+		userIDClaim = provider.DefaultUserIDClaim
+	}
+
+	return &ProviderIntrospection{
+		ProviderID:   prov.ProviderID,
+		Endpoint:     prov.Endpoint,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		UserIDClaim:  userIDClaim,
+	}
+}
+
+func (p *parser) toUpdateIntrospectionProviderMap(prov *provider.IntrospectionConfig) map[string]interface{} {
+	updates := make(map[string]interface{})
+
+	if prov.Endpoint != "" {
+		updates["endpoint"] = prov.Endpoint
+	}
+
+	if prov.ClientID != "" {
+		updates["client_id"] = prov.ClientID
+	}
+
+	if prov.ClientSecret != "" {
+		updates["client_secret"] = prov.ClientSecret
+	}
+
+	if prov.UserIDClaim != "" {
+		updates["user_id_claim"] = prov.UserIDClaim
+	}
+	return updates
+}
+
+func (p *parser) toDomainIntrospectionProvider(prov *ProviderIntrospection) *provider.IntrospectionConfig {
+	clientID := ""
+	if prov.ClientID != nil {
+		clientID = *prov.ClientID
+	}
+
+	clientSecret := ""
+	if prov.ClientSecret != nil {
+		clientSecret = *prov.ClientSecret
+	}
+
+	return &provider.IntrospectionConfig{
+		ProviderID:   prov.ProviderID,
+		Endpoint:     prov.Endpoint,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		UserIDClaim:  prov.UserIDClaim,
+	}
+}
+
 func (p *parser) toDatabaseCustomProvider(prov *provider.CustomConfig) *ProviderCustom {
 	var jwkURL *string
 	if prov.JWK != "" {
@@ -83,6 +157,11 @@ func (p *parser) toDatabaseCustomProvider(prov *provider.CustomConfig) *Provider
 		pem = &prov.PEM
 	}
 
+	var secret *string
+	if prov.Secret != "" {
+		secret = &prov.Secret
+	}
+
 	var cookieFieldName *string
 	if prov.CookieFieldName != nil {
 		cookieFieldName = prov.CookieFieldName
@@ -96,6 +175,7 @@ func (p *parser) toDatabaseCustomProvider(prov *provider.CustomConfig) *Provider
 		ProviderID:      prov.ProviderID,
 		JWKUrl:          jwkURL,
 		PEM:             pem,
+		HMACSecret:      secret,
 		KeyType:         keyType,
 		CookieFieldName: cookieFieldName,
 	}
@@ -108,13 +188,21 @@ func (p *parser) toUpdateCustomProviderMap(prov *provider.CustomConfig) map[stri
 		updates["cookie_field_name"] = *prov.CookieFieldName
 	}
 
-	if prov.JWK != "" {
+	switch {
+	case prov.JWK != "":
 		updates["jwk_url"] = prov.JWK
 		updates["pem_cert"] = nil
+		updates["hmac_secret"] = nil
 		updates["key_type"] = nil
-	} else if prov.PEM != "" {
+	case prov.Secret != "":
+		updates["hmac_secret"] = prov.Secret
+		updates["jwk_url"] = nil
+		updates["pem_cert"] = nil
+		updates["key_type"] = KeyTypeHS
+	case prov.PEM != "":
 		updates["pem_cert"] = prov.PEM
 		updates["jwk_url"] = nil
+		updates["hmac_secret"] = nil
 		if keyType := p.mapKeyTypeToDatabase[prov.KeyType]; keyType != "" {
 			updates["key_type"] = keyType
 		}
@@ -133,6 +221,11 @@ func (p *parser) toDomainCustomProvider(prov *ProviderCustom) *provider.CustomCo
 		pem = *prov.PEM
 	}
 
+	secret := ""
+	if prov.HMACSecret != nil {
+		secret = *prov.HMACSecret
+	}
+
 	cookieFieldName := ""
 	if prov.CookieFieldName != nil {
 		cookieFieldName = *prov.CookieFieldName
@@ -142,11 +235,52 @@ func (p *parser) toDomainCustomProvider(prov *ProviderCustom) *provider.CustomCo
 	if prov.KeyType != nil {
 		keyType = p.mapKeyTypeToDomain[*prov.KeyType]
 	}
+	var keys []*provider.PEMKey
+	for i := range prov.Keys {
+		keys = append(keys, p.toDomainCustomKey(&prov.Keys[i]))
+	}
+
 	return &provider.CustomConfig{
 		ProviderID:      prov.ProviderID,
 		JWK:             jwk,
 		PEM:             pem,
+		Secret:          secret,
+		Keys:            keys,
 		KeyType:         keyType,
 		CookieFieldName: &cookieFieldName,
 	}
 }
+
+func (p *parser) toDatabaseCustomKey(key *provider.PEMKey) *ProviderCustomKey {
+	var kid *string
+	if key.KID != "" {
+		kid = &key.KID
+	}
+
+	return &ProviderCustomKey{
+		ID:         key.ID,
+		ProviderID: key.ProviderID,
+		KID:        kid,
+		PEM:        key.PEM,
+		KeyType:    p.mapKeyTypeToDatabase[key.KeyType],
+		NotBefore:  key.NotBefore,
+		NotAfter:   key.NotAfter,
+	}
+}
+
+func (p *parser) toDomainCustomKey(key *ProviderCustomKey) *provider.PEMKey {
+	kid := ""
+	if key.KID != nil {
+		kid = *key.KID
+	}
+
+	return &provider.PEMKey{
+		ID:         key.ID,
+		ProviderID: key.ProviderID,
+		KID:        kid,
+		PEM:        key.PEM,
+		KeyType:    p.mapKeyTypeToDomain[key.KeyType],
+		NotBefore:  key.NotBefore,
+		NotAfter:   key.NotAfter,
+	}
+}
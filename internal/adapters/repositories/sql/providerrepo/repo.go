@@ -11,23 +11,26 @@ import (
 	"github.com/openfort-xyz/shield/internal/adapters/repositories/sql"
 	"github.com/openfort-xyz/shield/internal/core/domain/provider"
 	"github.com/openfort-xyz/shield/internal/core/ports/repositories"
+	"github.com/openfort-xyz/shield/pkg/cypher"
 	"github.com/openfort-xyz/shield/pkg/logger"
 	"gorm.io/gorm"
 )
 
 type repository struct {
-	db     *sql.Client
-	logger *slog.Logger
-	parser *parser
+	db        *sql.Client
+	logger    *slog.Logger
+	parser    *parser
+	secretKey string
 }
 
 var _ repositories.ProviderRepository = (*repository)(nil)
 
-func New(db *sql.Client) repositories.ProviderRepository {
+func New(db *sql.Client, cfg *Config) repositories.ProviderRepository {
 	return &repository{
-		db:     db,
-		logger: logger.New("provider_repository"),
-		parser: newParser(),
+		db:        db,
+		logger:    logger.New("provider_repository"),
+		parser:    newParser(),
+		secretKey: cfg.SecretEncryptionKey,
 	}
 }
 
@@ -52,7 +55,7 @@ func (r *repository) GetByProjectAndType(ctx context.Context, projectID string,
 	r.logger.InfoContext(ctx, "getting provider", slog.String("project_id", projectID), slog.String("provider_type", providerType.String()))
 
 	dbProv := Provider{}
-	err := r.db.Preload("Custom").Preload("Openfort").Where("project_id = ? AND type = ?", projectID, r.parser.mapProviderTypeToDatabase[providerType]).First(&dbProv).Error
+	err := r.db.Preload("Custom").Preload("Custom.Keys").Preload("Openfort").Preload("Introspection").Where("project_id = ? AND type = ?", projectID, r.parser.mapProviderTypeToDatabase[providerType]).First(&dbProv).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domainErrors.ErrProviderNotFound
@@ -61,14 +64,20 @@ func (r *repository) GetByProjectAndType(ctx context.Context, projectID string,
 		return nil, err
 	}
 
-	return r.parser.toDomainProvider(dbProv), nil
+	prov := r.parser.toDomainProvider(dbProv)
+	if err = r.decryptConfig(prov); err != nil {
+		r.logger.ErrorContext(ctx, "error decrypting provider secret", logger.Error(err))
+		return nil, err
+	}
+
+	return prov, nil
 }
 
 func (r *repository) Get(ctx context.Context, id string) (*provider.Provider, error) {
 	r.logger.InfoContext(ctx, "getting provider", slog.String("provider_id", id))
 
 	dbProv := Provider{}
-	err := r.db.Preload("Custom").Preload("Openfort").Where("id = ?", id).First(&dbProv).Error
+	err := r.db.Preload("Custom").Preload("Custom.Keys").Preload("Openfort").Preload("Introspection").Where("id = ?", id).First(&dbProv).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domainErrors.ErrProviderNotFound
@@ -77,7 +86,13 @@ func (r *repository) Get(ctx context.Context, id string) (*provider.Provider, er
 		return nil, err
 	}
 
-	return r.parser.toDomainProvider(dbProv), nil
+	prov := r.parser.toDomainProvider(dbProv)
+	if err = r.decryptConfig(prov); err != nil {
+		r.logger.ErrorContext(ctx, "error decrypting provider secret", logger.Error(err))
+		return nil, err
+	}
+
+	return prov, nil
 }
 
 func (r *repository) List(ctx context.Context, projectID string) ([]*provider.Provider, error) {
@@ -118,6 +133,15 @@ func (r *repository) CreateCustom(ctx context.Context, prov *provider.CustomConf
 	r.logger.InfoContext(ctx, "creating custom provider", slog.String("provider_id", prov.ProviderID))
 
 	dbProv := r.parser.toDatabaseCustomProvider(prov)
+	if dbProv.HMACSecret != nil {
+		secret, err := r.encryptSecret(*dbProv.HMACSecret)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "error encrypting provider secret", logger.Error(err))
+			return err
+		}
+		dbProv.HMACSecret = &secret
+	}
+
 	err := r.db.Create(dbProv).Error
 	if err != nil {
 		r.logger.ErrorContext(ctx, "error creating custom provider", logger.Error(err))
@@ -131,7 +155,7 @@ func (r *repository) GetCustom(ctx context.Context, providerID string) (*provide
 	r.logger.InfoContext(ctx, "getting custom provider", slog.String("provider_id", providerID))
 
 	dbProv := &ProviderCustom{}
-	err := r.db.Where("provider_id = ?", providerID).First(dbProv).Error
+	err := r.db.Preload("Keys").Where("provider_id = ?", providerID).First(dbProv).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domainErrors.ErrProviderNotFound
@@ -140,22 +164,91 @@ func (r *repository) GetCustom(ctx context.Context, providerID string) (*provide
 		return nil, err
 	}
 
-	return r.parser.toDomainCustomProvider(dbProv), nil
+	cfg := r.parser.toDomainCustomProvider(dbProv)
+	if cfg.Secret, err = r.decrypt(cfg.Secret); err != nil {
+		r.logger.ErrorContext(ctx, "error decrypting provider secret", logger.Error(err))
+		return nil, err
+	}
+
+	return cfg, nil
 }
 
 func (r *repository) UpdateCustom(ctx context.Context, prov *provider.CustomConfig) error {
 	r.logger.InfoContext(ctx, "updating custom provider", slog.String("provider_id", prov.ProviderID))
 
 	updates := r.parser.toUpdateCustomProviderMap(prov)
-	err := r.db.Model(&ProviderCustom{}).Where("provider_id = ?", prov.ProviderID).Updates(updates).Error
+	if secret, ok := updates["hmac_secret"].(string); ok {
+		encrypted, err := r.encryptSecret(secret)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "error encrypting provider secret", logger.Error(err))
+			return err
+		}
+		updates["hmac_secret"] = encrypted
+	}
+
+	// Switching to a JWK URL or a shared secret replaces PEM verification
+	// entirely, so any rotating keys registered for the provider go with it
+	_, jwkSet := updates["jwk_url"].(string)
+	_, secretSet := updates["hmac_secret"].(string)
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&ProviderCustom{}).Where("provider_id = ?", prov.ProviderID).Updates(updates).Error
+		if err != nil {
+			r.logger.ErrorContext(ctx, "error updating custom provider", logger.Error(err))
+			return err
+		}
+
+		if jwkSet || secretSet {
+			err = tx.Where("provider_id = ?", prov.ProviderID).Delete(&ProviderCustomKey{}).Error
+			if err != nil {
+				r.logger.ErrorContext(ctx, "error deleting custom provider keys", logger.Error(err))
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *repository) AddCustomKey(ctx context.Context, key *provider.PEMKey) error {
+	r.logger.InfoContext(ctx, "adding custom provider key", slog.String("provider_id", key.ProviderID))
+
+	if key.ID == "" {
+		key.ID = uuid.NewString()
+	}
+
+	dbKey := r.parser.toDatabaseCustomKey(key)
+	err := r.db.Create(dbKey).Error
 	if err != nil {
-		r.logger.ErrorContext(ctx, "error updating custom provider", logger.Error(err))
+		r.logger.ErrorContext(ctx, "error adding custom provider key", logger.Error(err))
 		return err
 	}
 
 	return nil
 }
 
+func (r *repository) DeleteCustomKey(ctx context.Context, providerID, keyID string) error {
+	r.logger.InfoContext(ctx, "deleting custom provider key", slog.String("provider_id", providerID), slog.String("key_id", keyID))
+
+	var cmd *gorm.DB
+	if keyID == provider.LegacyPEMKeyID {
+		// The legacy PEM lives on the provider itself, retiring it clears it
+		cmd = r.db.Model(&ProviderCustom{}).Where("provider_id = ? AND pem_cert IS NOT NULL", providerID).Updates(map[string]interface{}{"pem_cert": nil, "key_type": nil})
+	} else {
+		cmd = r.db.Where("provider_id = ? AND id = ?", providerID, keyID).Delete(&ProviderCustomKey{})
+	}
+	if cmd.Error != nil {
+		r.logger.ErrorContext(ctx, "error deleting custom provider key", logger.Error(cmd.Error))
+		return cmd.Error
+	}
+
+	if cmd.RowsAffected == 0 {
+		return domainErrors.ErrProviderKeyNotFound
+	}
+
+	return nil
+}
+
 func (r *repository) CreateOpenfort(ctx context.Context, prov *provider.OpenfortConfig) error {
 	r.logger.InfoContext(ctx, "creating openfort provider", slog.String("provider_id", prov.ProviderID))
 
@@ -197,3 +290,97 @@ func (r *repository) UpdateOpenfort(ctx context.Context, prov *provider.Openfort
 
 	return nil
 }
+
+func (r *repository) CreateIntrospection(ctx context.Context, prov *provider.IntrospectionConfig) error {
+	r.logger.InfoContext(ctx, "creating introspection provider", slog.String("provider_id", prov.ProviderID))
+
+	dbProv := r.parser.toDatabaseIntrospectionProvider(prov)
+	if dbProv.ClientSecret != nil {
+		secret, err := r.encryptSecret(*dbProv.ClientSecret)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "error encrypting provider secret", logger.Error(err))
+			return err
+		}
+		dbProv.ClientSecret = &secret
+	}
+
+	err := r.db.Create(dbProv).Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error creating introspection provider", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) GetIntrospection(ctx context.Context, providerID string) (*provider.IntrospectionConfig, error) {
+	r.logger.InfoContext(ctx, "getting introspection provider", slog.String("provider_id", providerID))
+
+	dbProv := &ProviderIntrospection{}
+	err := r.db.Where("provider_id = ?", providerID).First(dbProv).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrProviderNotFound
+		}
+		r.logger.ErrorContext(ctx, "error getting introspection provider", logger.Error(err))
+		return nil, err
+	}
+
+	cfg := r.parser.toDomainIntrospectionProvider(dbProv)
+	if cfg.ClientSecret, err = r.decrypt(cfg.ClientSecret); err != nil {
+		r.logger.ErrorContext(ctx, "error decrypting provider secret", logger.Error(err))
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func (r *repository) UpdateIntrospection(ctx context.Context, prov *provider.IntrospectionConfig) error {
+	r.logger.InfoContext(ctx, "updating introspection provider", slog.String("provider_id", prov.ProviderID))
+
+	updates := r.parser.toUpdateIntrospectionProviderMap(prov)
+	if secret, ok := updates["client_secret"].(string); ok {
+		encrypted, err := r.encryptSecret(secret)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "error encrypting provider secret", logger.Error(err))
+			return err
+		}
+		updates["client_secret"] = encrypted
+	}
+
+	err := r.db.Model(&ProviderIntrospection{}).Where("provider_id = ?", prov.ProviderID).Updates(updates).Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error updating introspection provider", logger.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) encryptSecret(secret string) (string, error) {
+	if r.secretKey == "" {
+		return "", domainErrors.ErrSecretEncryptionNotSet
+	}
+	return cypher.Encrypt(secret, r.secretKey)
+}
+
+func (r *repository) decryptConfig(prov *provider.Provider) error {
+	var err error
+	switch cfg := prov.Config.(type) {
+	case *provider.CustomConfig:
+		cfg.Secret, err = r.decrypt(cfg.Secret)
+	case *provider.IntrospectionConfig:
+		cfg.ClientSecret, err = r.decrypt(cfg.ClientSecret)
+	}
+	return err
+}
+
+func (r *repository) decrypt(secret string) (string, error) {
+	if secret == "" {
+		return "", nil
+	}
+	if r.secretKey == "" {
+		return "", domainErrors.ErrSecretEncryptionNotSet
+	}
+	return cypher.Decrypt(secret, r.secretKey)
+}
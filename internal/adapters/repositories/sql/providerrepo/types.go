@@ -14,8 +14,9 @@ type Provider struct {
 	UpdatedAt time.Time      `gorm:"column:updated_at;autoUpdateTime"`
 	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at"`
 
-	Openfort *ProviderOpenfort
-	Custom   *ProviderCustom
+	Openfort      *ProviderOpenfort
+	Custom        *ProviderCustom
+	Introspection *ProviderIntrospection
 }
 
 func (Provider) TableName() string {
@@ -25,8 +26,9 @@ func (Provider) TableName() string {
 type Type string
 
 const (
-	TypeOpenfort Type = "OPENFORT"
-	TypeCustom   Type = "CUSTOM"
+	TypeOpenfort      Type = "OPENFORT"
+	TypeCustom        Type = "CUSTOM"
+	TypeIntrospection Type = "INTROSPECTION"
 )
 
 type ProviderOpenfort struct {
@@ -38,22 +40,53 @@ func (ProviderOpenfort) TableName() string {
 	return "shld_openfort_providers"
 }
 
+type ProviderIntrospection struct {
+	ProviderID   string  `gorm:"column:provider_id;primary_key"`
+	Endpoint     string  `gorm:"column:endpoint"`
+	ClientID     *string `gorm:"column:client_id"`
+	ClientSecret *string `gorm:"column:client_secret"`
+	UserIDClaim  string  `gorm:"column:user_id_claim"`
+}
+
+func (ProviderIntrospection) TableName() string {
+	return "shld_introspection_providers"
+}
+
 type ProviderCustom struct {
 	ProviderID      string   `gorm:"column:provider_id;primary_key"`
 	JWKUrl          *string  `gorm:"column:jwk_url"`
 	PEM             *string  `gorm:"column:pem_cert"`
+	HMACSecret      *string  `gorm:"column:hmac_secret"`
 	CookieFieldName *string  `gorm:"column:cookie_field_name"`
 	KeyType         *KeyType `gorm:"column:key_type"`
+
+	Keys []ProviderCustomKey `gorm:"foreignKey:ProviderID;references:ProviderID"`
 }
 
 func (ProviderCustom) TableName() string {
 	return "shld_custom_providers"
 }
 
+type ProviderCustomKey struct {
+	ID         string     `gorm:"column:id;primary_key"`
+	ProviderID string     `gorm:"column:provider_id;not null"`
+	KID        *string    `gorm:"column:kid"`
+	PEM        string     `gorm:"column:pem_cert;not null"`
+	KeyType    KeyType    `gorm:"column:key_type;not null"`
+	NotBefore  *time.Time `gorm:"column:not_before"`
+	NotAfter   *time.Time `gorm:"column:not_after"`
+	CreatedAt  time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (ProviderCustomKey) TableName() string {
+	return "shld_custom_provider_keys"
+}
+
 type KeyType string
 
 const (
 	KeyTypeRSA KeyType = "RSA"
 	KeyTypeEC  KeyType = "ECDSA"
 	KeyTypeEd  KeyType = "ED25519"
+	KeyTypeHS  KeyType = "HMAC"
 )
@@ -1,6 +1,7 @@
 package sharerepo
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/openfort-xyz/shield/internal/core/domain/share"
@@ -107,6 +108,10 @@ func (p *parser) toDomain(s *Share) *share.Share {
 		Reference:            s.Reference,
 		ShareStorageMethodID: p.mapStorageMethodDomain[s.ShareStorageMethodID],
 		PasskeyReference:     passkeyReference,
+		Metadata:             databaseToMetadata(s.Metadata),
+		Version:              s.Version,
+		CreatedAt:            s.CreatedAt,
+		UpdatedAt:            s.UpdatedAt,
 	}
 }
 
@@ -131,6 +136,35 @@ func envToDatabase(p *share.PasskeyEnv) *string {
 	return nil
 }
 
+// databaseToMetadata reads the metadata column, a share without labels has
+// none.
+func databaseToMetadata(s *string) share.Metadata {
+	if s == nil {
+		return nil
+	}
+
+	var metadata share.Metadata
+	if err := json.Unmarshal([]byte(*s), &metadata); err != nil || len(metadata) == 0 {
+		return nil
+	}
+	return metadata
+}
+
+// metadataToDatabase writes the metadata column. Nil metadata leaves the
+// column as it is on update, empty metadata clears it.
+func metadataToDatabase(m share.Metadata) *string {
+	if m == nil {
+		return nil
+	}
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		return nil
+	}
+	ret := string(raw)
+	return &ret
+}
+
 func (p *parser) toDatabase(s *share.Share) *Share {
 	var usrID *string
 	if s.UserID != "" {
@@ -144,6 +178,8 @@ func (p *parser) toDatabase(s *share.Share) *Share {
 		Reference:            s.Reference,
 		ShareStorageMethodID: p.mapDomainStorageMethod[s.ShareStorageMethodID],
 		Entropy:              p.mapDomainEntropy[s.Entropy],
+		Metadata:             metadataToDatabase(s.Metadata),
+		Version:              s.Version,
 	}
 
 	if s.EncryptionParameters != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"strings"
 
 	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
 
@@ -82,6 +83,47 @@ func (r *repository) ListByKeychainID(ctx context.Context, keychainID string) ([
 	return shares, nil
 }
 
+func (r *repository) ListPageByKeychainID(ctx context.Context, keychainID string, filter *share.ListFilter, afterID string, limit int) ([]*share.Share, error) {
+	r.logger.InfoContext(ctx, "listing shares", slog.String("keychain_id", keychainID), slog.String("after", afterID))
+
+	query := r.db.Preload("PasskeyReference").Where("keychain_id = ?", keychainID)
+	if filter != nil {
+		if filter.ReferencePrefix != "" {
+			query = query.Where(`reference LIKE ? ESCAPE '\'`, likeEscaper.Replace(filter.ReferencePrefix)+"%")
+		}
+		if filter.Entropy != 0 {
+			query = query.Where("entropy = ?", r.parser.mapDomainEntropy[filter.Entropy])
+		}
+		if len(filter.Metadata) != 0 {
+			query = query.Where("metadata @> ?::jsonb", *metadataToDatabase(filter.Metadata))
+		}
+	}
+	if afterID != "" {
+		query = query.Where("id > ?", afterID)
+	}
+	query = query.Order("id")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var dbShares []*Share
+	err := query.Find(&dbShares).Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error listing shares", logger.Error(err))
+		return nil, err
+	}
+
+	shares := make([]*share.Share, 0, len(dbShares))
+	for _, dbShr := range dbShares {
+		shares = append(shares, r.parser.toDomain(dbShr))
+	}
+
+	return shares, nil
+}
+
+// likeEscaper escapes the LIKE wildcards of a reference prefix.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 func (r *repository) GetByReference(ctx context.Context, reference string) (*share.Share, error) {
 	r.logger.InfoContext(ctx, "getting share", slog.String("reference", reference))
 
@@ -184,10 +226,38 @@ func (r *repository) ListProjectIDAndEntropy(ctx context.Context, projectID stri
 	return shares, nil
 }
 
+func (r *repository) ListByProject(ctx context.Context, projectID string, afterID string, limit int) ([]*share.Share, error) {
+	r.logger.InfoContext(ctx, "listing project shares", slog.String("project_id", projectID), slog.String("after", afterID))
+
+	query := r.db.Preload("PasskeyReference").Joins("JOIN shld_users ON shld_shares.user_id = shld_users.id").
+		Where("shld_users.project_id = ?", projectID)
+	if afterID != "" {
+		query = query.Where("shld_shares.id > ?", afterID)
+	}
+
+	var dbShares []*Share
+	err := query.Order("shld_shares.id").Limit(limit).Find(&dbShares).Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error listing project shares", logger.Error(err))
+		return nil, err
+	}
+
+	shares := make([]*share.Share, 0, len(dbShares))
+	for _, dbShr := range dbShares {
+		shares = append(shares, r.parser.toDomain(dbShr))
+	}
+
+	return shares, nil
+}
+
 func (r *repository) UpdateProjectEncryption(ctx context.Context, shareID string, encrypted string) error {
 	r.logger.InfoContext(ctx, "updating share", slog.String("id", shareID))
 
-	err := r.db.Model(&Share{}).Where("id = ?", shareID).Update("data", encrypted).Update("entropy", EntropyProject).Error
+	err := r.db.Model(&Share{}).Where("id = ?", shareID).Updates(map[string]any{
+		"data":    encrypted,
+		"entropy": EntropyProject,
+		"version": gorm.Expr("version + 1"),
+	}).Error
 	if err != nil {
 		r.logger.ErrorContext(ctx, "error updating share", logger.Error(err))
 		return err
@@ -196,42 +266,127 @@ func (r *repository) UpdateProjectEncryption(ctx context.Context, shareID string
 	return nil
 }
 
+func (r *repository) UpdateReference(ctx context.Context, keychainID, reference, newReference string) error {
+	r.logger.InfoContext(ctx, "renaming share reference", slog.String("keychain_id", keychainID), slog.String("reference", reference))
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// References are looked up across keychains, two renames to the same
+		// one have to take turns for only one of them to get it
+		err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", newReference).Error
+		if err != nil {
+			return err
+		}
+
+		res := tx.Model(&Share{}).
+			Where("keychain_id = ? AND reference = ?", keychainID, reference).
+			Where("NOT EXISTS (SELECT 1 FROM shld_shares taken WHERE taken.reference = ? AND taken.deleted_at IS NULL)", newReference).
+			Updates(map[string]any{
+				"reference": newReference,
+				"version":   gorm.Expr("version + 1"),
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected > 0 {
+			return nil
+		}
+
+		var count int64
+		err = tx.Model(&Share{}).Where("keychain_id = ? AND reference = ?", keychainID, reference).Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return domainErrors.ErrShareNotFound
+		}
+		return domainErrors.ErrShareAlreadyExists
+	})
+	if err != nil {
+		if !errors.Is(err, domainErrors.ErrShareNotFound) && !errors.Is(err, domainErrors.ErrShareAlreadyExists) {
+			r.logger.ErrorContext(ctx, "error renaming share reference", logger.Error(err))
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *repository) UpdateKeychain(ctx context.Context, shareID, keychainID, userID string) error {
+	r.logger.InfoContext(ctx, "moving share", slog.String("id", shareID), slog.String("keychain_id", keychainID))
+
+	res := r.db.Model(&Share{}).Where("id = ?", shareID).Updates(map[string]any{
+		"keychain_id": keychainID,
+		"user_id":     userID,
+		"version":     gorm.Expr("version + 1"),
+	})
+	if res.Error != nil {
+		r.logger.ErrorContext(ctx, "error moving share", logger.Error(res.Error))
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domainErrors.ErrShareNotFound
+	}
+
+	return nil
+}
+
 // Intentionally left out of ShareRepository interface
 // since usage is only internal
-func updatePasskeyReference(r *repository, passkeyReference *PasskeyReference) error {
+func updatePasskeyReference(tx *gorm.DB, passkeyReference *PasskeyReference) error {
 	if passkeyReference != nil {
 		// WHERE clause is necessary for GORM >= v2 (it won't figure out which field to change even if PK is provided otherwise)
-		return r.db.Model(&PasskeyReference{}).Where("share_reference = ?", passkeyReference.ShareReference).Save(passkeyReference).Error
+		return tx.Model(&PasskeyReference{}).Where("share_reference = ?", passkeyReference.ShareReference).Save(passkeyReference).Error
 	}
 	return nil
 }
 
 // Intentionally left out of ShareRepository interface
 // since usage is only internal
-func updateShare(r *repository, dbShr *Share) error {
-	err := updatePasskeyReference(r, dbShr.PasskeyReference)
-
-	if err != nil {
-		return err
-	}
-
-	return r.db.
+//
+// updateShare only applies to the version the share was read at, and moves
+// it to the next one.
+func updateShare(tx *gorm.DB, dbShr *Share) error {
+	version := dbShr.Version
+	dbShr.Version++
+
+	res := tx.
 		Session(&gorm.Session{FullSaveAssociations: true}).
 		Model(&Share{}).
-		Where("id = ?", dbShr.ID).
-		Updates(dbShr).Error
+		Where("id = ? AND version = ?", dbShr.ID, version).
+		Updates(dbShr)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		var count int64
+		err := tx.Model(&Share{}).Where("id = ?", dbShr.ID).Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return domainErrors.ErrShareNotFound
+		}
+		return domainErrors.ErrShareVersionMismatch
+	}
+
+	return updatePasskeyReference(tx, dbShr.PasskeyReference)
 }
 
 func (r *repository) Update(ctx context.Context, shr *share.Share) error {
 	r.logger.InfoContext(ctx, "updating share", slog.String("id", shr.ID))
 
 	dbShr := r.parser.toDatabase(shr)
-	err := updateShare(r, dbShr)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		return updateShare(tx, dbShr)
+	})
 	if err != nil {
-		r.logger.ErrorContext(ctx, "error updating share", logger.Error(err))
+		if !errors.Is(err, domainErrors.ErrShareVersionMismatch) {
+			r.logger.ErrorContext(ctx, "error updating share", logger.Error(err))
+		}
 		return err
 	}
 
+	shr.Version = dbShr.Version
 	return nil
 }
 
@@ -243,9 +398,9 @@ func (r *repository) BulkUpdate(ctx context.Context, shrs []*share.Share) error
 		dbShares = append(dbShares, r.parser.toDatabase(shr))
 	}
 
-	return r.db.Transaction(func(_ *gorm.DB) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
 		for _, dbShr := range dbShares {
-			err := updateShare(r, dbShr)
+			err := updateShare(tx, dbShr)
 			if err != nil {
 				r.logger.ErrorContext(ctx, "error updating share", logger.Error(err))
 				return err
@@ -282,7 +437,7 @@ func (r *repository) GetSharesEncryptionForProjectAndReferences(ctx context.Cont
 
 	var queryResult []InfoByReference
 	err := r.db.Table("shld_shares").
-		Select("shld_shares.reference AS reference, shld_shares.entropy AS entropy, shld_passkey_references.passkey_id AS passkey_id, shld_passkey_references.passkey_env AS passkey_env").
+		Select("shld_shares.reference AS reference, shld_shares.entropy AS entropy, shld_passkey_references.passkey_id AS passkey_id, shld_passkey_references.passkey_env AS passkey_env, shld_shares.metadata AS metadata").
 		Joins("LEFT JOIN shld_passkey_references ON shld_shares.id = shld_passkey_references.share_reference").
 		Joins("JOIN shld_users ON shld_shares.user_id = shld_users.id").
 		Where("shld_shares.reference IN ?", references).
@@ -302,6 +457,7 @@ func (r *repository) GetSharesEncryptionForProjectAndReferences(ctx context.Cont
 			Entropy:    r.parser.mapEntropyDomain[row.Entropy],
 			PasskeyID:  row.PasskeyID,
 			PasskeyEnv: row.PasskeyEnv,
+			Metadata:   databaseToMetadata(row.Metadata),
 		}
 	}
 
@@ -315,7 +471,7 @@ func (r *repository) GetSharesEncryptionForProjectAndExternalUserIDs(ctx context
 
 	var queryResult []InfoByUserID
 	req := r.db.Table("shld_shares").
-		Select("shld_external_users.external_user_id AS user_id, shld_shares.entropy AS entropy, shld_passkey_references.passkey_id AS passkey_id, shld_passkey_references.passkey_env AS passkey_env").
+		Select("shld_external_users.external_user_id AS user_id, shld_shares.entropy AS entropy, shld_passkey_references.passkey_id AS passkey_id, shld_passkey_references.passkey_env AS passkey_env, shld_shares.metadata AS metadata").
 		Joins("LEFT JOIN shld_passkey_references ON shld_shares.id = shld_passkey_references.share_reference").
 		Joins("JOIN shld_users ON shld_shares.user_id = shld_users.id").
 		Joins("JOIN shld_external_users ON shld_external_users.user_id = shld_users.id").
@@ -342,6 +498,7 @@ func (r *repository) GetSharesEncryptionForProjectAndExternalUserIDs(ctx context
 			Entropy:    r.parser.mapEntropyDomain[row.Entropy],
 			PasskeyID:  row.PasskeyID,
 			PasskeyEnv: row.PasskeyEnv,
+			Metadata:   databaseToMetadata(row.Metadata),
 		}
 	}
 
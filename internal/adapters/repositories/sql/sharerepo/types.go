@@ -20,6 +20,8 @@ type Share struct {
 	ShareStorageMethodID ShareStorageMethodID `gorm:"column:storage_method_id;not null"`
 	ShareStorageMethod   *ShareStorageMethod  `gorm:"foreignKey:ShareStorageMethodID"`
 	PasskeyReference     *PasskeyReference    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:ShareReference;references:ID"`
+	Metadata             *string              `gorm:"column:metadata;default:null"`
+	Version              int64                `gorm:"column:version;default:1"`
 	CreatedAt            time.Time            `gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt            time.Time            `gorm:"column:updated_at;autoUpdateTime"`
 	DeletedAt            gorm.DeletedAt       `gorm:"column:deleted_at"`
@@ -71,6 +73,7 @@ type InfoByReference struct {
 	Entropy    Entropy
 	PasskeyID  *string
 	PasskeyEnv *string
+	Metadata   *string
 }
 
 type InfoByUserID struct {
@@ -78,4 +81,5 @@ type InfoByUserID struct {
 	Entropy    Entropy
 	PasskeyID  *string
 	PasskeyEnv *string
+	Metadata   *string
 }
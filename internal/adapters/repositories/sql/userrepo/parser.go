@@ -1,20 +1,31 @@
 package userrepo
 
 import (
+	"github.com/openfort-xyz/shield/internal/core/domain/share"
 	"github.com/openfort-xyz/shield/internal/core/domain/user"
 )
 
 type parser struct {
+	// mapEntropyToDatabase matches the values the share repository stores
+	mapEntropyToDatabase map[share.Entropy]string
 }
 
 func newParser() *parser {
-	return &parser{}
+	return &parser{
+		mapEntropyToDatabase: map[share.Entropy]string{
+			share.EntropyNone:    "none",
+			share.EntropyUser:    "user",
+			share.EntropyProject: "project",
+			share.EntropyPasskey: "passkey",
+		},
+	}
 }
 
 func (p *parser) toDomain(u *User) *user.User {
 	return &user.User{
 		ID:        u.ID,
 		ProjectID: u.ProjectID,
+		CreatedAt: u.CreatedAt,
 	}
 }
 
@@ -22,6 +33,7 @@ func (p *parser) toDatabase(u *user.User) *User {
 	return &User{
 		ID:        u.ID,
 		ProjectID: u.ProjectID,
+		CreatedAt: u.CreatedAt,
 	}
 }
 
@@ -152,3 +152,40 @@ func (r *repository) GetUserIDsByExternalID(ctx context.Context, externalUserID
 
 	return response, nil
 }
+
+func (r *repository) ListByProject(ctx context.Context, projectID string, filter *user.ListFilter, after *user.Cursor, limit int) ([]*user.User, error) {
+	r.logger.InfoContext(ctx, "listing users", slog.String("project_id", projectID))
+
+	query := r.db.Where("shld_users.project_id = ?", projectID)
+	if filter != nil {
+		if filter.ProviderID != "" {
+			query = query.Where("EXISTS (SELECT 1 FROM shld_external_users eu WHERE eu.user_id = shld_users.id AND eu.provider_id = ? AND eu.deleted_at IS NULL)", filter.ProviderID)
+		}
+		if filter.Entropy != 0 {
+			query = query.Where("EXISTS (SELECT 1 FROM shld_shares s WHERE (s.user_id = shld_users.id OR s.keychain_id IN (SELECT k.id FROM shld_keychains k WHERE k.user_id = shld_users.id)) AND s.entropy = ? AND s.deleted_at IS NULL)", r.parser.mapEntropyToDatabase[filter.Entropy])
+		}
+		if filter.CreatedAfter != nil {
+			query = query.Where("shld_users.created_at >= ?", *filter.CreatedAfter)
+		}
+		if filter.CreatedBefore != nil {
+			query = query.Where("shld_users.created_at < ?", *filter.CreatedBefore)
+		}
+	}
+	if after != nil {
+		query = query.Where("(shld_users.created_at, shld_users.id) > (?, ?)", after.CreatedAt, after.ID)
+	}
+
+	var dbUsrs []*User
+	err := query.Order("shld_users.created_at, shld_users.id").Limit(limit).Find(&dbUsrs).Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error listing users", logger.Error(err))
+		return nil, err
+	}
+
+	usrs := make([]*user.User, len(dbUsrs))
+	for i, dbUsr := range dbUsrs {
+		usrs[i] = r.parser.toDomain(dbUsr)
+	}
+
+	return usrs, nil
+}
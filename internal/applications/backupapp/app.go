@@ -0,0 +1,169 @@
+package backupapp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+
+	"github.com/openfort-xyz/shield/internal/core/ports/factories"
+	"github.com/openfort-xyz/shield/internal/core/ports/repositories"
+	"github.com/openfort-xyz/shield/pkg/cypher"
+	"github.com/openfort-xyz/shield/pkg/logger"
+)
+
+// KeySize is the size of the key backups are encrypted with.
+const KeySize = 32
+
+// Application backs up every Shield table into one encrypted file, checks
+// such a file can be used and restores it into an empty database.
+type Application struct {
+	backupRepo        repositories.BackupRepository
+	encryptionFactory factories.EncryptionFactory
+	logger            *slog.Logger
+}
+
+func New(backupRepo repositories.BackupRepository, encryptionFactory factories.EncryptionFactory) *Application {
+	return &Application{
+		backupRepo:        backupRepo,
+		encryptionFactory: encryptionFactory,
+		logger:            logger.New("backup_application"),
+	}
+}
+
+// Create writes a consistent snapshot of the database to w, encrypted with
+// the base64 encoded 32 byte key.
+func (a *Application) Create(ctx context.Context, w io.Writer, key string) (*Manifest, error) {
+	a.logger.InfoContext(ctx, "creating backup")
+
+	stream, err := cypher.NewStreamWriter(w, key)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to open backup stream", logger.Error(err))
+		return nil, fromDomainError(err)
+	}
+
+	writer := newBackupWriter(stream)
+	err = a.backupRepo.Snapshot(ctx, writer.begin, writer.row)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to snapshot database", logger.Error(err))
+		return nil, fromDomainError(err)
+	}
+
+	err = writer.finish()
+	if err == nil {
+		err = stream.Close()
+	}
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to write backup", logger.Error(err))
+		return nil, ErrInternal
+	}
+
+	return writer.manifest, nil
+}
+
+// Restore loads a backup into a database migrated to the backup's schema
+// version that holds no data yet. Nothing is written unless the whole backup
+// checks out.
+func (a *Application) Restore(ctx context.Context, r io.Reader, key string) (*RestoreReport, error) {
+	a.logger.InfoContext(ctx, "restoring backup")
+
+	reader, err := openBackup(r, key)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to open backup", logger.Error(err))
+		return nil, err
+	}
+
+	restored, err := a.backupRepo.Restore(ctx, reader.snapshot, reader.next)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to restore backup", logger.Error(err))
+		return nil, fromDomainError(err)
+	}
+
+	return &RestoreReport{Manifest: reader.manifest, Restored: restored}, nil
+}
+
+// Verify reads a whole backup, checking every table against its digest, and
+// decrypts up to sample project entropy shares of each project a part is
+// given for, keyed by project ID. Shares that fail to decrypt are counted in
+// the report rather than returned as an error.
+func (a *Application) Verify(ctx context.Context, r io.Reader, key string, projectParts map[string]string, sample int) (*VerifyReport, error) {
+	a.logger.InfoContext(ctx, "verifying backup", slog.Int("projects", len(projectParts)))
+
+	reader, err := openBackup(r, key)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to open backup", logger.Error(err))
+		return nil, err
+	}
+
+	sampler := newShareSampler(projectParts, sample)
+	for {
+		table, row, err := reader.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			a.logger.ErrorContext(ctx, "failed to read backup", logger.Error(err))
+			return nil, err
+		}
+
+		err = sampler.add(table, row)
+		if err != nil {
+			a.logger.ErrorContext(ctx, "failed to decode backup row", slog.String("table", table), logger.Error(err))
+			return nil, ErrInvalidBackup
+		}
+	}
+
+	for projectID := range projectParts {
+		if !sampler.projects[projectID] {
+			return nil, ErrProjectNotInBackup
+		}
+	}
+
+	return &VerifyReport{
+		Manifest: reader.manifest,
+		Projects: sampler.verify(a.encryptionFactory),
+	}, nil
+}
+
+func openBackup(r io.Reader, key string) (*backupReader, error) {
+	stream, err := cypher.NewStreamReader(r, key)
+	if err != nil {
+		return nil, fromDomainError(err)
+	}
+
+	return newBackupReader(stream)
+}
+
+// RestoreReport counts the rows restored by table. Rows the migrations seed
+// were already there and aren't counted.
+type RestoreReport struct {
+	Manifest *Manifest
+	Restored map[string]int64
+}
+
+// VerifyReport is what verifying a backup found, the projects sorted by ID.
+type VerifyReport struct {
+	Manifest *Manifest
+	Projects []ProjectVerification
+}
+
+// ProjectVerification tells how many of a project's project entropy shares
+// were sampled and decrypted. Error is set when the project's encryption key
+// couldn't be rebuilt from the part given and the backup.
+type ProjectVerification struct {
+	ProjectID string
+	Shares    int64
+	Sampled   int
+	Decrypted int
+	Error     string
+}
+
+// Failed is true when a sampled share of any project didn't decrypt.
+func (r *VerifyReport) Failed() bool {
+	for _, p := range r.Projects {
+		if p.Decrypted < p.Sampled || p.Error != "" {
+			return true
+		}
+	}
+	return false
+}
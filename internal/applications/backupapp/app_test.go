@@ -0,0 +1,160 @@
+package backupapp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/openfort-xyz/shield/internal/adapters/encryption"
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/mocks/backupmockrepo"
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/mocks/encryptionpartsmockrepo"
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/mocks/projectmockrepo"
+	"github.com/openfort-xyz/shield/internal/core/domain/backup"
+	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
+	"github.com/openfort-xyz/shield/pkg/random"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type backupRow struct {
+	table string
+	row   string
+}
+
+func TestApplication_CreateVerifyRestore(t *testing.T) {
+	ctx := context.Background()
+	backupRepo := new(backupmockrepo.MockBackupRepository)
+	encryptionFactory := encryption.NewEncryptionFactory(new(encryptionpartsmockrepo.MockEncryptionPartsRepository), new(projectmockrepo.MockProjectRepository))
+	app := New(backupRepo, encryptionFactory)
+
+	backupKey, err := random.GenerateRandomString(32)
+	require.NoError(t, err)
+	otherKey, err := random.GenerateRandomString(32)
+	require.NoError(t, err)
+
+	encryptionKey, err := random.GenerateRandomString(32)
+	require.NoError(t, err)
+	storedPart, projectPart, err := encryptionFactory.CreateReconstructionStrategy(true).Split(encryptionKey)
+	require.NoError(t, err)
+	_, otherPart, err := encryptionFactory.CreateReconstructionStrategy(true).Split(encryptionKey)
+	require.NoError(t, err)
+	secret, err := encryptionFactory.CreateEncryptionStrategy(encryptionKey).Encrypt("secret")
+	require.NoError(t, err)
+
+	snapshot := &backup.Snapshot{
+		SchemaVersion: 20261019160000,
+		Tables:        []string{"shld_projects", "shld_encryption_parts", "shld_shamir_migrations", "shld_users", "shld_keychains", "shld_shares", "shld_notifications"},
+	}
+	rows := []backupRow{
+		{"shld_projects", `{"id": "project_id", "name": "test"}`},
+		{"shld_encryption_parts", `{"id": "part_id", "project_id": "project_id", "part": "` + storedPart + `"}`},
+		{"shld_shamir_migrations", `{"id": "migration_id", "project_id": "project_id", "success": true}`},
+		{"shld_users", `{"id": "user_id", "project_id": "project_id"}`},
+		{"shld_keychains", `{"id": "keychain_id", "user_id": "user_id"}`},
+		{"shld_shares", `{"id": "share_1", "data": "` + secret + `", "entropy": "project", "user_id": "user_id"}`},
+		{"shld_shares", `{"id": "share_2", "data": "` + secret + `", "entropy": "project", "keychain_id": "keychain_id"}`},
+		{"shld_shares", `{"id": "share_3", "data": "plain", "entropy": "none", "user_id": "user_id"}`},
+	}
+
+	backupRepo.On("Snapshot", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		begin := args.Get(1).(func(*backup.Snapshot) error)
+		emit := args.Get(2).(func(string, json.RawMessage) error)
+		require.NoError(t, begin(snapshot))
+		for _, r := range rows {
+			require.NoError(t, emit(r.table, json.RawMessage(r.row)))
+		}
+	}).Return(nil)
+
+	var out bytes.Buffer
+	manifest, err := app.Create(ctx, &out, backupKey)
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.SchemaVersion, manifest.SchemaVersion)
+	require.Len(t, manifest.Tables, len(snapshot.Tables))
+	assert.Equal(t, int64(3), manifest.Tables[5].Rows)
+	assert.Equal(t, int64(0), manifest.Tables[6].Rows)
+	created := out.Bytes()
+
+	t.Run("verify", func(t *testing.T) {
+		report, err := app.Verify(ctx, bytes.NewReader(created), backupKey, map[string]string{"project_id": projectPart}, 10)
+		require.NoError(t, err)
+		assert.Equal(t, manifest.Tables, report.Manifest.Tables)
+		assert.Equal(t, []ProjectVerification{{ProjectID: "project_id", Shares: 2, Sampled: 2, Decrypted: 2}}, report.Projects)
+		assert.False(t, report.Failed())
+	})
+
+	t.Run("verify samples", func(t *testing.T) {
+		report, err := app.Verify(ctx, bytes.NewReader(created), backupKey, map[string]string{"project_id": projectPart}, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []ProjectVerification{{ProjectID: "project_id", Shares: 2, Sampled: 1, Decrypted: 1}}, report.Projects)
+	})
+
+	t.Run("verify with the wrong part", func(t *testing.T) {
+		report, err := app.Verify(ctx, bytes.NewReader(created), backupKey, map[string]string{"project_id": otherPart}, 10)
+		require.NoError(t, err)
+		assert.True(t, report.Failed())
+	})
+
+	t.Run("verify without parts", func(t *testing.T) {
+		report, err := app.Verify(ctx, bytes.NewReader(created), backupKey, nil, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []ProjectVerification{{ProjectID: "project_id", Shares: 2}}, report.Projects)
+		assert.False(t, report.Failed())
+	})
+
+	t.Run("verify project not in backup", func(t *testing.T) {
+		_, err := app.Verify(ctx, bytes.NewReader(created), backupKey, map[string]string{"other_project": projectPart}, 10)
+		assert.ErrorIs(t, err, ErrProjectNotInBackup)
+	})
+
+	t.Run("verify with another key", func(t *testing.T) {
+		_, err := app.Verify(ctx, bytes.NewReader(created), otherKey, nil, 10)
+		assert.ErrorIs(t, err, ErrInvalidBackup)
+	})
+
+	t.Run("verify cut short", func(t *testing.T) {
+		_, err := app.Verify(ctx, bytes.NewReader(created[:len(created)-1]), backupKey, nil, 10)
+		assert.ErrorIs(t, err, ErrInvalidBackup)
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		_, err := app.Create(ctx, io.Discard, "not a key")
+		assert.ErrorIs(t, err, ErrInvalidBackupKey)
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		var restored []backupRow
+		backupRepo.On("Restore", mock.Anything, snapshot, mock.Anything).Run(func(args mock.Arguments) {
+			next := args.Get(2).(func() (string, json.RawMessage, error))
+			for {
+				table, row, err := next()
+				if errors.Is(err, io.EOF) {
+					return
+				}
+				require.NoError(t, err)
+				restored = append(restored, backupRow{table, string(row)})
+			}
+		}).Return(map[string]int64{"shld_shares": 3}, nil).Once()
+
+		report, err := app.Restore(ctx, bytes.NewReader(created), backupKey)
+		require.NoError(t, err)
+		assert.Equal(t, manifest.Tables, report.Manifest.Tables)
+		assert.Equal(t, int64(3), report.Restored["shld_shares"])
+
+		require.Len(t, restored, len(rows))
+		for i, r := range rows {
+			assert.Equal(t, r.table, restored[i].table)
+			assert.JSONEq(t, r.row, restored[i].row)
+		}
+	})
+
+	t.Run("restore into a database with data", func(t *testing.T) {
+		backupRepo.On("Restore", mock.Anything, snapshot, mock.Anything).Return(nil, domainErrors.ErrRestoreDatabaseNotEmpty).Once()
+
+		_, err := app.Restore(ctx, bytes.NewReader(created), backupKey)
+		assert.ErrorIs(t, err, ErrDatabaseNotEmpty)
+	})
+}
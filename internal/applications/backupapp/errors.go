@@ -0,0 +1,38 @@
+package backupapp
+
+import (
+	"errors"
+
+	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
+	"github.com/openfort-xyz/shield/pkg/cypher"
+)
+
+var (
+	ErrInvalidBackupKey         = errors.New("invalid backup key")
+	ErrInvalidBackup            = errors.New("invalid backup")
+	ErrUnsupportedBackupVersion = errors.New("unsupported backup version")
+	ErrSchemaVersionMismatch    = errors.New("backup schema version differs from the database")
+	ErrDatabaseNotEmpty         = errors.New("database to restore into is not empty")
+	ErrProjectNotInBackup       = errors.New("project not in backup")
+	ErrInternal                 = errors.New("internal error")
+)
+
+func fromDomainError(err error) error {
+	if errors.Is(err, ErrInvalidBackup) || errors.Is(err, domainErrors.ErrInvalidBackup) || errors.Is(err, cypher.ErrInvalidStream) {
+		return ErrInvalidBackup
+	}
+
+	if errors.Is(err, cypher.ErrInvalidStreamKey) {
+		return ErrInvalidBackupKey
+	}
+
+	if errors.Is(err, domainErrors.ErrBackupSchemaMismatch) {
+		return ErrSchemaVersionMismatch
+	}
+
+	if errors.Is(err, domainErrors.ErrRestoreDatabaseNotEmpty) {
+		return ErrDatabaseNotEmpty
+	}
+
+	return ErrInternal
+}
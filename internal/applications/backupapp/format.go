@@ -0,0 +1,230 @@
+package backupapp
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"hash"
+	"io"
+	"time"
+
+	"github.com/openfort-xyz/shield/internal/core/domain/backup"
+)
+
+// FormatVersion is the layout of the lines in a backup file.
+const FormatVersion = 1
+
+// A backup file is a stream of JSON lines encrypted with the backup key. A
+// header lists the tables, each table's rows follow in that order and are
+// closed by a line with their count and SHA-256, a last line ends the file.
+// A table line is written for every table, empty ones too.
+type recordType string
+
+const (
+	recordHeader recordType = "header"
+	recordRow    recordType = "row"
+	recordTable  recordType = "table"
+	recordEnd    recordType = "end"
+)
+
+type record struct {
+	Type          recordType      `json:"type"`
+	Version       int             `json:"version,omitempty"`
+	SchemaVersion int64           `json:"schema_version,omitempty"`
+	CreatedAt     *time.Time      `json:"created_at,omitempty"`
+	Tables        []string        `json:"tables,omitempty"`
+	Table         string          `json:"table,omitempty"`
+	Row           json.RawMessage `json:"row,omitempty"`
+	Rows          int64           `json:"rows,omitempty"`
+	Digest        string          `json:"digest,omitempty"`
+}
+
+// Manifest describes a backup, its tables in the order they're restored.
+type Manifest struct {
+	FormatVersion int
+	SchemaVersion int64
+	CreatedAt     time.Time
+	Tables        []TableSummary
+}
+
+// TableSummary is what a backup holds of one table. Digest is the SHA-256 of
+// its rows, one JSON line each.
+type TableSummary struct {
+	Name   string
+	Rows   int64
+	Digest string
+}
+
+type backupWriter struct {
+	encoder  *json.Encoder
+	tables   []string
+	manifest *Manifest
+	rows     int64
+	hash     hash.Hash
+}
+
+func newBackupWriter(w io.Writer) *backupWriter {
+	return &backupWriter{encoder: json.NewEncoder(w), hash: sha256.New()}
+}
+
+func (b *backupWriter) begin(snapshot *backup.Snapshot) error {
+	createdAt := time.Now().UTC()
+	b.tables = snapshot.Tables
+	b.manifest = &Manifest{
+		FormatVersion: FormatVersion,
+		SchemaVersion: snapshot.SchemaVersion,
+		CreatedAt:     createdAt,
+	}
+
+	return b.encoder.Encode(&record{
+		Type:          recordHeader,
+		Version:       FormatVersion,
+		SchemaVersion: snapshot.SchemaVersion,
+		CreatedAt:     &createdAt,
+		Tables:        snapshot.Tables,
+	})
+}
+
+func (b *backupWriter) row(table string, row json.RawMessage) error {
+	// Rows come table by table, the tables before this one are done
+	for b.current() != table {
+		if b.current() == "" {
+			return errors.New("row of a table out of order")
+		}
+		err := b.endTable()
+		if err != nil {
+			return err
+		}
+	}
+
+	var compact bytes.Buffer
+	err := json.Compact(&compact, row)
+	if err != nil {
+		return err
+	}
+
+	b.rows++
+	b.hash.Write(compact.Bytes())
+	b.hash.Write([]byte("\n"))
+	return b.encoder.Encode(&record{Type: recordRow, Table: table, Row: compact.Bytes()})
+}
+
+func (b *backupWriter) finish() error {
+	for b.current() != "" {
+		err := b.endTable()
+		if err != nil {
+			return err
+		}
+	}
+
+	return b.encoder.Encode(&record{Type: recordEnd})
+}
+
+func (b *backupWriter) current() string {
+	if len(b.manifest.Tables) == len(b.tables) {
+		return ""
+	}
+	return b.tables[len(b.manifest.Tables)]
+}
+
+func (b *backupWriter) endTable() error {
+	summary := TableSummary{
+		Name:   b.current(),
+		Rows:   b.rows,
+		Digest: hex.EncodeToString(b.hash.Sum(nil)),
+	}
+	b.manifest.Tables = append(b.manifest.Tables, summary)
+	b.rows = 0
+	b.hash.Reset()
+
+	return b.encoder.Encode(&record{Type: recordTable, Table: summary.Name, Rows: summary.Rows, Digest: summary.Digest})
+}
+
+// backupReader checks every line of a backup as it's read, a row is only
+// handed out while the lines before it add up.
+type backupReader struct {
+	decoder  *json.Decoder
+	snapshot *backup.Snapshot
+	manifest *Manifest
+	rows     int64
+	hash     hash.Hash
+	done     bool
+}
+
+func newBackupReader(r io.Reader) (*backupReader, error) {
+	decoder := json.NewDecoder(r)
+
+	var header record
+	err := decoder.Decode(&header)
+	if err != nil || header.Type != recordHeader || header.CreatedAt == nil {
+		return nil, ErrInvalidBackup
+	}
+
+	if header.Version != FormatVersion {
+		return nil, ErrUnsupportedBackupVersion
+	}
+
+	return &backupReader{
+		decoder: decoder,
+		snapshot: &backup.Snapshot{
+			SchemaVersion: header.SchemaVersion,
+			Tables:        header.Tables,
+		},
+		manifest: &Manifest{
+			FormatVersion: header.Version,
+			SchemaVersion: header.SchemaVersion,
+			CreatedAt:     *header.CreatedAt,
+		},
+		hash: sha256.New(),
+	}, nil
+}
+
+// next returns the next row and the table it's in, or io.EOF once the last
+// line was read.
+func (b *backupReader) next() (string, json.RawMessage, error) {
+	for !b.done {
+		var line record
+		err := b.decoder.Decode(&line)
+		if err != nil {
+			return "", nil, ErrInvalidBackup
+		}
+
+		current := b.current()
+		switch {
+		case line.Type == recordRow && line.Table == current && current != "":
+			b.rows++
+			b.hash.Write(line.Row)
+			b.hash.Write([]byte("\n"))
+			return line.Table, line.Row, nil
+		case line.Type == recordTable && line.Table == current && current != "":
+			summary := TableSummary{Name: current, Rows: b.rows, Digest: hex.EncodeToString(b.hash.Sum(nil))}
+			if line.Rows != summary.Rows || line.Digest != summary.Digest {
+				return "", nil, ErrInvalidBackup
+			}
+			b.manifest.Tables = append(b.manifest.Tables, summary)
+			b.rows = 0
+			b.hash.Reset()
+		case line.Type == recordEnd && current == "":
+			// Nothing may follow the last line, and the stream has to end
+			// where it was closed
+			err = b.decoder.Decode(&record{})
+			if !errors.Is(err, io.EOF) {
+				return "", nil, ErrInvalidBackup
+			}
+			b.done = true
+		default:
+			return "", nil, ErrInvalidBackup
+		}
+	}
+
+	return "", nil, io.EOF
+}
+
+func (b *backupReader) current() string {
+	if len(b.manifest.Tables) == len(b.snapshot.Tables) {
+		return ""
+	}
+	return b.snapshot.Tables[len(b.manifest.Tables)]
+}
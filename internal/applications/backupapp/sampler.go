@@ -0,0 +1,171 @@
+package backupapp
+
+import (
+	"encoding/json"
+	"math/rand/v2"
+	"slices"
+	"strings"
+
+	"github.com/openfort-xyz/shield/internal/core/ports/factories"
+)
+
+const entropyProject = "project"
+
+// shareSampler follows a backup's rows to the project entropy shares of each
+// project and keeps a uniform sample of those it has a part for. Parents are
+// read before the rows that refer to them, a share's user or keychain is
+// always known by the time the share is.
+type shareSampler struct {
+	parts  map[string]string
+	sample int
+
+	projects   map[string]bool
+	storedPart map[string]string
+	migrated   map[string]bool
+	users      map[string]string
+	keychains  map[string]string
+
+	shares  map[string]int64
+	samples map[string][]string
+}
+
+func newShareSampler(projectParts map[string]string, sample int) *shareSampler {
+	return &shareSampler{
+		parts:      projectParts,
+		sample:     sample,
+		projects:   make(map[string]bool),
+		storedPart: make(map[string]string),
+		migrated:   make(map[string]bool),
+		users:      make(map[string]string),
+		keychains:  make(map[string]string),
+		shares:     make(map[string]int64),
+		samples:    make(map[string][]string),
+	}
+}
+
+type sampledRow struct {
+	ID         string  `json:"id"`
+	ProjectID  string  `json:"project_id"`
+	UserID     *string `json:"user_id"`
+	KeychainID *string `json:"keychain_id"`
+	Part       string  `json:"part"`
+	Success    bool    `json:"success"`
+	Data       string  `json:"data"`
+	Entropy    string  `json:"entropy"`
+	DeletedAt  *string `json:"deleted_at"`
+}
+
+func (s *shareSampler) add(table string, raw json.RawMessage) error {
+	switch table {
+	case "shld_projects", "shld_encryption_parts", "shld_shamir_migrations", "shld_users", "shld_keychains", "shld_shares":
+	default:
+		return nil
+	}
+
+	var row sampledRow
+	err := json.Unmarshal(raw, &row)
+	if err != nil {
+		return err
+	}
+
+	switch table {
+	case "shld_projects":
+		s.projects[row.ID] = true
+	case "shld_encryption_parts":
+		s.storedPart[row.ProjectID] = row.Part
+	case "shld_shamir_migrations":
+		s.migrated[row.ProjectID] = s.migrated[row.ProjectID] || row.Success
+	case "shld_users":
+		s.users[row.ID] = row.ProjectID
+	case "shld_keychains":
+		if row.UserID != nil {
+			s.keychains[row.ID] = s.users[*row.UserID]
+		}
+	case "shld_shares":
+		s.addShare(&row)
+	}
+
+	return nil
+}
+
+func (s *shareSampler) addShare(row *sampledRow) {
+	if row.Entropy != entropyProject || row.DeletedAt != nil {
+		return
+	}
+
+	var projectID string
+	switch {
+	case row.UserID != nil:
+		projectID = s.users[*row.UserID]
+	case row.KeychainID != nil:
+		projectID = s.keychains[*row.KeychainID]
+	}
+	if projectID == "" {
+		return
+	}
+
+	s.shares[projectID]++
+	if _, ok := s.parts[projectID]; !ok {
+		return
+	}
+
+	// Reservoir sampling, every share seen so far is equally likely to be
+	// in the sample
+	samples := s.samples[projectID]
+	if len(samples) < s.sample {
+		s.samples[projectID] = append(samples, row.Data)
+		return
+	}
+	if i := rand.Int64N(s.shares[projectID]); i < int64(s.sample) {
+		samples[i] = row.Data
+	}
+}
+
+// verify decrypts the samples with each project's key, rebuilt from the part
+// given and the one the backup holds.
+func (s *shareSampler) verify(encryptionFactory factories.EncryptionFactory) []ProjectVerification {
+	ids := make([]string, 0, len(s.shares))
+	for projectID := range s.shares {
+		ids = append(ids, projectID)
+	}
+	for projectID := range s.parts {
+		if _, ok := s.shares[projectID]; !ok {
+			ids = append(ids, projectID)
+		}
+	}
+	slices.Sort(ids)
+
+	verifications := make([]ProjectVerification, 0, len(ids))
+	for _, projectID := range ids {
+		v := ProjectVerification{
+			ProjectID: projectID,
+			Shares:    s.shares[projectID],
+			Sampled:   len(s.samples[projectID]),
+		}
+
+		projectPart, ok := s.parts[projectID]
+		switch {
+		case !ok:
+		case s.storedPart[projectID] == "":
+			v.Error = "backup holds no encryption part for the project"
+		default:
+			key, err := encryptionFactory.CreateReconstructionStrategy(s.migrated[projectID]).Reconstruct(s.storedPart[projectID], strings.TrimSpace(projectPart))
+			if err != nil {
+				v.Error = "failed to rebuild the encryption key from the part given"
+				break
+			}
+
+			cypher := encryptionFactory.CreateEncryptionStrategy(key)
+			for _, data := range s.samples[projectID] {
+				_, err = cypher.Decrypt(data)
+				if err == nil {
+					v.Decrypted++
+				}
+			}
+		}
+
+		verifications = append(verifications, v)
+	}
+
+	return verifications
+}
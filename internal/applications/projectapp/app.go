@@ -9,6 +9,8 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net"
+	"net/url"
 	"reflect"
 	"time"
 
@@ -48,6 +50,10 @@ type ProjectApplication struct {
 	otpService          *otp.InMemoryOTPService
 	notificationService services.NotificationsService
 	rateLimiter         *RequestTracker
+	invalidationRepo    repositories.InvalidationRepository
+	archiveRepo         repositories.ArchiveRepository
+	userRepo            repositories.UserRepository
+	keychainRepo        repositories.KeychainRepository
 }
 
 const OTPEmailSubject = "Openfort OTP"
@@ -71,6 +77,10 @@ func New(
 	otpService *otp.InMemoryOTPService,
 	notificationService services.NotificationsService,
 	rateLimiter *RequestTracker,
+	invalidationRepo repositories.InvalidationRepository,
+	archiveRepo repositories.ArchiveRepository,
+	userRepo repositories.UserRepository,
+	keychainRepo repositories.KeychainRepository,
 ) *ProjectApplication {
 	return &ProjectApplication{
 		projectSvc:          projectSvc,
@@ -86,12 +96,59 @@ func New(
 		otpService:          otpService,
 		notificationService: notificationService,
 		rateLimiter:         rateLimiter,
+		invalidationRepo:    invalidationRepo,
+		archiveRepo:         archiveRepo,
+		userRepo:            userRepo,
+		keychainRepo:        keychainRepo,
+	}
+}
+
+// invalidateProject makes every replica drop what it cached about the
+// project, so it has to follow every change to the project record. Replicas
+// that miss it keep serving the cached record until its TTL runs out, so a
+// failure is logged rather than failing the change. Providers aren't part of
+// the record, they're read on every request and need no invalidation.
+func (a *ProjectApplication) invalidateProject(ctx context.Context, projectID string) {
+	err := a.invalidationRepo.Publish(ctx, project.InvalidationChannel, projectID)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to publish project invalidation", logger.Error(err))
 	}
 }
 
 func (a *ProjectApplication) CreateProject(ctx context.Context, name string, enable2fa bool, opts ...ProjectOption) (*project.Project, error) {
 	a.logger.InfoContext(ctx, "creating project")
 
+	var o projectOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.inviteToken != nil {
+		inviteHash := hashConfirmationToken(*o.inviteToken)
+		err := a.projectRepo.ConsumeInvite(ctx, inviteHash)
+		if err != nil {
+			a.logger.ErrorContext(ctx, "failed to consume registration invite", logger.Error(err))
+			return nil, fromDomainError(err)
+		}
+
+		// The invite is given back if the project could not be created, so
+		// that a failed registration can be retried with the same token.
+		proj, err := a.createProject(ctx, name, enable2fa, o)
+		if err != nil {
+			errR := a.projectRepo.ReleaseInvite(ctx, inviteHash)
+			if errR != nil {
+				a.logger.ErrorContext(ctx, "failed to release registration invite", logger.Error(errR))
+			}
+			return nil, err
+		}
+
+		return proj, nil
+	}
+
+	return a.createProject(ctx, name, enable2fa, o)
+}
+
+func (a *ProjectApplication) createProject(ctx context.Context, name string, enable2fa bool, o projectOptions) (*project.Project, error) {
 	proj, err := a.projectSvc.Create(ctx, name, enable2fa)
 	if err != nil {
 		a.logger.ErrorContext(ctx, "failed to create project", logger.Error(err))
@@ -104,11 +161,6 @@ func (a *ProjectApplication) CreateProject(ctx context.Context, name string, ena
 		return nil, err
 	}
 
-	var o projectOptions
-	for _, opt := range opts {
-		opt(&o)
-	}
-
 	if o.generateEncryptionKey {
 		part, err := a.registerEncryptionKey(ctx, proj.ID)
 		if err != nil {
@@ -146,9 +198,56 @@ func (a *ProjectApplication) ResetAPISecret(ctx context.Context) (string, error)
 		a.logger.ErrorContext(ctx, "failed to update API secret", logger.Error(err))
 		return "", fromDomainError(err)
 	}
+	a.invalidateProject(ctx, projectID)
 	return hex.EncodeToString(newAPISecretBytes), nil
 }
 
+// RotateSigningKey replaces the project's request signing key and returns the
+// new one, requests signed with the previous key stop verifying right away.
+func (a *ProjectApplication) RotateSigningKey(ctx context.Context) (string, error) {
+	a.logger.InfoContext(ctx, "rotating signing key")
+	projectID := contexter.GetProjectID(ctx)
+
+	keyBytes := make([]byte, 32)
+	_, err := rand.Read(keyBytes)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to generate signing key", logger.Error(err))
+		return "", fromDomainError(err)
+	}
+
+	signingKey := hex.EncodeToString(keyBytes)
+	err = a.projectRepo.SetSigningKey(ctx, projectID, signingKey)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to set signing key", logger.Error(err))
+		return "", fromDomainError(err)
+	}
+	a.invalidateProject(ctx, projectID)
+
+	return signingKey, nil
+}
+
+func (a *ProjectApplication) SetRequireSignedRequests(ctx context.Context, required bool) error {
+	a.logger.InfoContext(ctx, "setting signed requests requirement", slog.Bool("required", required))
+	projectID := contexter.GetProjectID(ctx)
+
+	if required {
+		_, err := a.projectRepo.GetSigningKey(ctx, projectID)
+		if err != nil {
+			a.logger.ErrorContext(ctx, "failed to get signing key", logger.Error(err))
+			return fromDomainError(err)
+		}
+	}
+
+	err := a.projectRepo.UpdateRequireSignedRequests(ctx, projectID, required)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to update signed requests requirement", logger.Error(err))
+		return fromDomainError(err)
+	}
+	a.invalidateProject(ctx, projectID)
+
+	return nil
+}
+
 func (a *ProjectApplication) GetProject(ctx context.Context) (*project.Project, error) {
 	a.logger.InfoContext(ctx, "getting project")
 	projectID := contexter.GetProjectID(ctx)
@@ -183,11 +282,132 @@ func (a *ProjectApplication) Enable2FA(ctx context.Context) error {
 		a.logger.ErrorContext(ctx, "failed to update 2FA", logger.Error(err))
 		return fromDomainError(err)
 	}
+	a.invalidateProject(ctx, projectID)
 
 	a.logger.InfoContext(ctx, "2FA enabled successfully", slog.String("project_id", projectID))
 	return nil
 }
 
+func (a *ProjectApplication) ListClientCertificates(ctx context.Context) ([]*project.ClientCertificate, error) {
+	a.logger.InfoContext(ctx, "listing client certificates")
+	projectID := contexter.GetProjectID(ctx)
+
+	certs, err := a.projectRepo.ListClientCertificates(ctx, projectID)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to list client certificates", logger.Error(err))
+		return nil, fromDomainError(err)
+	}
+
+	return certs, nil
+}
+
+func (a *ProjectApplication) AddClientCertificate(ctx context.Context, cert *project.ClientCertificate) (*project.ClientCertificate, error) {
+	a.logger.InfoContext(ctx, "adding client certificate")
+	projectID := contexter.GetProjectID(ctx)
+
+	fingerprint, ok := project.NormalizeFingerprint(cert.Fingerprint)
+	if !ok {
+		return nil, ErrInvalidCertificateFingerprint
+	}
+
+	if cert.Type != project.ClientCertificateTypeCA && cert.Type != project.ClientCertificateTypeLeaf {
+		return nil, ErrInvalidCertificateType
+	}
+
+	existing, err := a.projectRepo.ListClientCertificates(ctx, projectID)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to list client certificates", logger.Error(err))
+		return nil, fromDomainError(err)
+	}
+
+	for _, e := range existing {
+		if e.Fingerprint == fingerprint {
+			return nil, ErrClientCertificateAlreadyExists
+		}
+	}
+
+	cert.ProjectID = projectID
+	cert.Fingerprint = fingerprint
+	err = a.projectRepo.AddClientCertificate(ctx, cert)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to add client certificate", logger.Error(err))
+		return nil, fromDomainError(err)
+	}
+
+	return cert, nil
+}
+
+func (a *ProjectApplication) RemoveClientCertificate(ctx context.Context, certificateID string) error {
+	a.logger.InfoContext(ctx, "removing client certificate")
+	projectID := contexter.GetProjectID(ctx)
+
+	proj, err := a.projectRepo.Get(ctx, projectID)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to get project", logger.Error(err))
+		return fromDomainError(err)
+	}
+
+	certs, err := a.projectRepo.ListClientCertificates(ctx, projectID)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to list client certificates", logger.Error(err))
+		return fromDomainError(err)
+	}
+
+	found := false
+	for _, cert := range certs {
+		if cert.ID == certificateID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return ErrClientCertificateNotFound
+	}
+
+	// Removing the last certificate while one is required would lock the
+	// project's backend out
+	if proj.ClientCertMode == project.ClientCertModeRequired && len(certs) == 1 {
+		return ErrNoClientCertificates
+	}
+
+	err = a.projectRepo.DeleteClientCertificate(ctx, projectID, certificateID)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to remove client certificate", logger.Error(err))
+		return fromDomainError(err)
+	}
+
+	return nil
+}
+
+func (a *ProjectApplication) SetClientCertMode(ctx context.Context, mode project.ClientCertMode) error {
+	a.logger.InfoContext(ctx, "setting client certificate mode", slog.String("mode", string(mode)))
+	projectID := contexter.GetProjectID(ctx)
+
+	if !mode.Valid() {
+		return ErrInvalidClientCertMode
+	}
+
+	if mode == project.ClientCertModeRequired {
+		certs, err := a.projectRepo.ListClientCertificates(ctx, projectID)
+		if err != nil {
+			a.logger.ErrorContext(ctx, "failed to list client certificates", logger.Error(err))
+			return fromDomainError(err)
+		}
+		if len(certs) == 0 {
+			return ErrNoClientCertificates
+		}
+	}
+
+	err := a.projectRepo.UpdateClientCertMode(ctx, projectID, mode)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to update client certificate mode", logger.Error(err))
+		return fromDomainError(err)
+	}
+	a.invalidateProject(ctx, projectID)
+
+	return nil
+}
+
 func (a *ProjectApplication) AddProviders(ctx context.Context, opts ...ProviderOption) ([]*provider.Provider, error) {
 	a.logger.InfoContext(ctx, "adding providers")
 	projectID := contexter.GetProjectID(ctx)
@@ -214,6 +434,10 @@ func (a *ProjectApplication) AddProviders(ctx context.Context, opts ...ProviderO
 		return nil, ErrJWKPemConflict
 	}
 
+	if cfg.hmacSecret != nil && (cfg.jwkURL != nil || cfg.pem != nil) {
+		return nil, ErrHMACSecretConflict
+	}
+
 	if cfg.jwkURL != nil {
 		prov, err := a.providerRepo.GetByProjectAndType(ctx, projectID, provider.TypeCustom)
 		if err != nil && !errors.Is(err, domainErrors.ErrProviderNotFound) {
@@ -246,6 +470,38 @@ func (a *ProjectApplication) AddProviders(ctx context.Context, opts ...ProviderO
 		providers = append(providers, &provider.Provider{ProjectID: projectID, Type: provider.TypeCustom, Config: &provider.CustomConfig{PEM: *cfg.pem, KeyType: cfg.keyType, CookieFieldName: cfg.cookieFieldName}})
 	}
 
+	if cfg.hmacSecret != nil {
+		err := pem.CheckHMACSecret([]byte(*cfg.hmacSecret))
+		if err != nil {
+			a.logger.ErrorContext(ctx, "failed to validate HMAC secret", logger.Error(err))
+			return nil, ErrInvalidHMACSecret
+		}
+		prov, err := a.providerRepo.GetByProjectAndType(ctx, projectID, provider.TypeCustom)
+		if err != nil && !errors.Is(err, domainErrors.ErrProviderNotFound) {
+			a.logger.ErrorContext(ctx, "failed to get provider", logger.Error(err))
+			return nil, fromDomainError(err)
+		}
+		if err == nil && prov != nil {
+			return nil, ErrProviderAlreadyExists
+		}
+		providers = append(providers, &provider.Provider{ProjectID: projectID, Type: provider.TypeCustom, Config: &provider.CustomConfig{Secret: *cfg.hmacSecret, KeyType: provider.KeyTypeHMAC, CookieFieldName: cfg.cookieFieldName}})
+	}
+
+	if cfg.introspection != nil {
+		if !isValidIntrospectionEndpoint(cfg.introspection.Endpoint) {
+			return nil, ErrInvalidProviderConfig
+		}
+		prov, err := a.providerRepo.GetByProjectAndType(ctx, projectID, provider.TypeIntrospection)
+		if err != nil && !errors.Is(err, domainErrors.ErrProviderNotFound) {
+			a.logger.ErrorContext(ctx, "failed to get provider", logger.Error(err))
+			return nil, fromDomainError(err)
+		}
+		if err == nil && prov != nil {
+			return nil, ErrProviderAlreadyExists
+		}
+		providers = append(providers, &provider.Provider{ProjectID: projectID, Type: provider.TypeIntrospection, Config: cfg.introspection})
+	}
+
 	if len(providers) == 0 {
 		return nil, ErrNoProviderSpecified
 	}
@@ -440,6 +696,10 @@ func (a *ProjectApplication) UpdateProvider(ctx context.Context, providerID stri
 		return ErrProviderMismatch
 	}
 
+	if cfg.hmacSecret != nil && (cfg.jwkURL != nil || cfg.pem != nil) {
+		return ErrHMACSecretConflict
+	}
+
 	if cfg.jwkURL != nil {
 		if prov.Type != provider.TypeCustom {
 			return ErrProviderMismatch
@@ -486,6 +746,41 @@ func (a *ProjectApplication) UpdateProvider(ctx context.Context, providerID stri
 		}
 	}
 
+	if cfg.hmacSecret != nil {
+		if prov.Type != provider.TypeCustom {
+			return ErrProviderMismatch
+		}
+
+		err := pem.CheckHMACSecret([]byte(*cfg.hmacSecret))
+		if err != nil {
+			a.logger.ErrorContext(ctx, "failed to validate HMAC secret", logger.Error(err))
+			return ErrInvalidHMACSecret
+		}
+
+		err = a.providerRepo.UpdateCustom(ctx, &provider.CustomConfig{ProviderID: providerID, Secret: *cfg.hmacSecret, KeyType: provider.KeyTypeHMAC, CookieFieldName: cfg.cookieFieldName})
+		if err != nil {
+			a.logger.ErrorContext(ctx, "failed to update custom provider", logger.Error(err))
+			return fromDomainError(err)
+		}
+	}
+
+	if cfg.introspection != nil {
+		if prov.Type != provider.TypeIntrospection {
+			return ErrProviderMismatch
+		}
+
+		if cfg.introspection.Endpoint != "" && !isValidIntrospectionEndpoint(cfg.introspection.Endpoint) {
+			return ErrInvalidProviderConfig
+		}
+
+		cfg.introspection.ProviderID = providerID
+		err = a.providerRepo.UpdateIntrospection(ctx, cfg.introspection)
+		if err != nil {
+			a.logger.ErrorContext(ctx, "failed to update introspection provider", logger.Error(err))
+			return fromDomainError(err)
+		}
+	}
+
 	if cfg.cookieFieldName != nil {
 		if prov.Type != provider.TypeCustom {
 			return ErrProviderMismatch
@@ -494,6 +789,32 @@ func (a *ProjectApplication) UpdateProvider(ctx context.Context, providerID stri
 	return nil
 }
 
+// isValidIntrospectionEndpoint accepts absolute https URLs only, the client
+// credentials and the user's token are sent to it and RFC 7662 section 4
+// requires TLS. Plain http is left to loopback hosts, for local development.
+func isValidIntrospectionEndpoint(endpoint string) bool {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	switch u.Scheme {
+	case "https":
+		return true
+	case "http":
+		return isLoopback(u.Hostname())
+	default:
+		return false
+	}
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
 func (a *ProjectApplication) RemoveProvider(ctx context.Context, providerID string) error {
 	a.logger.InfoContext(ctx, "removing provider")
 	projectID := contexter.GetProjectID(ctx)
@@ -518,10 +839,112 @@ func (a *ProjectApplication) RemoveProvider(ctx context.Context, providerID stri
 	return nil
 }
 
+func (a *ProjectApplication) AddProviderKey(ctx context.Context, providerID string, key *provider.PEMKey) (*provider.PEMKey, error) {
+	a.logger.InfoContext(ctx, "adding provider key")
+
+	cfg, err := a.getCustomProviderConfig(ctx, providerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.JWK != "" {
+		return nil, ErrJWKPemConflict
+	}
+
+	if cfg.Secret != "" {
+		return nil, ErrHMACSecretConflict
+	}
+
+	if key.KeyType == provider.KeyTypeUnknown {
+		return nil, ErrKeyTypeNotSpecified
+	}
+
+	err = pem.CheckPEM([]byte(key.PEM), key.KeyType)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to validate PEM", logger.Error(err))
+		return nil, ErrInvalidPemCertificate
+	}
+
+	if key.NotBefore != nil && key.NotAfter != nil && !key.NotBefore.Before(*key.NotAfter) {
+		return nil, ErrInvalidKeyValidity
+	}
+
+	if key.KID != "" {
+		for _, existing := range cfg.Keys {
+			if existing.KID == key.KID {
+				return nil, ErrProviderKeyAlreadyExists
+			}
+		}
+	}
+
+	key.ProviderID = providerID
+	err = a.providerRepo.AddCustomKey(ctx, key)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to add provider key", logger.Error(err))
+		return nil, fromDomainError(err)
+	}
+
+	return key, nil
+}
+
+func (a *ProjectApplication) RemoveProviderKey(ctx context.Context, providerID, keyID string) error {
+	a.logger.InfoContext(ctx, "removing provider key")
+
+	cfg, err := a.getCustomProviderConfig(ctx, providerID)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, key := range cfg.PEMKeys() {
+		if key.ID == keyID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return ErrProviderKeyNotFound
+	}
+
+	err = a.providerRepo.DeleteCustomKey(ctx, providerID, keyID)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to remove provider key", logger.Error(err))
+		return fromDomainError(err)
+	}
+
+	return nil
+}
+
+func (a *ProjectApplication) getCustomProviderConfig(ctx context.Context, providerID string) (*provider.CustomConfig, error) {
+	projectID := contexter.GetProjectID(ctx)
+
+	prov, err := a.providerRepo.Get(ctx, providerID)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to get provider", logger.Error(err))
+		return nil, fromDomainError(err)
+	}
+
+	if prov.ProjectID != projectID {
+		a.logger.ErrorContext(ctx, "unauthorized access, trying to access provider from different project", slog.String("project_id", projectID), slog.String("provider_project_id", prov.ProjectID))
+		return nil, ErrProviderNotFound
+	}
+
+	cfg, ok := prov.Config.(*provider.CustomConfig)
+	if prov.Type != provider.TypeCustom || !ok {
+		return nil, ErrProviderMismatch
+	}
+
+	return cfg, nil
+}
+
 func (a *ProjectApplication) EncryptProjectShares(ctx context.Context, externalPart string) error {
 	a.logger.InfoContext(ctx, "encrypting project shares")
 	projectID := contexter.GetProjectID(ctx)
 
+	if err := checkWritable(ctx); err != nil {
+		return err
+	}
+
 	isMigrated, err := a.projectRepo.HasSuccessfulMigration(ctx, projectID)
 	if err != nil {
 		a.logger.ErrorContext(ctx, "failed to check migration", logger.Error(err))
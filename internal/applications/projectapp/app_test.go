@@ -7,16 +7,22 @@ import (
 	"time"
 
 	"github.com/openfort-xyz/shield/internal/adapters/encryption"
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/mocks/archivemockrepo"
 	"github.com/openfort-xyz/shield/internal/adapters/repositories/mocks/encryptionpartsmockrepo"
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/mocks/invalidationmockrepo"
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/mocks/keychainmockrepo"
 	"github.com/openfort-xyz/shield/internal/adapters/repositories/mocks/notificationsmockrepo"
 	"github.com/openfort-xyz/shield/internal/adapters/repositories/mocks/projectmockrepo"
 	"github.com/openfort-xyz/shield/internal/adapters/repositories/mocks/providermockrepo"
 	"github.com/openfort-xyz/shield/internal/adapters/repositories/mocks/sharemockrepo"
 	"github.com/openfort-xyz/shield/internal/adapters/repositories/mocks/usercontactmockrepo"
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/mocks/usermockedrepo"
 	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
+	"github.com/openfort-xyz/shield/internal/core/domain/keychain"
 	"github.com/openfort-xyz/shield/internal/core/domain/project"
 	"github.com/openfort-xyz/shield/internal/core/domain/provider"
 	"github.com/openfort-xyz/shield/internal/core/domain/share"
+	"github.com/openfort-xyz/shield/internal/core/domain/user"
 	"github.com/openfort-xyz/shield/internal/core/services/projectsvc"
 	"github.com/openfort-xyz/shield/internal/core/services/providersvc"
 	"github.com/openfort-xyz/shield/pkg/contexter"
@@ -45,12 +51,14 @@ func TestProjectApplication_CreateProject(t *testing.T) {
 	providerRepo := new(providermockrepo.MockProviderRepository)
 	notificationsRepo := new(notificationsmockrepo.MockNotificationsRepository)
 	userContactRepo := new(usercontactmockrepo.MockUserContactRepository)
-	projectService := projectsvc.New(projectRepo, 60*time.Second)
+	projectService := projectsvc.New(projectRepo, nil, nil)
 	providerService := providersvc.New(providerRepo)
 	encryptionPartsRepo := new(encryptionpartsmockrepo.MockEncryptionPartsRepository)
 	encryptionFactory := encryption.NewEncryptionFactory(encryptionPartsRepo, projectRepo)
 	rateLimiter := NewRequestTracker(&TestClock{})
-	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter)
+	invalidationRepo := new(invalidationmockrepo.MockInvalidationRepository)
+	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter, invalidationRepo, nil, nil, nil)
+	released := false
 
 	tc := []struct {
 		name     string
@@ -131,6 +139,50 @@ func TestProjectApplication_CreateProject(t *testing.T) {
 				projectRepo.On("Delete", mock.Anything, mock.Anything).Return(errors.New("repository error"))
 			},
 		},
+		{
+			name:     "success with invite",
+			projName: "project_name",
+			options: []ProjectOption{
+				WithInviteToken("invite_token"),
+			},
+			wantProj: &project.Project{
+				Name: "project_name",
+			},
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				projectRepo.On("ConsumeInvite", mock.Anything, hashConfirmationToken("invite_token")).Return(nil)
+				projectRepo.On("Create", mock.Anything, mock.AnythingOfType("*project.Project")).Return(nil)
+				projectRepo.On("SaveProjectRateLimits", mock.Anything, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:     "invalid invite",
+			projName: "project_name",
+			options: []ProjectOption{
+				WithInviteToken("invite_token"),
+			},
+			wantErr: ErrInvalidInvite,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				projectRepo.On("ConsumeInvite", mock.Anything, hashConfirmationToken("invite_token")).Return(domainErrors.ErrInviteNotFound)
+			},
+		},
+		{
+			name:     "invite released when creation fails",
+			projName: "project_name",
+			options: []ProjectOption{
+				WithInviteToken("invite_token"),
+			},
+			wantErr: ErrInternal,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				projectRepo.On("ConsumeInvite", mock.Anything, hashConfirmationToken("invite_token")).Return(nil)
+				projectRepo.On("Create", mock.Anything, mock.AnythingOfType("*project.Project")).Return(errors.New("repository error"))
+				projectRepo.On("ReleaseInvite", mock.Anything, hashConfirmationToken("invite_token")).Run(func(mock.Arguments) {
+					released = true
+				}).Return(nil).Once()
+			},
+		},
 	}
 
 	for _, tt := range tc {
@@ -149,6 +201,7 @@ func TestProjectApplication_CreateProject(t *testing.T) {
 			}
 		})
 	}
+	assert.True(t, released, "invite should be released when the project can't be created")
 }
 
 func TestProjectApplication_GetProject(t *testing.T) {
@@ -159,12 +212,13 @@ func TestProjectApplication_GetProject(t *testing.T) {
 	providerRepo := new(providermockrepo.MockProviderRepository)
 	notificationsRepo := new(notificationsmockrepo.MockNotificationsRepository)
 	userContactRepo := new(usercontactmockrepo.MockUserContactRepository)
-	projectService := projectsvc.New(projectRepo, 60*time.Second)
+	projectService := projectsvc.New(projectRepo, nil, nil)
 	providerService := providersvc.New(providerRepo)
 	encryptionPartsRepo := new(encryptionpartsmockrepo.MockEncryptionPartsRepository)
 	encryptionFactory := encryption.NewEncryptionFactory(encryptionPartsRepo, projectRepo)
 	rateLimiter := NewRequestTracker(&TestClock{})
-	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter)
+	invalidationRepo := new(invalidationmockrepo.MockInvalidationRepository)
+	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter, invalidationRepo, nil, nil, nil)
 	projOK := &project.Project{
 		ID:             "project-id",
 		Name:           "project name",
@@ -227,12 +281,13 @@ func TestProjectApplication_AddProviders(t *testing.T) {
 	providerRepo := new(providermockrepo.MockProviderRepository)
 	notificationsRepo := new(notificationsmockrepo.MockNotificationsRepository)
 	userContactRepo := new(usercontactmockrepo.MockUserContactRepository)
-	projectService := projectsvc.New(projectRepo, 60*time.Second)
+	projectService := projectsvc.New(projectRepo, nil, nil)
 	providerService := providersvc.New(providerRepo)
 	encryptionPartsRepo := new(encryptionpartsmockrepo.MockEncryptionPartsRepository)
 	encryptionFactory := encryption.NewEncryptionFactory(encryptionPartsRepo, projectRepo)
 	rateLimiter := NewRequestTracker(&TestClock{})
-	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter)
+	invalidationRepo := new(invalidationmockrepo.MockInvalidationRepository)
+	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter, invalidationRepo, nil, nil, nil)
 	validPEM := "-----BEGIN PUBLIC KEY-----\nMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA1ljaGMp9BrY6KQtUIWhw\ng2weyyF65zzNFR9VCxyxk7M/NCTvash6nJO4HwZ+/51YO6kZFr0JDdIMrMmNu/pE\na4FfvmAQJ+vDdc8LSwS7IWAp9y04MZVVFLEQzbToQ3kqkaJV5KsbKuADjm3JCXng\nkeOvuS04AeO4W2lB5BqQ+wX5TjAZ9P7xusJUd2ovk1kWVKeJDTxpAImpVhK2nLZ3\nFV/TWWVYutYFU1wmoRRyOeypTP4ZSPhKB5s6PqQuyl9KPqiWz7ESL9zAW3/yxONb\nEPc9pB8w/qXcW++g6iCYN66xH4punt7KuismzQwGysgnMyK6UnNuOJyJznPzAvB+\nQwIDAQAB\n-----END PUBLIC KEY-----\n"
 
 	tc := []struct {
@@ -290,6 +345,112 @@ func TestProjectApplication_AddProviders(t *testing.T) {
 				providerRepo.On("CreateCustom", mock.Anything, mock.AnythingOfType("*provider.CustomConfig")).Return(nil)
 			},
 		},
+		{
+			name: "success with hmac secret",
+			options: []ProviderOption{
+				WithCustomHMACSecret("0123456789abcdef0123456789abcdef"),
+			},
+			wantErr:       nil,
+			wantProviders: 1,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				providerRepo.ExpectedCalls = nil
+				providerRepo.On("GetByProjectAndType", mock.Anything, mock.Anything, provider.TypeCustom).Return(nil, domainErrors.ErrProviderNotFound)
+				providerRepo.On("Create", mock.Anything, mock.AnythingOfType("*provider.Provider")).Return(nil)
+				providerRepo.On("CreateCustom", mock.Anything, mock.AnythingOfType("*provider.CustomConfig")).Return(nil)
+			},
+		},
+		{
+			name: "error with short hmac secret",
+			options: []ProviderOption{
+				WithCustomHMACSecret("too-short"),
+			},
+			wantErr:       ErrInvalidHMACSecret,
+			wantProviders: 0,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				providerRepo.ExpectedCalls = nil
+			},
+		},
+		{
+			name: "error with hmac secret and pem",
+			options: []ProviderOption{
+				WithCustomPEM(validPEM, provider.KeyTypeRSA),
+				WithCustomHMACSecret("0123456789abcdef0123456789abcdef"),
+			},
+			wantErr:       ErrHMACSecretConflict,
+			wantProviders: 0,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				providerRepo.ExpectedCalls = nil
+			},
+		},
+		{
+			name: "success with introspection",
+			options: []ProviderOption{
+				WithIntrospection("https://issuer.example/oauth/introspect", "client", "secret", "sub"),
+			},
+			wantErr:       nil,
+			wantProviders: 1,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				providerRepo.ExpectedCalls = nil
+				providerRepo.On("GetByProjectAndType", mock.Anything, mock.Anything, provider.TypeIntrospection).Return(nil, domainErrors.ErrProviderNotFound)
+				providerRepo.On("Create", mock.Anything, mock.AnythingOfType("*provider.Provider")).Return(nil)
+				providerRepo.On("CreateIntrospection", mock.Anything, mock.AnythingOfType("*provider.IntrospectionConfig")).Return(nil)
+			},
+		},
+		{
+			name: "error with invalid introspection endpoint",
+			options: []ProviderOption{
+				WithIntrospection("/oauth/introspect", "client", "secret", ""),
+			},
+			wantErr:       ErrInvalidProviderConfig,
+			wantProviders: 0,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				providerRepo.ExpectedCalls = nil
+			},
+		},
+		{
+			name: "error with plain http introspection endpoint",
+			options: []ProviderOption{
+				WithIntrospection("http://issuer.example/oauth/introspect", "client", "secret", ""),
+			},
+			wantErr:       ErrInvalidProviderConfig,
+			wantProviders: 0,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				providerRepo.ExpectedCalls = nil
+			},
+		},
+		{
+			name: "success with loopback http introspection endpoint",
+			options: []ProviderOption{
+				WithIntrospection("http://127.0.0.1:8080/oauth/introspect", "client", "secret", ""),
+			},
+			wantErr:       nil,
+			wantProviders: 1,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				providerRepo.ExpectedCalls = nil
+				providerRepo.On("GetByProjectAndType", mock.Anything, mock.Anything, provider.TypeIntrospection).Return(nil, domainErrors.ErrProviderNotFound)
+				providerRepo.On("Create", mock.Anything, mock.AnythingOfType("*provider.Provider")).Return(nil)
+				providerRepo.On("CreateIntrospection", mock.Anything, mock.AnythingOfType("*provider.IntrospectionConfig")).Return(nil)
+			},
+		},
+		{
+			name: "introspection provider already exists",
+			options: []ProviderOption{
+				WithIntrospection("https://issuer.example/oauth/introspect", "client", "secret", ""),
+			},
+			wantErr: ErrProviderAlreadyExists,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				providerRepo.ExpectedCalls = nil
+				providerRepo.On("GetByProjectAndType", mock.Anything, mock.Anything, provider.TypeIntrospection).Return(&provider.Provider{}, nil)
+			},
+		},
 		{
 			name:    "no providers",
 			wantErr: ErrNoProviderSpecified,
@@ -418,12 +579,13 @@ func TestProjectApplication_GetProviders(t *testing.T) {
 	providerRepo := new(providermockrepo.MockProviderRepository)
 	notificationsRepo := new(notificationsmockrepo.MockNotificationsRepository)
 	userContactRepo := new(usercontactmockrepo.MockUserContactRepository)
-	projectService := projectsvc.New(projectRepo, 60*time.Second)
+	projectService := projectsvc.New(projectRepo, nil, nil)
 	providerService := providersvc.New(providerRepo)
 	encryptionPartsRepo := new(encryptionpartsmockrepo.MockEncryptionPartsRepository)
 	encryptionFactory := encryption.NewEncryptionFactory(encryptionPartsRepo, projectRepo)
 	rateLimiter := NewRequestTracker(&TestClock{})
-	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter)
+	invalidationRepo := new(invalidationmockrepo.MockInvalidationRepository)
+	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter, invalidationRepo, nil, nil, nil)
 	providers := []*provider.Provider{
 		{
 			ID:        "provider-id",
@@ -493,12 +655,13 @@ func TestProjectApplication_GetProviderDetail(t *testing.T) {
 	providerRepo := new(providermockrepo.MockProviderRepository)
 	notificationsRepo := new(notificationsmockrepo.MockNotificationsRepository)
 	userContactRepo := new(usercontactmockrepo.MockUserContactRepository)
-	projectService := projectsvc.New(projectRepo, 60*time.Second)
+	projectService := projectsvc.New(projectRepo, nil, nil)
 	providerService := providersvc.New(providerRepo)
 	encryptionPartsRepo := new(encryptionpartsmockrepo.MockEncryptionPartsRepository)
 	encryptionFactory := encryption.NewEncryptionFactory(encryptionPartsRepo, projectRepo)
 	rateLimiter := NewRequestTracker(&TestClock{})
-	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter)
+	invalidationRepo := new(invalidationmockrepo.MockInvalidationRepository)
+	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter, invalidationRepo, nil, nil, nil)
 
 	prov := &provider.Provider{
 		ID:        "provider-id",
@@ -582,12 +745,13 @@ func TestProjectApplication_UpdateProvider(t *testing.T) {
 	providerRepo := new(providermockrepo.MockProviderRepository)
 	notificationsRepo := new(notificationsmockrepo.MockNotificationsRepository)
 	userContactRepo := new(usercontactmockrepo.MockUserContactRepository)
-	projectService := projectsvc.New(projectRepo, 60*time.Second)
+	projectService := projectsvc.New(projectRepo, nil, nil)
 	providerService := providersvc.New(providerRepo)
 	encryptionPartsRepo := new(encryptionpartsmockrepo.MockEncryptionPartsRepository)
 	encryptionFactory := encryption.NewEncryptionFactory(encryptionPartsRepo, projectRepo)
 	rateLimiter := NewRequestTracker(&TestClock{})
-	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter)
+	invalidationRepo := new(invalidationmockrepo.MockInvalidationRepository)
+	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter, invalidationRepo, nil, nil, nil)
 	validPEM := "-----BEGIN PUBLIC KEY-----\nMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA1ljaGMp9BrY6KQtUIWhw\ng2weyyF65zzNFR9VCxyxk7M/NCTvash6nJO4HwZ+/51YO6kZFr0JDdIMrMmNu/pE\na4FfvmAQJ+vDdc8LSwS7IWAp9y04MZVVFLEQzbToQ3kqkaJV5KsbKuADjm3JCXng\nkeOvuS04AeO4W2lB5BqQ+wX5TjAZ9P7xusJUd2ovk1kWVKeJDTxpAImpVhK2nLZ3\nFV/TWWVYutYFU1wmoRRyOeypTP4ZSPhKB5s6PqQuyl9KPqiWz7ESL9zAW3/yxONb\nEPc9pB8w/qXcW++g6iCYN66xH4punt7KuismzQwGysgnMyK6UnNuOJyJznPzAvB+\nQwIDAQAB\n-----END PUBLIC KEY-----\n"
 
 	openfortProvider := &provider.Provider{
@@ -658,6 +822,66 @@ func TestProjectApplication_UpdateProvider(t *testing.T) {
 				WithCustomPEM(validPEM, provider.KeyTypeRSA),
 			},
 		},
+		{
+			name: "success custom hmac secret",
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				providerRepo.ExpectedCalls = nil
+				providerRepo.On("Get", mock.Anything, mock.Anything).Return(customProvider, nil)
+				providerRepo.On("UpdateCustom", mock.Anything, mock.Anything).Return(nil)
+			},
+			options: []ProviderOption{
+				WithCustomHMACSecret("0123456789abcdef0123456789abcdef"),
+			},
+		},
+		{
+			name:    "error custom hmac secret too short",
+			wantErr: ErrInvalidHMACSecret,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				providerRepo.ExpectedCalls = nil
+				providerRepo.On("Get", mock.Anything, mock.Anything).Return(customProvider, nil)
+			},
+			options: []ProviderOption{
+				WithCustomHMACSecret("too-short"),
+			},
+		},
+		{
+			name:    "error hmac secret on openfort provider",
+			wantErr: ErrProviderMismatch,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				providerRepo.ExpectedCalls = nil
+				providerRepo.On("Get", mock.Anything, mock.Anything).Return(openfortProvider, nil)
+			},
+			options: []ProviderOption{
+				WithCustomHMACSecret("0123456789abcdef0123456789abcdef"),
+			},
+		},
+		{
+			name: "success introspection",
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				providerRepo.ExpectedCalls = nil
+				providerRepo.On("Get", mock.Anything, mock.Anything).Return(&provider.Provider{ProjectID: "project_id", Type: provider.TypeIntrospection, Config: &provider.IntrospectionConfig{}}, nil)
+				providerRepo.On("UpdateIntrospection", mock.Anything, mock.AnythingOfType("*provider.IntrospectionConfig")).Return(nil)
+			},
+			options: []ProviderOption{
+				WithIntrospection("", "", "rotated-secret", ""),
+			},
+		},
+		{
+			name:    "error introspection on custom provider",
+			wantErr: ErrProviderMismatch,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				providerRepo.ExpectedCalls = nil
+				providerRepo.On("Get", mock.Anything, mock.Anything).Return(customProvider, nil)
+			},
+			options: []ProviderOption{
+				WithIntrospection("https://issuer.example/oauth/introspect", "", "", ""),
+			},
+		},
 		{
 			name:       "provider not found",
 			providerID: "provider-id",
@@ -818,12 +1042,13 @@ func TestProjectApplication_RemoveProvider(t *testing.T) {
 	providerRepo := new(providermockrepo.MockProviderRepository)
 	notificationsRepo := new(notificationsmockrepo.MockNotificationsRepository)
 	userContactRepo := new(usercontactmockrepo.MockUserContactRepository)
-	projectService := projectsvc.New(projectRepo, 60*time.Second)
+	projectService := projectsvc.New(projectRepo, nil, nil)
 	providerService := providersvc.New(providerRepo)
 	encryptionPartsRepo := new(encryptionpartsmockrepo.MockEncryptionPartsRepository)
 	encryptionFactory := encryption.NewEncryptionFactory(encryptionPartsRepo, projectRepo)
 	rateLimiter := NewRequestTracker(&TestClock{})
-	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter)
+	invalidationRepo := new(invalidationmockrepo.MockInvalidationRepository)
+	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter, invalidationRepo, nil, nil, nil)
 
 	openfortProvider := &provider.Provider{
 		ID:        "provider-id",
@@ -905,125 +1130,380 @@ func TestProjectApplication_RemoveProvider(t *testing.T) {
 	}
 }
 
-func TestProjectApplication_EncryptProjectShares(t *testing.T) {
+func TestProjectApplication_AddProviderKey(t *testing.T) {
 	ctx := contexter.WithProjectID(context.Background(), "project_id")
-	ctx = contexter.WithUserID(ctx, "user_id")
 	shareRepo := new(sharemockrepo.MockShareRepository)
 	projectRepo := new(projectmockrepo.MockProjectRepository)
 	providerRepo := new(providermockrepo.MockProviderRepository)
 	notificationsRepo := new(notificationsmockrepo.MockNotificationsRepository)
 	userContactRepo := new(usercontactmockrepo.MockUserContactRepository)
-	projectService := projectsvc.New(projectRepo, 60*time.Second)
+	projectService := projectsvc.New(projectRepo, nil, nil)
 	providerService := providersvc.New(providerRepo)
 	encryptionPartsRepo := new(encryptionpartsmockrepo.MockEncryptionPartsRepository)
 	encryptionFactory := encryption.NewEncryptionFactory(encryptionPartsRepo, projectRepo)
 	rateLimiter := NewRequestTracker(&TestClock{})
-	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter)
-
-	key, err := random.GenerateRandomString(32)
-	if err != nil {
-		t.Fatalf("%s", key)
-	}
-
-	reconstructor := encryptionFactory.CreateReconstructionStrategy(true)
-	storedPart, projectPart, err := reconstructor.Split(key)
-	if err != nil {
-		t.Fatalf("failed to generate encryption key: %v", err)
-	}
+	invalidationRepo := new(invalidationmockrepo.MockInvalidationRepository)
+	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter, invalidationRepo, nil, nil, nil)
+	validPEM := "-----BEGIN PUBLIC KEY-----\nMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA1ljaGMp9BrY6KQtUIWhw\ng2weyyF65zzNFR9VCxyxk7M/NCTvash6nJO4HwZ+/51YO6kZFr0JDdIMrMmNu/pE\na4FfvmAQJ+vDdc8LSwS7IWAp9y04MZVVFLEQzbToQ3kqkaJV5KsbKuADjm3JCXng\nkeOvuS04AeO4W2lB5BqQ+wX5TjAZ9P7xusJUd2ovk1kWVKeJDTxpAImpVhK2nLZ3\nFV/TWWVYutYFU1wmoRRyOeypTP4ZSPhKB5s6PqQuyl9KPqiWz7ESL9zAW3/yxONb\nEPc9pB8w/qXcW++g6iCYN66xH4punt7KuismzQwGysgnMyK6UnNuOJyJznPzAvB+\nQwIDAQAB\n-----END PUBLIC KEY-----\n"
+	now := time.Now()
+	later := now.Add(time.Hour)
 
-	encryptedShare := &share.Share{
-		ID:      "encrypted_share_id",
-		Secret:  "djksalfjadsfds",
-		UserID:  "user_id",
-		Entropy: share.EntropyUser,
-		EncryptionParameters: &share.EncryptionParameters{
-			Salt:       "somesalt",
-			Iterations: 1000,
-			Length:     256,
-			Digest:     "SHA-256",
+	pemProvider := &provider.Provider{
+		ID:        "provider-id",
+		ProjectID: "project_id",
+		Type:      provider.TypeCustom,
+		Config: &provider.CustomConfig{
+			ProviderID: "provider-id",
+			PEM:        validPEM,
+			KeyType:    provider.KeyTypeRSA,
+			Keys:       []*provider.PEMKey{{ID: "key-id", KID: "existing", PEM: validPEM, KeyType: provider.KeyTypeRSA}},
 		},
 	}
 
-	plainShare := &share.Share{
-		ID:      "share_id",
-		Secret:  "secret",
-		UserID:  "user_id",
-		Entropy: share.EntropyNone,
-	}
-
-	plainShare2 := &share.Share{
-		ID:      "share_id",
-		Secret:  "secret",
-		UserID:  "user_id",
-		Entropy: share.EntropyNone,
-	}
-
 	tc := []struct {
-		name         string
-		externalPart string
-		wantErr      error
-		mock         func()
+		name    string
+		key     *provider.PEMKey
+		wantErr error
+		mock    func()
 	}{
 		{
-			name:         "success",
-			externalPart: projectPart,
+			name:    "success",
+			key:     &provider.PEMKey{KID: "next", PEM: validPEM, KeyType: provider.KeyTypeRSA, NotBefore: &now, NotAfter: &later},
+			wantErr: nil,
 			mock: func() {
-				projectRepo.ExpectedCalls = nil
-				shareRepo.ExpectedCalls = nil
-				shareRepo.On("ListProjectIDAndEntropy", mock.Anything, mock.Anything, mock.Anything).Return([]*share.Share{plainShare, encryptedShare}, nil)
-				shareRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
-				projectRepo.On("GetEncryptionPart", mock.Anything, mock.Anything).Return(storedPart, nil)
-				shareRepo.On("UpdateProjectEncryption", mock.Anything, mock.Anything, mock.Anything).Return(nil)
-				projectRepo.On("HasSuccessfulMigration", mock.Anything, mock.Anything).Return(true, nil)
+				providerRepo.ExpectedCalls = nil
+				providerRepo.On("Get", mock.Anything, "provider-id").Return(pemProvider, nil)
+				providerRepo.On("AddCustomKey", mock.Anything, mock.AnythingOfType("*provider.PEMKey")).Return(nil)
 			},
 		},
 		{
-			name:         "encryption part not found",
-			externalPart: projectPart,
+			name:    "provider from other project",
+			key:     &provider.PEMKey{PEM: validPEM, KeyType: provider.KeyTypeRSA},
+			wantErr: ErrProviderNotFound,
 			mock: func() {
-				projectRepo.ExpectedCalls = nil
-				projectRepo.On("GetEncryptionPart", mock.Anything, mock.Anything).Return("", domainErrors.ErrEncryptionPartNotFound)
-				projectRepo.On("HasSuccessfulMigration", mock.Anything, mock.Anything).Return(true, nil)
+				providerRepo.ExpectedCalls = nil
+				providerRepo.On("Get", mock.Anything, "provider-id").Return(&provider.Provider{ProjectID: "other-project", Type: provider.TypeCustom, Config: &provider.CustomConfig{}}, nil)
 			},
-			wantErr: ErrEncryptionNotConfigured,
 		},
 		{
-			name:         "error getting encryption part",
-			externalPart: projectPart,
+			name:    "openfort provider",
+			key:     &provider.PEMKey{PEM: validPEM, KeyType: provider.KeyTypeRSA},
+			wantErr: ErrProviderMismatch,
 			mock: func() {
-				projectRepo.ExpectedCalls = nil
-				projectRepo.On("GetEncryptionPart", mock.Anything, mock.Anything).Return("", errors.New("repository error"))
-				projectRepo.On("HasSuccessfulMigration", mock.Anything, mock.Anything).Return(true, nil)
+				providerRepo.ExpectedCalls = nil
+				providerRepo.On("Get", mock.Anything, "provider-id").Return(&provider.Provider{ProjectID: "project_id", Type: provider.TypeOpenfort, Config: &provider.OpenfortConfig{}}, nil)
 			},
-			wantErr: ErrInternal,
 		},
 		{
-			name:         "error reconstructing encryption key",
-			externalPart: "invalid",
+			name:    "jwk provider",
+			key:     &provider.PEMKey{PEM: validPEM, KeyType: provider.KeyTypeRSA},
+			wantErr: ErrJWKPemConflict,
 			mock: func() {
-				projectRepo.ExpectedCalls = nil
-				projectRepo.On("GetEncryptionPart", mock.Anything, mock.Anything).Return("invalid", nil)
-				projectRepo.On("HasSuccessfulMigration", mock.Anything, mock.Anything).Return(true, nil)
+				providerRepo.ExpectedCalls = nil
+				providerRepo.On("Get", mock.Anything, "provider-id").Return(&provider.Provider{ProjectID: "project_id", Type: provider.TypeCustom, Config: &provider.CustomConfig{JWK: "url"}}, nil)
 			},
-			wantErr: ErrInvalidEncryptionPart,
 		},
 		{
-			name:         "error listing shares",
-			externalPart: projectPart,
+			name:    "missing key type",
+			key:     &provider.PEMKey{PEM: validPEM},
+			wantErr: ErrKeyTypeNotSpecified,
 			mock: func() {
-				projectRepo.ExpectedCalls = nil
-				shareRepo.ExpectedCalls = nil
-				projectRepo.On("GetEncryptionPart", mock.Anything, mock.Anything).Return(storedPart, nil)
-				shareRepo.On("ListProjectIDAndEntropy", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("repository error"))
-				projectRepo.On("HasSuccessfulMigration", mock.Anything, mock.Anything).Return(true, nil)
+				providerRepo.ExpectedCalls = nil
+				providerRepo.On("Get", mock.Anything, "provider-id").Return(pemProvider, nil)
 			},
-			wantErr: ErrInternal,
 		},
 		{
-			name:         "error updating share",
-			externalPart: projectPart,
+			name:    "invalid pem",
+			key:     &provider.PEMKey{PEM: validPEM, KeyType: provider.KeyTypeECDSA},
+			wantErr: ErrInvalidPemCertificate,
 			mock: func() {
-				projectRepo.ExpectedCalls = nil
+				providerRepo.ExpectedCalls = nil
+				providerRepo.On("Get", mock.Anything, "provider-id").Return(pemProvider, nil)
+			},
+		},
+		{
+			name:    "invalid validity window",
+			key:     &provider.PEMKey{PEM: validPEM, KeyType: provider.KeyTypeRSA, NotBefore: &later, NotAfter: &now},
+			wantErr: ErrInvalidKeyValidity,
+			mock: func() {
+				providerRepo.ExpectedCalls = nil
+				providerRepo.On("Get", mock.Anything, "provider-id").Return(pemProvider, nil)
+			},
+		},
+		{
+			name:    "duplicate kid",
+			key:     &provider.PEMKey{KID: "existing", PEM: validPEM, KeyType: provider.KeyTypeRSA},
+			wantErr: ErrProviderKeyAlreadyExists,
+			mock: func() {
+				providerRepo.ExpectedCalls = nil
+				providerRepo.On("Get", mock.Anything, "provider-id").Return(pemProvider, nil)
+			},
+		},
+		{
+			name:    "repository error",
+			key:     &provider.PEMKey{PEM: validPEM, KeyType: provider.KeyTypeRSA},
+			wantErr: ErrInternal,
+			mock: func() {
+				providerRepo.ExpectedCalls = nil
+				providerRepo.On("Get", mock.Anything, "provider-id").Return(pemProvider, nil)
+				providerRepo.On("AddCustomKey", mock.Anything, mock.AnythingOfType("*provider.PEMKey")).Return(errors.New("repository error"))
+			},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			ass := assert.New(t)
+			key, err := app.AddProviderKey(ctx, "provider-id", tt.key)
+			ass.Equal(tt.wantErr, err)
+			if tt.wantErr == nil {
+				ass.Equal("provider-id", key.ProviderID)
+			}
+		})
+	}
+}
+
+func TestProjectApplication_RemoveProviderKey(t *testing.T) {
+	ctx := contexter.WithProjectID(context.Background(), "project_id")
+	shareRepo := new(sharemockrepo.MockShareRepository)
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+	providerRepo := new(providermockrepo.MockProviderRepository)
+	notificationsRepo := new(notificationsmockrepo.MockNotificationsRepository)
+	userContactRepo := new(usercontactmockrepo.MockUserContactRepository)
+	projectService := projectsvc.New(projectRepo, nil, nil)
+	providerService := providersvc.New(providerRepo)
+	encryptionPartsRepo := new(encryptionpartsmockrepo.MockEncryptionPartsRepository)
+	encryptionFactory := encryption.NewEncryptionFactory(encryptionPartsRepo, projectRepo)
+	rateLimiter := NewRequestTracker(&TestClock{})
+	invalidationRepo := new(invalidationmockrepo.MockInvalidationRepository)
+	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter, invalidationRepo, nil, nil, nil)
+
+	customProvider := &provider.Provider{
+		ID:        "provider-id",
+		ProjectID: "project_id",
+		Type:      provider.TypeCustom,
+		Config: &provider.CustomConfig{
+			ProviderID: "provider-id",
+			Keys:       []*provider.PEMKey{{ID: "key-id", ProviderID: "provider-id"}},
+		},
+	}
+
+	tc := []struct {
+		name    string
+		keyID   string
+		wantErr error
+		mock    func()
+	}{
+		{
+			name:  "success",
+			keyID: "key-id",
+			mock: func() {
+				providerRepo.ExpectedCalls = nil
+				providerRepo.On("Get", mock.Anything, "provider-id").Return(customProvider, nil)
+				providerRepo.On("DeleteCustomKey", mock.Anything, "provider-id", "key-id").Return(nil)
+			},
+		},
+		{
+			name:    "key not found",
+			keyID:   "other-key",
+			wantErr: ErrProviderKeyNotFound,
+			mock: func() {
+				providerRepo.ExpectedCalls = nil
+				providerRepo.On("Get", mock.Anything, "provider-id").Return(customProvider, nil)
+			},
+		},
+		{
+			name:    "provider not found",
+			keyID:   "key-id",
+			wantErr: ErrProviderNotFound,
+			mock: func() {
+				providerRepo.ExpectedCalls = nil
+				providerRepo.On("Get", mock.Anything, "provider-id").Return(nil, domainErrors.ErrProviderNotFound)
+			},
+		},
+		{
+			name:    "repository error",
+			keyID:   "key-id",
+			wantErr: ErrInternal,
+			mock: func() {
+				providerRepo.ExpectedCalls = nil
+				providerRepo.On("Get", mock.Anything, "provider-id").Return(customProvider, nil)
+				providerRepo.On("DeleteCustomKey", mock.Anything, "provider-id", "key-id").Return(errors.New("repository error"))
+			},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			ass := assert.New(t)
+			err := app.RemoveProviderKey(ctx, "provider-id", tt.keyID)
+			ass.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func TestProjectApplication_RolloverLegacyProviderKey(t *testing.T) {
+	ctx := contexter.WithProjectID(context.Background(), "project_id")
+	providerRepo := new(providermockrepo.MockProviderRepository)
+	app := New(nil, nil, providersvc.New(providerRepo), providerRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	validPEM := "-----BEGIN PUBLIC KEY-----\nMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA1ljaGMp9BrY6KQtUIWhw\ng2weyyF65zzNFR9VCxyxk7M/NCTvash6nJO4HwZ+/51YO6kZFr0JDdIMrMmNu/pE\na4FfvmAQJ+vDdc8LSwS7IWAp9y04MZVVFLEQzbToQ3kqkaJV5KsbKuADjm3JCXng\nkeOvuS04AeO4W2lB5BqQ+wX5TjAZ9P7xusJUd2ovk1kWVKeJDTxpAImpVhK2nLZ3\nFV/TWWVYutYFU1wmoRRyOeypTP4ZSPhKB5s6PqQuyl9KPqiWz7ESL9zAW3/yxONb\nEPc9pB8w/qXcW++g6iCYN66xH4punt7KuismzQwGysgnMyK6UnNuOJyJznPzAvB+\nQwIDAQAB\n-----END PUBLIC KEY-----\n"
+
+	cfg := &provider.CustomConfig{ProviderID: "provider-id", PEM: validPEM, KeyType: provider.KeyTypeRSA}
+	providerRepo.On("Get", mock.Anything, "provider-id").Return(&provider.Provider{ID: "provider-id", ProjectID: "project_id", Type: provider.TypeCustom, Config: cfg}, nil)
+	providerRepo.On("AddCustomKey", mock.Anything, mock.AnythingOfType("*provider.PEMKey")).Run(func(args mock.Arguments) {
+		key := args.Get(1).(*provider.PEMKey)
+		key.ID = "next-id"
+		cfg.Keys = append(cfg.Keys, key)
+	}).Return(nil)
+	providerRepo.On("DeleteCustomKey", mock.Anything, "provider-id", provider.LegacyPEMKeyID).Run(func(mock.Arguments) {
+		cfg.PEM = ""
+		cfg.KeyType = provider.KeyTypeUnknown
+	}).Return(nil).Once()
+
+	ass := assert.New(t)
+	ass.Equal([]string{provider.LegacyPEMKeyID}, keyIDs(cfg.PEMKeys()))
+
+	_, err := app.AddProviderKey(ctx, "provider-id", &provider.PEMKey{KID: "next", PEM: validPEM, KeyType: provider.KeyTypeRSA})
+	ass.NoError(err)
+	ass.Equal([]string{provider.LegacyPEMKeyID, "next-id"}, keyIDs(cfg.PEMKeys()))
+
+	ass.NoError(app.RemoveProviderKey(ctx, "provider-id", provider.LegacyPEMKeyID))
+	ass.Equal([]string{"next-id"}, keyIDs(cfg.PEMKeys()))
+
+	ass.Equal(ErrProviderKeyNotFound, app.RemoveProviderKey(ctx, "provider-id", provider.LegacyPEMKeyID))
+	providerRepo.AssertExpectations(t)
+}
+
+func keyIDs(keys []*provider.PEMKey) []string {
+	var ids []string
+	for _, key := range keys {
+		ids = append(ids, key.ID)
+	}
+	return ids
+}
+
+func TestProjectApplication_EncryptProjectShares(t *testing.T) {
+	ctx := contexter.WithProjectID(context.Background(), "project_id")
+	ctx = contexter.WithUserID(ctx, "user_id")
+	shareRepo := new(sharemockrepo.MockShareRepository)
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+	providerRepo := new(providermockrepo.MockProviderRepository)
+	notificationsRepo := new(notificationsmockrepo.MockNotificationsRepository)
+	userContactRepo := new(usercontactmockrepo.MockUserContactRepository)
+	projectService := projectsvc.New(projectRepo, nil, nil)
+	providerService := providersvc.New(providerRepo)
+	encryptionPartsRepo := new(encryptionpartsmockrepo.MockEncryptionPartsRepository)
+	encryptionFactory := encryption.NewEncryptionFactory(encryptionPartsRepo, projectRepo)
+	rateLimiter := NewRequestTracker(&TestClock{})
+	invalidationRepo := new(invalidationmockrepo.MockInvalidationRepository)
+	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter, invalidationRepo, nil, nil, nil)
+
+	key, err := random.GenerateRandomString(32)
+	if err != nil {
+		t.Fatalf("%s", key)
+	}
+
+	reconstructor := encryptionFactory.CreateReconstructionStrategy(true)
+	storedPart, projectPart, err := reconstructor.Split(key)
+	if err != nil {
+		t.Fatalf("failed to generate encryption key: %v", err)
+	}
+
+	encryptedShare := &share.Share{
+		ID:      "encrypted_share_id",
+		Secret:  "djksalfjadsfds",
+		UserID:  "user_id",
+		Entropy: share.EntropyUser,
+		EncryptionParameters: &share.EncryptionParameters{
+			Salt:       "somesalt",
+			Iterations: 1000,
+			Length:     256,
+			Digest:     "SHA-256",
+		},
+	}
+
+	plainShare := &share.Share{
+		ID:      "share_id",
+		Secret:  "secret",
+		UserID:  "user_id",
+		Entropy: share.EntropyNone,
+	}
+
+	plainShare2 := &share.Share{
+		ID:      "share_id",
+		Secret:  "secret",
+		UserID:  "user_id",
+		Entropy: share.EntropyNone,
+	}
+
+	tc := []struct {
+		name         string
+		externalPart string
+		wantErr      error
+		mock         func()
+	}{
+		{
+			name:         "success",
+			externalPart: projectPart,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				shareRepo.ExpectedCalls = nil
+				shareRepo.On("ListProjectIDAndEntropy", mock.Anything, mock.Anything, mock.Anything).Return([]*share.Share{plainShare, encryptedShare}, nil)
+				shareRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+				projectRepo.On("GetEncryptionPart", mock.Anything, mock.Anything).Return(storedPart, nil)
+				shareRepo.On("UpdateProjectEncryption", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				projectRepo.On("HasSuccessfulMigration", mock.Anything, mock.Anything).Return(true, nil)
+			},
+		},
+		{
+			name:         "encryption part not found",
+			externalPart: projectPart,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				projectRepo.On("GetEncryptionPart", mock.Anything, mock.Anything).Return("", domainErrors.ErrEncryptionPartNotFound)
+				projectRepo.On("HasSuccessfulMigration", mock.Anything, mock.Anything).Return(true, nil)
+			},
+			wantErr: ErrEncryptionNotConfigured,
+		},
+		{
+			name:         "error getting encryption part",
+			externalPart: projectPart,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				projectRepo.On("GetEncryptionPart", mock.Anything, mock.Anything).Return("", errors.New("repository error"))
+				projectRepo.On("HasSuccessfulMigration", mock.Anything, mock.Anything).Return(true, nil)
+			},
+			wantErr: ErrInternal,
+		},
+		{
+			name:         "error reconstructing encryption key",
+			externalPart: "invalid",
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				projectRepo.On("GetEncryptionPart", mock.Anything, mock.Anything).Return("invalid", nil)
+				projectRepo.On("HasSuccessfulMigration", mock.Anything, mock.Anything).Return(true, nil)
+			},
+			wantErr: ErrInvalidEncryptionPart,
+		},
+		{
+			name:         "error listing shares",
+			externalPart: projectPart,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				shareRepo.ExpectedCalls = nil
+				projectRepo.On("GetEncryptionPart", mock.Anything, mock.Anything).Return(storedPart, nil)
+				shareRepo.On("ListProjectIDAndEntropy", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("repository error"))
+				projectRepo.On("HasSuccessfulMigration", mock.Anything, mock.Anything).Return(true, nil)
+			},
+			wantErr: ErrInternal,
+		},
+		{
+			name:         "error updating share",
+			externalPart: projectPart,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
 				projectRepo.On("GetEncryptionPart", mock.Anything, mock.Anything).Return(storedPart, nil)
 				shareRepo.ExpectedCalls = nil
 				shareRepo.On("ListProjectIDAndEntropy", mock.Anything, mock.Anything, mock.Anything).Return([]*share.Share{plainShare2}, nil)
@@ -1052,12 +1532,13 @@ func TestProjectApplication_RegisterEncryptionKey(t *testing.T) {
 	providerRepo := new(providermockrepo.MockProviderRepository)
 	notificationsRepo := new(notificationsmockrepo.MockNotificationsRepository)
 	userContactRepo := new(usercontactmockrepo.MockUserContactRepository)
-	projectService := projectsvc.New(projectRepo, 60*time.Second)
+	projectService := projectsvc.New(projectRepo, nil, nil)
 	providerService := providersvc.New(providerRepo)
 	encryptionPartsRepo := new(encryptionpartsmockrepo.MockEncryptionPartsRepository)
 	encryptionFactory := encryption.NewEncryptionFactory(encryptionPartsRepo, projectRepo)
 	rateLimiter := NewRequestTracker(&TestClock{})
-	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter)
+	invalidationRepo := new(invalidationmockrepo.MockInvalidationRepository)
+	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter, invalidationRepo, nil, nil, nil)
 
 	tc := []struct {
 		name    string
@@ -1119,12 +1600,13 @@ func TestProjectApplication_RegisterEncryptionSession(t *testing.T) {
 	providerRepo := new(providermockrepo.MockProviderRepository)
 	notificationsRepo := new(notificationsmockrepo.MockNotificationsRepository)
 	userContactRepo := new(usercontactmockrepo.MockUserContactRepository)
-	projectService := projectsvc.New(projectRepo, 60*time.Second)
+	projectService := projectsvc.New(projectRepo, nil, nil)
 	providerService := providersvc.New(providerRepo)
 	encryptionPartsRepo := new(encryptionpartsmockrepo.MockEncryptionPartsRepository)
 	encryptionFactory := encryption.NewEncryptionFactory(encryptionPartsRepo, projectRepo)
 	rateLimiter := NewRequestTracker(&TestClock{})
-	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter)
+	invalidationRepo := new(invalidationmockrepo.MockInvalidationRepository)
+	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter, invalidationRepo, nil, nil, nil)
 
 	tc := []struct {
 		name    string
@@ -1169,12 +1651,14 @@ func TestProjectApplication_Enable2FA(t *testing.T) {
 	providerRepo := new(providermockrepo.MockProviderRepository)
 	notificationsRepo := new(notificationsmockrepo.MockNotificationsRepository)
 	userContactRepo := new(usercontactmockrepo.MockUserContactRepository)
-	projectService := projectsvc.New(projectRepo, 60*time.Second)
+	projectService := projectsvc.New(projectRepo, nil, nil)
 	providerService := providersvc.New(providerRepo)
 	encryptionPartsRepo := new(encryptionpartsmockrepo.MockEncryptionPartsRepository)
 	encryptionFactory := encryption.NewEncryptionFactory(encryptionPartsRepo, projectRepo)
 	rateLimiter := NewRequestTracker(&TestClock{})
-	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter)
+	invalidationRepo := new(invalidationmockrepo.MockInvalidationRepository)
+	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter, invalidationRepo, nil, nil, nil)
+	invalidationRepo.On("Publish", mock.Anything, project.InvalidationChannel, "project_id").Return(nil)
 
 	tc := []struct {
 		name    string
@@ -1234,3 +1718,709 @@ func TestProjectApplication_Enable2FA(t *testing.T) {
 		})
 	}
 }
+
+func TestProjectApplication_AddClientCertificate(t *testing.T) {
+	ctx := contexter.WithProjectID(context.Background(), "project_id")
+	shareRepo := new(sharemockrepo.MockShareRepository)
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+	providerRepo := new(providermockrepo.MockProviderRepository)
+	notificationsRepo := new(notificationsmockrepo.MockNotificationsRepository)
+	userContactRepo := new(usercontactmockrepo.MockUserContactRepository)
+	projectService := projectsvc.New(projectRepo, nil, nil)
+	providerService := providersvc.New(providerRepo)
+	encryptionPartsRepo := new(encryptionpartsmockrepo.MockEncryptionPartsRepository)
+	encryptionFactory := encryption.NewEncryptionFactory(encryptionPartsRepo, projectRepo)
+	rateLimiter := NewRequestTracker(&TestClock{})
+	invalidationRepo := new(invalidationmockrepo.MockInvalidationRepository)
+	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter, invalidationRepo, nil, nil, nil)
+
+	fingerprint := "5d41402abc4b2a76b9719d911017c5925d41402abc4b2a76b9719d911017c592"
+	opensslFingerprint := "5D:41:40:2A:BC:4B:2A:76:B9:71:9D:91:10:17:C5:92:5D:41:40:2A:BC:4B:2A:76:B9:71:9D:91:10:17:C5:92"
+
+	tc := []struct {
+		name    string
+		cert    *project.ClientCertificate
+		wantErr error
+		mock    func()
+	}{
+		{
+			name:    "success",
+			cert:    &project.ClientCertificate{Fingerprint: fingerprint, Type: project.ClientCertificateTypeCA},
+			wantErr: nil,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				projectRepo.On("ListClientCertificates", mock.Anything, "project_id").Return([]*project.ClientCertificate{}, nil)
+				projectRepo.On("AddClientCertificate", mock.Anything, mock.MatchedBy(func(c *project.ClientCertificate) bool {
+					return c.ProjectID == "project_id" && c.Fingerprint == fingerprint
+				})).Return(nil)
+			},
+		},
+		{
+			name:    "normalizes openssl fingerprint",
+			cert:    &project.ClientCertificate{Fingerprint: opensslFingerprint, Type: project.ClientCertificateTypeLeaf},
+			wantErr: nil,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				projectRepo.On("ListClientCertificates", mock.Anything, "project_id").Return([]*project.ClientCertificate{}, nil)
+				projectRepo.On("AddClientCertificate", mock.Anything, mock.MatchedBy(func(c *project.ClientCertificate) bool {
+					return c.Fingerprint == fingerprint
+				})).Return(nil)
+			},
+		},
+		{
+			name:    "invalid fingerprint",
+			cert:    &project.ClientCertificate{Fingerprint: "not-a-fingerprint", Type: project.ClientCertificateTypeCA},
+			wantErr: ErrInvalidCertificateFingerprint,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+			},
+		},
+		{
+			name:    "invalid type",
+			cert:    &project.ClientCertificate{Fingerprint: fingerprint},
+			wantErr: ErrInvalidCertificateType,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+			},
+		},
+		{
+			name:    "already registered",
+			cert:    &project.ClientCertificate{Fingerprint: fingerprint, Type: project.ClientCertificateTypeCA},
+			wantErr: ErrClientCertificateAlreadyExists,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				projectRepo.On("ListClientCertificates", mock.Anything, "project_id").Return([]*project.ClientCertificate{{ID: "cert_id", Fingerprint: fingerprint}}, nil)
+			},
+		},
+		{
+			name:    "repository error",
+			cert:    &project.ClientCertificate{Fingerprint: fingerprint, Type: project.ClientCertificateTypeCA},
+			wantErr: ErrInternal,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				projectRepo.On("ListClientCertificates", mock.Anything, "project_id").Return([]*project.ClientCertificate{}, nil)
+				projectRepo.On("AddClientCertificate", mock.Anything, mock.Anything).Return(errors.New("repository error"))
+			},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			ass := assert.New(t)
+			_, err := app.AddClientCertificate(ctx, tt.cert)
+			ass.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func TestProjectApplication_RemoveClientCertificate(t *testing.T) {
+	ctx := contexter.WithProjectID(context.Background(), "project_id")
+	shareRepo := new(sharemockrepo.MockShareRepository)
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+	providerRepo := new(providermockrepo.MockProviderRepository)
+	notificationsRepo := new(notificationsmockrepo.MockNotificationsRepository)
+	userContactRepo := new(usercontactmockrepo.MockUserContactRepository)
+	projectService := projectsvc.New(projectRepo, nil, nil)
+	providerService := providersvc.New(providerRepo)
+	encryptionPartsRepo := new(encryptionpartsmockrepo.MockEncryptionPartsRepository)
+	encryptionFactory := encryption.NewEncryptionFactory(encryptionPartsRepo, projectRepo)
+	rateLimiter := NewRequestTracker(&TestClock{})
+	invalidationRepo := new(invalidationmockrepo.MockInvalidationRepository)
+	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter, invalidationRepo, nil, nil, nil)
+
+	tc := []struct {
+		name    string
+		wantErr error
+		mock    func()
+	}{
+		{
+			name:    "success",
+			wantErr: nil,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				projectRepo.On("Get", mock.Anything, "project_id").Return(&project.Project{ID: "project_id", ClientCertMode: project.ClientCertModeRequired}, nil)
+				projectRepo.On("ListClientCertificates", mock.Anything, "project_id").Return([]*project.ClientCertificate{{ID: "cert_id"}, {ID: "other_cert_id"}}, nil)
+				projectRepo.On("DeleteClientCertificate", mock.Anything, "project_id", "cert_id").Return(nil)
+			},
+		},
+		{
+			name:    "certificate not found",
+			wantErr: ErrClientCertificateNotFound,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				projectRepo.On("Get", mock.Anything, "project_id").Return(&project.Project{ID: "project_id"}, nil)
+				projectRepo.On("ListClientCertificates", mock.Anything, "project_id").Return([]*project.ClientCertificate{{ID: "other_cert_id"}}, nil)
+			},
+		},
+		{
+			name:    "last certificate while required",
+			wantErr: ErrNoClientCertificates,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				projectRepo.On("Get", mock.Anything, "project_id").Return(&project.Project{ID: "project_id", ClientCertMode: project.ClientCertModeRequired}, nil)
+				projectRepo.On("ListClientCertificates", mock.Anything, "project_id").Return([]*project.ClientCertificate{{ID: "cert_id"}}, nil)
+			},
+		},
+		{
+			name:    "last certificate while alternative",
+			wantErr: nil,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				projectRepo.On("Get", mock.Anything, "project_id").Return(&project.Project{ID: "project_id", ClientCertMode: project.ClientCertModeAlternative}, nil)
+				projectRepo.On("ListClientCertificates", mock.Anything, "project_id").Return([]*project.ClientCertificate{{ID: "cert_id"}}, nil)
+				projectRepo.On("DeleteClientCertificate", mock.Anything, "project_id", "cert_id").Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			ass := assert.New(t)
+			err := app.RemoveClientCertificate(ctx, "cert_id")
+			ass.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func TestProjectApplication_SetClientCertMode(t *testing.T) {
+	ctx := contexter.WithProjectID(context.Background(), "project_id")
+	shareRepo := new(sharemockrepo.MockShareRepository)
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+	providerRepo := new(providermockrepo.MockProviderRepository)
+	notificationsRepo := new(notificationsmockrepo.MockNotificationsRepository)
+	userContactRepo := new(usercontactmockrepo.MockUserContactRepository)
+	projectService := projectsvc.New(projectRepo, nil, nil)
+	providerService := providersvc.New(providerRepo)
+	encryptionPartsRepo := new(encryptionpartsmockrepo.MockEncryptionPartsRepository)
+	encryptionFactory := encryption.NewEncryptionFactory(encryptionPartsRepo, projectRepo)
+	rateLimiter := NewRequestTracker(&TestClock{})
+	invalidationRepo := new(invalidationmockrepo.MockInvalidationRepository)
+	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter, invalidationRepo, nil, nil, nil)
+	invalidationRepo.On("Publish", mock.Anything, project.InvalidationChannel, "project_id").Return(nil)
+
+	tc := []struct {
+		name    string
+		mode    project.ClientCertMode
+		wantErr error
+		mock    func()
+	}{
+		{
+			name:    "alternative",
+			mode:    project.ClientCertModeAlternative,
+			wantErr: nil,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				projectRepo.On("UpdateClientCertMode", mock.Anything, "project_id", project.ClientCertModeAlternative).Return(nil)
+			},
+		},
+		{
+			name:    "required with a certificate",
+			mode:    project.ClientCertModeRequired,
+			wantErr: nil,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				projectRepo.On("ListClientCertificates", mock.Anything, "project_id").Return([]*project.ClientCertificate{{ID: "cert_id"}}, nil)
+				projectRepo.On("UpdateClientCertMode", mock.Anything, "project_id", project.ClientCertModeRequired).Return(nil)
+			},
+		},
+		{
+			name:    "required without certificates",
+			mode:    project.ClientCertModeRequired,
+			wantErr: ErrNoClientCertificates,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				projectRepo.On("ListClientCertificates", mock.Anything, "project_id").Return([]*project.ClientCertificate{}, nil)
+			},
+		},
+		{
+			name:    "invalid mode",
+			mode:    project.ClientCertMode("SOMETIMES"),
+			wantErr: ErrInvalidClientCertMode,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+			},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			ass := assert.New(t)
+			err := app.SetClientCertMode(ctx, tt.mode)
+			ass.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func TestProjectApplication_SetRequireSignedRequests(t *testing.T) {
+	ctx := contexter.WithProjectID(context.Background(), "project_id")
+	shareRepo := new(sharemockrepo.MockShareRepository)
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+	providerRepo := new(providermockrepo.MockProviderRepository)
+	notificationsRepo := new(notificationsmockrepo.MockNotificationsRepository)
+	userContactRepo := new(usercontactmockrepo.MockUserContactRepository)
+	projectService := projectsvc.New(projectRepo, nil, nil)
+	providerService := providersvc.New(providerRepo)
+	encryptionPartsRepo := new(encryptionpartsmockrepo.MockEncryptionPartsRepository)
+	encryptionFactory := encryption.NewEncryptionFactory(encryptionPartsRepo, projectRepo)
+	rateLimiter := NewRequestTracker(&TestClock{})
+	invalidationRepo := new(invalidationmockrepo.MockInvalidationRepository)
+	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter, invalidationRepo, nil, nil, nil)
+	invalidationRepo.On("Publish", mock.Anything, project.InvalidationChannel, "project_id").Return(nil)
+
+	tc := []struct {
+		name     string
+		required bool
+		wantErr  error
+		mock     func()
+	}{
+		{
+			name:     "require with a signing key",
+			required: true,
+			wantErr:  nil,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				projectRepo.On("GetSigningKey", mock.Anything, "project_id").Return("signing_key", nil)
+				projectRepo.On("UpdateRequireSignedRequests", mock.Anything, "project_id", true).Return(nil)
+			},
+		},
+		{
+			name:     "require without a signing key",
+			required: true,
+			wantErr:  ErrSigningKeyNotFound,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				projectRepo.On("GetSigningKey", mock.Anything, "project_id").Return("", domainErrors.ErrSigningKeyNotFound)
+			},
+		},
+		{
+			name:     "stop requiring",
+			required: false,
+			wantErr:  nil,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				projectRepo.On("UpdateRequireSignedRequests", mock.Anything, "project_id", false).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			ass := assert.New(t)
+			err := app.SetRequireSignedRequests(ctx, tt.required)
+			ass.Equal(tt.wantErr, err)
+		})
+	}
+}
+
+func TestProjectApplication_RotateSigningKey(t *testing.T) {
+	ctx := contexter.WithProjectID(context.Background(), "project_id")
+	shareRepo := new(sharemockrepo.MockShareRepository)
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+	providerRepo := new(providermockrepo.MockProviderRepository)
+	notificationsRepo := new(notificationsmockrepo.MockNotificationsRepository)
+	userContactRepo := new(usercontactmockrepo.MockUserContactRepository)
+	projectService := projectsvc.New(projectRepo, nil, nil)
+	providerService := providersvc.New(providerRepo)
+	encryptionPartsRepo := new(encryptionpartsmockrepo.MockEncryptionPartsRepository)
+	encryptionFactory := encryption.NewEncryptionFactory(encryptionPartsRepo, projectRepo)
+	rateLimiter := NewRequestTracker(&TestClock{})
+	invalidationRepo := new(invalidationmockrepo.MockInvalidationRepository)
+	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter, invalidationRepo, nil, nil, nil)
+	invalidationRepo.On("Publish", mock.Anything, project.InvalidationChannel, "project_id").Return(nil)
+
+	projectRepo.On("SetSigningKey", mock.Anything, "project_id", mock.AnythingOfType("string")).Return(nil).Once()
+	signingKey, err := app.RotateSigningKey(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, signingKey, 64)
+	projectRepo.AssertExpectations(t)
+
+	projectRepo.On("SetSigningKey", mock.Anything, "project_id", mock.AnythingOfType("string")).Return(domainErrors.ErrProjectSecretEncryptionNotSet).Once()
+	_, err = app.RotateSigningKey(ctx)
+	assert.Equal(t, ErrSigningNotConfigured, err)
+}
+
+func TestProjectApplication_RequestDeletion(t *testing.T) {
+	ctx := contexter.WithProjectID(context.Background(), "project_id")
+	shareRepo := new(sharemockrepo.MockShareRepository)
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+	providerRepo := new(providermockrepo.MockProviderRepository)
+	notificationsRepo := new(notificationsmockrepo.MockNotificationsRepository)
+	userContactRepo := new(usercontactmockrepo.MockUserContactRepository)
+	projectService := projectsvc.New(projectRepo, nil, nil)
+	providerService := providersvc.New(providerRepo)
+	encryptionPartsRepo := new(encryptionpartsmockrepo.MockEncryptionPartsRepository)
+	encryptionFactory := encryption.NewEncryptionFactory(encryptionPartsRepo, projectRepo)
+	rateLimiter := NewRequestTracker(&TestClock{})
+	invalidationRepo := new(invalidationmockrepo.MockInvalidationRepository)
+	archiveRepo := new(archivemockrepo.MockArchiveRepository)
+	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter, invalidationRepo, archiveRepo, nil, nil)
+
+	archive := &project.Archive{
+		ProjectID:  "project_id",
+		ExportedAt: time.Now(),
+		Tables:     map[string][]map[string]any{"shld_projects": {{"id": "project_id"}}},
+	}
+	digest, err := archive.Digest()
+	if err != nil {
+		t.Fatalf("failed to digest archive: %v", err)
+	}
+
+	archiveRepo.On("ExportProject", mock.Anything, "project_id").Return(archive, nil)
+	var saved *project.DeletionRequest
+	projectRepo.On("SaveDeletionRequest", mock.Anything, mock.AnythingOfType("*project.DeletionRequest")).Run(func(args mock.Arguments) {
+		saved = args.Get(1).(*project.DeletionRequest)
+	}).Return(nil)
+
+	pending, err := app.RequestDeletion(ctx)
+	ass := assert.New(t)
+	ass.NoError(err)
+	ass.Equal(digest, pending.ArchiveDigest)
+	ass.Equal(archive, pending.Archive)
+	ass.Equal(digest, saved.ArchiveDigest)
+	ass.Equal(hashConfirmationToken(pending.ConfirmationToken), saved.TokenHash)
+	ass.NotEqual(pending.ConfirmationToken, saved.TokenHash)
+	ass.WithinDuration(time.Now().Add(DeletionConfirmationTTL), saved.ExpiresAt, time.Minute)
+}
+
+func TestProjectApplication_DeleteProject(t *testing.T) {
+	ctx := contexter.WithProjectID(context.Background(), "project_id")
+	shareRepo := new(sharemockrepo.MockShareRepository)
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+	providerRepo := new(providermockrepo.MockProviderRepository)
+	notificationsRepo := new(notificationsmockrepo.MockNotificationsRepository)
+	userContactRepo := new(usercontactmockrepo.MockUserContactRepository)
+	projectService := projectsvc.New(projectRepo, nil, nil)
+	providerService := providersvc.New(providerRepo)
+	encryptionPartsRepo := new(encryptionpartsmockrepo.MockEncryptionPartsRepository)
+	encryptionFactory := encryption.NewEncryptionFactory(encryptionPartsRepo, projectRepo)
+	rateLimiter := NewRequestTracker(&TestClock{})
+	invalidationRepo := new(invalidationmockrepo.MockInvalidationRepository)
+	archiveRepo := new(archivemockrepo.MockArchiveRepository)
+	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter, invalidationRepo, archiveRepo, nil, nil)
+	invalidationRepo.On("Publish", mock.Anything, project.InvalidationChannel, "project_id").Return(nil)
+
+	pendingRequest := &project.DeletionRequest{
+		ProjectID:     "project_id",
+		TokenHash:     hashConfirmationToken("token"),
+		ArchiveDigest: "digest",
+		ExpiresAt:     time.Now().Add(time.Minute),
+	}
+	expiredRequest := &project.DeletionRequest{
+		ProjectID:     "project_id",
+		TokenHash:     hashConfirmationToken("token"),
+		ArchiveDigest: "digest",
+		ExpiresAt:     time.Now().Add(-time.Minute),
+	}
+	receipt := &project.DeletionReceipt{ID: "receipt_id", ProjectID: "project_id"}
+
+	tc := []struct {
+		name    string
+		token   string
+		mode    project.DeletionMode
+		wantErr error
+		mock    func()
+	}{
+		{
+			name:  "hard delete",
+			token: "token",
+			mode:  project.DeletionModeHardDelete,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				archiveRepo.ExpectedCalls = nil
+				projectRepo.On("GetDeletionRequest", mock.Anything, "project_id").Return(pendingRequest, nil)
+				archiveRepo.On("DeleteProject", mock.Anything, "project_id", project.DeletionModeHardDelete, "digest").Return(receipt, nil)
+			},
+		},
+		{
+			name:  "crypto shred",
+			token: "token",
+			mode:  project.DeletionModeCryptoShred,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				archiveRepo.ExpectedCalls = nil
+				projectRepo.On("GetDeletionRequest", mock.Anything, "project_id").Return(pendingRequest, nil)
+				projectRepo.On("GetEncryptionPart", mock.Anything, "project_id").Return("part", nil)
+				archiveRepo.On("DeleteProject", mock.Anything, "project_id", project.DeletionModeCryptoShred, "digest").Return(receipt, nil)
+			},
+		},
+		{
+			name:    "crypto shred without an encryption part",
+			token:   "token",
+			mode:    project.DeletionModeCryptoShred,
+			wantErr: ErrEncryptionNotConfigured,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				archiveRepo.ExpectedCalls = nil
+				projectRepo.On("GetDeletionRequest", mock.Anything, "project_id").Return(pendingRequest, nil)
+				projectRepo.On("GetEncryptionPart", mock.Anything, "project_id").Return("", domainErrors.ErrEncryptionPartNotFound)
+			},
+		},
+		{
+			name:    "invalid mode",
+			token:   "token",
+			mode:    project.DeletionMode("SOFT_DELETE"),
+			wantErr: ErrInvalidDeletionMode,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				archiveRepo.ExpectedCalls = nil
+			},
+		},
+		{
+			name:    "not requested",
+			token:   "token",
+			mode:    project.DeletionModeHardDelete,
+			wantErr: ErrDeletionNotRequested,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				archiveRepo.ExpectedCalls = nil
+				projectRepo.On("GetDeletionRequest", mock.Anything, "project_id").Return(nil, domainErrors.ErrDeletionRequestNotFound)
+			},
+		},
+		{
+			name:    "wrong token",
+			token:   "other token",
+			mode:    project.DeletionModeHardDelete,
+			wantErr: ErrInvalidDeletionToken,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				archiveRepo.ExpectedCalls = nil
+				projectRepo.On("GetDeletionRequest", mock.Anything, "project_id").Return(pendingRequest, nil)
+			},
+		},
+		{
+			name:    "expired request",
+			token:   "token",
+			mode:    project.DeletionModeHardDelete,
+			wantErr: ErrDeletionRequestExpired,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				archiveRepo.ExpectedCalls = nil
+				projectRepo.On("GetDeletionRequest", mock.Anything, "project_id").Return(expiredRequest, nil)
+			},
+		},
+		{
+			name:    "project changed since export",
+			token:   "token",
+			mode:    project.DeletionModeHardDelete,
+			wantErr: ErrProjectChangedSinceExport,
+			mock: func() {
+				projectRepo.ExpectedCalls = nil
+				archiveRepo.ExpectedCalls = nil
+				projectRepo.On("GetDeletionRequest", mock.Anything, "project_id").Return(pendingRequest, nil)
+				archiveRepo.On("DeleteProject", mock.Anything, "project_id", project.DeletionModeHardDelete, "digest").Return(nil, domainErrors.ErrProjectChangedSinceExport)
+			},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			ass := assert.New(t)
+			got, err := app.DeleteProject(ctx, tt.token, tt.mode)
+			ass.Equal(tt.wantErr, err)
+			if tt.wantErr == nil {
+				ass.Equal(receipt, got)
+			}
+		})
+	}
+}
+
+func TestProjectApplication_EraseUser(t *testing.T) {
+	ctx := contexter.WithProjectID(context.Background(), "project_id")
+	shareRepo := new(sharemockrepo.MockShareRepository)
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+	providerRepo := new(providermockrepo.MockProviderRepository)
+	notificationsRepo := new(notificationsmockrepo.MockNotificationsRepository)
+	userContactRepo := new(usercontactmockrepo.MockUserContactRepository)
+	projectService := projectsvc.New(projectRepo, nil, nil)
+	providerService := providersvc.New(providerRepo)
+	encryptionPartsRepo := new(encryptionpartsmockrepo.MockEncryptionPartsRepository)
+	encryptionFactory := encryption.NewEncryptionFactory(encryptionPartsRepo, projectRepo)
+	rateLimiter := NewRequestTracker(&TestClock{})
+	invalidationRepo := new(invalidationmockrepo.MockInvalidationRepository)
+	archiveRepo := new(archivemockrepo.MockArchiveRepository)
+	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter, invalidationRepo, archiveRepo, nil, nil)
+
+	receipt := &user.ErasureReceipt{ID: "receipt_id", ProjectID: "project_id", ExternalUserIDHash: user.HashExternalUserID("external_user_id")}
+
+	tc := []struct {
+		name           string
+		externalUserID string
+		wantErr        error
+		mock           func()
+	}{
+		{
+			name:           "success",
+			externalUserID: "external_user_id",
+			mock: func() {
+				archiveRepo.ExpectedCalls = nil
+				archiveRepo.On("EraseUser", mock.Anything, "project_id", "external_user_id").Return(receipt, nil)
+			},
+		},
+		{
+			name:           "unknown external user",
+			externalUserID: "unknown",
+			wantErr:        ErrExternalUserNotFound,
+			mock: func() {
+				archiveRepo.ExpectedCalls = nil
+				archiveRepo.On("EraseUser", mock.Anything, "project_id", "unknown").Return(nil, domainErrors.ErrExternalUserNotFound)
+			},
+		},
+		{
+			name:           "repository error",
+			externalUserID: "external_user_id",
+			wantErr:        ErrInternal,
+			mock: func() {
+				archiveRepo.ExpectedCalls = nil
+				archiveRepo.On("EraseUser", mock.Anything, "project_id", "external_user_id").Return(nil, errors.New("connection reset"))
+			},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			ass := assert.New(t)
+			got, err := app.EraseUser(ctx, tt.externalUserID)
+			ass.Equal(tt.wantErr, err)
+			if tt.wantErr == nil {
+				ass.Equal(receipt, got)
+				ass.NotContains(got.ExternalUserIDHash, "external_user_id")
+			}
+		})
+	}
+}
+
+func TestProjectApplication_ListUsers(t *testing.T) {
+	ctx := contexter.WithProjectID(context.Background(), "project_id")
+	shareRepo := new(sharemockrepo.MockShareRepository)
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+	providerRepo := new(providermockrepo.MockProviderRepository)
+	notificationsRepo := new(notificationsmockrepo.MockNotificationsRepository)
+	userContactRepo := new(usercontactmockrepo.MockUserContactRepository)
+	projectService := projectsvc.New(projectRepo, nil, nil)
+	providerService := providersvc.New(providerRepo)
+	encryptionPartsRepo := new(encryptionpartsmockrepo.MockEncryptionPartsRepository)
+	encryptionFactory := encryption.NewEncryptionFactory(encryptionPartsRepo, projectRepo)
+	rateLimiter := NewRequestTracker(&TestClock{})
+	invalidationRepo := new(invalidationmockrepo.MockInvalidationRepository)
+	userRepo := new(usermockedrepo.MockUserRepository)
+	keychainRepo := new(keychainmockrepo.MockKeychainRepository)
+	app := New(projectService, projectRepo, providerService, providerRepo, shareRepo, notificationsRepo, userContactRepo, encryptionFactory, encryptionPartsRepo, nil, nil, rateLimiter, invalidationRepo, nil, userRepo, keychainRepo)
+
+	createdAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	users := []*user.User{
+		{ID: "keychain_user", ProjectID: "project_id", CreatedAt: createdAt},
+		{ID: "legacy_user", ProjectID: "project_id", CreatedAt: createdAt.Add(time.Minute)},
+		{ID: "next_page_user", ProjectID: "project_id", CreatedAt: createdAt.Add(2 * time.Minute)},
+	}
+	reference := "wallet"
+	filter := &user.ListFilter{Entropy: share.EntropyProject}
+
+	userRepo.On("ListByProject", mock.Anything, "project_id", filter, (*user.Cursor)(nil), 3).Return(users, nil)
+	userRepo.On("FindExternalBy", mock.Anything, mock.Anything).Return([]*user.ExternalUser{{ExternalUserID: "external_user_id", ProviderID: "provider_id"}}, nil)
+	shareRepo.On("GetByUserID", mock.Anything, "keychain_user").Return(nil, domainErrors.ErrShareNotFound)
+	shareRepo.On("GetByUserID", mock.Anything, "legacy_user").Return(&share.Share{ID: "legacy_share", Entropy: share.EntropyUser}, nil)
+	keychainRepo.On("GetByUserID", mock.Anything, "keychain_user").Return(&keychain.Keychain{ID: "keychain_id", UserID: "keychain_user"}, nil)
+	keychainRepo.On("GetByUserID", mock.Anything, "legacy_user").Return(nil, domainErrors.ErrKeychainNotFound)
+	shareRepo.On("ListByKeychainID", mock.Anything, "keychain_id").Return([]*share.Share{{ID: "keychain_share", Reference: &reference, Entropy: share.EntropyProject}}, nil)
+
+	ass := assert.New(t)
+	page, err := app.ListUsers(ctx, filter, nil, 2)
+	ass.NoError(err)
+	ass.Len(page.Users, 2)
+	ass.Equal(&user.Cursor{CreatedAt: createdAt.Add(time.Minute), ID: "legacy_user"}, page.Next)
+
+	ass.Equal("keychain_id", *page.Users[0].KeychainID)
+	ass.Len(page.Users[0].Shares, 1)
+	ass.Equal("keychain_share", page.Users[0].Shares[0].ID)
+
+	ass.Nil(page.Users[1].KeychainID)
+	ass.Len(page.Users[1].Shares, 1)
+	ass.Equal("legacy_share", page.Users[1].Shares[0].ID)
+
+	_, err = app.ListUsers(ctx, nil, nil, MaxUsersPageSize+1)
+	ass.Equal(ErrInvalidPageSize, err)
+}
+
+func TestProjectApplication_CreateInvite(t *testing.T) {
+	ctx := context.Background()
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+	projectService := projectsvc.New(projectRepo, nil, nil)
+	app := New(projectService, projectRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	var saved *project.Invite
+	projectRepo.On("CreateInvite", mock.Anything, mock.AnythingOfType("*project.Invite")).Run(func(args mock.Arguments) {
+		saved = args.Get(1).(*project.Invite)
+	}).Return(nil)
+
+	ass := assert.New(t)
+	token, invite, err := app.CreateInvite(ctx, time.Hour)
+	ass.NoError(err)
+	ass.Equal(saved, invite)
+	ass.Equal(hashConfirmationToken(token), saved.TokenHash)
+	ass.NotEqual(token, saved.TokenHash)
+	ass.WithinDuration(time.Now().Add(time.Hour), saved.ExpiresAt, time.Minute)
+
+	_, _, err = app.CreateInvite(ctx, 0)
+	ass.ErrorIs(err, ErrInvalidInviteTTL)
+}
+
+func TestProjectApplication_DisableProject(t *testing.T) {
+	ctx := context.Background()
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+	projectService := projectsvc.New(projectRepo, nil, nil)
+	invalidationRepo := new(invalidationmockrepo.MockInvalidationRepository)
+	app := New(projectService, projectRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, invalidationRepo, nil, nil, nil)
+
+	projectRepo.On("Disable", mock.Anything, "project_id").Return(nil)
+	projectRepo.On("Disable", mock.Anything, "missing").Return(domainErrors.ErrProjectNotFound)
+	invalidationRepo.On("Publish", mock.Anything, project.InvalidationChannel, "project_id").Return(nil).Once()
+
+	ass := assert.New(t)
+	ass.NoError(app.DisableProject(ctx, "project_id"))
+	ass.ErrorIs(app.DisableProject(ctx, "missing"), ErrProjectNotFound)
+	invalidationRepo.AssertExpectations(t)
+}
+
+func TestProjectApplication_SetProjectStatus(t *testing.T) {
+	ctx := context.Background()
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+	projectService := projectsvc.New(projectRepo, nil, nil)
+	invalidationRepo := new(invalidationmockrepo.MockInvalidationRepository)
+	app := New(projectService, projectRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, invalidationRepo, nil, nil, nil)
+
+	projectRepo.On("UpdateStatus", mock.Anything, "project_id", project.StatusSuspended).Return(nil)
+	projectRepo.On("UpdateStatus", mock.Anything, "missing", project.StatusReadOnly).Return(domainErrors.ErrProjectNotFound)
+	invalidationRepo.On("Publish", mock.Anything, project.InvalidationChannel, "project_id").Return(nil).Once()
+
+	ass := assert.New(t)
+	ass.NoError(app.SetProjectStatus(ctx, "project_id", project.StatusSuspended))
+	ass.ErrorIs(app.SetProjectStatus(ctx, "missing", project.StatusReadOnly), ErrProjectNotFound)
+	ass.ErrorIs(app.SetProjectStatus(ctx, "project_id", project.Status("PAUSED")), ErrInvalidProjectStatus)
+	invalidationRepo.AssertExpectations(t)
+	projectRepo.AssertNumberOfCalls(t, "UpdateStatus", 2)
+}
+
+func TestProjectApplication_ReadOnlyProject(t *testing.T) {
+	ctx := contexter.WithProjectID(context.Background(), "project_id")
+	ctx = contexter.WithProjectStatus(ctx, project.StatusReadOnly)
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+	archiveRepo := new(archivemockrepo.MockArchiveRepository)
+	app := New(nil, projectRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, archiveRepo, nil, nil)
+
+	ass := assert.New(t)
+	_, err := app.EraseUser(ctx, "external_user_id")
+	ass.ErrorIs(err, ErrProjectReadOnly)
+	ass.ErrorIs(app.EncryptProjectShares(ctx, "external_part"), ErrProjectReadOnly)
+	_, err = app.DeleteProject(ctx, "token", project.DeletionModeHardDelete)
+	ass.ErrorIs(err, ErrProjectReadOnly)
+
+	archiveRepo.AssertNotCalled(t, "EraseUser", mock.Anything, mock.Anything, mock.Anything)
+	archiveRepo.AssertNotCalled(t, "DeleteProject", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	projectRepo.AssertNotCalled(t, "HasSuccessfulMigration", mock.Anything, mock.Anything)
+}
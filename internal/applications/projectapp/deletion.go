@@ -0,0 +1,127 @@
+package projectapp
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	"github.com/openfort-xyz/shield/internal/core/domain/project"
+	"github.com/openfort-xyz/shield/pkg/contexter"
+	"github.com/openfort-xyz/shield/pkg/logger"
+)
+
+// DeletionConfirmationTTL is how long a deletion request can be confirmed.
+const DeletionConfirmationTTL = 15 * time.Minute
+
+// PendingDeletion is handed back by RequestDeletion. The token is only ever
+// returned here, it has to be sent back to DeleteProject to confirm.
+type PendingDeletion struct {
+	ConfirmationToken string
+	ExpiresAt         time.Time
+	ArchiveDigest     string
+	Archive           *project.Archive
+}
+
+// RequestDeletion exports the project's final archive and opens a deletion
+// request for it. A new request replaces any pending one.
+func (a *ProjectApplication) RequestDeletion(ctx context.Context) (*PendingDeletion, error) {
+	a.logger.InfoContext(ctx, "requesting project deletion")
+	projectID := contexter.GetProjectID(ctx)
+
+	archive, err := a.archiveRepo.ExportProject(ctx, projectID)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to export project", logger.Error(err))
+		return nil, fromDomainError(err)
+	}
+
+	digest, err := archive.Digest()
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to digest project archive", logger.Error(err))
+		return nil, ErrInternal
+	}
+
+	tokenBytes := make([]byte, 32)
+	_, err = rand.Read(tokenBytes)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to generate confirmation token", logger.Error(err))
+		return nil, ErrInternal
+	}
+	token := hex.EncodeToString(tokenBytes)
+
+	req := &project.DeletionRequest{
+		ProjectID:     projectID,
+		TokenHash:     hashConfirmationToken(token),
+		ArchiveDigest: digest,
+		ExpiresAt:     time.Now().Add(DeletionConfirmationTTL),
+	}
+	err = a.projectRepo.SaveDeletionRequest(ctx, req)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to save deletion request", logger.Error(err))
+		return nil, fromDomainError(err)
+	}
+
+	return &PendingDeletion{
+		ConfirmationToken: token,
+		ExpiresAt:         req.ExpiresAt,
+		ArchiveDigest:     digest,
+		Archive:           archive,
+	}, nil
+}
+
+// DeleteProject confirms the pending deletion request. It fails with
+// ErrProjectChangedSinceExport when anything was written to the project after
+// the archive was exported, the caller has to request the deletion again to
+// get an archive that covers it.
+func (a *ProjectApplication) DeleteProject(ctx context.Context, confirmationToken string, mode project.DeletionMode) (*project.DeletionReceipt, error) {
+	a.logger.InfoContext(ctx, "deleting project", slog.String("mode", string(mode)))
+	projectID := contexter.GetProjectID(ctx)
+
+	if !mode.Valid() {
+		return nil, ErrInvalidDeletionMode
+	}
+
+	if err := checkWritable(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := a.projectRepo.GetDeletionRequest(ctx, projectID)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to get deletion request", logger.Error(err))
+		return nil, fromDomainError(err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(req.TokenHash), []byte(hashConfirmationToken(confirmationToken))) != 1 {
+		return nil, ErrInvalidDeletionToken
+	}
+	if time.Now().After(req.ExpiresAt) {
+		return nil, ErrDeletionRequestExpired
+	}
+
+	// Without the part there's nothing to destroy, the project entropy shares
+	// would be left readable by whoever holds the other half
+	if mode == project.DeletionModeCryptoShred {
+		_, err = a.projectRepo.GetEncryptionPart(ctx, projectID)
+		if err != nil {
+			a.logger.ErrorContext(ctx, "failed to get encryption part", logger.Error(err))
+			return nil, fromDomainError(err)
+		}
+	}
+
+	receipt, err := a.archiveRepo.DeleteProject(ctx, projectID, mode, req.ArchiveDigest)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to delete project", logger.Error(err))
+		return nil, fromDomainError(err)
+	}
+	a.invalidateProject(ctx, projectID)
+
+	return receipt, nil
+}
+
+func hashConfirmationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
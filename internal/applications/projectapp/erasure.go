@@ -0,0 +1,43 @@
+package projectapp
+
+import (
+	"context"
+
+	"github.com/openfort-xyz/shield/internal/core/domain/user"
+	"github.com/openfort-xyz/shield/pkg/contexter"
+	"github.com/openfort-xyz/shield/pkg/logger"
+)
+
+// ExportUser returns everything stored about an external user of the
+// project, across every provider that links to it.
+func (a *ProjectApplication) ExportUser(ctx context.Context, externalUserID string) (*user.Export, error) {
+	a.logger.InfoContext(ctx, "exporting user")
+	projectID := contexter.GetProjectID(ctx)
+
+	export, err := a.archiveRepo.ExportUser(ctx, projectID, externalUserID)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to export user", logger.Error(err))
+		return nil, fromDomainError(err)
+	}
+
+	return export, nil
+}
+
+// EraseUser removes everything stored about an external user of the project.
+// The user's shares go with it and can't be recovered.
+func (a *ProjectApplication) EraseUser(ctx context.Context, externalUserID string) (*user.ErasureReceipt, error) {
+	a.logger.InfoContext(ctx, "erasing user")
+	projectID := contexter.GetProjectID(ctx)
+
+	if err := checkWritable(ctx); err != nil {
+		return nil, err
+	}
+
+	receipt, err := a.archiveRepo.EraseUser(ctx, projectID, externalUserID)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to erase user", logger.Error(err))
+		return nil, fromDomainError(err)
+	}
+
+	return receipt, nil
+}
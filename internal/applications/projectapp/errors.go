@@ -15,12 +15,18 @@ var (
 	ErrUnknownProviderType              = errors.New("unknown provider type")
 	ErrProviderAlreadyExists            = errors.New("custom authentication already registered for this project")
 	ErrProviderNotFound                 = errors.New("custom authentication not found")
+	ErrProviderKeyNotFound              = errors.New("provider key not found")
+	ErrProviderKeyAlreadyExists         = errors.New("a key with the same kid is already registered for this provider")
+	ErrInvalidKeyValidity               = errors.New("key not_before must be earlier than not_after")
 	ErrInvalidEncryptionPart            = errors.New("invalid encryption part")
 	ErrInvalidEncryptionSession         = errors.New("invalid encryption session")
 	ErrEncryptionPartAlreadyExists      = errors.New("encryption part already exists")
 	ErrEncryptionNotConfigured          = errors.New("encryption not configured")
 	ErrJWKPemConflict                   = errors.New("jwk and pem cannot be set at the same time")
 	ErrInvalidPemCertificate            = errors.New("invalid PEM certificate")
+	ErrHMACSecretConflict               = errors.New("hmac secret cannot be set together with jwk or pem")
+	ErrInvalidHMACSecret                = errors.New("invalid HMAC secret")
+	ErrSecretEncryptionNotConfigured    = errors.New("provider secret encryption not configured")
 	ErrOTPRequired                      = errors.New("OTP is required for this request")
 	ErrOTPRateLimitExceeded             = errors.New("rate limit exceeded")
 	ErrOTPFailedToGenerate              = errors.New("failed to generate OTP")
@@ -38,6 +44,25 @@ var (
 	ErrProject2FAAlreadyEnabled         = errors.New("project already has 2FA enabled")
 	ErrUserContactInformationMismatch   = errors.New("user contact information mismatch")
 	ErrNoUserContactInformationProvided = errors.New("no user contact information provided")
+	ErrInvalidCertificateFingerprint    = errors.New("fingerprint must be a hex encoded SHA-256 digest")
+	ErrInvalidCertificateType           = errors.New("certificate type must be ca or leaf")
+	ErrClientCertificateAlreadyExists   = errors.New("client certificate already registered")
+	ErrClientCertificateNotFound        = errors.New("client certificate not found")
+	ErrInvalidClientCertMode            = errors.New("invalid client certificate mode")
+	ErrNoClientCertificates             = errors.New("a client certificate must stay registered while certificates are required")
+	ErrSigningKeyNotFound               = errors.New("no signing key has been generated for this project")
+	ErrSigningNotConfigured             = errors.New("project secret encryption not configured")
+	ErrDeletionNotRequested             = errors.New("project deletion was not requested")
+	ErrInvalidDeletionToken             = errors.New("invalid deletion confirmation token")
+	ErrDeletionRequestExpired           = errors.New("deletion request expired")
+	ErrInvalidDeletionMode              = errors.New("invalid deletion mode")
+	ErrProjectChangedSinceExport        = errors.New("project changed since the deletion archive was exported")
+	ErrExternalUserNotFound             = errors.New("external user not found")
+	ErrInvalidPageSize                  = errors.New("invalid page size")
+	ErrInvalidProjectStatus             = errors.New("invalid project status")
+	ErrProjectReadOnly                  = errors.New("project is read-only")
+	ErrInvalidInvite                    = errors.New("registration invite is invalid, used or expired")
+	ErrInvalidInviteTTL                 = errors.New("invite expiration must be positive")
 	ErrInternal                         = errors.New("internal error")
 )
 
@@ -65,6 +90,42 @@ func fromDomainError(err error) error {
 		return ErrProviderNotFound
 	}
 
+	if errors.Is(err, domainErrors.ErrProviderKeyNotFound) {
+		return ErrProviderKeyNotFound
+	}
+
+	if errors.Is(err, domainErrors.ErrClientCertificateNotFound) {
+		return ErrClientCertificateNotFound
+	}
+
+	if errors.Is(err, domainErrors.ErrSecretEncryptionNotSet) {
+		return ErrSecretEncryptionNotConfigured
+	}
+
+	if errors.Is(err, domainErrors.ErrSigningKeyNotFound) {
+		return ErrSigningKeyNotFound
+	}
+
+	if errors.Is(err, domainErrors.ErrProjectSecretEncryptionNotSet) {
+		return ErrSigningNotConfigured
+	}
+
+	if errors.Is(err, domainErrors.ErrExternalUserNotFound) {
+		return ErrExternalUserNotFound
+	}
+
+	if errors.Is(err, domainErrors.ErrInviteNotFound) {
+		return ErrInvalidInvite
+	}
+
+	if errors.Is(err, domainErrors.ErrDeletionRequestNotFound) {
+		return ErrDeletionNotRequested
+	}
+
+	if errors.Is(err, domainErrors.ErrProjectChangedSinceExport) {
+		return ErrProjectChangedSinceExport
+	}
+
 	if errors.Is(err, domainErrors.ErrEncryptionPartNotFound) {
 		return ErrEncryptionNotConfigured
 	}
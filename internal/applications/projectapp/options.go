@@ -17,6 +17,13 @@ func WithCustomPEM(pem string, keyType provider.KeyType) ProviderOption {
 	}
 }
 
+func WithCustomHMACSecret(secret string) ProviderOption {
+	return func(c *providerConfig) {
+		c.hmacSecret = &secret
+		c.keyType = provider.KeyTypeHMAC
+	}
+}
+
 func WithCustomCookieFieldName(cookieFieldName string) ProviderOption {
 	return func(c *providerConfig) {
 		c.cookieFieldName = &cookieFieldName
@@ -29,18 +36,34 @@ func WithOpenfort(openfortProjectID string) ProviderOption {
 	}
 }
 
+// WithIntrospection configures an RFC 7662 introspection provider. On update,
+// empty values leave the stored configuration untouched.
+func WithIntrospection(endpoint, clientID, clientSecret, userIDClaim string) ProviderOption {
+	return func(c *providerConfig) {
+		c.introspection = &provider.IntrospectionConfig{
+			Endpoint:     endpoint,
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			UserIDClaim:  userIDClaim,
+		}
+	}
+}
+
 type providerConfig struct {
 	jwkURL                 *string
 	pem                    *string
+	hmacSecret             *string
 	cookieFieldName        *string
 	keyType                provider.KeyType
 	openfortPublishableKey *string
+	introspection          *provider.IntrospectionConfig
 }
 
 type ProjectOption func(options *projectOptions)
 
 type projectOptions struct {
 	generateEncryptionKey bool
+	inviteToken           *string
 }
 
 func WithEncryptionKey() ProjectOption {
@@ -48,3 +71,10 @@ func WithEncryptionKey() ProjectOption {
 		o.generateEncryptionKey = true
 	}
 }
+
+// WithInviteToken makes the creation consume the given registration invite.
+func WithInviteToken(token string) ProjectOption {
+	return func(o *projectOptions) {
+		o.inviteToken = &token
+	}
+}
@@ -0,0 +1,74 @@
+package projectapp
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	"github.com/openfort-xyz/shield/internal/core/domain/project"
+	"github.com/openfort-xyz/shield/pkg/logger"
+)
+
+// DefaultInviteTTL is how long a registration invite stays usable when no
+// expiration is requested.
+const DefaultInviteTTL = 7 * 24 * time.Hour
+
+// CreateInvite issues a single-use registration invite. The token is only
+// ever returned here, the repository keeps its hash.
+func (a *ProjectApplication) CreateInvite(ctx context.Context, ttl time.Duration) (string, *project.Invite, error) {
+	a.logger.InfoContext(ctx, "creating registration invite")
+	if ttl <= 0 {
+		return "", nil, ErrInvalidInviteTTL
+	}
+
+	tokenBytes := make([]byte, 32)
+	_, err := rand.Read(tokenBytes)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to generate invite token", logger.Error(err))
+		return "", nil, ErrInternal
+	}
+	token := hex.EncodeToString(tokenBytes)
+
+	invite := &project.Invite{
+		TokenHash: hashConfirmationToken(token),
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	err = a.projectRepo.CreateInvite(ctx, invite)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to create registration invite", logger.Error(err))
+		return "", nil, fromDomainError(err)
+	}
+
+	return token, invite, nil
+}
+
+// ListProjects returns every project of the deployment, disabled ones
+// included.
+func (a *ProjectApplication) ListProjects(ctx context.Context) ([]*project.Project, error) {
+	a.logger.InfoContext(ctx, "listing projects")
+
+	projs, err := a.projectRepo.List(ctx)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to list projects", logger.Error(err))
+		return nil, fromDomainError(err)
+	}
+
+	return projs, nil
+}
+
+// DisableProject stops the project's API key from authenticating. Its data is
+// kept so that it can still be exported or deleted by an operator.
+func (a *ProjectApplication) DisableProject(ctx context.Context, projectID string) error {
+	a.logger.InfoContext(ctx, "disabling project", slog.String("project_id", projectID))
+
+	err := a.projectRepo.Disable(ctx, projectID)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to disable project", logger.Error(err))
+		return fromDomainError(err)
+	}
+
+	a.invalidateProject(ctx, projectID)
+	return nil
+}
@@ -0,0 +1,38 @@
+package projectapp
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/openfort-xyz/shield/internal/core/domain/project"
+	"github.com/openfort-xyz/shield/pkg/contexter"
+	"github.com/openfort-xyz/shield/pkg/logger"
+)
+
+// SetProjectStatus restricts what the project's credentials can do. Every
+// replica is told to drop the credentials it cached, so that the new status
+// is applied to the next request.
+func (a *ProjectApplication) SetProjectStatus(ctx context.Context, projectID string, status project.Status) error {
+	a.logger.InfoContext(ctx, "setting project status", slog.String("project_id", projectID), slog.String("status", string(status)))
+	if !status.Valid() {
+		return ErrInvalidProjectStatus
+	}
+
+	err := a.projectRepo.UpdateStatus(ctx, projectID, status)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to update project status", logger.Error(err))
+		return fromDomainError(err)
+	}
+
+	a.invalidateProject(ctx, projectID)
+	return nil
+}
+
+// checkWritable refuses changes to the shares of a read-only project.
+func checkWritable(ctx context.Context) error {
+	if contexter.GetProjectStatus(ctx) == project.StatusReadOnly {
+		return ErrProjectReadOnly
+	}
+
+	return nil
+}
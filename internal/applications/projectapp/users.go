@@ -0,0 +1,110 @@
+package projectapp
+
+import (
+	"context"
+	"errors"
+
+	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
+	"github.com/openfort-xyz/shield/internal/core/domain/share"
+	"github.com/openfort-xyz/shield/internal/core/domain/user"
+	"github.com/openfort-xyz/shield/pkg/contexter"
+	"github.com/openfort-xyz/shield/pkg/logger"
+)
+
+const (
+	DefaultUsersPageSize = 50
+	MaxUsersPageSize     = 100
+)
+
+// UserSummary describes a user of the project without any share secret.
+type UserSummary struct {
+	User          *user.User
+	ExternalUsers []*user.ExternalUser
+	KeychainID    *string
+	Shares        []*share.Share
+}
+
+type UsersPage struct {
+	Users []*UserSummary
+	// Next is nil on the last page
+	Next *user.Cursor
+}
+
+// ListUsers returns a page of the project's users, oldest first. A limit of
+// zero uses DefaultUsersPageSize.
+func (a *ProjectApplication) ListUsers(ctx context.Context, filter *user.ListFilter, after *user.Cursor, limit int) (*UsersPage, error) {
+	a.logger.InfoContext(ctx, "listing users")
+	projectID := contexter.GetProjectID(ctx)
+
+	if limit == 0 {
+		limit = DefaultUsersPageSize
+	}
+	if limit < 0 || limit > MaxUsersPageSize {
+		return nil, ErrInvalidPageSize
+	}
+
+	// One extra user tells whether there's a next page
+	usrs, err := a.userRepo.ListByProject(ctx, projectID, filter, after, limit+1)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to list users", logger.Error(err))
+		return nil, fromDomainError(err)
+	}
+
+	page := &UsersPage{Users: make([]*UserSummary, 0, len(usrs))}
+	if len(usrs) > limit {
+		usrs = usrs[:limit]
+		last := usrs[limit-1]
+		page.Next = &user.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	for _, usr := range usrs {
+		summary, err := a.summarizeUser(ctx, usr)
+		if err != nil {
+			return nil, fromDomainError(err)
+		}
+		page.Users = append(page.Users, summary)
+	}
+
+	return page, nil
+}
+
+func (a *ProjectApplication) summarizeUser(ctx context.Context, usr *user.User) (*UserSummary, error) {
+	summary := &UserSummary{User: usr}
+
+	extUsrs, err := a.userRepo.FindExternalBy(ctx, a.userRepo.WithUserID(usr.ID))
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to find external users", logger.Error(err))
+		return nil, err
+	}
+	summary.ExternalUsers = extUsrs
+
+	// Users registered before keychains existed keep their single share
+	// outside of one
+	shr, err := a.sharesRepo.GetByUserID(ctx, usr.ID)
+	if err != nil && !errors.Is(err, domainErrors.ErrShareNotFound) {
+		a.logger.ErrorContext(ctx, "failed to get share", logger.Error(err))
+		return nil, err
+	}
+	if shr != nil {
+		summary.Shares = append(summary.Shares, shr)
+	}
+
+	kc, err := a.keychainRepo.GetByUserID(ctx, usr.ID)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrKeychainNotFound) {
+			return summary, nil
+		}
+		a.logger.ErrorContext(ctx, "failed to get keychain", logger.Error(err))
+		return nil, err
+	}
+	summary.KeychainID = &kc.ID
+
+	shrs, err := a.sharesRepo.ListByKeychainID(ctx, kc.ID)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to list keychain shares", logger.Error(err))
+		return nil, err
+	}
+	summary.Shares = append(summary.Shares, shrs...)
+
+	return summary, nil
+}
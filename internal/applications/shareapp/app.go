@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"slices"
 
 	"github.com/google/uuid"
 	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
@@ -17,6 +18,7 @@ import (
 	"github.com/openfort-xyz/shield/internal/core/domain/share"
 	"github.com/openfort-xyz/shield/internal/core/ports/repositories"
 	"github.com/openfort-xyz/shield/internal/core/ports/services"
+	"github.com/openfort-xyz/shield/internal/core/ports/strategies"
 	"github.com/openfort-xyz/shield/pkg/contexter"
 	"github.com/openfort-xyz/shield/pkg/logger"
 )
@@ -47,6 +49,9 @@ func New(shareSvc services.ShareService, shareRepo repositories.ShareRepository,
 
 func (a *ShareApplication) RegisterShare(ctx context.Context, shr *share.Share, opts ...Option) error {
 	a.logger.InfoContext(ctx, "registering share")
+	if err := checkWritable(ctx); err != nil {
+		return err
+	}
 	usrID := contexter.GetUserID(ctx)
 	projID := contexter.GetProjectID(ctx)
 	shr.UserID = usrID
@@ -87,15 +92,27 @@ func (a *ShareApplication) RegisterShare(ctx context.Context, shr *share.Share,
 
 func (a *ShareApplication) UpdateShare(ctx context.Context, shr *share.Share, reference string, opts ...Option) (*share.Share, error) {
 	a.logger.InfoContext(ctx, "updating share")
+	if err := checkWritable(ctx); err != nil {
+		return nil, err
+	}
 	usrID := contexter.GetUserID(ctx)
 	projID := contexter.GetProjectID(ctx)
 
+	var opt options
+	for _, o := range opts {
+		o(&opt)
+	}
+
 	dbShare, err := a.shareSvc.Find(ctx, usrID, nil, &reference)
 	if err != nil {
 		a.logger.ErrorContext(ctx, "failed to get share by user ID", logger.Error(err))
 		return nil, fromDomainError(err)
 	}
 
+	if opt.ifMatch != nil && !slices.Contains(opt.ifMatch, dbShare.Version) {
+		return nil, ErrShareVersionMismatch
+	}
+
 	if shr.Entropy != 0 {
 		dbShare.Entropy = shr.Entropy
 	}
@@ -120,9 +137,8 @@ func (a *ShareApplication) UpdateShare(ctx context.Context, shr *share.Share, re
 		dbShare.Secret = shr.Secret
 	}
 
-	var opt options
-	for _, o := range opts {
-		o(&opt)
+	if shr.Metadata != nil {
+		dbShare.Metadata = shr.Metadata
 	}
 
 	if dbShare.RequiresEncryption() {
@@ -141,10 +157,13 @@ func (a *ShareApplication) UpdateShare(ctx context.Context, shr *share.Share, re
 
 	err = a.shareRepo.Update(ctx, dbShare)
 	if err != nil {
-		a.logger.ErrorContext(ctx, "failed to create share", logger.Error(err))
+		if !errors.Is(err, domainErrors.ErrShareVersionMismatch) {
+			a.logger.ErrorContext(ctx, "failed to create share", logger.Error(err))
+		}
 		return nil, fromDomainError(err)
 	}
 
+	shr.Version = dbShare.Version
 	return shr, nil
 }
 
@@ -195,6 +214,15 @@ func (a *ShareApplication) GetSharesEncryptionForUsers(ctx context.Context, user
 	return returnValue, nil
 }
 
+// checkWritable refuses changes to the shares of a read-only project.
+func checkWritable(ctx context.Context) error {
+	if contexter.GetProjectStatus(ctx) == project.StatusReadOnly {
+		return ErrProjectReadOnly
+	}
+
+	return nil
+}
+
 func (a *ShareApplication) getProject(ctx context.Context, projID string) (*project.Project, error) {
 	if proj := contexter.GetProject(ctx); proj != nil {
 		return proj, nil
@@ -275,28 +303,8 @@ func (a *ShareApplication) GetKeychainShares(ctx context.Context, reference *str
 			return nil, ErrShareNotFound
 		}
 
-		if shr.RequiresEncryption() {
-			projID := contexter.GetProjectID(ctx)
-			project, err := a.getProject(ctx, projID)
-			if err != nil {
-				return nil, fromDomainError(err)
-			}
-
-			if project.Enable2FA {
-				opt.requireOTPCheck = true
-			}
-
-			encryptionKey, err := a.reconstructEncryptionKey(ctx, projID, opt)
-			if err != nil {
-				return nil, err
-			}
-
-			cypher := a.encryptionFactory.CreateEncryptionStrategy(encryptionKey)
-			shr.Secret, err = cypher.Decrypt(shr.Secret)
-			if err != nil {
-				a.logger.ErrorContext(ctx, "failed to decrypt secret", logger.Error(err))
-				return nil, ErrInternal
-			}
+		if err := a.revealSecrets(ctx, []*share.Share{shr}, opt); err != nil {
+			return nil, err
 		}
 
 		return []*share.Share{shr}, nil
@@ -308,42 +316,67 @@ func (a *ShareApplication) GetKeychainShares(ctx context.Context, reference *str
 		return nil, fromDomainError(err)
 	}
 
+	if len(opt.metadataFilter) != 0 {
+		shrs = slices.DeleteFunc(shrs, func(shr *share.Share) bool {
+			return !shr.Metadata.Matches(opt.metadataFilter)
+		})
+	}
+
 	if len(shrs) == 0 {
 		return nil, nil
 	}
 
-	var encryptionKey *string
-
-	projID := contexter.GetProjectID(ctx)
-	project, err := a.getProject(ctx, projID)
-	if err != nil {
-		return nil, fromDomainError(err)
+	if err := a.revealSecrets(ctx, shrs, opt); err != nil {
+		return nil, err
 	}
 
-	if project.Enable2FA {
-		opt.requireOTPCheck = true
+	return shrs, nil
+}
+
+// revealSecrets decrypts the project entropy secrets of the shares, or
+// clears every secret when the caller asked for none.
+func (a *ShareApplication) revealSecrets(ctx context.Context, shrs []*share.Share, opt options) error {
+	if opt.withoutSecrets {
+		for _, shr := range shrs {
+			shr.Secret = ""
+		}
+		return nil
 	}
 
+	var cypher strategies.EncryptionStrategy
 	for _, shr := range shrs {
-		if shr.RequiresEncryption() {
-			// Reconstruct encryption key just once
-			if encryptionKey == nil {
-				retrievedKey, err := a.reconstructEncryptionKey(ctx, projID, opt)
-				encryptionKey = &retrievedKey
-				if err != nil {
-					return nil, err
-				}
+		if !shr.RequiresEncryption() {
+			continue
+		}
+
+		// Reconstruct encryption key just once
+		if cypher == nil {
+			projID := contexter.GetProjectID(ctx)
+			project, err := a.getProject(ctx, projID)
+			if err != nil {
+				return fromDomainError(err)
+			}
+
+			if project.Enable2FA {
+				opt.requireOTPCheck = true
 			}
-			cypher := a.encryptionFactory.CreateEncryptionStrategy(*encryptionKey)
-			shr.Secret, err = cypher.Decrypt(shr.Secret)
+
+			encryptionKey, err := a.reconstructEncryptionKey(ctx, projID, opt)
 			if err != nil {
-				a.logger.ErrorContext(ctx, "failed to decrypt secret", logger.Error(err))
-				return nil, ErrInternal
+				return err
 			}
+			cypher = a.encryptionFactory.CreateEncryptionStrategy(encryptionKey)
+		}
+
+		var err error
+		shr.Secret, err = cypher.Decrypt(shr.Secret)
+		if err != nil {
+			a.logger.ErrorContext(ctx, "failed to decrypt secret", logger.Error(err))
+			return ErrInternal
 		}
 	}
 
-	return shrs, nil
+	return nil
 }
 
 func (a *ShareApplication) GetShareByReference(ctx context.Context, reference string, opts ...Option) (*share.Share, error) {
@@ -456,6 +489,9 @@ func (a *ShareApplication) GetShare(ctx context.Context, opts ...Option) (*share
 
 func (a *ShareApplication) DeleteShare(ctx context.Context, reference *string) error {
 	a.logger.InfoContext(ctx, "deleting share")
+	if err := checkWritable(ctx); err != nil {
+		return err
+	}
 	usrID := contexter.GetUserID(ctx)
 
 	shr, err := a.shareSvc.Find(ctx, usrID, nil, reference)
@@ -553,6 +589,9 @@ func (a *ShareApplication) ExportShare(ctx context.Context, reference string) (*
 
 func (a *ShareApplication) ImportShare(ctx context.Context, shr *share.Share) error {
 	a.logger.InfoContext(ctx, "importing share")
+	if err := checkWritable(ctx); err != nil {
+		return err
+	}
 	projID := contexter.GetProjectID(ctx)
 
 	usr, err := a.userRepo.Get(ctx, shr.UserID)
@@ -18,6 +18,7 @@ import (
 	"github.com/openfort-xyz/shield/internal/core/domain/keychain"
 	"github.com/openfort-xyz/shield/internal/core/domain/project"
 	"github.com/openfort-xyz/shield/internal/core/domain/share"
+	"github.com/openfort-xyz/shield/internal/core/domain/user"
 	"github.com/openfort-xyz/shield/internal/core/services/sharesvc"
 	"github.com/openfort-xyz/shield/pkg/contexter"
 	"github.com/openfort-xyz/shield/pkg/random"
@@ -1092,6 +1093,7 @@ func TestShareApplication_UpdateShare(t *testing.T) {
 		wantErr error
 		mock    func()
 		updates *share.Share
+		opts    []Option
 	}{
 		{
 			name:    "success",
@@ -1103,6 +1105,37 @@ func TestShareApplication_UpdateShare(t *testing.T) {
 				shareRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
 			},
 		},
+		{
+			name:    "if-match the current version",
+			wantErr: nil,
+			updates: updates,
+			opts:    []Option{WithIfMatch([]int64{1, 2})},
+			mock: func() {
+				shareRepo.ExpectedCalls = nil
+				shareRepo.On("GetByUserID", mock.Anything, "user_id").Return(&share.Share{ID: "share-id", Version: 2}, nil)
+				shareRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:    "if-match an older version",
+			wantErr: ErrShareVersionMismatch,
+			updates: updates,
+			opts:    []Option{WithIfMatch([]int64{1})},
+			mock: func() {
+				shareRepo.ExpectedCalls = nil
+				shareRepo.On("GetByUserID", mock.Anything, "user_id").Return(&share.Share{ID: "share-id", Version: 2}, nil)
+			},
+		},
+		{
+			name:    "changed while updating",
+			wantErr: ErrShareVersionMismatch,
+			updates: updates,
+			mock: func() {
+				shareRepo.ExpectedCalls = nil
+				shareRepo.On("GetByUserID", mock.Anything, "user_id").Return(&share.Share{ID: "share-id", Version: 2}, nil)
+				shareRepo.On("Update", mock.Anything, mock.Anything).Return(domainErrors.ErrShareVersionMismatch)
+			},
+		},
 		{
 			name:    "share not found",
 			wantErr: ErrShareNotFound,
@@ -1138,8 +1171,476 @@ func TestShareApplication_UpdateShare(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mock()
 			ass := assert.New(t)
-			_, err := app.UpdateShare(ctx, tt.updates, "default")
+			_, err := app.UpdateShare(ctx, tt.updates, "default", tt.opts...)
 			ass.ErrorIs(tt.wantErr, err)
 		})
 	}
 }
+
+func TestShareApplication_MigrateShares(t *testing.T) {
+	ctx := contexter.WithProjectID(context.Background(), "project_id")
+	userRepo := new(usermockedrepo.MockUserRepository)
+	shareRepo := new(sharemockrepo.MockShareRepository)
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+	encryptionPartsRepo := new(encryptionpartsmockrepo.MockEncryptionPartsRepository)
+	encryptionFactory := encryption.NewEncryptionFactory(encryptionPartsRepo, projectRepo)
+	keychainRepo := new(keychainmockrepo.MockKeychainRepository)
+	shareSvc := sharesvc.New(shareRepo, keychainRepo, encryptionFactory)
+	app := New(shareSvc, shareRepo, projectRepo, userRepo, keychainRepo, encryptionFactory, &shamirjob.Job{})
+
+	key, err := random.GenerateRandomString(32)
+	if err != nil {
+		t.Fatalf("failed to generate encryption key: %v", err)
+	}
+	storedPart, projectPart, err := encryptionFactory.CreateReconstructionStrategy(true).Split(key)
+	if err != nil {
+		t.Fatalf("failed to split encryption key: %v", err)
+	}
+	transferKey, err := random.GenerateRandomString(TransferKeySize)
+	if err != nil {
+		t.Fatalf("failed to generate transfer key: %v", err)
+	}
+
+	encryptedSecret, err := encryptionFactory.CreateEncryptionStrategy(key).Encrypt("secret")
+	if err != nil {
+		t.Fatalf("failed to cypher secret: %v", err)
+	}
+
+	newShares := func() []*share.Share {
+		return []*share.Share{
+			{ID: "share-1", UserID: "user_id", Secret: "plain", Entropy: share.EntropyNone},
+			{ID: "share-2", UserID: "user_id", Secret: encryptedSecret, Entropy: share.EntropyProject},
+		}
+	}
+
+	reset := func() {
+		shareRepo.ExpectedCalls = nil
+		shareRepo.Calls = nil
+		projectRepo.ExpectedCalls = nil
+		userRepo.ExpectedCalls = nil
+		projectRepo.On("GetEncryptionPart", mock.Anything, "project_id").Return(storedPart, nil)
+		projectRepo.On("HasSuccessfulMigration", mock.Anything, "project_id").Return(true, nil)
+	}
+
+	export := func(opts ...Option) []*MigratedShare {
+		var exported []*MigratedShare
+		err := app.ExportShares(ctx, "", func(m *MigratedShare) error {
+			exported = append(exported, m)
+			return nil
+		}, opts...)
+		assert.NoError(t, err)
+		return exported
+	}
+
+	t.Run("project entropy shares require a transfer key", func(t *testing.T) {
+		reset()
+		shareRepo.On("ListByProject", mock.Anything, "project_id", "", MigrationPageSize).Return(newShares(), nil)
+
+		exported := export()
+		assert.Len(t, exported, 2)
+		assert.NoError(t, exported[0].Err)
+		assert.Equal(t, "plain", exported[0].Share.Secret)
+		assert.ErrorIs(t, exported[1].Err, ErrTransferKeyRequired)
+		assert.Empty(t, exported[1].Share.Secret)
+	})
+
+	t.Run("invalid transfer key", func(t *testing.T) {
+		reset()
+		err := app.ExportShares(ctx, "", func(*MigratedShare) error { return nil }, WithTransferKey("short"), WithEncryptionPart(projectPart))
+		assert.ErrorIs(t, err, ErrInvalidTransferKey)
+		_, err = app.NewShareImporter(ctx, WithTransferKey("short"), WithEncryptionPart(projectPart))
+		assert.ErrorIs(t, err, ErrInvalidTransferKey)
+	})
+
+	t.Run("round trip through the transfer key", func(t *testing.T) {
+		reset()
+		shareRepo.On("ListByProject", mock.Anything, "project_id", "", MigrationPageSize).Return(newShares(), nil)
+
+		exported := export(WithTransferKey(transferKey), WithEncryptionPart(projectPart))
+		assert.Len(t, exported, 2)
+		assert.True(t, exported[1].TransferEncrypted)
+		secret, err := encryptionFactory.CreateEncryptionStrategy(transferKey).Decrypt(exported[1].Share.Secret)
+		assert.NoError(t, err)
+		assert.Equal(t, "secret", secret)
+
+		importer, err := app.NewShareImporter(ctx, WithTransferKey(transferKey), WithEncryptionPart(projectPart))
+		assert.NoError(t, err)
+
+		shareRepo.On("Get", mock.Anything, "share-1").Return(&share.Share{ID: "share-1", UserID: "user_id"}, nil)
+		shareRepo.On("Get", mock.Anything, "share-2").Return(nil, domainErrors.ErrShareNotFound)
+		userRepo.On("Get", mock.Anything, "user_id").Return(&user.User{ID: "user_id", ProjectID: "project_id"}, nil)
+		shareRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		status, err := importer.Import(ctx, exported[0])
+		assert.NoError(t, err)
+		assert.Equal(t, ImportStatusSkipped, status)
+
+		status, err = importer.Import(ctx, exported[1])
+		assert.NoError(t, err)
+		assert.Equal(t, ImportStatusImported, status)
+		secret, err = encryptionFactory.CreateEncryptionStrategy(key).Decrypt(exported[1].Share.Secret)
+		assert.NoError(t, err)
+		assert.Equal(t, "secret", secret)
+	})
+
+	t.Run("import into another project's user", func(t *testing.T) {
+		reset()
+		importer, err := app.NewShareImporter(ctx)
+		assert.NoError(t, err)
+
+		shareRepo.On("Get", mock.Anything, "share-1").Return(nil, domainErrors.ErrShareNotFound)
+		userRepo.On("Get", mock.Anything, "user_id").Return(&user.User{ID: "user_id", ProjectID: "other_project"}, nil)
+
+		_, err = importer.Import(ctx, &MigratedShare{Share: newShares()[0]})
+		assert.ErrorIs(t, err, ErrUserNotFound)
+	})
+}
+
+func TestShareApplication_GetKeychainMetadata(t *testing.T) {
+	ctx := contexter.WithProjectID(context.Background(), "project_id")
+	ctx = contexter.WithUserID(ctx, "user_id")
+	userRepo := new(usermockedrepo.MockUserRepository)
+	shareRepo := new(sharemockrepo.MockShareRepository)
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+	encryptionPartsRepo := new(encryptionpartsmockrepo.MockEncryptionPartsRepository)
+	encryptionFactory := encryption.NewEncryptionFactory(encryptionPartsRepo, projectRepo)
+	keychainRepo := new(keychainmockrepo.MockKeychainRepository)
+	shareSvc := sharesvc.New(shareRepo, keychainRepo, encryptionFactory)
+	app := New(shareSvc, shareRepo, projectRepo, userRepo, keychainRepo, encryptionFactory, &shamirjob.Job{})
+
+	testKeychain := &keychain.Keychain{
+		ID:     "keychain_id",
+		UserID: "user_id",
+	}
+	reference := "reference"
+
+	keychainRepo.On("GetByUserID", mock.Anything, "user_id").Return(testKeychain, nil)
+	keychainRepo.On("Get", mock.Anything, "keychain_id").Return(testKeychain, nil)
+	shareRepo.On("GetByUserID", mock.Anything, "user_id").Return(nil, domainErrors.ErrShareNotFound)
+	shareRepo.On("ListByKeychainID", mock.Anything, "keychain_id").Return([]*share.Share{{ID: "share_id", Secret: "secret", Reference: &reference, Entropy: share.EntropyProject}}, nil)
+
+	ass := assert.New(t)
+	kc, shrs, err := app.GetKeychainMetadata(ctx)
+	ass.NoError(err)
+	ass.Equal(testKeychain, kc)
+	ass.Len(shrs, 1)
+	ass.Equal(reference, *shrs[0].Reference)
+	ass.Empty(shrs[0].Secret)
+}
+
+func TestShareApplication_GetKeychainSharesByMetadata(t *testing.T) {
+	ctx := contexter.WithProjectID(context.Background(), "project_id")
+	ctx = contexter.WithUserID(ctx, "user_id")
+	userRepo := new(usermockedrepo.MockUserRepository)
+	shareRepo := new(sharemockrepo.MockShareRepository)
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+	encryptionPartsRepo := new(encryptionpartsmockrepo.MockEncryptionPartsRepository)
+	encryptionFactory := encryption.NewEncryptionFactory(encryptionPartsRepo, projectRepo)
+	keychainRepo := new(keychainmockrepo.MockKeychainRepository)
+	shareSvc := sharesvc.New(shareRepo, keychainRepo, encryptionFactory)
+	app := New(shareSvc, shareRepo, projectRepo, userRepo, keychainRepo, encryptionFactory, &shamirjob.Job{})
+
+	ethereum := &share.Share{ID: "ethereum", Entropy: share.EntropyNone, Metadata: share.Metadata{"chain": "ethereum", "device": "ios"}}
+	polygon := &share.Share{ID: "polygon", Entropy: share.EntropyNone, Metadata: share.Metadata{"chain": "polygon", "device": "ios"}}
+	unlabeled := &share.Share{ID: "unlabeled", Entropy: share.EntropyNone}
+
+	keychainRepo.On("GetByUserID", mock.Anything, "user_id").Return(&keychain.Keychain{ID: "keychain_id", UserID: "user_id"}, nil)
+	shareRepo.On("GetByUserID", mock.Anything, "user_id").Return(nil, domainErrors.ErrShareNotFound)
+	projectRepo.On("Get", mock.Anything, "project_id").Return(&project.Project{ID: "project_id"}, nil)
+
+	tc := []struct {
+		name   string
+		filter share.Metadata
+		want   []*share.Share
+	}{
+		{
+			name: "no filter",
+			want: []*share.Share{ethereum, polygon, unlabeled},
+		},
+		{
+			name:   "one entry",
+			filter: share.Metadata{"device": "ios"},
+			want:   []*share.Share{ethereum, polygon},
+		},
+		{
+			name:   "every entry",
+			filter: share.Metadata{"device": "ios", "chain": "polygon"},
+			want:   []*share.Share{polygon},
+		},
+		{
+			name:   "no match",
+			filter: share.Metadata{"chain": "solana"},
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			shareRepo.On("ListByKeychainID", mock.Anything, "keychain_id").Return([]*share.Share{ethereum, polygon, unlabeled}, nil).Once()
+			ass := assert.New(t)
+			shrs, err := app.GetKeychainShares(ctx, nil, WithMetadataFilter(tt.filter))
+			ass.NoError(err)
+			ass.Equal(tt.want, shrs)
+		})
+	}
+}
+
+func TestShareApplication_ListKeychainShares(t *testing.T) {
+	ctx := contexter.WithProjectID(context.Background(), "project_id")
+	ctx = contexter.WithUserID(ctx, "user_id")
+	userRepo := new(usermockedrepo.MockUserRepository)
+	shareRepo := new(sharemockrepo.MockShareRepository)
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+	encryptionPartsRepo := new(encryptionpartsmockrepo.MockEncryptionPartsRepository)
+	encryptionFactory := encryption.NewEncryptionFactory(encryptionPartsRepo, projectRepo)
+	keychainRepo := new(keychainmockrepo.MockKeychainRepository)
+	shareSvc := sharesvc.New(shareRepo, keychainRepo, encryptionFactory)
+	app := New(shareSvc, shareRepo, projectRepo, userRepo, keychainRepo, encryptionFactory, &shamirjob.Job{})
+
+	keychainRepo.On("GetByUserID", mock.Anything, "user_id").Return(&keychain.Keychain{ID: "keychain_id", UserID: "user_id"}, nil)
+	shareRepo.On("GetByUserID", mock.Anything, "user_id").Return(nil, domainErrors.ErrShareNotFound)
+
+	filter := &share.ListFilter{ReferencePrefix: "wallet-", Entropy: share.EntropyProject}
+	newShares := func() []*share.Share {
+		return []*share.Share{
+			{ID: "share-1", Secret: "encrypted-1", Entropy: share.EntropyProject},
+			{ID: "share-2", Secret: "encrypted-2", Entropy: share.EntropyProject},
+			{ID: "share-3", Secret: "encrypted-3", Entropy: share.EntropyProject},
+		}
+	}
+
+	tc := []struct {
+		name      string
+		after     string
+		limit     int
+		repoLimit int
+		repoShrs  []*share.Share
+		wantIDs   []string
+		wantNext  string
+		wantErr   error
+	}{
+		{
+			name:      "first page",
+			limit:     2,
+			repoLimit: 3,
+			repoShrs:  newShares(),
+			wantIDs:   []string{"share-1", "share-2"},
+			wantNext:  "share-2",
+		},
+		{
+			name:      "last page",
+			after:     "share-2",
+			limit:     2,
+			repoLimit: 3,
+			repoShrs:  newShares()[2:],
+			wantIDs:   []string{"share-3"},
+		},
+		{
+			name:     "every share",
+			repoShrs: newShares(),
+			wantIDs:  []string{"share-1", "share-2", "share-3"},
+		},
+		{
+			name:    "page too large",
+			limit:   MaxKeychainPageSize + 1,
+			wantErr: ErrInvalidPageSize,
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			ass := assert.New(t)
+			if tt.wantErr == nil {
+				shareRepo.On("ListPageByKeychainID", mock.Anything, "keychain_id", filter, tt.after, tt.repoLimit).Return(tt.repoShrs, nil).Once()
+			}
+
+			// Nothing is decrypted without secrets, no encryption part is needed
+			page, err := app.ListKeychainShares(ctx, filter, tt.after, tt.limit, WithoutSecrets())
+			if tt.wantErr != nil {
+				ass.ErrorIs(err, tt.wantErr)
+				return
+			}
+			ass.NoError(err)
+			ass.Equal(tt.wantNext, page.Next)
+			ass.Len(page.Shares, len(tt.wantIDs))
+			for i, shr := range page.Shares {
+				ass.Equal(tt.wantIDs[i], shr.ID)
+				ass.Empty(shr.Secret)
+			}
+		})
+	}
+}
+
+func TestShareApplication_RenameReference(t *testing.T) {
+	ctx := contexter.WithProjectID(context.Background(), "project_id")
+	ctx = contexter.WithUserID(ctx, "user_id")
+	userRepo := new(usermockedrepo.MockUserRepository)
+	shareRepo := new(sharemockrepo.MockShareRepository)
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+	encryptionPartsRepo := new(encryptionpartsmockrepo.MockEncryptionPartsRepository)
+	encryptionFactory := encryption.NewEncryptionFactory(encryptionPartsRepo, projectRepo)
+	keychainRepo := new(keychainmockrepo.MockKeychainRepository)
+	shareSvc := sharesvc.New(shareRepo, keychainRepo, encryptionFactory)
+	app := New(shareSvc, shareRepo, projectRepo, userRepo, keychainRepo, encryptionFactory, &shamirjob.Job{})
+
+	testKeychain := &keychain.Keychain{
+		ID:     "keychain_id",
+		UserID: "user_id",
+	}
+
+	tc := []struct {
+		name         string
+		wantErr      error
+		newReference string
+		mock         func()
+	}{
+		{
+			name:         "success",
+			wantErr:      nil,
+			newReference: "new",
+			mock: func() {
+				shareRepo.ExpectedCalls = nil
+				shareRepo.On("UpdateReference", mock.Anything, "keychain_id", "old", "new").Return(nil)
+			},
+		},
+		{
+			name:         "same reference",
+			wantErr:      nil,
+			newReference: "old",
+			mock: func() {
+				shareRepo.ExpectedCalls = nil
+			},
+		},
+		{
+			name:         "reference taken",
+			wantErr:      ErrShareAlreadyExists,
+			newReference: "new",
+			mock: func() {
+				shareRepo.ExpectedCalls = nil
+				shareRepo.On("UpdateReference", mock.Anything, "keychain_id", "old", "new").Return(domainErrors.ErrShareAlreadyExists)
+			},
+		},
+		{
+			name:         "share not found",
+			wantErr:      ErrShareNotFound,
+			newReference: "new",
+			mock: func() {
+				shareRepo.ExpectedCalls = nil
+				shareRepo.On("UpdateReference", mock.Anything, "keychain_id", "old", "new").Return(domainErrors.ErrShareNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			keychainRepo.ExpectedCalls = nil
+			keychainRepo.On("GetByUserID", mock.Anything, "user_id").Return(testKeychain, nil)
+			tt.mock()
+			shareRepo.On("GetByUserID", mock.Anything, "user_id").Return(nil, domainErrors.ErrShareNotFound)
+			ass := assert.New(t)
+			err := app.RenameReference(ctx, "old", tt.newReference)
+			ass.ErrorIs(err, tt.wantErr)
+		})
+	}
+}
+
+func TestShareApplication_ReassignShare(t *testing.T) {
+	ctx := contexter.WithProjectID(context.Background(), "project_id")
+	userRepo := new(usermockedrepo.MockUserRepository)
+	shareRepo := new(sharemockrepo.MockShareRepository)
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+	encryptionPartsRepo := new(encryptionpartsmockrepo.MockEncryptionPartsRepository)
+	encryptionFactory := encryption.NewEncryptionFactory(encryptionPartsRepo, projectRepo)
+	keychainRepo := new(keychainmockrepo.MockKeychainRepository)
+	shareSvc := sharesvc.New(shareRepo, keychainRepo, encryptionFactory)
+	app := New(shareSvc, shareRepo, projectRepo, userRepo, keychainRepo, encryptionFactory, &shamirjob.Job{})
+
+	sourceKeychain := "source_keychain"
+	shr := &share.Share{ID: "share_id", KeychainID: &sourceKeychain}
+
+	tc := []struct {
+		name    string
+		wantErr error
+		mock    func()
+	}{
+		{
+			name:    "success",
+			wantErr: nil,
+			mock: func() {
+				keychainRepo.On("Get", mock.Anything, "target_keychain").Return(&keychain.Keychain{ID: "target_keychain", UserID: "target_user"}, nil)
+				userRepo.On("Get", mock.Anything, "target_user").Return(&user.User{ID: "target_user", ProjectID: "project_id"}, nil)
+				shareRepo.On("UpdateKeychain", mock.Anything, "share_id", "target_keychain", "target_user").Return(nil).Once()
+			},
+		},
+		{
+			name:    "already in keychain",
+			wantErr: nil,
+			mock: func() {
+				keychainRepo.On("Get", mock.Anything, "target_keychain").Return(&keychain.Keychain{ID: sourceKeychain, UserID: "target_user"}, nil)
+				userRepo.On("Get", mock.Anything, "target_user").Return(&user.User{ID: "target_user", ProjectID: "project_id"}, nil)
+			},
+		},
+		{
+			name:    "keychain of another project",
+			wantErr: ErrKeychainNotFound,
+			mock: func() {
+				keychainRepo.On("Get", mock.Anything, "target_keychain").Return(&keychain.Keychain{ID: "target_keychain", UserID: "target_user"}, nil)
+				userRepo.On("Get", mock.Anything, "target_user").Return(&user.User{ID: "target_user", ProjectID: "other_project"}, nil)
+			},
+		},
+		{
+			name:    "keychain not found",
+			wantErr: ErrKeychainNotFound,
+			mock: func() {
+				keychainRepo.On("Get", mock.Anything, "target_keychain").Return(nil, domainErrors.ErrKeychainNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			shareRepo.ExpectedCalls = nil
+			keychainRepo.ExpectedCalls = nil
+			userRepo.ExpectedCalls = nil
+			shareRepo.On("GetByReferenceAndProjectID", mock.Anything, "reference", "project_id").Return(shr, nil)
+			tt.mock()
+			ass := assert.New(t)
+			err := app.ReassignShare(ctx, "reference", "target_keychain")
+			ass.ErrorIs(err, tt.wantErr)
+			shareRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestShareApplication_ReadOnlyProject(t *testing.T) {
+	ctx := contexter.WithProjectID(context.Background(), "project_id")
+	ctx = contexter.WithUserID(ctx, "user_id")
+	ctx = contexter.WithProjectStatus(ctx, project.StatusReadOnly)
+	userRepo := new(usermockedrepo.MockUserRepository)
+	shareRepo := new(sharemockrepo.MockShareRepository)
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+	encryptionPartsRepo := new(encryptionpartsmockrepo.MockEncryptionPartsRepository)
+	encryptionFactory := encryption.NewEncryptionFactory(encryptionPartsRepo, projectRepo)
+	keychainRepo := new(keychainmockrepo.MockKeychainRepository)
+	shareSvc := sharesvc.New(shareRepo, keychainRepo, encryptionFactory)
+	app := New(shareSvc, shareRepo, projectRepo, userRepo, keychainRepo, encryptionFactory, &shamirjob.Job{})
+
+	reference := "reference"
+	ass := assert.New(t)
+	ass.ErrorIs(app.RegisterShare(ctx, &share.Share{Secret: "secret", Reference: &reference}), ErrProjectReadOnly)
+	_, err := app.UpdateShare(ctx, &share.Share{Secret: "secret"}, reference)
+	ass.ErrorIs(err, ErrProjectReadOnly)
+	ass.ErrorIs(app.DeleteShare(ctx, &reference), ErrProjectReadOnly)
+	ass.ErrorIs(app.RenameReference(ctx, reference, "other"), ErrProjectReadOnly)
+	ass.ErrorIs(app.ReassignShare(ctx, reference, "keychain_id"), ErrProjectReadOnly)
+
+	testKeychain := &keychain.Keychain{ID: "keychain_id", UserID: "user_id"}
+	keychainRepo.On("GetByUserID", mock.Anything, "user_id").Return(testKeychain, nil)
+	keychainRepo.On("Get", mock.Anything, "keychain_id").Return(testKeychain, nil)
+	shareRepo.On("GetByUserID", mock.Anything, "user_id").Return(nil, domainErrors.ErrShareNotFound)
+	shareRepo.On("ListByKeychainID", mock.Anything, "keychain_id").Return([]*share.Share{{ID: "share_id", Reference: &reference}}, nil)
+
+	_, shrs, err := app.GetKeychainMetadata(ctx)
+	ass.NoError(err)
+	ass.Len(shrs, 1)
+}
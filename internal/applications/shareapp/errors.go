@@ -9,6 +9,7 @@ import (
 var (
 	ErrShareNotFound             = errors.New("share not found")
 	ErrShareAlreadyExists        = errors.New("share already exists")
+	ErrKeychainNotFound          = errors.New("keychain not found")
 	ErrUserNotFound              = errors.New("user not found")
 	ErrExternalUserNotFound      = errors.New("external user not found")
 	ErrExternalUserAlreadyExists = errors.New("external user already exists")
@@ -17,6 +18,11 @@ var (
 	ErrInvalidEncryptionPart     = errors.New("invalid encryption part")
 	ErrInvalidEncryptionSession  = errors.New("invalid encryption session")
 	ErrOTPVerificationRequired   = errors.New("otp verification required")
+	ErrTransferKeyRequired       = errors.New("transfer key is required")
+	ErrInvalidTransferKey        = errors.New("invalid transfer key")
+	ErrProjectReadOnly           = errors.New("project is read-only")
+	ErrShareVersionMismatch      = errors.New("share was changed since it was read")
+	ErrInvalidPageSize           = errors.New("invalid page size")
 	ErrInternal                  = errors.New("internal error")
 )
 
@@ -25,10 +31,18 @@ func fromDomainError(err error) error {
 		return ErrShareNotFound
 	}
 
+	if errors.Is(err, domainErrors.ErrShareVersionMismatch) {
+		return ErrShareVersionMismatch
+	}
+
 	if errors.Is(err, domainErrors.ErrShareAlreadyExists) {
 		return ErrShareAlreadyExists
 	}
 
+	if errors.Is(err, domainErrors.ErrKeychainNotFound) {
+		return ErrKeychainNotFound
+	}
+
 	if errors.Is(err, domainErrors.ErrEncryptionPartRequired) {
 		return ErrEncryptionPartRequired
 	}
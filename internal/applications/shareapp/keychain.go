@@ -0,0 +1,174 @@
+package shareapp
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
+	"github.com/openfort-xyz/shield/internal/core/domain/keychain"
+	"github.com/openfort-xyz/shield/internal/core/domain/share"
+	"github.com/openfort-xyz/shield/pkg/contexter"
+	"github.com/openfort-xyz/shield/pkg/logger"
+)
+
+// MaxKeychainPageSize bounds the shares listed in a keychain page.
+const MaxKeychainPageSize = 100
+
+// SharesPage is a page of the user's keychain shares.
+type SharesPage struct {
+	Shares []*share.Share
+	// Next is the ID of the last share of the page, empty on the last page
+	Next string
+}
+
+// ListKeychainShares returns a page of the user's keychain shares matching
+// the filter, ordered by ID and starting after the share with the ID after.
+// A limit of zero lists every matching share in a single page.
+func (a *ShareApplication) ListKeychainShares(ctx context.Context, filter *share.ListFilter, after string, limit int, opts ...Option) (*SharesPage, error) {
+	a.logger.InfoContext(ctx, "listing keychain shares")
+	usrID := contexter.GetUserID(ctx)
+
+	var opt options
+	for _, o := range opts {
+		o(&opt)
+	}
+
+	if limit < 0 || limit > MaxKeychainPageSize {
+		return nil, ErrInvalidPageSize
+	}
+
+	keychainID, err := a.migrateToKeychainIfRequired(ctx, usrID)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to migrate keychain shares", logger.Error(err))
+		return nil, fromDomainError(err)
+	}
+
+	// One extra share tells whether there's a next page
+	fetch := limit
+	if limit > 0 {
+		fetch++
+	}
+	shrs, err := a.shareRepo.ListPageByKeychainID(ctx, keychainID, filter, after, fetch)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to list shares by keychain ID", logger.Error(err))
+		return nil, fromDomainError(err)
+	}
+
+	page := &SharesPage{}
+	if limit > 0 && len(shrs) > limit {
+		shrs = shrs[:limit]
+		page.Next = shrs[limit-1].ID
+	}
+	page.Shares = shrs
+
+	if err := a.revealSecrets(ctx, page.Shares, opt); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// GetKeychainMetadata returns the user's keychain along with its shares.
+// The shares carry no secret, nothing is decrypted to list them.
+func (a *ShareApplication) GetKeychainMetadata(ctx context.Context) (*keychain.Keychain, []*share.Share, error) {
+	a.logger.InfoContext(ctx, "getting keychain metadata")
+	usrID := contexter.GetUserID(ctx)
+
+	keychainID, err := a.migrateToKeychainIfRequired(ctx, usrID)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to migrate keychain shares", logger.Error(err))
+		return nil, nil, fromDomainError(err)
+	}
+
+	userKeychain, err := a.keychainRepository.Get(ctx, keychainID)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to get keychain", logger.Error(err))
+		return nil, nil, fromDomainError(err)
+	}
+
+	shrs, err := a.shareRepo.ListByKeychainID(ctx, keychainID)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to list shares by keychain ID", logger.Error(err))
+		return nil, nil, fromDomainError(err)
+	}
+
+	for _, shr := range shrs {
+		shr.Secret = ""
+	}
+
+	return userKeychain, shrs, nil
+}
+
+// RenameReference renames one of the references in the user's keychain. The
+// new reference must not be in use by any share yet.
+func (a *ShareApplication) RenameReference(ctx context.Context, reference, newReference string) error {
+	a.logger.InfoContext(ctx, "renaming reference", slog.String("reference", reference))
+	if err := checkWritable(ctx); err != nil {
+		return err
+	}
+	usrID := contexter.GetUserID(ctx)
+
+	if reference == newReference {
+		return nil
+	}
+
+	keychainID, err := a.migrateToKeychainIfRequired(ctx, usrID)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to migrate keychain shares", logger.Error(err))
+		return fromDomainError(err)
+	}
+
+	err = a.shareRepo.UpdateReference(ctx, keychainID, reference, newReference)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to rename reference", logger.Error(err))
+		return fromDomainError(err)
+	}
+
+	return nil
+}
+
+// ReassignShare moves the share to another keychain of the project, the
+// share then belongs to the keychain's user.
+func (a *ShareApplication) ReassignShare(ctx context.Context, reference, keychainID string) error {
+	a.logger.InfoContext(ctx, "reassigning share", slog.String("reference", reference), slog.String("keychain_id", keychainID))
+	if err := checkWritable(ctx); err != nil {
+		return err
+	}
+	projID := contexter.GetProjectID(ctx)
+
+	shr, err := a.shareRepo.GetByReferenceAndProjectID(ctx, reference, projID)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to get share by reference", logger.Error(err))
+		return fromDomainError(err)
+	}
+
+	target, err := a.keychainRepository.Get(ctx, keychainID)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to get keychain", logger.Error(err))
+		return fromDomainError(err)
+	}
+
+	// A keychain of another project is reported as missing, its existence
+	// is none of this project's business
+	usr, err := a.userRepo.Get(ctx, target.UserID)
+	if err != nil && !errors.Is(err, domainErrors.ErrUserNotFound) {
+		a.logger.ErrorContext(ctx, "failed to get keychain user", logger.Error(err))
+		return fromDomainError(err)
+	}
+	if usr == nil || usr.ProjectID != projID {
+		return ErrKeychainNotFound
+	}
+
+	if shr.KeychainID != nil && *shr.KeychainID == target.ID {
+		return nil
+	}
+
+	err = a.shareRepo.UpdateKeychain(ctx, shr.ID, target.ID, target.UserID)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to reassign share", logger.Error(err))
+		return fromDomainError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,208 @@
+package shareapp
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"log/slog"
+
+	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
+	"github.com/openfort-xyz/shield/internal/core/domain/share"
+	"github.com/openfort-xyz/shield/internal/core/ports/strategies"
+	"github.com/openfort-xyz/shield/pkg/contexter"
+	"github.com/openfort-xyz/shield/pkg/logger"
+)
+
+// MigrationPageSize is how many shares ExportShares reads at a time.
+const MigrationPageSize = 500
+
+// TransferKeySize is the size in bytes of a decoded transfer key.
+const TransferKeySize = 32
+
+// MigratedShare is a share on its way between projects. Project entropy
+// shares only ever travel encrypted to the transfer key, never to the key of
+// either project.
+type MigratedShare struct {
+	Share             *share.Share
+	TransferEncrypted bool
+	// Err is set when the share could not be exported, Share then only
+	// carries what identifies it.
+	Err error
+}
+
+type ImportStatus string
+
+const (
+	ImportStatusImported ImportStatus = "imported"
+	ImportStatusSkipped  ImportStatus = "skipped"
+)
+
+// ExportShares hands every share of the project after the given share ID to
+// emit, ordered by ID, so the last ID emitted is a checkpoint to resume from.
+// Project entropy shares are only exported when a transfer key is given, the
+// others are reported through MigratedShare.Err.
+func (a *ShareApplication) ExportShares(ctx context.Context, after string, emit func(*MigratedShare) error, opts ...Option) error {
+	a.logger.InfoContext(ctx, "exporting shares", slog.String("after", after))
+	projID := contexter.GetProjectID(ctx)
+
+	var opt options
+	for _, o := range opts {
+		o(&opt)
+	}
+
+	projectCypher, transferCypher, err := a.transferCyphers(ctx, projID, opt)
+	if err != nil {
+		return err
+	}
+
+	for {
+		shares, err := a.shareRepo.ListByProject(ctx, projID, after, MigrationPageSize)
+		if err != nil {
+			a.logger.ErrorContext(ctx, "failed to list shares for export", logger.Error(err))
+			return fromDomainError(err)
+		}
+
+		for _, shr := range shares {
+			err = emit(a.exportShare(ctx, shr, projectCypher, transferCypher))
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(shares) < MigrationPageSize {
+			return nil
+		}
+		after = shares[len(shares)-1].ID
+	}
+}
+
+func (a *ShareApplication) exportShare(ctx context.Context, shr *share.Share, projectCypher, transferCypher strategies.EncryptionStrategy) *MigratedShare {
+	if shr.Entropy != share.EntropyProject {
+		return &MigratedShare{Share: shr}
+	}
+
+	if transferCypher == nil {
+		shr.Secret = ""
+		return &MigratedShare{Share: shr, Err: ErrTransferKeyRequired}
+	}
+
+	secret, err := projectCypher.Decrypt(shr.Secret)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to decrypt share for export", slog.String("share_id", shr.ID), logger.Error(err))
+		shr.Secret = ""
+		return &MigratedShare{Share: shr, Err: ErrInvalidEncryptionPart}
+	}
+
+	shr.Secret, err = transferCypher.Encrypt(secret)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to encrypt share to transfer key", slog.String("share_id", shr.ID), logger.Error(err))
+		shr.Secret = ""
+		return &MigratedShare{Share: shr, Err: ErrInternal}
+	}
+
+	return &MigratedShare{Share: shr, TransferEncrypted: true}
+}
+
+// ShareImporter imports exported shares into the authenticated project one at
+// a time. Shares keep their ID, importing one that is already there again is
+// skipped, so an interrupted import can be replayed from any earlier point.
+type ShareImporter struct {
+	app            *ShareApplication
+	projectID      string
+	projectCypher  strategies.EncryptionStrategy
+	transferCypher strategies.EncryptionStrategy
+}
+
+// NewShareImporter checks the transfer key and reconstructs the project's
+// encryption key up front, so a bad key fails the import before any share.
+func (a *ShareApplication) NewShareImporter(ctx context.Context, opts ...Option) (*ShareImporter, error) {
+	if err := checkWritable(ctx); err != nil {
+		return nil, err
+	}
+	projID := contexter.GetProjectID(ctx)
+
+	var opt options
+	for _, o := range opts {
+		o(&opt)
+	}
+
+	projectCypher, transferCypher, err := a.transferCyphers(ctx, projID, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ShareImporter{
+		app:            a,
+		projectID:      projID,
+		projectCypher:  projectCypher,
+		transferCypher: transferCypher,
+	}, nil
+}
+
+func (i *ShareImporter) Import(ctx context.Context, migrated *MigratedShare) (ImportStatus, error) {
+	a := i.app
+	shr := migrated.Share
+	a.logger.InfoContext(ctx, "importing migrated share", slog.String("share_id", shr.ID))
+
+	existing, err := a.shareRepo.Get(ctx, shr.ID)
+	if err == nil {
+		if existing.UserID != shr.UserID {
+			return "", ErrShareAlreadyExists
+		}
+		return ImportStatusSkipped, nil
+	}
+	if !errors.Is(err, domainErrors.ErrShareNotFound) {
+		a.logger.ErrorContext(ctx, "failed to get share for import", logger.Error(err))
+		return "", fromDomainError(err)
+	}
+
+	usr, err := a.userRepo.Get(ctx, shr.UserID)
+	if err != nil || usr.ProjectID != i.projectID {
+		return "", ErrUserNotFound
+	}
+
+	if shr.Entropy == share.EntropyProject {
+		if !migrated.TransferEncrypted || i.transferCypher == nil {
+			return "", ErrTransferKeyRequired
+		}
+
+		secret, err := i.transferCypher.Decrypt(shr.Secret)
+		if err != nil {
+			return "", ErrInvalidTransferKey
+		}
+
+		shr.Secret, err = i.projectCypher.Encrypt(secret)
+		if err != nil {
+			a.logger.ErrorContext(ctx, "failed to encrypt imported share", logger.Error(err))
+			return "", ErrInternal
+		}
+	}
+
+	err = a.shareRepo.Create(ctx, shr)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to import share", logger.Error(err))
+		return "", fromDomainError(err)
+	}
+
+	return ImportStatusImported, nil
+}
+
+// transferCyphers returns nil cyphers when no transfer key is given, project
+// entropy shares are then left out of the migration.
+func (a *ShareApplication) transferCyphers(ctx context.Context, projID string, opt options) (strategies.EncryptionStrategy, strategies.EncryptionStrategy, error) {
+	if opt.transferKey == nil {
+		return nil, nil, nil
+	}
+
+	rawKey, err := base64.StdEncoding.DecodeString(*opt.transferKey)
+	if err != nil || len(rawKey) != TransferKeySize {
+		return nil, nil, ErrInvalidTransferKey
+	}
+
+	encryptionKey, err := a.reconstructEncryptionKey(ctx, projID, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return a.encryptionFactory.CreateEncryptionStrategy(encryptionKey), a.encryptionFactory.CreateEncryptionStrategy(*opt.transferKey), nil
+}
@@ -1,9 +1,15 @@
 package shareapp
 
+import "github.com/openfort-xyz/shield/internal/core/domain/share"
+
 type options struct {
 	encryptionPart    *string
 	encryptionSession *string
 	requireOTPCheck   bool
+	transferKey       *string
+	metadataFilter    share.Metadata
+	ifMatch           []int64
+	withoutSecrets    bool
 }
 
 type Option func(*options)
@@ -19,3 +25,34 @@ func WithEncryptionSession(encryptionSession string) Option {
 		o.encryptionSession = &encryptionSession
 	}
 }
+
+// WithTransferKey re-encrypts project entropy shares to the given key while
+// they are moved between projects.
+func WithTransferKey(transferKey string) Option {
+	return func(o *options) {
+		o.transferKey = &transferKey
+	}
+}
+
+// WithMetadataFilter keeps only the keychain shares whose metadata holds every
+// entry of the filter.
+func WithMetadataFilter(filter share.Metadata) Option {
+	return func(o *options) {
+		o.metadataFilter = filter
+	}
+}
+
+// WithIfMatch only updates the share when it's at one of the given versions.
+func WithIfMatch(versions []int64) Option {
+	return func(o *options) {
+		o.ifMatch = versions
+	}
+}
+
+// WithoutSecrets leaves the secrets out of the keychain shares, nothing is
+// decrypted so no encryption part or session is needed.
+func WithoutSecrets() Option {
+	return func(o *options) {
+		o.withoutSecrets = true
+	}
+}
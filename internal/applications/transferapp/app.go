@@ -0,0 +1,153 @@
+package transferapp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/openfort-xyz/shield/internal/core/domain/project"
+	"github.com/openfort-xyz/shield/internal/core/ports/repositories"
+	"github.com/openfort-xyz/shield/pkg/cypher"
+	"github.com/openfort-xyz/shield/pkg/logger"
+)
+
+// ArchiveVersion is the layout of the archive sealed in a transfer file.
+const ArchiveVersion = 1
+
+// archive is what a transfer file holds once opened. Digest covers Tables
+// only, the destination's import report carries the same one.
+type archive struct {
+	Version    int                         `json:"version"`
+	ProjectID  string                      `json:"project_id"`
+	ExportedAt time.Time                   `json:"exported_at"`
+	Digest     string                      `json:"digest"`
+	Tables     map[string][]map[string]any `json:"tables"`
+}
+
+// Application moves whole projects between Shield deployments. A project
+// leaves as an archive sealed to the destination's public key, only the
+// destination can open it.
+type Application struct {
+	archiveRepo repositories.ArchiveRepository
+	logger      *slog.Logger
+}
+
+func New(archiveRepo repositories.ArchiveRepository) *Application {
+	return &Application{
+		archiveRepo: archiveRepo,
+		logger:      logger.New("transfer_application"),
+	}
+}
+
+// Export returns the project's archive sealed to publicKey, and its digest.
+func (a *Application) Export(ctx context.Context, projectID, publicKey string) ([]byte, string, error) {
+	a.logger.InfoContext(ctx, "exporting project for transfer", slog.String("project_id", projectID))
+
+	exported, err := a.archiveRepo.ExportProjectForTransfer(ctx, projectID)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to export project", logger.Error(err))
+		return nil, "", fromDomainError(err)
+	}
+
+	digest, err := exported.Digest()
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to digest project archive", logger.Error(err))
+		return nil, "", ErrInternal
+	}
+
+	raw, err := json.Marshal(&archive{
+		Version:    ArchiveVersion,
+		ProjectID:  exported.ProjectID,
+		ExportedAt: exported.ExportedAt,
+		Digest:     digest,
+		Tables:     exported.Tables,
+	})
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to encode project archive", logger.Error(err))
+		return nil, "", ErrInternal
+	}
+
+	sealed, err := cypher.Seal(raw, publicKey)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to seal project archive", logger.Error(err))
+		return nil, "", fromDomainError(err)
+	}
+
+	return sealed, digest, nil
+}
+
+// Import opens an archive sealed to the public key of privateKey, checks it
+// and writes the project into this deployment. Rows already there are kept,
+// so an import that failed halfway, or a finished one, can be run again.
+func (a *Application) Import(ctx context.Context, sealed []byte, privateKey string, dryRun bool) (*project.ImportReport, error) {
+	a.logger.InfoContext(ctx, "importing project", slog.Bool("dry_run", dryRun))
+
+	raw, err := cypher.Open(sealed, privateKey)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to open project archive", logger.Error(err))
+		return nil, fromDomainError(err)
+	}
+
+	opened, err := decodeArchive(raw)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to decode project archive", logger.Error(err))
+		return nil, ErrInvalidArchive
+	}
+
+	if opened.Version != ArchiveVersion {
+		return nil, ErrUnsupportedArchiveVersion
+	}
+
+	imported := &project.Archive{
+		ProjectID:  opened.ProjectID,
+		ExportedAt: opened.ExportedAt,
+		Tables:     opened.Tables,
+	}
+	digest, err := imported.Digest()
+	if err != nil || digest != opened.Digest {
+		a.logger.ErrorContext(ctx, "project archive digest mismatch")
+		return nil, ErrInvalidArchive
+	}
+
+	report, err := a.archiveRepo.ImportProject(ctx, imported, dryRun)
+	if err != nil {
+		a.logger.ErrorContext(ctx, "failed to import project", logger.Error(err))
+		return nil, fromDomainError(err)
+	}
+	report.Digest = digest
+
+	return report, nil
+}
+
+// decodeArchive keeps whole numbers as int64, as they were read from the
+// database, rather than float64.
+func decodeArchive(raw []byte) (*archive, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var opened archive
+	err := decoder.Decode(&opened)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rows := range opened.Tables {
+		for _, row := range rows {
+			for column, value := range row {
+				number, ok := value.(json.Number)
+				if !ok {
+					continue
+				}
+				if i, err := number.Int64(); err == nil {
+					row[column] = i
+				} else if f, err := number.Float64(); err == nil {
+					row[column] = f
+				}
+			}
+		}
+	}
+
+	return &opened, nil
+}
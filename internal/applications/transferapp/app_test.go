@@ -0,0 +1,133 @@
+package transferapp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/mocks/archivemockrepo"
+	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
+	"github.com/openfort-xyz/shield/internal/core/domain/project"
+	"github.com/openfort-xyz/shield/pkg/cypher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplication_ExportImport(t *testing.T) {
+	ctx := context.Background()
+	archiveRepo := new(archivemockrepo.MockArchiveRepository)
+	app := New(archiveRepo)
+
+	privateKey, publicKey, err := cypher.GenerateSealingKeyPair()
+	require.NoError(t, err)
+	otherKey, _, err := cypher.GenerateSealingKeyPair()
+	require.NoError(t, err)
+
+	exported := &project.Archive{
+		ProjectID:  "project_id",
+		ExportedAt: time.Now().UTC(),
+		Tables: map[string][]map[string]any{
+			"shld_projects":   {{"id": "project_id", "name": "test", "signing_key": "decrypted"}},
+			"shld_rate_limit": {{"id": "limit_id", "project_id": "project_id", "requests_per_minute": int64(60)}},
+		},
+	}
+	digest, err := exported.Digest()
+	require.NoError(t, err)
+
+	seal := func(v any) []byte {
+		raw, err := json.Marshal(v)
+		require.NoError(t, err)
+		sealed, err := cypher.Seal(raw, publicKey)
+		require.NoError(t, err)
+		return sealed
+	}
+
+	tc := []struct {
+		name    string
+		sealed  func() []byte
+		key     string
+		wantErr error
+		mock    func()
+	}{
+		{
+			name: "success",
+			sealed: func() []byte {
+				sealed, gotDigest, err := app.Export(ctx, "project_id", publicKey)
+				require.NoError(t, err)
+				assert.Equal(t, digest, gotDigest)
+				return sealed
+			},
+			key: privateKey,
+			mock: func() {
+				archiveRepo.ExpectedCalls = nil
+				archiveRepo.On("ExportProjectForTransfer", mock.Anything, "project_id").Return(exported, nil)
+				archiveRepo.On("ImportProject", mock.Anything, mock.MatchedBy(func(a *project.Archive) bool {
+					// Whole numbers come back as they were read from the database
+					return a.ProjectID == "project_id" && a.Tables["shld_rate_limit"][0]["requests_per_minute"] == int64(60)
+				}), true).Return(&project.ImportReport{ProjectID: "project_id", DryRun: true}, nil)
+			},
+		},
+		{
+			name: "sealed to another key",
+			sealed: func() []byte {
+				return seal(&archive{Version: ArchiveVersion, ProjectID: "project_id", Digest: digest, Tables: exported.Tables})
+			},
+			key:     otherKey,
+			wantErr: ErrInvalidArchive,
+			mock:    func() { archiveRepo.ExpectedCalls = nil },
+		},
+		{
+			name: "digest mismatch",
+			sealed: func() []byte {
+				return seal(&archive{Version: ArchiveVersion, ProjectID: "project_id", Digest: "other", Tables: exported.Tables})
+			},
+			key:     privateKey,
+			wantErr: ErrInvalidArchive,
+			mock:    func() { archiveRepo.ExpectedCalls = nil },
+		},
+		{
+			name: "unsupported version",
+			sealed: func() []byte {
+				return seal(&archive{Version: ArchiveVersion + 1, ProjectID: "project_id", Digest: digest, Tables: exported.Tables})
+			},
+			key:     privateKey,
+			wantErr: ErrUnsupportedArchiveVersion,
+			mock:    func() { archiveRepo.ExpectedCalls = nil },
+		},
+		{
+			name: "destination secret encryption not configured",
+			sealed: func() []byte {
+				return seal(&archive{Version: ArchiveVersion, ProjectID: "project_id", Digest: digest, Tables: exported.Tables})
+			},
+			key:     privateKey,
+			wantErr: ErrSecretEncryptionNotConfigured,
+			mock: func() {
+				archiveRepo.ExpectedCalls = nil
+				archiveRepo.On("ImportProject", mock.Anything, mock.Anything, true).Return(nil, domainErrors.ErrProjectSecretEncryptionNotSet)
+			},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			report, err := app.Import(ctx, tt.sealed(), tt.key, true)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.Equal(t, digest, report.Digest)
+				assert.True(t, report.DryRun)
+			}
+		})
+	}
+}
+
+func TestApplication_ExportInvalidRecipient(t *testing.T) {
+	archiveRepo := new(archivemockrepo.MockArchiveRepository)
+	app := New(archiveRepo)
+	archiveRepo.On("ExportProjectForTransfer", mock.Anything, "project_id").Return(&project.Archive{ProjectID: "project_id"}, nil)
+
+	_, _, err := app.Export(context.Background(), "project_id", "not a key")
+	assert.ErrorIs(t, err, ErrInvalidSealingKey)
+}
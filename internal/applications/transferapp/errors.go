@@ -0,0 +1,37 @@
+package transferapp
+
+import (
+	"errors"
+
+	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
+	"github.com/openfort-xyz/shield/pkg/cypher"
+)
+
+var (
+	ErrProjectNotFound               = errors.New("project not found")
+	ErrInvalidSealingKey             = errors.New("invalid sealing key")
+	ErrInvalidArchive                = errors.New("invalid project archive")
+	ErrUnsupportedArchiveVersion     = errors.New("unsupported project archive version")
+	ErrSecretEncryptionNotConfigured = errors.New("secret encryption not configured")
+	ErrInternal                      = errors.New("internal error")
+)
+
+func fromDomainError(err error) error {
+	if errors.Is(err, domainErrors.ErrProjectNotFound) {
+		return ErrProjectNotFound
+	}
+
+	if errors.Is(err, domainErrors.ErrInvalidProjectArchive) || errors.Is(err, cypher.ErrInvalidSealedMessage) {
+		return ErrInvalidArchive
+	}
+
+	if errors.Is(err, cypher.ErrInvalidSealingKey) {
+		return ErrInvalidSealingKey
+	}
+
+	if errors.Is(err, domainErrors.ErrProjectSecretEncryptionNotSet) || errors.Is(err, domainErrors.ErrSecretEncryptionNotSet) {
+		return ErrSecretEncryptionNotConfigured
+	}
+
+	return ErrInternal
+}
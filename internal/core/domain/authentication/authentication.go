@@ -1,7 +1,10 @@
 package authentication
 
+import "github.com/openfort-xyz/shield/internal/core/domain/project"
+
 type Authentication struct {
 	UserID         string
 	ProjectID      string
 	ExternalUserID string
+	ProjectStatus  project.Status
 }
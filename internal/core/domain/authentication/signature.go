@@ -0,0 +1,12 @@
+package authentication
+
+// RequestSignature carries what a project backend sent to prove it holds
+// the project's signing key without sending a reusable secret.
+type RequestSignature struct {
+	Method     string
+	RequestURI string
+	BodyHash   string
+	Timestamp  int64
+	Nonce      string
+	Signature  string
+}
@@ -0,0 +1,11 @@
+package backup
+
+// Snapshot describes the tables of a consistent snapshot of the database.
+// Tables are ordered parents first, rows restored in that order only ever
+// refer to rows already written.
+type Snapshot struct {
+	// SchemaVersion is the last migration applied when the snapshot was
+	// taken, a snapshot only restores into a database at the same version
+	SchemaVersion int64
+	Tables        []string
+}
@@ -0,0 +1,9 @@
+package errors
+
+import "errors"
+
+var (
+	ErrInvalidBackup           = errors.New("invalid backup")
+	ErrBackupSchemaMismatch    = errors.New("backup schema version differs from the database")
+	ErrRestoreDatabaseNotEmpty = errors.New("database to restore into is not empty")
+)
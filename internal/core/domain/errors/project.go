@@ -17,4 +17,19 @@ var (
 	ErrDatabasePartRequired            = errors.New("database part is required")
 	ErrFailedToSplitKey                = errors.New("failed to split key")
 	ErrOTPVerificationRequired         = errors.New("otp verification required")
+	ErrClientCertificateNotFound       = errors.New("client certificate not found")
+	ErrClientCertificateRequired       = errors.New("client certificate required")
+	ErrClientCertificateNotTrusted     = errors.New("client certificate not trusted")
+	ErrSigningKeyNotFound              = errors.New("signing key not found")
+	ErrProjectSecretEncryptionNotSet   = errors.New("project secret encryption key not configured")
+	ErrRequestSignatureRequired        = errors.New("request signature required")
+	ErrInvalidRequestSignature         = errors.New("invalid request signature")
+	ErrRequestSignatureExpired         = errors.New("request signature timestamp outside the accepted window")
+	ErrNonceAlreadyUsed                = errors.New("nonce already used")
+	ErrDeletionRequestNotFound         = errors.New("project deletion was not requested")
+	ErrProjectChangedSinceExport       = errors.New("project data changed since the deletion archive was exported")
+	ErrInvalidProjectArchive           = errors.New("invalid project archive")
+	ErrInviteNotFound                  = errors.New("invite not found, used or expired")
+	ErrProjectSuspended                = errors.New("project suspended")
+	ErrProjectReadOnly                 = errors.New("project is read-only")
 )
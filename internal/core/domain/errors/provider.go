@@ -3,15 +3,19 @@ package errors
 import "errors"
 
 var (
-	ErrInvalidProviderConfig  = errors.New("invalid provider config")
-	ErrUnknownProviderType    = errors.New("unknown provider type")
-	ErrProviderAlreadyExists  = errors.New("custom authentication already registered for this project")
-	ErrProviderNotFound       = errors.New("custom authentication not found")
-	ErrProviderNotConfigured  = errors.New("provider not configured")
-	ErrProviderConfigMismatch = errors.New("provider config mismatch")
-	ErrUnexpectedStatusCode   = errors.New("unexpected status code")
-	ErrCertTypeNotSupported   = errors.New("certificate type not supported")
-	ErrProviderMisconfigured  = errors.New("provider misconfigured")
-	ErrSessionExpired         = errors.New("session expired")
-	ErrInvalidToken           = errors.New("invalid token")
+	ErrInvalidProviderConfig       = errors.New("invalid provider config")
+	ErrUnknownProviderType         = errors.New("unknown provider type")
+	ErrProviderAlreadyExists       = errors.New("custom authentication already registered for this project")
+	ErrProviderNotFound            = errors.New("custom authentication not found")
+	ErrProviderKeyNotFound         = errors.New("provider key not found")
+	ErrProviderNotConfigured       = errors.New("provider not configured")
+	ErrProviderConfigMismatch      = errors.New("provider config mismatch")
+	ErrUnexpectedStatusCode        = errors.New("unexpected status code")
+	ErrCertTypeNotSupported        = errors.New("certificate type not supported")
+	ErrProviderMisconfigured       = errors.New("provider misconfigured")
+	ErrSessionExpired              = errors.New("session expired")
+	ErrIdentityProviderUnavailable = errors.New("identity provider unavailable")
+	ErrInvalidToken                = errors.New("invalid token")
+	ErrHMACSecretTooShort          = errors.New("hmac secret too short")
+	ErrSecretEncryptionNotSet      = errors.New("provider secret encryption key not configured")
 )
@@ -3,6 +3,7 @@ package errors
 import "errors"
 
 var (
-	ErrShareNotFound      = errors.New("share not found")
-	ErrShareAlreadyExists = errors.New("share already exists")
+	ErrShareNotFound        = errors.New("share not found")
+	ErrShareAlreadyExists   = errors.New("share already exists")
+	ErrShareVersionMismatch = errors.New("share was changed since it was read")
 )
@@ -0,0 +1,26 @@
+package idempotency
+
+import "time"
+
+// Record is a request sent with an idempotency key, and once it completed
+// the response to replay for retries of it.
+type Record struct {
+	// KeyHash identifies the key within the scope of its caller.
+	KeyHash     string
+	RequestHash string
+	// Status is 0 while the request is in flight.
+	Status      int
+	ContentType string
+	// ETag is replayed with the response, so a retried update still tells
+	// the version it left the resource at.
+	ETag string
+	// Body is encrypted with a key derived from the idempotency key, which
+	// isn't stored, so responses carrying secrets aren't readable at rest.
+	Body      string
+	ExpiresAt time.Time
+}
+
+// Completed reports whether the response of the request was stored.
+func (r *Record) Completed() bool {
+	return r.Status != 0
+}
@@ -1,6 +1,10 @@
 package keychain
 
+import "time"
+
 type Keychain struct {
-	ID     string
-	UserID string
+	ID        string
+	UserID    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
@@ -0,0 +1,70 @@
+package project
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// ClientCertMode decides how a verified TLS client certificate takes part
+// in authenticating a project's backend calls.
+type ClientCertMode string
+
+const (
+	// ClientCertModeDisabled authenticates with the API secret only, client
+	// certificates are ignored.
+	ClientCertModeDisabled ClientCertMode = "DISABLED"
+	// ClientCertModeAlternative accepts either a registered certificate or
+	// the API secret.
+	ClientCertModeAlternative ClientCertMode = "ALTERNATIVE"
+	// ClientCertModeRequired demands both a registered certificate and the
+	// API secret.
+	ClientCertModeRequired ClientCertMode = "REQUIRED"
+)
+
+func (m ClientCertMode) Valid() bool {
+	switch m {
+	case ClientCertModeDisabled, ClientCertModeAlternative, ClientCertModeRequired:
+		return true
+	default:
+		return false
+	}
+}
+
+type ClientCertificateType string
+
+const (
+	// ClientCertificateTypeCA trusts every certificate the CA issued.
+	ClientCertificateTypeCA ClientCertificateType = "CA"
+	// ClientCertificateTypeLeaf trusts a single client certificate.
+	ClientCertificateTypeLeaf ClientCertificateType = "LEAF"
+)
+
+// ClientCertificate pins a certificate by the SHA-256 fingerprint of its DER
+// encoding, only the fingerprint is stored.
+type ClientCertificate struct {
+	ID          string
+	ProjectID   string
+	Name        string
+	Fingerprint string
+	Type        ClientCertificateType
+	CreatedAt   time.Time
+}
+
+// Fingerprint returns the lowercase hex SHA-256 of a DER encoded certificate.
+func Fingerprint(der []byte) string {
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:])
+}
+
+// NormalizeFingerprint accepts the upper case and colon separated forms
+// printed by openssl and returns the form Fingerprint produces.
+func NormalizeFingerprint(fingerprint string) (string, bool) {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(fingerprint), ":", ""))
+	decoded, err := hex.DecodeString(normalized)
+	if err != nil || len(decoded) != sha256.Size {
+		return "", false
+	}
+	return normalized, true
+}
@@ -0,0 +1,76 @@
+package project
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// DeletionMode decides what happens to a project's data once its deletion is
+// confirmed.
+type DeletionMode string
+
+const (
+	// DeletionModeHardDelete removes every row that belongs to the project.
+	DeletionModeHardDelete DeletionMode = "HARD_DELETE"
+	// DeletionModeCryptoShred destroys the project's encryption part, which
+	// leaves its project entropy shares as ciphertext nobody can decrypt.
+	// Those shares, the users and keychains they hang off, and the project
+	// itself are only soft-deleted. Everything the part doesn't protect or
+	// that identifies a person is removed as in DeletionModeHardDelete.
+	DeletionModeCryptoShred DeletionMode = "CRYPTO_SHRED"
+)
+
+func (m DeletionMode) Valid() bool {
+	switch m {
+	case DeletionModeHardDelete, DeletionModeCryptoShred:
+		return true
+	default:
+		return false
+	}
+}
+
+// Archive is everything Shield holds for a project, one entry per table with
+// its rows as column to value maps. Values are exported as stored: shares
+// keep whatever encryption they were registered with and the API secret is
+// a bcrypt hash. Columns encrypted with a key of this deployment are left
+// out, they're of no use anywhere else, except in transfer archives where
+// they're decrypted to be encrypted again with the destination's keys.
+type Archive struct {
+	ProjectID  string
+	ExportedAt time.Time
+	Tables     map[string][]map[string]any
+}
+
+// Digest identifies the archived data, ExportedAt doesn't take part so two
+// exports of an unchanged project have the same digest.
+func (a *Archive) Digest() (string, error) {
+	raw, err := json.Marshal(a.Tables)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// DeletionRequest is the first of the two steps that delete a project. Only
+// a hash of the confirmation token is kept.
+type DeletionRequest struct {
+	ProjectID     string
+	TokenHash     string
+	ArchiveDigest string
+	ExpiresAt     time.Time
+}
+
+// DeletionReceipt is recorded in the audit trail when a project is deleted
+// and outlives it.
+type DeletionReceipt struct {
+	ID            string
+	ProjectID     string
+	ProjectName   string
+	Mode          DeletionMode
+	ArchiveDigest string
+	DeletedRows   map[string]int64
+	DeletedAt     time.Time
+}
@@ -1,25 +1,36 @@
 package project
 
+import "time"
+
 type Project struct {
-	ID             string
-	Name           string
-	APIKey         string
-	APISecret      string
-	EncryptionPart string
-	Enable2FA      bool
-	SMSRateLimit   int64
-	EmailRateLimit int64
+	ID                    string
+	Name                  string
+	APIKey                string
+	APISecret             string
+	EncryptionPart        string
+	Enable2FA             bool
+	ClientCertMode        ClientCertMode
+	RequireSignedRequests bool
+	Status                Status
+	SMSRateLimit          int64
+	EmailRateLimit        int64
+	CreatedAt             time.Time
+	// DisabledAt is set once the operator disables the project, its
+	// credentials stop authenticating from then on.
+	DisabledAt *time.Time
 }
 
 type WithRateLimit struct {
-	ID             string
-	Name           string
-	APIKey         string
-	APISecret      string
-	EncryptionPart string
-	Enable2FA      bool
-	SMSRateLimit   int64
-	EmailRateLimit int64
+	ID                    string
+	Name                  string
+	APIKey                string
+	APISecret             string
+	EncryptionPart        string
+	Enable2FA             bool
+	ClientCertMode        ClientCertMode
+	RequireSignedRequests bool
+	SMSRateLimit          int64
+	EmailRateLimit        int64
 }
 
 type RateLimit struct {
@@ -27,3 +38,7 @@ type RateLimit struct {
 	SMSRequestsPerHour   int64
 	EmailRequestsPerHour int64
 }
+
+// InvalidationChannel carries the ID of a project whose record changed, so
+// every replica drops what it cached about it. An empty ID drops everything.
+const InvalidationChannel = "shld_project_invalidations"
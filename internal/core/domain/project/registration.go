@@ -0,0 +1,36 @@
+package project
+
+import "time"
+
+// RegistrationMode decides who can create projects through the public
+// registration endpoint. The operator can always create them.
+type RegistrationMode string
+
+const (
+	// RegistrationModeOpen lets anyone create a project.
+	RegistrationModeOpen RegistrationMode = "open"
+	// RegistrationModeInvite requires an unused invite issued by the
+	// operator, each invite creates a single project.
+	RegistrationModeInvite RegistrationMode = "invite"
+	// RegistrationModeOperator leaves project creation to the operator.
+	RegistrationModeOperator RegistrationMode = "operator"
+)
+
+func (m RegistrationMode) Valid() bool {
+	switch m {
+	case RegistrationModeOpen, RegistrationModeInvite, RegistrationModeOperator:
+		return true
+	default:
+		return false
+	}
+}
+
+// Invite lets whoever holds its token register one project. Only a hash of
+// the token is kept.
+type Invite struct {
+	ID        string
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,27 @@
+package project
+
+// Status restricts what a project's credentials can do, independently of
+// how they authenticate. The operator sets it.
+type Status string
+
+const (
+	// StatusActive puts no restriction on the project.
+	StatusActive Status = "ACTIVE"
+	// StatusReadOnly keeps shares readable but refuses to register, update
+	// or delete them, including through user erasure, project share
+	// encryption and project deletion. Users are still created on their
+	// first authentication or registration, reading their shares needs them,
+	// and the project's own settings stay editable.
+	StatusReadOnly Status = "READ_ONLY"
+	// StatusSuspended refuses every request authenticated for the project.
+	StatusSuspended Status = "SUSPENDED"
+)
+
+func (s Status) Valid() bool {
+	switch s {
+	case StatusActive, StatusReadOnly, StatusSuspended:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,17 @@
+package project
+
+// ImportReport is what importing a transfer archive did, or with DryRun what
+// it would do, table by table.
+type ImportReport struct {
+	ProjectID string
+	Digest    string
+	DryRun    bool
+	Tables    map[string]ImportedRows
+}
+
+// ImportedRows counts the rows of one table. Existing rows were already in
+// the destination, importing the same archive twice only finds those.
+type ImportedRows struct {
+	Inserted int64
+	Existing int64
+}
@@ -4,6 +4,8 @@ type CustomConfig struct {
 	ProviderID      string
 	JWK             string
 	PEM             string
+	Secret          string
+	Keys            []*PEMKey
 	CookieFieldName *string
 	KeyType         KeyType
 }
@@ -15,4 +17,5 @@ const (
 	KeyTypeRSA
 	KeyTypeECDSA
 	KeyTypeEd25519
+	KeyTypeHMAC
 )
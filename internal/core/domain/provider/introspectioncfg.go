@@ -0,0 +1,15 @@
+package provider
+
+// DefaultUserIDClaim is the introspection response member used as the
+// external user ID when a provider doesn't configure one.
+const DefaultUserIDClaim = "sub"
+
+// IntrospectionConfig configures an RFC 7662 token introspection provider
+// for issuers that hand out opaque access tokens.
+type IntrospectionConfig struct {
+	ProviderID   string
+	Endpoint     string
+	ClientID     string
+	ClientSecret string
+	UserIDClaim  string
+}
@@ -0,0 +1,41 @@
+package provider
+
+import "time"
+
+// PEMKey is one of the public keys a custom provider accepts. Issuers that
+// rotate their signing keys can register the next key ahead of time and
+// retire the old one once every token it signed has expired.
+type PEMKey struct {
+	ID         string
+	ProviderID string
+	KID        string
+	PEM        string
+	KeyType    KeyType
+	NotBefore  *time.Time
+	NotAfter   *time.Time
+}
+
+// LegacyPEMKeyID identifies the single PEM a custom provider was set up with
+// before it could hold several keys, so it can be retired like the others.
+const LegacyPEMKeyID = "legacy"
+
+// ActiveAt reports whether the key can be used to verify tokens at t.
+func (k *PEMKey) ActiveAt(t time.Time) bool {
+	if k.NotBefore != nil && t.Before(*k.NotBefore) {
+		return false
+	}
+	if k.NotAfter != nil && t.After(*k.NotAfter) {
+		return false
+	}
+	return true
+}
+
+// PEMKeys returns the keys the provider accepts, the legacy single PEM first
+// under LegacyPEMKeyID.
+func (c *CustomConfig) PEMKeys() []*PEMKey {
+	var keys []*PEMKey
+	if c.PEM != "" && c.KeyType != KeyTypeUnknown && c.KeyType != KeyTypeHMAC {
+		keys = append(keys, &PEMKey{ID: LegacyPEMKeyID, ProviderID: c.ProviderID, PEM: c.PEM, KeyType: c.KeyType})
+	}
+	return append(keys, c.Keys...)
+}
@@ -6,6 +6,7 @@ const (
 	TypeUnknown Type = iota
 	TypeOpenfort
 	TypeCustom
+	TypeIntrospection
 )
 
 func (t Type) String() string {
@@ -14,6 +15,8 @@ func (t Type) String() string {
 		return "OPENFORT"
 	case TypeCustom:
 		return "CUSTOM"
+	case TypeIntrospection:
+		return "INTROSPECTION"
 	default:
 		return "UNKNOWN"
 	}
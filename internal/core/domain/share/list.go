@@ -0,0 +1,10 @@
+package share
+
+// ListFilter narrows a keychain's share listing, zero fields don't filter.
+type ListFilter struct {
+	// ReferencePrefix keeps shares whose reference starts with it.
+	ReferencePrefix string
+	Entropy         Entropy
+	// Metadata keeps shares whose metadata holds every entry of it.
+	Metadata Metadata
+}
@@ -0,0 +1,21 @@
+package share
+
+// Metadata holds the labels a client attaches to a share, such as the wallet,
+// chain or device it belongs to. Shield never interprets them.
+type Metadata map[string]string
+
+const (
+	MaxMetadataEntries     = 16
+	MaxMetadataKeyLength   = 64
+	MaxMetadataValueLength = 256
+)
+
+// Matches reports whether the metadata holds every entry of the filter.
+func (m Metadata) Matches(filter Metadata) bool {
+	for k, v := range filter {
+		if value, ok := m[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
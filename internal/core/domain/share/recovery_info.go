@@ -4,4 +4,5 @@ type RecoveryInfo struct {
 	Entropy    Entropy
 	PasskeyID  *string
 	PasskeyEnv *string
+	Metadata   Metadata
 }
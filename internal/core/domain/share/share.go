@@ -1,5 +1,7 @@
 package share
 
+import "time"
+
 type Share struct {
 	ID                   string
 	Secret               string
@@ -10,6 +12,12 @@ type Share struct {
 	ShareStorageMethodID StorageMethodID
 	EncryptionParameters *EncryptionParameters
 	PasskeyReference     *PasskeyReference
+	Metadata             Metadata
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+	// Version is incremented by every change to the share, updates only
+	// apply to the version they were read at.
+	Version int64
 }
 
 func (s *Share) RequiresEncryption() bool {
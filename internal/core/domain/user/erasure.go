@@ -0,0 +1,33 @@
+package user
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// Export is everything Shield holds about one external user of a project,
+// across every provider that links to it. Tables are laid out as in a
+// project archive, one entry per table with its rows as column to value maps.
+type Export struct {
+	ProjectID      string
+	ExternalUserID string
+	ExportedAt     time.Time
+	Tables         map[string][]map[string]any
+}
+
+// ErasureReceipt is recorded in the audit trail when an external user's data
+// is erased. It only keeps a hash of the external user ID, the audit trail
+// mustn't become the one place the erased user can still be found.
+type ErasureReceipt struct {
+	ID                 string
+	ProjectID          string
+	ExternalUserIDHash string
+	DeletedRows        map[string]int64
+	ErasedAt           time.Time
+}
+
+func HashExternalUserID(externalUserID string) string {
+	sum := sha256.Sum256([]byte(externalUserID))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,24 @@
+package user
+
+import (
+	"time"
+
+	"github.com/openfort-xyz/shield/internal/core/domain/share"
+)
+
+// ListFilter narrows a project's user listing, zero fields don't filter.
+type ListFilter struct {
+	// ProviderID keeps users with an external user of the provider.
+	ProviderID string
+	// Entropy keeps users with at least one share of the entropy.
+	Entropy       share.Entropy
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
+
+// Cursor points at the last user of a page, users are listed by creation
+// time and then ID.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+}
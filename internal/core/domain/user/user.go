@@ -1,6 +1,9 @@
 package user
 
+import "time"
+
 type User struct {
 	ID        string
 	ProjectID string
+	CreatedAt time.Time
 }
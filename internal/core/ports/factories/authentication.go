@@ -2,13 +2,15 @@ package factories
 
 import (
 	"context"
+	"crypto/x509"
 
 	"github.com/openfort-xyz/shield/internal/core/domain/authentication"
 	"github.com/openfort-xyz/shield/internal/core/domain/project"
 )
 
 type AuthenticationFactory interface {
-	CreateProjectAuthenticator(apiKey, apiSecret string) Authenticator
+	CreateProjectAuthenticator(apiKey, apiSecret string, signature *authentication.RequestSignature) Authenticator
+	CreateCertificateAuthenticator(apiKey, apiSecret string, signature *authentication.RequestSignature, chain []*x509.Certificate) Authenticator
 	CreateUserAuthenticator(proj *project.Project, token string, identityFactory Identity) Authenticator
 }
 
@@ -7,6 +7,7 @@ import (
 type IdentityFactory interface {
 	CreateCustomIdentity(ctx context.Context, projectID string) (Identity, error)
 	CreateOpenfortIdentity(ctx context.Context, projectID string, authenticationProvider, tokenType *string) (Identity, error)
+	CreateIntrospectionIdentity(ctx context.Context, projectID string) (Identity, error)
 }
 
 type Identity interface {
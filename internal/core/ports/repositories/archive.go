@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/openfort-xyz/shield/internal/core/domain/project"
+	"github.com/openfort-xyz/shield/internal/core/domain/user"
+)
+
+// ArchiveRepository works across every table that holds a project's data.
+type ArchiveRepository interface {
+	ExportProject(ctx context.Context, projectID string) (*project.Archive, error)
+	// DeleteProject fails with ErrProjectChangedSinceExport unless the
+	// project's data still matches archiveDigest. The receipt is written to
+	// the audit trail in the same transaction as the deletion.
+	DeleteProject(ctx context.Context, projectID string, mode project.DeletionMode, archiveDigest string) (*project.DeletionReceipt, error)
+	ExportUser(ctx context.Context, projectID, externalUserID string) (*user.Export, error)
+	// EraseUser removes the external user's rows across every provider of the
+	// project in one transaction and records the receipt with them.
+	EraseUser(ctx context.Context, projectID, externalUserID string) (*user.ErasureReceipt, error)
+	// ExportProjectForTransfer is ExportProject with the columns encrypted
+	// with a key of this deployment decrypted instead of left out.
+	ExportProjectForTransfer(ctx context.Context, projectID string) (*project.Archive, error)
+	// ImportProject writes the rows of a transfer archive in one transaction,
+	// encrypting the secret columns with this deployment's keys. Rows that
+	// are already there are left as they are. With dryRun the transaction is
+	// rolled back once the report is complete.
+	ImportProject(ctx context.Context, archive *project.Archive, dryRun bool) (*project.ImportReport, error)
+}
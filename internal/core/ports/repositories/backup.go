@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/openfort-xyz/shield/internal/core/domain/backup"
+)
+
+// BackupRepository reads and writes every Shield table at once.
+type BackupRepository interface {
+	// Snapshot reads every table in one consistent snapshot. The snapshot is
+	// handed to begin before its rows are handed to emit, table by table in
+	// the snapshot's order, each row as a JSON object of its columns.
+	Snapshot(ctx context.Context, begin func(*backup.Snapshot) error, emit func(table string, row json.RawMessage) error) error
+	// Restore writes the rows returned by next, in the snapshot's table
+	// order, into a database that holds no data yet, in one transaction.
+	// next returns io.EOF after the last row. The rows written are counted
+	// by table.
+	Restore(ctx context.Context, snapshot *backup.Snapshot, next func() (table string, row json.RawMessage, err error)) (map[string]int64, error)
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/openfort-xyz/shield/internal/core/domain/idempotency"
+)
+
+type IdempotencyRepository interface {
+	// Claim records the key of rec as in flight until rec.ExpiresAt. When the
+	// key was claimed before and hasn't expired, the existing record is
+	// returned and rec isn't stored, otherwise it returns nil.
+	Claim(ctx context.Context, rec *idempotency.Record) (*idempotency.Record, error)
+	// Complete stores the response of a claimed key and keeps it until
+	// rec.ExpiresAt.
+	Complete(ctx context.Context, rec *idempotency.Record) error
+	// Release drops a claim so the request can be sent again with its key.
+	Release(ctx context.Context, keyHash string) error
+	// DeleteExpired removes the records past their expiry.
+	DeleteExpired(ctx context.Context) error
+}
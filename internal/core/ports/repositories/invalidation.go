@@ -0,0 +1,14 @@
+package repositories
+
+import "context"
+
+// InvalidationRepository fans cache invalidations out to every replica.
+type InvalidationRepository interface {
+	// Publish announces key changed on channel. Subscribers in this process
+	// are called before it returns.
+	Publish(ctx context.Context, channel, key string) error
+	// Subscribe calls fn for every key published on channel, by any replica.
+	// fn is also called with an empty key after the replica may have missed
+	// messages, subscribers should drop everything they cached then.
+	Subscribe(channel string, fn func(key string))
+}
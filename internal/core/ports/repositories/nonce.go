@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+	"time"
+)
+
+type NonceRepository interface {
+	// Claim records key as used for ttl, it fails with ErrNonceAlreadyUsed
+	// when the key was claimed before and hasn't expired yet.
+	Claim(ctx context.Context, key string, ttl time.Duration) error
+}
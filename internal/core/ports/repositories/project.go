@@ -11,14 +11,37 @@ type ProjectRepository interface {
 	SaveProjectRateLimits(ctx context.Context, rateLimits *project.RateLimit) error
 	Get(ctx context.Context, projectID string) (*project.Project, error)
 	GetWithRateLimit(ctx context.Context, projectID string) (*project.WithRateLimit, error)
+	// GetByAPIKey only finds projects that aren't disabled.
 	GetByAPIKey(ctx context.Context, apiKey string) (*project.Project, error)
 	Delete(ctx context.Context, projectID string) error
+	List(ctx context.Context) ([]*project.Project, error)
+	Disable(ctx context.Context, projectID string) error
 
 	GetEncryptionPart(ctx context.Context, projectID string) (string, error)
 	SetEncryptionPart(ctx context.Context, projectID, part string) error
 
 	UpdateAPISecret(ctx context.Context, projectID, encryptedSecret string) error
 	Update2FA(ctx context.Context, projectID string, enable2FA bool) error
+	UpdateClientCertMode(ctx context.Context, projectID string, mode project.ClientCertMode) error
+	UpdateRequireSignedRequests(ctx context.Context, projectID string, required bool) error
+	UpdateStatus(ctx context.Context, projectID string, status project.Status) error
+
+	SetSigningKey(ctx context.Context, projectID, signingKey string) error
+	GetSigningKey(ctx context.Context, projectID string) (string, error)
+
+	SaveDeletionRequest(ctx context.Context, req *project.DeletionRequest) error
+	GetDeletionRequest(ctx context.Context, projectID string) (*project.DeletionRequest, error)
+
+	ListClientCertificates(ctx context.Context, projectID string) ([]*project.ClientCertificate, error)
+	AddClientCertificate(ctx context.Context, cert *project.ClientCertificate) error
+	DeleteClientCertificate(ctx context.Context, projectID, certificateID string) error
+
+	CreateInvite(ctx context.Context, invite *project.Invite) error
+	// ConsumeInvite marks the invite used, it fails with ErrInviteNotFound
+	// when there is no unused invite with that hash left to consume.
+	ConsumeInvite(ctx context.Context, tokenHash string) error
+	// ReleaseInvite makes a consumed invite usable again.
+	ReleaseInvite(ctx context.Context, tokenHash string) error
 
 	CreateMigration(ctx context.Context, projectID string, success bool) error
 	HasSuccessfulMigration(ctx context.Context, projectID string) (bool, error)
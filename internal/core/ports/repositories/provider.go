@@ -16,8 +16,14 @@ type ProviderRepository interface {
 	CreateCustom(ctx context.Context, provider *provider.CustomConfig) error
 	GetCustom(ctx context.Context, providerID string) (*provider.CustomConfig, error)
 	UpdateCustom(ctx context.Context, provider *provider.CustomConfig) error
+	AddCustomKey(ctx context.Context, key *provider.PEMKey) error
+	DeleteCustomKey(ctx context.Context, providerID, keyID string) error
 
 	CreateOpenfort(ctx context.Context, provider *provider.OpenfortConfig) error
 	GetOpenfort(ctx context.Context, providerID string) (*provider.OpenfortConfig, error)
 	UpdateOpenfort(ctx context.Context, provider *provider.OpenfortConfig) error
+
+	CreateIntrospection(ctx context.Context, provider *provider.IntrospectionConfig) error
+	GetIntrospection(ctx context.Context, providerID string) (*provider.IntrospectionConfig, error)
+	UpdateIntrospection(ctx context.Context, provider *provider.IntrospectionConfig) error
 }
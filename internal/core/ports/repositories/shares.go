@@ -15,9 +15,27 @@ type ShareRepository interface {
 	GetByReferenceAndProjectID(ctx context.Context, reference, projectID string) (*share.Share, error)
 	Delete(ctx context.Context, shareID string) error
 	ListByKeychainID(ctx context.Context, keychainID string) ([]*share.Share, error)
+	// ListPageByKeychainID returns up to limit shares of the keychain that
+	// match the filter, ordered by ID and starting after afterID. A limit of
+	// zero returns every share after afterID.
+	ListPageByKeychainID(ctx context.Context, keychainID string, filter *share.ListFilter, afterID string, limit int) ([]*share.Share, error)
 	ListProjectIDAndEntropy(ctx context.Context, projectID string, entropy share.Entropy) ([]*share.Share, error)
+	// ListByProject returns up to limit shares of the project ordered by ID,
+	// starting after afterID. An empty afterID starts from the first share.
+	ListByProject(ctx context.Context, projectID string, afterID string, limit int) ([]*share.Share, error)
+	// UpdateReference renames the reference of a share in the keychain. It
+	// fails with ErrShareAlreadyExists when another share already goes by
+	// newReference.
+	UpdateReference(ctx context.Context, keychainID, reference, newReference string) error
+	// UpdateKeychain moves the share to the keychain, and to the user who
+	// owns it.
+	UpdateKeychain(ctx context.Context, shareID, keychainID, userID string) error
 	UpdateProjectEncryption(ctx context.Context, shareID string, encrypted string) error
+	// Update saves the share when it's still at the version it was read at,
+	// and sets shr.Version to the next one. It fails with
+	// ErrShareVersionMismatch when the share changed in between.
 	Update(ctx context.Context, shr *share.Share) error
+	// BulkUpdate updates the shares like Update, all of them or none.
 	BulkUpdate(ctx context.Context, shrs []*share.Share) error
 	GetShareStorageMethods(ctx context.Context) ([]*share.StorageMethod, error)
 	GetSharesEncryptionForProjectAndReferences(ctx context.Context, projectID string, references []string) (map[string]share.RecoveryInfo, error)
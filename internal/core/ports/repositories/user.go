@@ -19,6 +19,9 @@ type UserRepository interface {
 
 	GetUserIDsByExternalID(ctx context.Context, externalUserID string) ([]string, error)
 	FindUserByExternalID(ctx context.Context, externalUserID, providerID string) (*user.User, error)
+	// ListByProject returns up to limit users of the project created after
+	// the cursor, a nil cursor starts from the first user.
+	ListByProject(ctx context.Context, projectID string, filter *user.ListFilter, after *user.Cursor, limit int) ([]*user.User, error)
 }
 
 type Option func(Options)
@@ -0,0 +1,84 @@
+package projectsvc
+
+import (
+	"time"
+
+	"github.com/openfort-xyz/shield/internal/core/domain/project"
+	"github.com/openfort-xyz/shield/internal/core/ports/repositories"
+	"github.com/openfort-xyz/shield/pkg/lru"
+)
+
+// cache keeps projects looked up by API key, so that authenticating end users
+// doesn't load the project on every request.
+//
+// Entries are dropped as soon as the project changes on any replica, see
+// project.InvalidationChannel.
+type cache struct {
+	ttl      time.Duration
+	projects *lru.Cache[string, *project.Project]
+	now      func() time.Time
+}
+
+// newCache returns nil when the cache is disabled, every method treats a nil
+// cache as always empty. Without invalidations entries only expire.
+func newCache(cfg *Config, invalidations repositories.InvalidationRepository) *cache {
+	if cfg == nil || cfg.CacheSize <= 0 || cfg.CacheTTL <= 0 {
+		return nil
+	}
+
+	c := &cache{
+		ttl:      cfg.CacheTTL,
+		projects: lru.New[string, *project.Project](cfg.CacheSize),
+		now:      time.Now,
+	}
+
+	if invalidations != nil {
+		invalidations.Subscribe(project.InvalidationChannel, func(projectID string) {
+			if projectID == "" {
+				c.projects.Purge()
+				return
+			}
+			c.projects.Invalidate(projectID)
+		})
+	}
+
+	return c
+}
+
+func (c *cache) get(apiKey string) (*project.Project, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	proj, ok := c.projects.Get(apiKey, c.now())
+	if !ok {
+		cacheLookups.WithLabelValues("miss").Inc()
+		return nil, false
+	}
+
+	cacheLookups.WithLabelValues("hit").Inc()
+	return proj, true
+}
+
+// snapshot is taken before the project is loaded and handed back to set, so
+// a load that raced with an invalidation isn't cached.
+func (c *cache) snapshot() uint64 {
+	if c == nil {
+		return 0
+	}
+	return c.projects.Generation()
+}
+
+func (c *cache) set(apiKey string, proj *project.Project, snapshot uint64) {
+	if c == nil {
+		return
+	}
+	c.projects.SetIfGeneration(snapshot, apiKey, proj, proj.ID, c.now().Add(c.ttl))
+}
+
+func (c *cache) len() int {
+	if c == nil {
+		return 0
+	}
+	return c.projects.Len()
+}
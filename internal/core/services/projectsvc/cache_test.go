@@ -0,0 +1,122 @@
+package projectsvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/mocks/invalidationmockrepo"
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/mocks/projectmockrepo"
+	"github.com/openfort-xyz/shield/internal/core/domain/project"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestCache(t *testing.T, size int) (*cache, func(string), *time.Time) {
+	t.Helper()
+
+	var notify func(string)
+	invalidationRepo := new(invalidationmockrepo.MockInvalidationRepository)
+	invalidationRepo.On("Subscribe", project.InvalidationChannel, mock.Anything).Run(func(args mock.Arguments) {
+		notify = args.Get(1).(func(string))
+	})
+
+	c := newCache(&Config{CacheTTL: time.Minute, CacheSize: size}, invalidationRepo)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	return c, notify, &now
+}
+
+func TestCache(t *testing.T) {
+	t.Run("hit until expiry", func(t *testing.T) {
+		c, _, now := newTestCache(t, 10)
+		c.set("key", &project.Project{ID: "project_id"}, c.snapshot())
+
+		proj, ok := c.get("key")
+		assert.True(t, ok)
+		assert.Equal(t, "project_id", proj.ID)
+
+		*now = now.Add(time.Minute)
+		_, ok = c.get("key")
+		assert.False(t, ok)
+		assert.Equal(t, 0, c.len())
+	})
+
+	t.Run("invalidation drops the project's entries", func(t *testing.T) {
+		c, notify, _ := newTestCache(t, 10)
+		c.set("key", &project.Project{ID: "project_id"}, c.snapshot())
+		c.set("other_key", &project.Project{ID: "other_project_id"}, c.snapshot())
+
+		notify("project_id")
+		_, ok := c.get("key")
+		assert.False(t, ok)
+		_, ok = c.get("other_key")
+		assert.True(t, ok)
+
+		notify("")
+		assert.Equal(t, 0, c.len())
+	})
+
+	t.Run("load racing an invalidation isn't cached", func(t *testing.T) {
+		c, notify, _ := newTestCache(t, 10)
+		snapshot := c.snapshot()
+		notify("project_id")
+		c.set("key", &project.Project{ID: "project_id"}, snapshot)
+
+		_, ok := c.get("key")
+		assert.False(t, ok)
+	})
+
+	t.Run("least recently used is evicted", func(t *testing.T) {
+		c, _, _ := newTestCache(t, 2)
+		c.set("a", &project.Project{ID: "project_a"}, c.snapshot())
+		c.set("b", &project.Project{ID: "project_b"}, c.snapshot())
+		c.get("a")
+		c.set("c", &project.Project{ID: "project_c"}, c.snapshot())
+
+		_, ok := c.get("b")
+		assert.False(t, ok)
+		_, ok = c.get("a")
+		assert.True(t, ok)
+		assert.Equal(t, 2, c.len())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.Nil(t, newCache(&Config{CacheTTL: time.Minute, CacheSize: 0}, nil))
+		assert.Nil(t, newCache(nil, nil))
+
+		var c *cache
+		c.set("key", &project.Project{ID: "project_id"}, c.snapshot())
+		_, ok := c.get("key")
+		assert.False(t, ok)
+	})
+}
+
+func TestService_GetByAPIKeyInvalidation(t *testing.T) {
+	ctx := context.Background()
+
+	var notify func(string)
+	invalidationRepo := new(invalidationmockrepo.MockInvalidationRepository)
+	invalidationRepo.On("Subscribe", project.InvalidationChannel, mock.Anything).Run(func(args mock.Arguments) {
+		notify = args.Get(1).(func(string))
+	})
+
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+	projectRepo.On("GetByAPIKey", mock.Anything, "api_key").Return(&project.Project{ID: "project_id", Status: project.StatusActive}, nil).Once()
+	projectRepo.On("GetByAPIKey", mock.Anything, "api_key").Return(&project.Project{ID: "project_id", Status: project.StatusSuspended}, nil).Once()
+
+	svc := New(projectRepo, &Config{CacheTTL: time.Minute, CacheSize: 10}, invalidationRepo)
+
+	for range 3 {
+		proj, err := svc.GetByAPIKey(ctx, "api_key")
+		assert.NoError(t, err)
+		assert.Equal(t, project.StatusActive, proj.Status)
+	}
+	projectRepo.AssertNumberOfCalls(t, "GetByAPIKey", 1)
+
+	notify("project_id")
+	proj, err := svc.GetByAPIKey(ctx, "api_key")
+	assert.NoError(t, err)
+	assert.Equal(t, project.StatusSuspended, proj.Status)
+	projectRepo.AssertNumberOfCalls(t, "GetByAPIKey", 2)
+}
@@ -0,0 +1,26 @@
+package projectsvc
+
+import (
+	"time"
+
+	env "github.com/caarlos0/env/v10"
+)
+
+type Config struct {
+	// CacheTTL is how long a project looked up by API key is served from
+	// memory. Changes to the project are pushed to every replica, the TTL
+	// bounds how stale a replica can be when that push is lost. Zero disables
+	// the cache.
+	CacheTTL time.Duration `env:"PROJECT_CACHE_TTL" envDefault:"60s"`
+	// CacheSize is the maximum number of projects cached, the least recently
+	// used one is evicted once it's reached.
+	CacheSize int `env:"PROJECT_CACHE_SIZE" envDefault:"10000"`
+}
+
+func GetConfigFromEnv() (*Config, error) {
+	cfg := &Config{}
+	if err := env.Parse(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
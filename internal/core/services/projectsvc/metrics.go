@@ -0,0 +1,11 @@
+package projectsvc
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var cacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "shield_project_cache_lookups_total",
+	Help: "Project cache lookups by API key, by result (hit or miss).",
+}, []string{"result"})
@@ -6,8 +6,6 @@ import (
 	"encoding/hex"
 	"errors"
 	"log/slog"
-	"sync"
-	"time"
 
 	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
 
@@ -19,29 +17,24 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
-type cacheEntry struct {
-	project   *project.Project
-	expiresAt time.Time
-}
-
 type service struct {
-	repo     repositories.ProjectRepository
-	logger   *slog.Logger
-	cost     int
-	cache    map[string]*cacheEntry
-	cacheMu  sync.RWMutex
-	cacheTTL time.Duration
+	repo   repositories.ProjectRepository
+	logger *slog.Logger
+	cost   int
+	cache  *cache
 }
 
 var _ services.ProjectService = (*service)(nil)
 
-func New(repo repositories.ProjectRepository, cacheTTL time.Duration) services.ProjectService {
+// New caches the projects looked up by API key as configured by cfg, a nil
+// cfg disables the cache. Cached projects are dropped when an invalidation
+// for them is received from invalidations, which can be nil in tests.
+func New(repo repositories.ProjectRepository, cfg *Config, invalidations repositories.InvalidationRepository) services.ProjectService {
 	return &service{
-		repo:     repo,
-		logger:   logger.New("project_service"),
-		cost:     bcrypt.DefaultCost,
-		cache:    make(map[string]*cacheEntry),
-		cacheTTL: cacheTTL,
+		repo:   repo,
+		logger: logger.New("project_service"),
+		cost:   bcrypt.DefaultCost,
+		cache:  newCache(cfg, invalidations),
 	}
 }
 
@@ -79,12 +72,10 @@ func (s *service) Create(ctx context.Context, name string, enable2fa bool) (*pro
 func (s *service) GetByAPIKey(ctx context.Context, apiKey string) (*project.Project, error) {
 	s.logger.InfoContext(ctx, "getting project by api key")
 
-	s.cacheMu.RLock()
-	if entry, ok := s.cache[apiKey]; ok && time.Now().Before(entry.expiresAt) {
-		s.cacheMu.RUnlock()
-		return entry.project, nil
+	if proj, ok := s.cache.get(apiKey); ok {
+		return proj, nil
 	}
-	s.cacheMu.RUnlock()
+	snapshot := s.cache.snapshot()
 
 	proj, err := s.repo.GetByAPIKey(ctx, apiKey)
 	if err != nil {
@@ -92,13 +83,7 @@ func (s *service) GetByAPIKey(ctx context.Context, apiKey string) (*project.Proj
 		return nil, err
 	}
 
-	s.cacheMu.Lock()
-	s.cache[apiKey] = &cacheEntry{
-		project:   proj,
-		expiresAt: time.Now().Add(s.cacheTTL),
-	}
-	s.cacheMu.Unlock()
-
+	s.cache.set(apiKey, proj, snapshot)
 	return proj, nil
 }
 
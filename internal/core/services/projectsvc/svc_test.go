@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"testing"
-	"time"
 
 	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
 
@@ -14,7 +13,7 @@ import (
 
 func TestService_Create(t *testing.T) {
 	mockRepo := new(projectmockrepo.MockProjectRepository)
-	svc := New(mockRepo, 60*time.Second)
+	svc := New(mockRepo, nil, nil)
 	ctx := context.Background()
 	testName := "test-project"
 
@@ -65,7 +64,7 @@ func TestService_Create(t *testing.T) {
 
 func TestService_SetEncryptionPart(t *testing.T) {
 	mockRepo := new(projectmockrepo.MockProjectRepository)
-	svc := New(mockRepo, 60*time.Second)
+	svc := New(mockRepo, nil, nil)
 	ctx := context.Background()
 	testProjectID := "test-project-id"
 	testPart := "test-part"
@@ -33,6 +33,8 @@ func (s *service) Configure(ctx context.Context, prov *provider.Provider) error
 		return s.configureCustomProvider(ctx, prov)
 	case provider.TypeOpenfort:
 		return s.configureOpenfortProvider(ctx, prov)
+	case provider.TypeIntrospection:
+		return s.configureIntrospectionProvider(ctx, prov)
 	default:
 		return domainErrors.ErrUnknownProviderType
 	}
@@ -99,3 +101,34 @@ func (s *service) configureOpenfortProvider(ctx context.Context, prov *provider.
 
 	return nil
 }
+
+func (s *service) configureIntrospectionProvider(ctx context.Context, prov *provider.Provider) error {
+	s.logger.InfoContext(ctx, "configuring introspection provider", slog.String("project_id", prov.ProjectID))
+
+	err := s.repo.Create(ctx, prov)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to create provider", logger.Error(err))
+		return err
+	}
+
+	introspectionAuth, ok := prov.Config.(*provider.IntrospectionConfig)
+	if !ok {
+		s.logger.ErrorContext(ctx, "invalid introspection provider config")
+		return domainErrors.ErrInvalidProviderConfig
+	}
+
+	introspectionAuth.ProviderID = prov.ID
+
+	err = s.repo.CreateIntrospection(ctx, introspectionAuth)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to create introspection provider", logger.Error(err))
+		errD := s.repo.Delete(ctx, prov.ID)
+		if errD != nil {
+			s.logger.ErrorContext(ctx, "failed to delete provider", slog.String("provider", prov.ID), logger.Error(errD))
+			err = errors.Join(err, errD)
+		}
+		return err
+	}
+
+	return nil
+}
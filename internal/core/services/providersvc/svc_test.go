@@ -36,6 +36,14 @@ func TestConfigureProvider(t *testing.T) {
 		},
 	}
 
+	introspectionProvider := &provider.Provider{
+		ProjectID: projectID,
+		Type:      provider.TypeIntrospection,
+		Config: &provider.IntrospectionConfig{
+			Endpoint: "https://issuer.example/introspect",
+		},
+	}
+
 	unknownProvider := &provider.Provider{
 		ProjectID: projectID,
 		Type:      provider.TypeUnknown,
@@ -80,6 +88,26 @@ func TestConfigureProvider(t *testing.T) {
 				mockRepo.On("CreateOpenfort", mock.Anything, mock.AnythingOfType("*provider.OpenfortConfig")).Return(nil)
 			},
 		},
+		{
+			name:     "configure introspection provider success",
+			provider: introspectionProvider,
+			mock: func() {
+				mockRepo.ExpectedCalls = nil
+				mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*provider.Provider")).Return(nil)
+				mockRepo.On("CreateIntrospection", mock.Anything, mock.AnythingOfType("*provider.IntrospectionConfig")).Return(nil)
+			},
+		},
+		{
+			name:     "failed to create introspection provider config and provider is deleted successfully",
+			provider: introspectionProvider,
+			wantErr:  true,
+			mock: func() {
+				mockRepo.ExpectedCalls = nil
+				mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*provider.Provider")).Return(nil)
+				mockRepo.On("CreateIntrospection", mock.Anything, mock.AnythingOfType("*provider.IntrospectionConfig")).Return(errors.New("repository error"))
+				mockRepo.On("Delete", mock.Anything, mock.AnythingOfType("string")).Return(nil)
+			},
+		},
 		{
 			name:     "invalid provider type",
 			provider: unknownProvider,
@@ -0,0 +1,424 @@
+// Package client is a Go client for the Shield REST API.
+//
+// Project endpoints authenticate with the project's API key and either its
+// API secret or its request signing key:
+//
+//	c, err := client.New("https://shield.openfort.io",
+//		client.WithProjectCredentials(apiKey, apiSecret))
+//	project, err := c.GetProject(ctx)
+//
+// Share and keychain endpoints act on behalf of a user:
+//
+//	share, err := c.ForUser(client.UserCredentials{Token: token, Provider: client.AuthProviderOpenfort}).GetShare(ctx, client.EncryptionOptions{})
+//
+// Errors answered by the API are *Error values that match the sentinel
+// errors of their code with errors.Is.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openfort-xyz/shield/pkg/random"
+	"github.com/openfort-xyz/shield/pkg/signing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const (
+	apiKeyHeader            = "X-API-Key"
+	apiSecretHeader         = "X-API-Secret"
+	authProviderHeader      = "X-Auth-Provider"
+	openfortProviderHeader  = "X-Openfort-Provider"
+	openfortTokenTypeHeader = "X-Openfort-Token-Type"
+	encryptionPartHeader    = "X-Encryption-Part"
+	encryptionSessionHeader = "X-Encryption-Session"
+	transferKeyHeader       = "X-Transfer-Key"
+	userIDHeader            = "X-User-ID"
+	operatorKeyHeader       = "X-Operator-Key"
+	inviteTokenHeader       = "X-Invite-Token"
+	requestIDHeader         = "X-Request-ID"
+	etagHeader              = "ETag"
+	ifMatchHeader           = "If-Match"
+	idempotencyKeyHeader    = "Idempotency-Key"
+	retryAfterHeader        = "Retry-After"
+
+	jsonContentType    = "application/json"
+	problemContentType = "application/problem+json"
+	ndjsonContentType  = "application/x-ndjson"
+
+	defaultMaxAttempts = 3
+	defaultBackoff     = 200 * time.Millisecond
+	maxBackoff         = 5 * time.Second
+)
+
+// Client calls the Shield API. It's safe for concurrent use.
+type Client struct {
+	baseURL     *url.URL
+	httpClient  *http.Client
+	apiKey      string
+	apiSecret   string
+	signingKey  []byte
+	operatorKey string
+	maxAttempts int
+	backoff     time.Duration
+}
+
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client requests go through, e.g. one with a
+// client certificate for projects that authenticate with mTLS.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithProjectCredentials sets the API key and secret of the project. The
+// secret can be left empty when requests are signed or the HTTP client
+// presents a client certificate.
+func WithProjectCredentials(apiKey, apiSecret string) Option {
+	return func(c *Client) {
+		c.apiKey = apiKey
+		c.apiSecret = apiSecret
+	}
+}
+
+// WithSigningKey signs project requests with the project's signing key, the
+// API secret isn't sent when it's set.
+func WithSigningKey(key []byte) Option {
+	return func(c *Client) {
+		c.signingKey = key
+	}
+}
+
+// WithOperatorKey sets the key of the operator endpoints.
+func WithOperatorKey(key string) Option {
+	return func(c *Client) {
+		c.operatorKey = key
+	}
+}
+
+// WithRetries sets how many times a request is attempted, 1 disables retries,
+// and the backoff before the first retry, doubled on each of the next ones.
+func WithRetries(maxAttempts int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxAttempts = maxAttempts
+		c.backoff = backoff
+	}
+}
+
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid base URL %q, expected an absolute URL", baseURL)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+
+	c := &Client{
+		baseURL:     u,
+		httpClient:  http.DefaultClient,
+		maxAttempts: defaultMaxAttempts,
+		backoff:     defaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.maxAttempts < 1 {
+		c.maxAttempts = 1
+	}
+
+	return c, nil
+}
+
+type requestIDKey struct{}
+
+type idempotencyKeyKey struct{}
+
+// WithRequestID sets the request ID sent with the requests made with ctx,
+// otherwise each call gets its own. Retries of a call share its request ID.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// WithIdempotencyKey sets the Idempotency-Key sent with the POST, PUT and
+// DELETE requests made with ctx, otherwise each call gets its own. Set it to
+// retry a call beyond the client's own retries, e.g. after a restart; the
+// server replays the response of the first call for 24 hours.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyKey{}, key)
+}
+
+// auth is how a request authenticates.
+type auth func(c *Client, req *http.Request, body []byte) error
+
+func projectAuth(c *Client, req *http.Request, body []byte) error {
+	req.Header.Set(apiKeyHeader, c.apiKey)
+	if len(c.signingKey) == 0 {
+		if c.apiSecret != "" {
+			req.Header.Set(apiSecretHeader, c.apiSecret)
+		}
+		return nil
+	}
+
+	nonce, err := random.UUIDv7()
+	if err != nil {
+		return err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set(signing.TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(signing.NonceHeader, nonce)
+	req.Header.Set(signing.SignatureHeader, signing.Sign(c.signingKey, req.Method, req.URL.RequestURI(), signing.BodyHash(body), timestamp, nonce))
+	return nil
+}
+
+func operatorAuth(c *Client, req *http.Request, _ []byte) error {
+	req.Header.Set(operatorKeyHeader, c.operatorKey)
+	return nil
+}
+
+func noAuth(*Client, *http.Request, []byte) error {
+	return nil
+}
+
+type request struct {
+	method string
+	// path is escaped, segments taken from arguments go through url.PathEscape.
+	path   string
+	query  url.Values
+	header http.Header
+	auth   auth
+	// body is marshaled to JSON, unless it's already encoded in rawBody.
+	body        any
+	rawBody     []byte
+	contentType string
+	// out is unmarshaled from a successful response, unless the caller
+	// reads the body itself with stream.
+	out    any
+	stream func(io.Reader) error
+	// onHeader gets the headers of a successful response.
+	onHeader func(http.Header)
+	// once sends the request a single time, for the endpoints that don't
+	// take an idempotency key to make their retries safe.
+	once bool
+}
+
+// do sends the request, retrying it on transport failures and on the failed
+// responses worth retrying.
+func (c *Client) do(ctx context.Context, r *request) error {
+	body := r.rawBody
+	if r.body != nil {
+		var err error
+		body, err = json.Marshal(r.body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	if requestID == "" {
+		var err error
+		requestID, err = random.UUIDv7()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Mutating requests carry an idempotency key, the server answers their
+	// retries with the response of the first attempt it handled
+	var idempotencyKey string
+	if r.method != http.MethodGet && !r.once {
+		idempotencyKey, _ = ctx.Value(idempotencyKeyKey{}).(string)
+		if idempotencyKey == "" {
+			var err error
+			idempotencyKey, err = random.UUIDv7()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	maxAttempts := c.maxAttempts
+	if r.once {
+		maxAttempts = 1
+	}
+
+	backoff := c.backoff
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(ctx, r, body, requestID, idempotencyKey)
+		if err != nil {
+			if ctx.Err() != nil || attempt >= maxAttempts {
+				return err
+			}
+			if err := sleep(ctx, backoff); err != nil {
+				return err
+			}
+			backoff = min(2*backoff, maxBackoff)
+			continue
+		}
+
+		if resp.StatusCode < http.StatusBadRequest {
+			return c.readResponse(resp, r)
+		}
+
+		apiErr := readError(resp, requestID)
+		if attempt >= maxAttempts || !retryable(apiErr) {
+			return apiErr
+		}
+		wait := backoff
+		if after, err := strconv.Atoi(resp.Header.Get(retryAfterHeader)); err == nil && after >= 0 {
+			wait = time.Duration(after) * time.Second
+		}
+		// Waits longer than a backoff are left to the caller, e.g. the
+		// registration limit that spans an hour.
+		if wait > maxBackoff {
+			return apiErr
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return err
+		}
+		backoff = min(2*backoff, maxBackoff)
+	}
+}
+
+func (c *Client) send(ctx context.Context, r *request, body []byte, requestID, idempotencyKey string) (*http.Response, error) {
+	u := *c.baseURL
+	u.RawPath = c.baseURL.EscapedPath() + r.path
+	u.Path, _ = url.PathUnescape(u.RawPath)
+	u.RawQuery = r.query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, r.method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range r.header {
+		req.Header[key] = values
+	}
+	if body != nil {
+		contentType := r.contentType
+		if contentType == "" {
+			contentType = jsonContentType
+		}
+		req.Header.Set("Content-Type", contentType)
+	}
+	// Errors are answered as problem details, with their own code each
+	req.Header.Set("Accept", jsonContentType+", "+problemContentType)
+	req.Header.Set(requestIDHeader, requestID)
+	if idempotencyKey != "" {
+		req.Header.Set(idempotencyKeyHeader, idempotencyKey)
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	if err := r.auth(c, req, body); err != nil {
+		return nil, err
+	}
+
+	return c.httpClient.Do(req)
+}
+
+func (c *Client) readResponse(resp *http.Response, r *request) error {
+	defer resp.Body.Close()
+
+	if r.onHeader != nil {
+		r.onHeader(resp.Header)
+	}
+	if r.stream != nil {
+		return r.stream(resp.Body)
+	}
+	if r.out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(r.out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// problem is an error answered as problem details.
+type problem struct {
+	Title     string       `json:"title"`
+	Detail    string       `json:"detail"`
+	Code      string       `json:"code"`
+	RequestID string       `json:"request_id"`
+	Errors    []FieldError `json:"errors"`
+}
+
+// readError reads the error of a failed response. Responses that aren't API
+// errors, e.g. from a proxy, keep their status with the body as message.
+func readError(resp *http.Response, requestID string) *Error {
+	defer resp.Body.Close()
+
+	apiErr := &Error{StatusCode: resp.StatusCode, RequestID: requestID}
+	if id := resp.Header.Get(requestIDHeader); id != "" {
+		apiErr.RequestID = id
+	}
+
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType == problemContentType {
+		var p problem
+		if err := json.Unmarshal(raw, &p); err == nil && p.Code != "" {
+			apiErr.Code = p.Code
+			apiErr.Message = p.Title
+			if p.Detail != "" {
+				apiErr.Message = p.Detail
+			}
+			apiErr.Fields = p.Errors
+			if p.RequestID != "" {
+				apiErr.RequestID = p.RequestID
+			}
+			return apiErr
+		}
+	}
+	if err := json.Unmarshal(raw, apiErr); err != nil || apiErr.Message == "" {
+		apiErr.Message = strings.TrimSpace(string(raw))
+		if apiErr.Message == "" {
+			apiErr.Message = http.StatusText(resp.StatusCode)
+		}
+	}
+	return apiErr
+}
+
+// retryable reports whether a failed response is worth retrying: rate
+// limits, unavailability, gateway failures, and retries that arrived while the
+// first attempt was still being handled.
+func retryable(err *Error) bool {
+	switch err.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return true
+	case http.StatusConflict:
+		return errors.Is(err, ErrIdempotencyInProgress)
+	default:
+		return false
+	}
+}
+
+// sleep waits d with up to 20% jitter, or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d > 0 {
+		// gosec G404: jitter only spreads retries, it doesn't need a secure source.
+		d += time.Duration(rand.Int64N(int64(d)/5 + 1)) //nolint:gosec
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
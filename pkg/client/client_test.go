@@ -0,0 +1,454 @@
+package client
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest"
+	"github.com/openfort-xyz/shield/internal/adapters/handlers/rest/api"
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/mocks/idempotencymockrepo"
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/mocks/keychainmockrepo"
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/mocks/projectmockrepo"
+	"github.com/openfort-xyz/shield/internal/adapters/repositories/mocks/sharemockrepo"
+	"github.com/openfort-xyz/shield/internal/applications/projectapp"
+	"github.com/openfort-xyz/shield/internal/applications/shareapp"
+	"github.com/openfort-xyz/shield/internal/core/domain/authentication"
+	domainErrors "github.com/openfort-xyz/shield/internal/core/domain/errors"
+	"github.com/openfort-xyz/shield/internal/core/domain/keychain"
+	"github.com/openfort-xyz/shield/internal/core/domain/project"
+	"github.com/openfort-xyz/shield/internal/core/domain/share"
+	"github.com/openfort-xyz/shield/internal/core/ports/factories"
+	"github.com/openfort-xyz/shield/internal/core/services/sharesvc"
+	"github.com/openfort-xyz/shield/pkg/signing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	testAPIKey     = "api-key"
+	testAPISecret  = "api-secret"
+	testUserToken  = "user-token"
+	testProjectID  = "project-id"
+	testUserID     = "user-id"
+	testSigningKey = "signing-key"
+)
+
+type stubAuthenticationFactory struct{}
+
+func (stubAuthenticationFactory) CreateProjectAuthenticator(apiKey, apiSecret string, signature *authentication.RequestSignature) factories.Authenticator {
+	ok := apiKey == testAPIKey && apiSecret == testAPISecret
+	if signature != nil {
+		ok = apiKey == testAPIKey && signing.Verify([]byte(testSigningKey), signature.Signature, signature.Method, signature.RequestURI, signature.BodyHash, signature.Timestamp, signature.Nonce)
+	}
+	return stubAuthenticator{ok: ok}
+}
+
+func (stubAuthenticationFactory) CreateCertificateAuthenticator(_, _ string, _ *authentication.RequestSignature, _ []*x509.Certificate) factories.Authenticator {
+	return stubAuthenticator{}
+}
+
+func (stubAuthenticationFactory) CreateUserAuthenticator(_ *project.Project, token string, _ factories.Identity) factories.Authenticator {
+	return stubAuthenticator{ok: token == testUserToken}
+}
+
+type stubAuthenticator struct {
+	ok bool
+}
+
+func (a stubAuthenticator) Authenticate(_ context.Context) (*authentication.Authentication, error) {
+	if !a.ok {
+		return nil, errors.New("invalid credentials")
+	}
+	return &authentication.Authentication{UserID: testUserID, ProjectID: testProjectID, ProjectStatus: project.StatusActive}, nil
+}
+
+type stubIdentityFactory struct{}
+
+func (stubIdentityFactory) CreateCustomIdentity(context.Context, string) (factories.Identity, error) {
+	return stubIdentity{}, nil
+}
+
+func (stubIdentityFactory) CreateOpenfortIdentity(context.Context, string, *string, *string) (factories.Identity, error) {
+	return stubIdentity{}, nil
+}
+
+func (stubIdentityFactory) CreateIntrospectionIdentity(context.Context, string) (factories.Identity, error) {
+	return stubIdentity{}, nil
+}
+
+type stubIdentity struct{}
+
+func (stubIdentity) GetProviderID() string                            { return "provider-id" }
+func (stubIdentity) GetCookieFieldName() string                       { return "" }
+func (stubIdentity) Identify(context.Context, string) (string, error) { return testUserID, nil }
+
+type stubProjectService struct{}
+
+func (stubProjectService) Create(context.Context, string, bool) (*project.Project, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (stubProjectService) GetByAPIKey(_ context.Context, apiKey string) (*project.Project, error) {
+	if apiKey != testAPIKey {
+		return nil, domainErrors.ErrProjectNotFound
+	}
+	return &project.Project{ID: testProjectID, APIKey: testAPIKey}, nil
+}
+
+func (stubProjectService) SaveProjectRateLimits(context.Context, string, int64, int64) error {
+	return nil
+}
+
+func (stubProjectService) SetEncryptionPart(context.Context, string, string) error {
+	return nil
+}
+
+type testServer struct {
+	projectRepo  *projectmockrepo.MockProjectRepository
+	shareRepo    *sharemockrepo.MockShareRepository
+	keychainRepo *keychainmockrepo.MockKeychainRepository
+	url          string
+}
+
+// newTestServer serves the real router, wrap sees every request before it.
+func newTestServer(t *testing.T, wrap func(http.Handler) http.Handler) *testServer {
+	t.Helper()
+
+	projectRepo := new(projectmockrepo.MockProjectRepository)
+	shareRepo := new(sharemockrepo.MockShareRepository)
+	keychainRepo := new(keychainmockrepo.MockKeychainRepository)
+	idempotencyRepo := new(idempotencymockrepo.MockIdempotencyRepository)
+	idempotencyRepo.On("Claim", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	idempotencyRepo.On("Complete", mock.Anything, mock.Anything).Return(nil).Maybe()
+	idempotencyRepo.On("Release", mock.Anything, mock.Anything).Return(nil).Maybe()
+	idempotencyRepo.On("DeleteExpired", mock.Anything).Return(nil).Maybe()
+
+	projectApp := projectapp.New(nil, projectRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	shareApp := shareapp.New(sharesvc.New(shareRepo, keychainRepo, nil), shareRepo, projectRepo, nil, keychainRepo, nil, nil)
+	server := rest.New(&rest.Config{RPS: 1000, RegistrationMode: string(project.RegistrationModeOpen)}, projectApp, shareApp,
+		stubAuthenticationFactory{}, stubIdentityFactory{}, nil, nil, stubProjectService{}, idempotencyRepo)
+
+	handler, err := server.Handler(context.Background())
+	require.NoError(t, err)
+	if wrap != nil {
+		handler = wrap(handler)
+	}
+
+	httpServer := httptest.NewServer(handler)
+	t.Cleanup(httpServer.Close)
+
+	return &testServer{projectRepo: projectRepo, shareRepo: shareRepo, keychainRepo: keychainRepo, url: httpServer.URL}
+}
+
+func newTestClient(t *testing.T, url string, opts ...Option) *Client {
+	t.Helper()
+
+	c, err := New(url, append([]Option{WithRetries(3, time.Millisecond)}, opts...)...)
+	require.NoError(t, err)
+	return c
+}
+
+func TestClient_GetProject(t *testing.T) {
+	srv := newTestServer(t, nil)
+	srv.projectRepo.On("Get", mock.Anything, testProjectID).Return(&project.Project{
+		ID:             testProjectID,
+		Name:           "project",
+		ClientCertMode: project.ClientCertModeDisabled,
+		Status:         project.StatusActive,
+	}, nil)
+
+	tc := []struct {
+		name    string
+		opts    []Option
+		wantErr error
+	}{
+		{
+			name: "api secret",
+			opts: []Option{WithProjectCredentials(testAPIKey, testAPISecret)},
+		},
+		{
+			name: "signed request",
+			opts: []Option{WithProjectCredentials(testAPIKey, ""), WithSigningKey([]byte(testSigningKey))},
+		},
+		{
+			name:    "wrong signing key",
+			opts:    []Option{WithProjectCredentials(testAPIKey, ""), WithSigningKey([]byte("other-key"))},
+			wantErr: ErrInvalidCredentials,
+		},
+		{
+			name:    "missing secret",
+			opts:    []Option{WithProjectCredentials(testAPIKey, "")},
+			wantErr: ErrMissingCredentials,
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, srv.url, tt.opts...)
+			proj, err := c.GetProject(context.Background())
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testProjectID, proj.ID)
+			assert.Equal(t, "project", proj.Name)
+			assert.Equal(t, ClientCertModeDisabled, proj.ClientCertMode)
+			assert.Equal(t, ProjectStatusActive, proj.Status)
+		})
+	}
+}
+
+func TestClient_Error(t *testing.T) {
+	srv := newTestServer(t, nil)
+	srv.projectRepo.On("Get", mock.Anything, testProjectID).Return(nil, domainErrors.ErrProjectNotFound)
+	c := newTestClient(t, srv.url, WithProjectCredentials(testAPIKey, testAPISecret))
+
+	_, err := c.GetProject(WithRequestID(context.Background(), "request-id"))
+	require.ErrorIs(t, err, ErrProjectNotFound)
+
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, "PJ_NOT_FOUND", apiErr.Code)
+	assert.Equal(t, "request-id", apiErr.RequestID)
+}
+
+func TestClient_ValidationError(t *testing.T) {
+	srv := newTestServer(t, nil)
+	usr := newTestClient(t, srv.url, WithProjectCredentials(testAPIKey, "")).ForUser(UserCredentials{Token: testUserToken, Provider: AuthProviderOpenfort})
+
+	_, err := usr.UpdateShare(WithRequestID(context.Background(), "request-id"), &Share{Entropy: EntropyUser, Salt: "salt", Iterations: 1000, Length: 32})
+	require.ErrorIs(t, err, ErrValidationFailed)
+	require.ErrorIs(t, err, ErrBadRequest)
+
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.Equal(t, "VALIDATION_FAILED", apiErr.Code)
+	assert.Equal(t, "request-id", apiErr.RequestID)
+	assert.ElementsMatch(t, []FieldError{
+		{Field: "secret", Code: "required", Message: "secret is required"},
+		{Field: "digest", Code: "required", Message: "digest is required when entropy is user"},
+	}, apiErr.Fields)
+}
+
+func TestClient_GetShare(t *testing.T) {
+	srv := newTestServer(t, nil)
+	srv.shareRepo.On("GetByUserID", mock.Anything, testUserID).Return(&share.Share{
+		Secret:               "secret",
+		UserID:               testUserID,
+		Entropy:              share.EntropyUser,
+		EncryptionParameters: &share.EncryptionParameters{Salt: "salt", Iterations: 1000, Length: 32, Digest: "sha256"},
+		Version:              3,
+	}, nil)
+	c := newTestClient(t, srv.url, WithProjectCredentials(testAPIKey, ""))
+
+	shr, err := c.ForUser(UserCredentials{Token: testUserToken, Provider: AuthProviderOpenfort}).GetShare(context.Background(), EncryptionOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "secret", shr.Secret)
+	assert.Equal(t, EntropyUser, shr.Entropy)
+	assert.Equal(t, "salt", shr.Salt)
+	assert.Equal(t, 1000, shr.Iterations)
+	assert.Equal(t, `"3"`, shr.ETag)
+
+	_, err = c.ForUser(UserCredentials{Token: "wrong", Provider: AuthProviderOpenfort}).GetShare(context.Background(), EncryptionOptions{})
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestClient_UpdateShareIfMatch(t *testing.T) {
+	srv := newTestServer(t, nil)
+	srv.shareRepo.On("GetByUserID", mock.Anything, testUserID).Return(&share.Share{
+		Secret:               "secret",
+		UserID:               testUserID,
+		Entropy:              share.EntropyUser,
+		EncryptionParameters: &share.EncryptionParameters{Salt: "salt", Iterations: 1000, Length: 32, Digest: "sha256"},
+		Version:              3,
+	}, nil)
+	srv.shareRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	usr := newTestClient(t, srv.url, WithProjectCredentials(testAPIKey, "")).ForUser(UserCredentials{Token: testUserToken, Provider: AuthProviderOpenfort})
+
+	shr := &Share{Secret: "new-secret", Entropy: EntropyUser, Salt: "salt", Iterations: 1000, Length: 32, Digest: "sha256", ETag: `"3"`}
+	updated, err := usr.UpdateShare(context.Background(), shr)
+	require.NoError(t, err)
+	assert.Equal(t, `"3"`, updated.ETag)
+
+	shr.ETag = `"2"`
+	_, err = usr.UpdateShare(context.Background(), shr)
+	require.ErrorIs(t, err, ErrShareVersionMismatch)
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusPreconditionFailed, apiErr.StatusCode)
+}
+
+func TestClient_ListKeychain(t *testing.T) {
+	srv := newTestServer(t, nil)
+	srv.keychainRepo.On("GetByUserID", mock.Anything, testUserID).Return(&keychain.Keychain{ID: "keychain-id", UserID: testUserID}, nil)
+	srv.shareRepo.On("GetByUserID", mock.Anything, testUserID).Return(nil, domainErrors.ErrShareNotFound)
+	newShare := func(id string) *share.Share {
+		reference := "wallet-" + id
+		return &share.Share{ID: id, Secret: "encrypted", Entropy: share.EntropyProject, Reference: &reference, Version: 1}
+	}
+	filter := &share.ListFilter{ReferencePrefix: "wallet-", Entropy: share.EntropyProject}
+	srv.shareRepo.On("ListPageByKeychainID", mock.Anything, "keychain-id", filter, "", 3).Return([]*share.Share{newShare("share-1"), newShare("share-2"), newShare("share-3")}, nil)
+	srv.shareRepo.On("ListPageByKeychainID", mock.Anything, "keychain-id", filter, "share-2", 3).Return([]*share.Share{newShare("share-3")}, nil)
+	usr := newTestClient(t, srv.url, WithProjectCredentials(testAPIKey, "")).ForUser(UserCredentials{Token: testUserToken, Provider: AuthProviderOpenfort})
+
+	// Without secrets no encryption part is needed for project entropy shares
+	keychainFilter := KeychainFilter{ReferencePrefix: "wallet-", Entropy: EntropyProject, WithoutSecrets: true}
+	page, err := usr.ListKeychain(context.Background(), keychainFilter, "", 2, EncryptionOptions{})
+	require.NoError(t, err)
+	require.Len(t, page.Shares, 2)
+	assert.Equal(t, "wallet-share-1", page.Shares[0].Reference)
+	assert.Empty(t, page.Shares[0].Secret)
+	assert.Equal(t, `"1"`, page.Shares[0].ETag)
+	require.NotEmpty(t, page.NextCursor)
+
+	page, err = usr.ListKeychain(context.Background(), keychainFilter, page.NextCursor, 2, EncryptionOptions{})
+	require.NoError(t, err)
+	require.Len(t, page.Shares, 1)
+	assert.Equal(t, "wallet-share-3", page.Shares[0].Reference)
+	assert.Empty(t, page.NextCursor)
+
+	_, err = usr.ListKeychain(context.Background(), KeychainFilter{Reference: "wallet-share-1", ReferencePrefix: "wallet-"}, "", 0, EncryptionOptions{})
+	require.ErrorIs(t, err, ErrBadRequest)
+}
+
+func TestClient_Retries(t *testing.T) {
+	var failures, attempts atomic.Int32
+	var mu sync.Mutex
+	var requestIDs, idempotencyKeys []string
+	srv := newTestServer(t, func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+			mu.Lock()
+			requestIDs = append(requestIDs, r.Header.Get(requestIDHeader))
+			idempotencyKeys = append(idempotencyKeys, r.Header.Get(idempotencyKeyHeader))
+			mu.Unlock()
+			if failures.Add(-1) >= 0 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	})
+	srv.projectRepo.On("Get", mock.Anything, testProjectID).Return(&project.Project{ID: testProjectID}, nil)
+	c := newTestClient(t, srv.url, WithProjectCredentials(testAPIKey, testAPISecret))
+
+	t.Run("get is retried", func(t *testing.T) {
+		failures.Store(2)
+		attempts.Store(0)
+		mu.Lock()
+		requestIDs, idempotencyKeys = nil, nil
+		mu.Unlock()
+
+		_, err := c.GetProject(context.Background())
+		require.NoError(t, err)
+		mu.Lock()
+		defer mu.Unlock()
+		assert.EqualValues(t, 3, attempts.Load())
+		require.Len(t, requestIDs, 3)
+		assert.NotEmpty(t, requestIDs[0])
+		assert.Equal(t, requestIDs[0], requestIDs[2], "retries keep the request ID")
+		assert.Empty(t, idempotencyKeys[0], "reads don't need an idempotency key")
+	})
+
+	t.Run("attempts are bounded", func(t *testing.T) {
+		failures.Store(5)
+		attempts.Store(0)
+
+		_, err := c.GetProject(context.Background())
+		var apiErr *Error
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+		assert.EqualValues(t, 3, attempts.Load())
+	})
+
+	t.Run("post is retried with its idempotency key", func(t *testing.T) {
+		failures.Store(1)
+		attempts.Store(0)
+		mu.Lock()
+		requestIDs, idempotencyKeys = nil, nil
+		mu.Unlock()
+		srv.shareRepo.On("GetSharesEncryptionForProjectAndReferences", mock.Anything).Return(map[string]share.RecoveryInfo{}, nil)
+
+		_, err := c.GetSharesEncryptionForReferences(WithIdempotencyKey(context.Background(), "idempotency-key"), []string{"reference"})
+		require.NoError(t, err)
+		mu.Lock()
+		defer mu.Unlock()
+		assert.EqualValues(t, 2, attempts.Load())
+		assert.Equal(t, []string{"idempotency-key", "idempotency-key"}, idempotencyKeys)
+	})
+
+	t.Run("registration isn't retried", func(t *testing.T) {
+		failures.Store(1)
+		attempts.Store(0)
+		mu.Lock()
+		requestIDs, idempotencyKeys = nil, nil
+		mu.Unlock()
+
+		_, err := c.CreateProject(context.Background(), &CreateProjectRequest{Name: "project"}, "")
+		var apiErr *Error
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+		mu.Lock()
+		defer mu.Unlock()
+		assert.EqualValues(t, 1, attempts.Load())
+		assert.Equal(t, []string{""}, idempotencyKeys)
+	})
+}
+
+func TestClient_TracePropagation(t *testing.T) {
+	previous := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(previous) })
+
+	var mu sync.Mutex
+	var traceparent string
+	srv := newTestServer(t, func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			traceparent = r.Header.Get("traceparent")
+			mu.Unlock()
+			next.ServeHTTP(w, r)
+		})
+	})
+	c := newTestClient(t, srv.url)
+
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	require.NoError(t, err)
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	srv.shareRepo.On("GetShareStorageMethods", mock.Anything).Return([]*share.StorageMethod{{ID: int32(share.StorageMethodShield), Name: "shield"}}, nil)
+	methods, err := c.GetShareStorageMethods(ctx)
+	require.NoError(t, err)
+	require.Len(t, methods, 1)
+	assert.Equal(t, StorageMethodShield, methods[0].ID)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", traceparent)
+}
+
+func TestErrorsByCode(t *testing.T) {
+	for _, code := range api.Codes() {
+		assert.Contains(t, errorsByCode, code, "no typed error for code %s", code)
+	}
+}
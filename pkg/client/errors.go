@@ -0,0 +1,229 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Error is an error answered by the Shield API. It unwraps to the sentinel
+// error of its code, so callers match it with errors.Is:
+//
+//	if errors.Is(err, client.ErrShareNotFound) { ... }
+type Error struct {
+	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
+	// StatusCode is the HTTP status of the response, zero for the errors
+	// reported per line by a bulk import.
+	StatusCode int    `json:"-"`
+	RequestID  string `json:"-"`
+	// Fields lists the invalid fields of a request that failed validation.
+	Fields []FieldError `json:"-"`
+}
+
+// FieldError is an invalid field of a request, Code is one of required,
+// invalid, not_allowed and too_large.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	if e.RequestID != "" {
+		return fmt.Sprintf("shield: %s (%s, status %d, request %s)", e.Message, e.Code, e.StatusCode, e.RequestID)
+	}
+	return fmt.Sprintf("shield: %s (%s, status %d)", e.Message, e.Code, e.StatusCode)
+}
+
+func (e *Error) Unwrap() error {
+	return errorsByCode[e.Code]
+}
+
+var (
+	ErrBadRequest = errors.New("shield: bad request")
+	ErrInternal   = errors.New("shield: internal error")
+	// ErrValidationFailed also matches ErrBadRequest, the code the server
+	// reported validation failures with before they got their own.
+	ErrValidationFailed = fmt.Errorf("shield: request validation failed: %w", ErrBadRequest)
+
+	ErrMissingCredentials         = errors.New("shield: missing credentials")
+	ErrInvalidCredentials         = errors.New("shield: invalid credentials")
+	ErrClientCertificateRequired  = errors.New("shield: client certificate required")
+	ErrSignatureRequired          = errors.New("shield: signed request required")
+	ErrInvalidSignature           = errors.New("shield: invalid request signature")
+	ErrSignatureExpired           = errors.New("shield: request signature expired")
+	ErrSignatureReplayed          = errors.New("shield: request signature replayed")
+	ErrSignedBodyTooLarge         = errors.New("shield: signed request body too large")
+	ErrIdentityUnavailable        = errors.New("shield: identity provider unavailable")
+	ErrProjectSuspended           = errors.New("shield: project suspended")
+	ErrOperatorDisabled           = errors.New("shield: operator API disabled")
+	ErrInviteRequired             = errors.New("shield: registration invite required")
+	ErrRegistrationOperatorOnly   = errors.New("shield: registration reserved to the operator")
+	ErrRegistrationRateLimited    = errors.New("shield: too many registrations")
+	ErrInvalidIdempotencyKey      = errors.New("shield: invalid idempotency key")
+	ErrIdempotencyInProgress      = errors.New("shield: request with the same idempotency key in progress")
+	ErrIdempotencyKeyReused       = errors.New("shield: idempotency key reused for a different request")
+	ErrProjectNotFound            = errors.New("shield: project not found")
+	ErrProjectReadOnly            = errors.New("shield: project read-only")
+	ErrInvalidProjectStatus       = errors.New("shield: invalid project status")
+	ErrInvalidInvite              = errors.New("shield: invalid invite")
+	ErrSigningKeyMissing          = errors.New("shield: signing key missing")
+	ErrSigningUnavailable         = errors.New("shield: signing unavailable")
+	ErrDeletionNotRequested       = errors.New("shield: deletion not requested")
+	ErrInvalidDeletionToken       = errors.New("shield: invalid deletion token")
+	ErrDeletionExpired            = errors.New("shield: deletion request expired")
+	ErrInvalidDeletionMode        = errors.New("shield: invalid deletion mode")
+	ErrDeletionStaleArchive       = errors.New("shield: project changed since the archive was exported")
+	ErrInvalidPageSize            = errors.New("shield: invalid page size")
+	ErrUnknownProvider            = errors.New("shield: unknown provider type")
+	ErrProviderMissing            = errors.New("shield: missing provider")
+	ErrProviderNotFound           = errors.New("shield: provider not found")
+	ErrProviderExists             = errors.New("shield: provider already exists")
+	ErrInvalidProviderConfig      = errors.New("shield: invalid provider config")
+	ErrProviderKeyMissing         = errors.New("shield: missing provider key")
+	ErrProviderKeyNotFound        = errors.New("shield: provider key not found")
+	ErrProviderKeyExists          = errors.New("shield: provider key already exists")
+	ErrProviderSecretUnavailable  = errors.New("shield: provider secret encryption unavailable")
+	ErrInvalidClientCertificate   = errors.New("shield: invalid client certificate")
+	ErrClientCertificateExists    = errors.New("shield: client certificate already exists")
+	ErrClientCertificateNotFound  = errors.New("shield: client certificate not found")
+	ErrClientCertificatesRequired = errors.New("shield: client certificates required")
+	ErrUserIDMissing              = errors.New("shield: missing user ID")
+	ErrUserNotFound               = errors.New("shield: user not found")
+	ErrExternalUserNotFound       = errors.New("shield: external user not found")
+	ErrExternalUserExists         = errors.New("shield: external user already exists")
+	ErrPreRegistrationFailed      = errors.New("shield: user pre-registration failed")
+	ErrUserContactsMismatch       = errors.New("shield: user contact information mismatch")
+	ErrInvalidEmail               = errors.New("shield: invalid email")
+	ErrInvalidPhoneNumber         = errors.New("shield: invalid phone number")
+	ErrShareNotFound              = errors.New("shield: share not found")
+	ErrShareExists                = errors.New("shield: share already exists")
+	ErrShareVersionMismatch       = errors.New("shield: share was changed since it was read")
+	ErrKeychainNotFound           = errors.New("shield: keychain not found")
+	ErrTransferKeyRequired        = errors.New("shield: transfer key required")
+	ErrInvalidTransferKey         = errors.New("shield: invalid transfer key")
+	ErrEncryptionPartRequired     = errors.New("shield: encryption part required")
+	ErrInvalidEncryption          = errors.New("shield: invalid encryption part or session")
+	ErrEncryptionPartExists       = errors.New("shield: encryption part already exists")
+	ErrOTPRequired                = errors.New("shield: OTP required")
+	ErrOTPRateLimited             = errors.New("shield: OTP rate limit exceeded")
+	ErrOTPExpired                 = errors.New("shield: OTP expired")
+	ErrOTPInvalidated             = errors.New("shield: OTP invalidated")
+	ErrOTPInvalid                 = errors.New("shield: invalid OTP")
+	ErrOTPUserInfoMissing         = errors.New("shield: missing user contact information")
+	ErrOTPNotSent                 = errors.New("shield: OTP requested but not sent")
+	ErrOTPNotFound                = errors.New("shield: OTP record not found")
+	Err2FANotSupported            = errors.New("shield: project doesn't support 2FA")
+	Err2FAAlreadyEnabled          = errors.New("shield: 2FA already enabled")
+	ErrNotificationsUnavailable   = errors.New("shield: notification service unavailable")
+)
+
+// errorsByCode maps the codes of the API errors to their sentinel, the
+// client tests check it covers every code the server reports.
+var errorsByCode = map[string]error{
+	"BAD_REQUEST":       ErrBadRequest,
+	"VALIDATION_FAILED": ErrValidationFailed,
+	"INTERNAL":          ErrInternal,
+
+	"A_MISSING":                  ErrMissingCredentials,
+	"A_INVALID":                  ErrInvalidCredentials,
+	"A_CERT_REQUIRED":            ErrClientCertificateRequired,
+	"A_SIGNATURE_REQUIRED":       ErrSignatureRequired,
+	"A_SIGNATURE_INVALID":        ErrInvalidSignature,
+	"A_SIGNATURE_EXPIRED":        ErrSignatureExpired,
+	"A_SIGNATURE_REPLAYED":       ErrSignatureReplayed,
+	"A_SIGNATURE_BODY_TOO_LARGE": ErrSignedBodyTooLarge,
+	"A_UNAVAILABLE":              ErrIdentityUnavailable,
+	"A_PROJECT_SUSPENDED":        ErrProjectSuspended,
+	"A_OPERATOR_DISABLED":        ErrOperatorDisabled,
+	"REG_INVITE_REQUIRED":        ErrInviteRequired,
+	"REG_OPERATOR_ONLY":          ErrRegistrationOperatorOnly,
+	"REG_RATE_LIMIT":             ErrRegistrationRateLimited,
+	"IDEM_KEY_INVALID":           ErrInvalidIdempotencyKey,
+	"IDEM_IN_PROGRESS":           ErrIdempotencyInProgress,
+	"IDEM_KEY_REUSED":            ErrIdempotencyKeyReused,
+
+	"PJ_NOT_FOUND":              ErrProjectNotFound,
+	"PJ_READ_ONLY":              ErrProjectReadOnly,
+	"PJ_STATUS_INVALID":         ErrInvalidProjectStatus,
+	"PJ_INVITE_INVALID":         ErrInvalidInvite,
+	"PJ_SIGNING_KEY_MISSING":    ErrSigningKeyMissing,
+	"PJ_SIGNING_UNAVAILABLE":    ErrSigningUnavailable,
+	"PJ_DELETION_NOT_REQUESTED": ErrDeletionNotRequested,
+	"PJ_DELETION_TOKEN_INVALID": ErrInvalidDeletionToken,
+	"PJ_DELETION_EXPIRED":       ErrDeletionExpired,
+	"PJ_DELETION_MODE_INVALID":  ErrInvalidDeletionMode,
+	"PJ_DELETION_STALE_ARCHIVE": ErrDeletionStaleArchive,
+	"PG_SIZE_INVALID":           ErrInvalidPageSize,
+
+	"PV_UNKNOWN":            ErrUnknownProvider,
+	"PV_MISSING":            ErrProviderMissing,
+	"PV_NOT_FOUND":          ErrProviderNotFound,
+	"PV_EXISTS":             ErrProviderExists,
+	"PV_CFG_INVALID":        ErrInvalidProviderConfig,
+	"PV_KEY_MISSING":        ErrProviderKeyMissing,
+	"PV_KEY_NOT_FOUND":      ErrProviderKeyNotFound,
+	"PV_KEY_EXISTS":         ErrProviderKeyExists,
+	"PV_SECRET_UNAVAILABLE": ErrProviderSecretUnavailable,
+
+	"CC_INVALID":   ErrInvalidClientCertificate,
+	"CC_EXISTS":    ErrClientCertificateExists,
+	"CC_NOT_FOUND": ErrClientCertificateNotFound,
+	"CC_REQUIRED":  ErrClientCertificatesRequired,
+
+	"US_ID_MISSING":          ErrUserIDMissing,
+	"US_NOT_FOUND":           ErrUserNotFound,
+	"US_EXT_NOT_FOUND":       ErrExternalUserNotFound,
+	"US_EXT_EXISTS":          ErrExternalUserExists,
+	"US_PREREG_FAILED":       ErrPreRegistrationFailed,
+	"USER_CONTACTS_MISMATCH": ErrUserContactsMismatch,
+	"EMAIL_INVALID":          ErrInvalidEmail,
+	"PHONE_INVALID":          ErrInvalidPhoneNumber,
+
+	"SH_NOT_FOUND":            ErrShareNotFound,
+	"SH_EXISTS":               ErrShareExists,
+	"SH_VERSION_MISMATCH":     ErrShareVersionMismatch,
+	"KC_NOT_FOUND":            ErrKeychainNotFound,
+	"SH_TRANSFER_KEY_MISSING": ErrTransferKeyRequired,
+	"SH_TRANSFER_KEY_INVALID": ErrInvalidTransferKey,
+	"EC_MISSING":              ErrEncryptionPartRequired,
+	"EC_INVALID":              ErrInvalidEncryption,
+	"EC_EXISTS":               ErrEncryptionPartExists,
+
+	"OTP_MISSING":                ErrOTPRequired,
+	"OTP_RATE_LIMIT":             ErrOTPRateLimited,
+	"OTP_EXPIRED":                ErrOTPExpired,
+	"OTP_INVALIDATED":            ErrOTPInvalidated,
+	"OTP_INVALID":                ErrOTPInvalid,
+	"OTP_USER_INFO_MISSING":      ErrOTPUserInfoMissing,
+	"OTP_REQUESTED_BUT_NOT_SENT": ErrOTPNotSent,
+	"OTP_RECORD_NOT_FOUND":       ErrOTPNotFound,
+	"OTP_NOT_SUPPORTED":          Err2FANotSupported,
+	"OTP_ALREADY_ENABLED":        Err2FAAlreadyEnabled,
+	"MISSING_NOTIFICATION_SERV":  ErrNotificationsUnavailable,
+
+	// Problem details report these codes instead of the ones shared by
+	// several errors
+	"A_API_KEY_MISSING":       ErrMissingCredentials,
+	"A_API_SECRET_MISSING":    ErrMissingCredentials,
+	"A_TOKEN_MISSING":         ErrMissingCredentials,
+	"A_PROVIDER_MISSING":      ErrMissingCredentials,
+	"A_OPERATOR_KEY_MISSING":  ErrMissingCredentials,
+	"A_CREDENTIALS_INVALID":   ErrInvalidCredentials,
+	"A_TOKEN_INVALID":         ErrInvalidCredentials,
+	"A_PROVIDER_INVALID":      ErrInvalidCredentials,
+	"A_OPERATOR_KEY_INVALID":  ErrInvalidCredentials,
+	"PJ_INVITE_TTL_INVALID":   ErrInvalidInvite,
+	"PV_KEY_TYPE_MISSING":     ErrInvalidProviderConfig,
+	"PV_KEY_VALIDITY_INVALID": ErrInvalidProviderConfig,
+	"PV_JWK_PEM_CONFLICT":     ErrInvalidProviderConfig,
+	"PV_PEM_INVALID":          ErrInvalidProviderConfig,
+	"PV_HMAC_CONFLICT":        ErrInvalidProviderConfig,
+	"PV_HMAC_SECRET_INVALID":  ErrInvalidProviderConfig,
+	"CC_FINGERPRINT_INVALID":  ErrInvalidClientCertificate,
+	"CC_TYPE_INVALID":         ErrInvalidClientCertificate,
+	"CC_MODE_INVALID":         ErrInvalidClientCertificate,
+	"EC_NOT_CONFIGURED":       ErrEncryptionPartRequired,
+	"EC_PART_INVALID":         ErrInvalidEncryption,
+	"EC_SESSION_INVALID":      ErrInvalidEncryption,
+}
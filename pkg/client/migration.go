@@ -0,0 +1,115 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// MigrationOptions carry the keys of a bulk migration. Shares with project
+// entropy are exported encrypted with the transfer key, and imported with the
+// same transfer key and the encryption of the importing project.
+type MigrationOptions struct {
+	TransferKey string
+	Encryption  EncryptionOptions
+}
+
+func (o MigrationOptions) header() http.Header {
+	header := o.Encryption.header()
+	if o.TransferKey != "" {
+		header.Set(transferKeyHeader, o.TransferKey)
+	}
+	return header
+}
+
+func (c *Client) ExportShare(ctx context.Context, reference string) (*ExportedShare, error) {
+	var shr ExportedShare
+	err := c.do(ctx, &request{method: http.MethodGet, path: "/shares/migration/export/" + url.PathEscape(reference), auth: projectAuth, out: &shr})
+	if err != nil {
+		return nil, err
+	}
+	return &shr, nil
+}
+
+func (c *Client) ImportShare(ctx context.Context, req *ImportShareRequest) error {
+	return c.do(ctx, &request{method: http.MethodPost, path: "/shares/migration/import", body: req, auth: projectAuth})
+}
+
+// ExportShares streams the shares of the project to fn, in ID order starting
+// after the share with ID after. The server ends the stream early when it
+// fails midway, resume it after the last share fn got.
+func (c *Client) ExportShares(ctx context.Context, after string, opts MigrationOptions, fn func(*MigratedShare) error) error {
+	query := make(url.Values)
+	if after != "" {
+		query.Set("after", after)
+	}
+
+	return c.do(ctx, &request{
+		method: http.MethodGet,
+		path:   "/shares/migration/bulk/export",
+		query:  query,
+		header: opts.header(),
+		auth:   projectAuth,
+		stream: func(body io.Reader) error {
+			decoder := json.NewDecoder(body)
+			for {
+				var shr MigratedShare
+				err := decoder.Decode(&shr)
+				if errors.Is(err, io.EOF) {
+					return nil
+				}
+				if err != nil {
+					return fmt.Errorf("failed to decode exported share: %w", err)
+				}
+				if err := fn(&shr); err != nil {
+					return err
+				}
+			}
+		},
+	})
+}
+
+// ImportShares imports the shares of a bulk export and returns the result of
+// each, in order. Shares keep their ID, importing them again skips them.
+func (c *Client) ImportShares(ctx context.Context, shares []*MigratedShare, opts MigrationOptions) ([]*ImportResult, error) {
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, shr := range shares {
+		if err := encoder.Encode(shr); err != nil {
+			return nil, fmt.Errorf("failed to encode share: %w", err)
+		}
+	}
+
+	var results []*ImportResult
+	err := c.do(ctx, &request{
+		method:      http.MethodPost,
+		path:        "/shares/migration/bulk/import",
+		header:      opts.header(),
+		rawBody:     body.Bytes(),
+		contentType: ndjsonContentType,
+		auth:        projectAuth,
+		stream: func(body io.Reader) error {
+			decoder := json.NewDecoder(body)
+			for {
+				var result ImportResult
+				err := decoder.Decode(&result)
+				if errors.Is(err, io.EOF) {
+					return nil
+				}
+				if err != nil {
+					return fmt.Errorf("failed to decode import result: %w", err)
+				}
+				results = append(results, &result)
+			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
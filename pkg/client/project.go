@@ -0,0 +1,371 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// CreateProject registers a new project. The invite token is required in
+// invite registration mode, the operator key is sent when the client has one.
+// It isn't retried, a retry could register a second project.
+func (c *Client) CreateProject(ctx context.Context, req *CreateProjectRequest, inviteToken string) (*CreatedProject, error) {
+	header := make(http.Header)
+	if inviteToken != "" {
+		header.Set(inviteTokenHeader, inviteToken)
+	}
+	auth := noAuth
+	if c.operatorKey != "" {
+		auth = operatorAuth
+	}
+
+	var project CreatedProject
+	err := c.do(ctx, &request{method: http.MethodPost, path: "/register", header: header, body: req, auth: auth, out: &project, once: true})
+	if err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+func (c *Client) GetProject(ctx context.Context) (*Project, error) {
+	var project Project
+	err := c.do(ctx, &request{method: http.MethodGet, path: "/project", auth: projectAuth, out: &project})
+	if err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// RequestDeletion returns the archive of the project and the token that
+// confirms its deletion with DeleteProject.
+func (c *Client) RequestDeletion(ctx context.Context) (*DeletionRequest, error) {
+	var deletion DeletionRequest
+	err := c.do(ctx, &request{method: http.MethodPost, path: "/project/deletion", auth: projectAuth, out: &deletion})
+	if err != nil {
+		return nil, err
+	}
+	return &deletion, nil
+}
+
+func (c *Client) DeleteProject(ctx context.Context, confirmationToken string, mode DeletionMode) (*DeletionReceipt, error) {
+	body := struct {
+		ConfirmationToken string       `json:"confirmation_token"`
+		Mode              DeletionMode `json:"mode"`
+	}{confirmationToken, mode}
+
+	var receipt DeletionReceipt
+	err := c.do(ctx, &request{method: http.MethodDelete, path: "/project", body: body, auth: projectAuth, out: &receipt})
+	if err != nil {
+		return nil, err
+	}
+	return &receipt, nil
+}
+
+// RequestOTP sends an OTP to the user, it's then required to register an
+// encryption session on projects with 2FA.
+func (c *Client) RequestOTP(ctx context.Context, req *OTPRequest) error {
+	return c.do(ctx, &request{method: http.MethodPost, path: "/project/otp", body: req, auth: projectAuth})
+}
+
+func (c *Client) GetProviders(ctx context.Context) ([]*ProviderSummary, error) {
+	var resp struct {
+		Providers []*ProviderSummary `json:"providers"`
+	}
+	err := c.do(ctx, &request{method: http.MethodGet, path: "/project/providers", auth: projectAuth, out: &resp})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Providers, nil
+}
+
+func (c *Client) AddProviders(ctx context.Context, providers *Providers) ([]*ProviderSummary, error) {
+	body := struct {
+		Providers *Providers `json:"providers"`
+	}{providers}
+
+	var resp struct {
+		Providers []*ProviderSummary `json:"providers"`
+	}
+	err := c.do(ctx, &request{method: http.MethodPost, path: "/project/providers", body: body, auth: projectAuth, out: &resp})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Providers, nil
+}
+
+func (c *Client) GetProvider(ctx context.Context, providerID string) (*Provider, error) {
+	var provider Provider
+	err := c.do(ctx, &request{method: http.MethodGet, path: "/project/providers/" + url.PathEscape(providerID), auth: projectAuth, out: &provider})
+	if err != nil {
+		return nil, err
+	}
+	return &provider, nil
+}
+
+func (c *Client) UpdateProvider(ctx context.Context, providerID string, req *UpdateProviderRequest) error {
+	return c.do(ctx, &request{method: http.MethodPut, path: "/project/providers/" + url.PathEscape(providerID), body: req, auth: projectAuth})
+}
+
+func (c *Client) DeleteProvider(ctx context.Context, providerID string) error {
+	return c.do(ctx, &request{method: http.MethodDelete, path: "/project/providers/" + url.PathEscape(providerID), auth: projectAuth})
+}
+
+func (c *Client) AddProviderKey(ctx context.Context, providerID string, req *AddProviderKeyRequest) (*ProviderKey, error) {
+	var key ProviderKey
+	err := c.do(ctx, &request{method: http.MethodPost, path: "/project/providers/" + url.PathEscape(providerID) + "/keys", body: req, auth: projectAuth, out: &key})
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (c *Client) DeleteProviderKey(ctx context.Context, providerID, keyID string) error {
+	return c.do(ctx, &request{method: http.MethodDelete, path: "/project/providers/" + url.PathEscape(providerID) + "/keys/" + url.PathEscape(keyID), auth: projectAuth})
+}
+
+func (c *Client) GetClientCertificates(ctx context.Context) ([]*ClientCertificate, error) {
+	var resp struct {
+		Certificates []*ClientCertificate `json:"certificates"`
+	}
+	err := c.do(ctx, &request{method: http.MethodGet, path: "/project/client-certificates", auth: projectAuth, out: &resp})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Certificates, nil
+}
+
+func (c *Client) AddClientCertificate(ctx context.Context, req *AddClientCertificateRequest) (*ClientCertificate, error) {
+	var cert ClientCertificate
+	err := c.do(ctx, &request{method: http.MethodPost, path: "/project/client-certificates", body: req, auth: projectAuth, out: &cert})
+	if err != nil {
+		return nil, err
+	}
+	return &cert, nil
+}
+
+func (c *Client) SetClientCertMode(ctx context.Context, mode ClientCertMode) error {
+	body := struct {
+		Mode ClientCertMode `json:"mode"`
+	}{mode}
+	return c.do(ctx, &request{method: http.MethodPut, path: "/project/client-certificates/mode", body: body, auth: projectAuth})
+}
+
+func (c *Client) DeleteClientCertificate(ctx context.Context, certificateID string) error {
+	return c.do(ctx, &request{method: http.MethodDelete, path: "/project/client-certificates/" + url.PathEscape(certificateID), auth: projectAuth})
+}
+
+// ListUsers returns a page of the users of the project, pass its NextCursor
+// in opts to get the next one.
+func (c *Client) ListUsers(ctx context.Context, opts ListUsersOptions) (*UsersPage, error) {
+	query := make(url.Values)
+	if opts.Provider != "" {
+		query.Set("provider", opts.Provider)
+	}
+	if opts.Entropy != "" {
+		query.Set("entropy", string(opts.Entropy))
+	}
+	if !opts.CreatedAfter.IsZero() {
+		query.Set("created_after", opts.CreatedAfter.Format(time.RFC3339))
+	}
+	if !opts.CreatedBefore.IsZero() {
+		query.Set("created_before", opts.CreatedBefore.Format(time.RFC3339))
+	}
+	if opts.Cursor != "" {
+		query.Set("cursor", opts.Cursor)
+	}
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.Itoa(opts.Limit))
+	}
+
+	var page UsersPage
+	err := c.do(ctx, &request{method: http.MethodGet, path: "/project/users", query: query, auth: projectAuth, out: &page})
+	if err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+func (c *Client) ExportUser(ctx context.Context, externalUserID string) (*UserExport, error) {
+	var export UserExport
+	err := c.do(ctx, &request{method: http.MethodGet, path: "/project/users/" + url.PathEscape(externalUserID), auth: projectAuth, out: &export})
+	if err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+func (c *Client) EraseUser(ctx context.Context, externalUserID string) (*ErasureReceipt, error) {
+	var receipt ErasureReceipt
+	err := c.do(ctx, &request{method: http.MethodDelete, path: "/project/users/" + url.PathEscape(externalUserID), auth: projectAuth, out: &receipt})
+	if err != nil {
+		return nil, err
+	}
+	return &receipt, nil
+}
+
+// CreateUser links an external user of a provider to a new Shield user and
+// returns its ID.
+func (c *Client) CreateUser(ctx context.Context, externalUserID, providerID string) (string, error) {
+	body := struct {
+		ExternalUserID string `json:"external_user_id"`
+		ProviderID     string `json:"provider_id"`
+	}{externalUserID, providerID}
+
+	var resp struct {
+		UserID string `json:"user_id"`
+	}
+	err := c.do(ctx, &request{method: http.MethodPost, path: "/user", body: body, auth: projectAuth, out: &resp})
+	if err != nil {
+		return "", err
+	}
+	return resp.UserID, nil
+}
+
+// RotateSigningKey generates a new request signing key, the previous one
+// stops working.
+func (c *Client) RotateSigningKey(ctx context.Context) (string, error) {
+	var resp struct {
+		SigningKey string `json:"signing_key"`
+	}
+	err := c.do(ctx, &request{method: http.MethodPost, path: "/project/signing-key", auth: projectAuth, out: &resp})
+	if err != nil {
+		return "", err
+	}
+	return resp.SigningKey, nil
+}
+
+func (c *Client) SetSignedRequests(ctx context.Context, required bool) error {
+	body := struct {
+		Required bool `json:"required"`
+	}{required}
+	return c.do(ctx, &request{method: http.MethodPut, path: "/project/signed-requests", body: body, auth: projectAuth})
+}
+
+// EncryptProjectShares encrypts the project entropy shares stored before the
+// project had an encryption part.
+func (c *Client) EncryptProjectShares(ctx context.Context, encryptionPart string) error {
+	body := struct {
+		EncryptionPart string `json:"encryption_part"`
+	}{encryptionPart}
+	return c.do(ctx, &request{method: http.MethodPost, path: "/project/encrypt", body: body, auth: projectAuth})
+}
+
+// RegisterEncryptionSession returns the ID of a single-use session that
+// stands for the encryption part in share requests.
+func (c *Client) RegisterEncryptionSession(ctx context.Context, req *EncryptionSessionRequest) (string, error) {
+	var resp struct {
+		SessionID string `json:"session_id"`
+	}
+	err := c.do(ctx, &request{method: http.MethodPost, path: "/project/encryption-session", body: req, auth: projectAuth, out: &resp})
+	if err != nil {
+		return "", err
+	}
+	return resp.SessionID, nil
+}
+
+// RegisterEncryptionKey generates the encryption key of the project and
+// returns the part Shield doesn't keep.
+func (c *Client) RegisterEncryptionKey(ctx context.Context) (string, error) {
+	var resp struct {
+		EncryptionPart string `json:"encryption_part"`
+	}
+	err := c.do(ctx, &request{method: http.MethodPost, path: "/project/encryption-key", auth: projectAuth, out: &resp})
+	if err != nil {
+		return "", err
+	}
+	return resp.EncryptionPart, nil
+}
+
+func (c *Client) Enable2FA(ctx context.Context) error {
+	return c.do(ctx, &request{method: http.MethodPost, path: "/project/enable-2fa", auth: projectAuth})
+}
+
+func (c *Client) GetShareStorageMethods(ctx context.Context) ([]*StorageMethod, error) {
+	var resp struct {
+		Methods []*StorageMethod `json:"methods"`
+	}
+	err := c.do(ctx, &request{method: http.MethodGet, path: "/storage-methods", auth: noAuth, out: &resp})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Methods, nil
+}
+
+// GetSharesEncryptionForReferences returns the encryption of the shares with
+// the references, keyed by reference.
+func (c *Client) GetSharesEncryptionForReferences(ctx context.Context, references []string) (map[string]*EncryptionType, error) {
+	body := struct {
+		References []string `json:"references"`
+	}{references}
+
+	var resp struct {
+		EncryptionTypes map[string]*EncryptionType `json:"encryption_types"`
+	}
+	err := c.do(ctx, &request{method: http.MethodPost, path: "/shares/encryption/reference/bulk", body: body, auth: projectAuth, out: &resp})
+	if err != nil {
+		return nil, err
+	}
+	return resp.EncryptionTypes, nil
+}
+
+// GetSharesEncryptionForUsers returns the encryption of the users' shares,
+// keyed by user. Without a reference it's their default share.
+func (c *Client) GetSharesEncryptionForUsers(ctx context.Context, userIDs []string, reference string) (map[string]*EncryptionType, error) {
+	body := struct {
+		UserIDs   []string `json:"user_ids"`
+		Reference *string  `json:"reference"`
+	}{UserIDs: userIDs}
+	if reference != "" {
+		body.Reference = &reference
+	}
+
+	var resp struct {
+		EncryptionTypes map[string]*EncryptionType `json:"encryption_types"`
+	}
+	err := c.do(ctx, &request{method: http.MethodPost, path: "/shares/encryption/user/bulk", body: body, auth: projectAuth, out: &resp})
+	if err != nil {
+		return nil, err
+	}
+	return resp.EncryptionTypes, nil
+}
+
+// ReassignShare moves a share to another keychain.
+func (c *Client) ReassignShare(ctx context.Context, reference, keychainID string) error {
+	body := struct {
+		KeychainID string `json:"keychain_id"`
+	}{keychainID}
+	return c.do(ctx, &request{method: http.MethodPut, path: "/shares/reassign/" + url.PathEscape(reference), body: body, auth: projectAuth})
+}
+
+// PreRegisterShare stores a share for an external user of the provider,
+// creating the user when it doesn't exist yet.
+func (c *Client) PreRegisterShare(ctx context.Context, externalUserID string, provider AuthProvider, shr *Share) error {
+	header := make(http.Header)
+	header.Set(userIDHeader, externalUserID)
+	header.Set(authProviderHeader, string(provider))
+	return c.do(ctx, &request{method: http.MethodPost, path: "/admin/preregister", header: header, body: shr, auth: projectAuth})
+}
+
+// CreateInvite issues a single-use registration invite, expiresIn zero uses
+// the server's default.
+func (c *Client) CreateInvite(ctx context.Context, expiresIn time.Duration) (*Invite, error) {
+	body := struct {
+		ExpiresIn int64 `json:"expires_in,omitempty"`
+	}{int64(expiresIn / time.Second)}
+
+	var invite Invite
+	err := c.do(ctx, &request{method: http.MethodPost, path: "/operator/invites", body: body, auth: operatorAuth, out: &invite})
+	if err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+func (c *Client) SetProjectStatus(ctx context.Context, projectID string, status ProjectStatus) error {
+	body := struct {
+		Status ProjectStatus `json:"status"`
+	}{status}
+	return c.do(ctx, &request{method: http.MethodPut, path: "/operator/projects/" + url.PathEscape(projectID) + "/status", body: body, auth: operatorAuth})
+}
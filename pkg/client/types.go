@@ -0,0 +1,433 @@
+package client
+
+import (
+	"net/http"
+	"time"
+)
+
+type Entropy string
+
+const (
+	EntropyNone    Entropy = "none"
+	EntropyUser    Entropy = "user"
+	EntropyProject Entropy = "project"
+	EntropyPasskey Entropy = "passkey"
+)
+
+type StorageMethodID int32
+
+const (
+	StorageMethodShield StorageMethodID = iota
+	StorageMethodGoogleDrive
+	StorageMethodICloud
+)
+
+type KeyType string
+
+const (
+	KeyTypeRSA     KeyType = "rsa"
+	KeyTypeECDSA   KeyType = "ecdsa"
+	KeyTypeEd25519 KeyType = "ed25519"
+	KeyTypeHMAC    KeyType = "hmac"
+)
+
+type ClientCertMode string
+
+const (
+	ClientCertModeDisabled    ClientCertMode = "disabled"
+	ClientCertModeAlternative ClientCertMode = "alternative"
+	ClientCertModeRequired    ClientCertMode = "required"
+)
+
+type ClientCertificateType string
+
+const (
+	ClientCertificateTypeCA   ClientCertificateType = "ca"
+	ClientCertificateTypeLeaf ClientCertificateType = "leaf"
+)
+
+type ProjectStatus string
+
+const (
+	ProjectStatusActive    ProjectStatus = "active"
+	ProjectStatusReadOnly  ProjectStatus = "read_only"
+	ProjectStatusSuspended ProjectStatus = "suspended"
+)
+
+type DeletionMode string
+
+const (
+	DeletionModeHardDelete  DeletionMode = "hard_delete"
+	DeletionModeCryptoShred DeletionMode = "crypto_shred"
+)
+
+type EncryptionTypeStatus string
+
+const (
+	EncryptionTypeStatusNotFound EncryptionTypeStatus = "not-found"
+	EncryptionTypeStatusFound    EncryptionTypeStatus = "found"
+)
+
+type ImportStatus string
+
+const (
+	ImportStatusImported ImportStatus = "imported"
+	ImportStatusSkipped  ImportStatus = "skipped"
+	ImportStatusFailed   ImportStatus = "failed"
+)
+
+type CreateProjectRequest struct {
+	Name                  string `json:"name"`
+	GenerateEncryptionKey bool   `json:"generate_encryption_key,omitempty"`
+	Enable2FA             *bool  `json:"enable_2fa"`
+}
+
+// CreatedProject holds the credentials of a new project, the API secret is
+// only ever returned here.
+type CreatedProject struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	APIKey         string `json:"api_key"`
+	APISecret      string `json:"api_secret"`
+	EncryptionPart string `json:"encryption_part,omitempty"`
+	Enabled2FA     bool   `json:"enabled_2fa"`
+}
+
+type Project struct {
+	ID                     string         `json:"id"`
+	Name                   string         `json:"name"`
+	Enabled2FA             bool           `json:"enabled_2fa"`
+	ClientCertMode         ClientCertMode `json:"client_cert_mode"`
+	SignedRequestsRequired bool           `json:"signed_requests_required"`
+	Status                 ProjectStatus  `json:"status"`
+}
+
+type Providers struct {
+	Openfort      *OpenfortProvider      `json:"openfort,omitempty"`
+	Custom        *CustomProvider        `json:"custom,omitempty"`
+	Introspection *IntrospectionProvider `json:"introspection,omitempty"`
+}
+
+type OpenfortProvider struct {
+	ProviderID     string `json:"provider_id,omitempty"`
+	PublishableKey string `json:"publishable_key,omitempty"`
+}
+
+type IntrospectionProvider struct {
+	ProviderID   string `json:"provider_id,omitempty"`
+	Endpoint     string `json:"endpoint,omitempty"`
+	ClientID     string `json:"client_id,omitempty"`
+	ClientSecret string `json:"client_secret,omitempty"`
+	UserIDClaim  string `json:"user_id_claim,omitempty"`
+}
+
+type CustomProvider struct {
+	ProviderID      string  `json:"provider_id,omitempty"`
+	JWK             string  `json:"jwk,omitempty"`
+	PEM             string  `json:"pem,omitempty"`
+	Secret          string  `json:"secret,omitempty"`
+	CookieFieldName *string `json:"cookie_field_name,omitempty"`
+	KeyType         KeyType `json:"key_type,omitempty"`
+}
+
+type ProviderSummary struct {
+	ProviderID string `json:"provider_id"`
+	Type       string `json:"type"`
+}
+
+type Provider struct {
+	ProviderID      string         `json:"provider_id"`
+	Type            string         `json:"type"`
+	PublishableKey  string         `json:"publishable_key,omitempty"`
+	JWK             string         `json:"jwk,omitempty"`
+	PEM             string         `json:"pem,omitempty"`
+	CookieFieldName *string        `json:"cookie_field_name,omitempty"`
+	KeyType         KeyType        `json:"key_type,omitempty"`
+	Endpoint        string         `json:"endpoint,omitempty"`
+	ClientID        string         `json:"client_id,omitempty"`
+	UserIDClaim     string         `json:"user_id_claim,omitempty"`
+	Keys            []*ProviderKey `json:"keys,omitempty"`
+}
+
+type UpdateProviderRequest struct {
+	PublishableKey  string                 `json:"publishable_key,omitempty"`
+	JWK             string                 `json:"jwk,omitempty"`
+	PEM             string                 `json:"pem,omitempty"`
+	Secret          string                 `json:"secret,omitempty"`
+	CookieFieldName *string                `json:"cookie_field_name,omitempty"`
+	KeyType         KeyType                `json:"key_type,omitempty"`
+	Introspection   *IntrospectionProvider `json:"introspection,omitempty"`
+}
+
+type AddProviderKeyRequest struct {
+	KID       string     `json:"kid,omitempty"`
+	PEM       string     `json:"pem"`
+	KeyType   KeyType    `json:"key_type"`
+	NotBefore *time.Time `json:"not_before,omitempty"`
+	NotAfter  *time.Time `json:"not_after,omitempty"`
+}
+
+type ProviderKey struct {
+	KeyID     string     `json:"key_id"`
+	KID       string     `json:"kid,omitempty"`
+	PEM       string     `json:"pem"`
+	KeyType   KeyType    `json:"key_type"`
+	NotBefore *time.Time `json:"not_before,omitempty"`
+	NotAfter  *time.Time `json:"not_after,omitempty"`
+}
+
+type AddClientCertificateRequest struct {
+	Name        string                `json:"name,omitempty"`
+	Fingerprint string                `json:"fingerprint"`
+	Type        ClientCertificateType `json:"type"`
+}
+
+type ClientCertificate struct {
+	CertificateID string                `json:"certificate_id"`
+	Name          string                `json:"name,omitempty"`
+	Fingerprint   string                `json:"fingerprint"`
+	Type          ClientCertificateType `json:"type"`
+	CreatedAt     time.Time             `json:"created_at"`
+}
+
+// OTPRequest asks for an OTP sent to exactly one of Email or Phone, or to
+// neither when DangerouslySkipVerification is set.
+type OTPRequest struct {
+	UserID                      string  `json:"user_id"`
+	DangerouslySkipVerification bool    `json:"dangerously_skip_verification"`
+	Email                       *string `json:"email"`
+	Phone                       *string `json:"phone"`
+}
+
+type EncryptionSessionRequest struct {
+	EncryptionPart string  `json:"encryption_part"`
+	UserID         string  `json:"user_id"`
+	OTPCode        *string `json:"otp_code"`
+}
+
+type ProjectArchive struct {
+	ProjectID  string                      `json:"project_id"`
+	ExportedAt int64                       `json:"exported_at"`
+	Tables     map[string][]map[string]any `json:"tables"`
+}
+
+type DeletionRequest struct {
+	ConfirmationToken string          `json:"confirmation_token"`
+	ExpiresAt         int64           `json:"expires_at"`
+	ArchiveDigest     string          `json:"archive_digest"`
+	Archive           *ProjectArchive `json:"archive"`
+}
+
+type DeletionReceipt struct {
+	ID            string           `json:"id"`
+	ProjectID     string           `json:"project_id"`
+	ProjectName   string           `json:"project_name"`
+	Mode          DeletionMode     `json:"mode"`
+	ArchiveDigest string           `json:"archive_digest"`
+	DeletedRows   map[string]int64 `json:"deleted_rows"`
+	DeletedAt     int64            `json:"deleted_at"`
+}
+
+// ListUsersOptions filters the users of a project, zero fields don't filter.
+type ListUsersOptions struct {
+	Provider      string
+	Entropy       Entropy
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	Cursor        string
+	Limit         int
+}
+
+type UsersPage struct {
+	Users []*UserSummary `json:"users"`
+	// NextCursor is empty on the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type UserSummary struct {
+	UserID        string          `json:"user_id"`
+	CreatedAt     int64           `json:"created_at"`
+	ExternalUsers []*ExternalUser `json:"external_users"`
+	KeychainID    *string         `json:"keychain_id,omitempty"`
+	ShareCount    int             `json:"share_count"`
+	Shares        []*ShareSummary `json:"shares"`
+}
+
+type ExternalUser struct {
+	ProviderID     string `json:"provider_id"`
+	ExternalUserID string `json:"external_user_id"`
+}
+
+type ShareSummary struct {
+	Reference string  `json:"reference"`
+	Entropy   Entropy `json:"entropy"`
+}
+
+type UserExport struct {
+	ProjectID      string                      `json:"project_id"`
+	ExternalUserID string                      `json:"external_user_id"`
+	ExportedAt     int64                       `json:"exported_at"`
+	Tables         map[string][]map[string]any `json:"tables"`
+}
+
+type ErasureReceipt struct {
+	ID                 string           `json:"id"`
+	ProjectID          string           `json:"project_id"`
+	ExternalUserIDHash string           `json:"external_user_id_hash"`
+	DeletedRows        map[string]int64 `json:"deleted_rows"`
+	ErasedAt           int64            `json:"erased_at"`
+}
+
+type Invite struct {
+	ID        string    `json:"id"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type Share struct {
+	Secret            string            `json:"secret"`
+	Entropy           Entropy           `json:"entropy"`
+	Salt              string            `json:"salt,omitempty"`
+	Iterations        int               `json:"iterations,omitempty"`
+	Length            int               `json:"length,omitempty"`
+	Digest            string            `json:"digest,omitempty"`
+	EncryptionPart    string            `json:"encryption_part,omitempty"`
+	EncryptionSession string            `json:"encryption_session,omitempty"`
+	Reference         string            `json:"reference,omitempty"`
+	StorageMethodID   StorageMethodID   `json:"storage_method_id,omitempty"`
+	PasskeyReference  *PasskeyReference `json:"passkey_reference,omitempty"`
+	KeychainID        string            `json:"keychain_id,omitempty"`
+	Metadata          map[string]string `json:"metadata,omitempty"`
+	// ETag is the version of the share as it was read, UpdateShare only
+	// applies to that version when it's set.
+	ETag string `json:"etag,omitempty"`
+}
+
+func (s *Share) readETag(header http.Header) {
+	s.ETag = header.Get(etagHeader)
+}
+
+type PasskeyEnv struct {
+	Name      *string `json:"name"`
+	OS        *string `json:"os"`
+	OSVersion *string `json:"osVersion"`
+	Device    *string `json:"device"`
+}
+
+type PasskeyReference struct {
+	PasskeyID  *string     `json:"passkey_id"`
+	PasskeyEnv *PasskeyEnv `json:"PasskeyEnv,omitempty"`
+}
+
+type StorageMethod struct {
+	ID   StorageMethodID `json:"id"`
+	Name string          `json:"name"`
+}
+
+type KeychainMetadata struct {
+	ID         string               `json:"id"`
+	CreatedAt  time.Time            `json:"created_at"`
+	UpdatedAt  time.Time            `json:"updated_at"`
+	References []*ReferenceMetadata `json:"references"`
+}
+
+type ReferenceMetadata struct {
+	Reference       string            `json:"reference"`
+	Entropy         Entropy           `json:"entropy"`
+	StorageMethodID StorageMethodID   `json:"storage_method_id"`
+	PasskeyID       string            `json:"passkey_id,omitempty"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+}
+
+// KeychainFilter selects the shares of a keychain, Metadata labels must all
+// match. Reference selects a single share and can't be combined with
+// ReferencePrefix or Entropy.
+type KeychainFilter struct {
+	Reference       string
+	ReferencePrefix string
+	Entropy         Entropy
+	Metadata        map[string]string
+	// WithoutSecrets returns the shares with empty secrets, nothing is
+	// decrypted so the encryption options aren't needed.
+	WithoutSecrets bool
+}
+
+type KeychainPage struct {
+	Shares []*Share `json:"shares"`
+	// NextCursor is empty on the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type ShareEncryption struct {
+	Entropy    Entropy `json:"entropy"`
+	Salt       *string `json:"salt,omitempty"`
+	Iterations *int    `json:"iterations,omitempty"`
+	Length     *int    `json:"length,omitempty"`
+	Digest     *string `json:"digest,omitempty"`
+}
+
+type EncryptionType struct {
+	Status         EncryptionTypeStatus `json:"status"`
+	EncryptionType *Entropy             `json:"encryption_type,omitempty"`
+	PasskeyID      *string              `json:"passkey_id,omitempty"`
+	PasskeyEnv     *PasskeyEnv          `json:"passkey_env,omitempty"`
+	Metadata       map[string]string    `json:"metadata,omitempty"`
+}
+
+type ExportedShare struct {
+	Secret           string            `json:"secret"`
+	Entropy          Entropy           `json:"entropy"`
+	Salt             string            `json:"salt,omitempty"`
+	Iterations       int               `json:"iterations,omitempty"`
+	Length           int               `json:"length,omitempty"`
+	Digest           string            `json:"digest,omitempty"`
+	Reference        string            `json:"reference,omitempty"`
+	StorageMethodID  StorageMethodID   `json:"storage_method_id"`
+	PasskeyReference *PasskeyReference `json:"passkey_reference,omitempty"`
+	Metadata         map[string]string `json:"metadata,omitempty"`
+}
+
+type ImportShareRequest struct {
+	UserID           string            `json:"user_id"`
+	Secret           string            `json:"secret"`
+	Entropy          Entropy           `json:"entropy"`
+	Salt             string            `json:"salt,omitempty"`
+	Iterations       int               `json:"iterations,omitempty"`
+	Length           int               `json:"length,omitempty"`
+	Digest           string            `json:"digest,omitempty"`
+	Reference        string            `json:"reference,omitempty"`
+	StorageMethodID  StorageMethodID   `json:"storage_method_id"`
+	PasskeyReference *PasskeyEnv       `json:"passkey_reference,omitempty"`
+	Metadata         map[string]string `json:"metadata,omitempty"`
+}
+
+// MigratedShare is one line of a bulk export, and of the bulk import that
+// takes it back in.
+type MigratedShare struct {
+	ID                string            `json:"id"`
+	UserID            string            `json:"user_id"`
+	Secret            string            `json:"secret,omitempty"`
+	Entropy           Entropy           `json:"entropy"`
+	Salt              string            `json:"salt,omitempty"`
+	Iterations        int               `json:"iterations,omitempty"`
+	Length            int               `json:"length,omitempty"`
+	Digest            string            `json:"digest,omitempty"`
+	Reference         string            `json:"reference,omitempty"`
+	StorageMethodID   StorageMethodID   `json:"storage_method_id"`
+	PasskeyReference  *PasskeyReference `json:"passkey_reference,omitempty"`
+	Metadata          map[string]string `json:"metadata,omitempty"`
+	TransferEncrypted bool              `json:"transfer_encrypted,omitempty"`
+	// Error is set on exported shares that couldn't be decrypted.
+	Error *Error `json:"error,omitempty"`
+}
+
+// ImportResult reports the outcome of one line of a bulk import, Line counts
+// from 1.
+type ImportResult struct {
+	Line   int          `json:"line"`
+	ID     string       `json:"id,omitempty"`
+	Status ImportStatus `json:"status"`
+	Error  *Error       `json:"error,omitempty"`
+}
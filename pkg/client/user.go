@@ -0,0 +1,199 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+)
+
+type AuthProvider string
+
+const (
+	AuthProviderOpenfort      AuthProvider = "openfort"
+	AuthProviderCustom        AuthProvider = "custom"
+	AuthProviderIntrospection AuthProvider = "introspection"
+)
+
+// UserCredentials authenticate a user of the project.
+type UserCredentials struct {
+	Token    string
+	Provider AuthProvider
+	// TokenCookie is the cookie the token is sent in, for custom providers
+	// configured with a cookie field. The Authorization header is used
+	// otherwise.
+	TokenCookie string
+	// OpenfortProvider and OpenfortTokenType identify third party tokens
+	// checked by Openfort.
+	OpenfortProvider  string
+	OpenfortTokenType string
+}
+
+// EncryptionOptions carry the project encryption part, or a session
+// registered for it, to read shares with project entropy.
+type EncryptionOptions struct {
+	Part    string
+	Session string
+}
+
+func (o EncryptionOptions) header() http.Header {
+	header := make(http.Header)
+	if o.Part != "" {
+		header.Set(encryptionPartHeader, o.Part)
+	}
+	if o.Session != "" {
+		header.Set(encryptionSessionHeader, o.Session)
+	}
+	return header
+}
+
+// UserClient calls the share and keychain endpoints on behalf of a user.
+type UserClient struct {
+	client *Client
+	creds  UserCredentials
+}
+
+// ForUser returns a client authenticated as the user, with the project's API
+// key.
+func (c *Client) ForUser(creds UserCredentials) *UserClient {
+	return &UserClient{client: c, creds: creds}
+}
+
+func (u *UserClient) auth(c *Client, req *http.Request, _ []byte) error {
+	req.Header.Set(apiKeyHeader, c.apiKey)
+	req.Header.Set(authProviderHeader, string(u.creds.Provider))
+	if u.creds.TokenCookie != "" {
+		req.AddCookie(&http.Cookie{Name: u.creds.TokenCookie, Value: u.creds.Token})
+	} else {
+		req.Header.Set("Authorization", "Bearer "+u.creds.Token)
+	}
+	if u.creds.OpenfortProvider != "" {
+		req.Header.Set(openfortProviderHeader, u.creds.OpenfortProvider)
+	}
+	if u.creds.OpenfortTokenType != "" {
+		req.Header.Set(openfortTokenTypeHeader, u.creds.OpenfortTokenType)
+	}
+	return nil
+}
+
+// GetShare returns the share of the user, decrypted with enc when it has
+// project entropy.
+func (u *UserClient) GetShare(ctx context.Context, enc EncryptionOptions) (*Share, error) {
+	var shr Share
+	err := u.client.do(ctx, &request{method: http.MethodGet, path: "/shares", header: enc.header(), auth: u.auth, out: &shr, onHeader: shr.readETag})
+	if err != nil {
+		return nil, err
+	}
+	return &shr, nil
+}
+
+func (u *UserClient) GetShareByReference(ctx context.Context, reference string, enc EncryptionOptions) (*Share, error) {
+	var shr Share
+	err := u.client.do(ctx, &request{method: http.MethodGet, path: "/shares/" + url.PathEscape(reference), header: enc.header(), auth: u.auth, out: &shr, onHeader: shr.readETag})
+	if err != nil {
+		return nil, err
+	}
+	return &shr, nil
+}
+
+// RegisterShare stores a new share, the encryption part or session of shares
+// with project entropy goes in the share itself.
+func (u *UserClient) RegisterShare(ctx context.Context, shr *Share) error {
+	return u.client.do(ctx, &request{method: http.MethodPost, path: "/shares", body: shr, auth: u.auth})
+}
+
+// UpdateShare updates the share with the reference of shr. When shr carries
+// the ETag it was read with, the update fails with ErrShareVersionMismatch if
+// the share changed since.
+func (u *UserClient) UpdateShare(ctx context.Context, shr *Share) (*Share, error) {
+	header := make(http.Header)
+	if shr.ETag != "" {
+		header.Set(ifMatchHeader, shr.ETag)
+	}
+	body := *shr
+	body.ETag = ""
+
+	var updated Share
+	err := u.client.do(ctx, &request{method: http.MethodPut, path: "/shares", header: header, body: &body, auth: u.auth, out: &updated, onHeader: updated.readETag})
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (u *UserClient) DeleteShare(ctx context.Context) error {
+	return u.client.do(ctx, &request{method: http.MethodDelete, path: "/shares", auth: u.auth})
+}
+
+func (u *UserClient) DeleteShareByReference(ctx context.Context, reference string) error {
+	return u.client.do(ctx, &request{method: http.MethodDelete, path: "/shares/" + url.PathEscape(reference), auth: u.auth})
+}
+
+// Keychain returns the shares of the user's keychain that match filter, all
+// of them at once. Use ListKeychain to page through large keychains.
+func (u *UserClient) Keychain(ctx context.Context, filter KeychainFilter, enc EncryptionOptions) ([]*Share, error) {
+	page, err := u.ListKeychain(ctx, filter, "", 0, enc)
+	if err != nil {
+		return nil, err
+	}
+	return page.Shares, nil
+}
+
+// ListKeychain returns a page of up to limit shares of the user's keychain
+// that match filter, pass its NextCursor as cursor to get the next one. A
+// limit of zero returns every share in a single page.
+func (u *UserClient) ListKeychain(ctx context.Context, filter KeychainFilter, cursor string, limit int, enc EncryptionOptions) (*KeychainPage, error) {
+	query := make(url.Values)
+	if filter.Reference != "" {
+		query.Set("reference", filter.Reference)
+	}
+	if filter.ReferencePrefix != "" {
+		query.Set("reference_prefix", filter.ReferencePrefix)
+	}
+	if filter.Entropy != "" {
+		query.Set("entropy", string(filter.Entropy))
+	}
+	keys := make([]string, 0, len(filter.Metadata))
+	for key := range filter.Metadata {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		query.Add("metadata", key+":"+filter.Metadata[key])
+	}
+	if filter.WithoutSecrets {
+		query.Set("fields", "metadata")
+	}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var page KeychainPage
+	err := u.client.do(ctx, &request{method: http.MethodGet, path: "/keychain", query: query, header: enc.header(), auth: u.auth, out: &page})
+	if err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+func (u *UserClient) KeychainMetadata(ctx context.Context) (*KeychainMetadata, error) {
+	var metadata KeychainMetadata
+	err := u.client.do(ctx, &request{method: http.MethodGet, path: "/keychain/metadata", auth: u.auth, out: &metadata})
+	if err != nil {
+		return nil, err
+	}
+	return &metadata, nil
+}
+
+func (u *UserClient) RenameReference(ctx context.Context, reference, newReference string) error {
+	return u.client.do(ctx, &request{
+		method: http.MethodPut,
+		path:   "/keychain/references/" + url.PathEscape(reference),
+		body:   map[string]string{"reference": newReference},
+		auth:   u.auth,
+	})
+}
@@ -70,3 +70,18 @@ func GetProject(ctx context.Context) *project.Project {
 
 	return proj
 }
+
+func WithProjectStatus(ctx context.Context, status project.Status) context.Context {
+	return context.WithValue(ctx, ContextKeyProjectStatus, status)
+}
+
+// GetProjectStatus returns StatusActive when no status was set, as for
+// requests that don't come through the API.
+func GetProjectStatus(ctx context.Context) project.Status {
+	status, ok := ctx.Value(ContextKeyProjectStatus).(project.Status)
+	if !ok || status == "" {
+		return project.StatusActive
+	}
+
+	return status
+}
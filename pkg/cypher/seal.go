@@ -0,0 +1,131 @@
+package cypher
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+)
+
+// sealVersion prefixes sealed messages so the layout can change later.
+const sealVersion byte = 1
+
+const sealInfo = "shield sealed box v1"
+
+var (
+	ErrInvalidSealingKey    = errors.New("invalid sealing key")
+	ErrInvalidSealedMessage = errors.New("invalid sealed message")
+)
+
+// GenerateSealingKeyPair returns a base64 encoded X25519 key pair. Anyone
+// holding the public key can seal a message only the private key opens.
+func GenerateSealingKeyPair() (privateKey string, publicKey string, err error) {
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(key.Bytes()), base64.StdEncoding.EncodeToString(key.PublicKey().Bytes()), nil
+}
+
+// Seal encrypts plaintext to the recipient's public key with an ephemeral
+// X25519 key, the shared secret keys AES-GCM through HKDF-SHA256.
+func Seal(plaintext []byte, publicKey string) ([]byte, error) {
+	rawKey, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil {
+		return nil, ErrInvalidSealingKey
+	}
+
+	recipient, err := ecdh.X25519().NewPublicKey(rawKey)
+	if err != nil {
+		return nil, ErrInvalidSealingKey
+	}
+
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	aesGCM, err := sealCipher(ephemeral, recipient, ephemeral.PublicKey(), recipient)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aesGCM.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed := append([]byte{sealVersion}, ephemeral.PublicKey().Bytes()...)
+	sealed = append(sealed, nonce...)
+	return aesGCM.Seal(sealed, nonce, plaintext, sealed[:1]), nil
+}
+
+// Open decrypts a message sealed to the public key of privateKey. It fails
+// with ErrInvalidSealedMessage when the message was sealed to another key or
+// changed since.
+func Open(sealed []byte, privateKey string) ([]byte, error) {
+	rawKey, err := base64.StdEncoding.DecodeString(privateKey)
+	if err != nil {
+		return nil, ErrInvalidSealingKey
+	}
+
+	key, err := ecdh.X25519().NewPrivateKey(rawKey)
+	if err != nil {
+		return nil, ErrInvalidSealingKey
+	}
+
+	const keySize = 32
+	if len(sealed) < 1+keySize || sealed[0] != sealVersion {
+		return nil, ErrInvalidSealedMessage
+	}
+
+	ephemeral, err := ecdh.X25519().NewPublicKey(sealed[1 : 1+keySize])
+	if err != nil {
+		return nil, ErrInvalidSealedMessage
+	}
+
+	aesGCM, err := sealCipher(key, ephemeral, ephemeral, key.PublicKey())
+	if err != nil {
+		return nil, ErrInvalidSealedMessage
+	}
+
+	header := 1 + keySize + aesGCM.NonceSize()
+	if len(sealed) < header {
+		return nil, ErrInvalidSealedMessage
+	}
+
+	plaintext, err := aesGCM.Open(nil, sealed[1+keySize:header], sealed[header:], sealed[:1])
+	if err != nil {
+		return nil, ErrInvalidSealedMessage
+	}
+
+	return plaintext, nil
+}
+
+// sealCipher binds the derived key to both public keys, a sealed message
+// can't be passed off as sealed to someone else.
+func sealCipher(key *ecdh.PrivateKey, peer *ecdh.PublicKey, ephemeral, recipient *ecdh.PublicKey) (cipher.AEAD, error) {
+	shared, err := key.ECDH(peer)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := append(ephemeral.Bytes(), recipient.Bytes()...)
+	aesKey, err := hkdf.Key(sha256.New, shared, salt, sealInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(aesKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
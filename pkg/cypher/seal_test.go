@@ -0,0 +1,36 @@
+package cypher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSealOpen(t *testing.T) {
+	privateKey, publicKey, err := GenerateSealingKeyPair()
+	require.NoError(t, err)
+	otherKey, _, err := GenerateSealingKeyPair()
+	require.NoError(t, err)
+
+	sealed, err := Seal([]byte("project archive"), publicKey)
+	require.NoError(t, err)
+
+	opened, err := Open(sealed, privateKey)
+	require.NoError(t, err)
+	assert.Equal(t, "project archive", string(opened))
+
+	_, err = Open(sealed, otherKey)
+	assert.ErrorIs(t, err, ErrInvalidSealedMessage)
+
+	tampered := append([]byte{}, sealed...)
+	tampered[len(tampered)-1] ^= 1
+	_, err = Open(tampered, privateKey)
+	assert.ErrorIs(t, err, ErrInvalidSealedMessage)
+
+	_, err = Open(sealed[:10], privateKey)
+	assert.ErrorIs(t, err, ErrInvalidSealedMessage)
+
+	_, err = Seal([]byte("project archive"), "not a key")
+	assert.ErrorIs(t, err, ErrInvalidSealingKey)
+}
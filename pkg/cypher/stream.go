@@ -0,0 +1,227 @@
+package cypher
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// streamVersion prefixes encrypted streams so the layout can change later.
+const streamVersion byte = 1
+
+const streamInfo = "shield stream v1"
+
+const (
+	streamSaltSize  = 32
+	streamChunkSize = 64 * 1024
+	// streamFinal marks the nonce of the last chunk, a stream cut at a chunk
+	// boundary doesn't end on one
+	streamFinal byte = 1
+)
+
+var (
+	ErrInvalidStreamKey = errors.New("invalid stream key")
+	ErrInvalidStream    = errors.New("invalid encrypted stream")
+)
+
+// NewStreamWriter encrypts everything written to it with the base64 encoded
+// 32 byte key, in authenticated chunks of 64 KiB. Close writes the last
+// chunk, a stream that wasn't closed can't be read back.
+func NewStreamWriter(w io.Writer, key string) (io.WriteCloser, error) {
+	salt := make([]byte, streamSaltSize)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return nil, err
+	}
+
+	header := append([]byte{streamVersion}, salt...)
+	aesGCM, err := streamCipher(key, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = w.Write(header)
+	if err != nil {
+		return nil, err
+	}
+
+	return &streamWriter{
+		w:      w,
+		aesGCM: aesGCM,
+		header: header,
+		buf:    make([]byte, 0, streamChunkSize),
+	}, nil
+}
+
+// NewStreamReader decrypts a stream written by NewStreamWriter. Reads fail
+// with ErrInvalidStream as soon as a chunk was changed, dropped or reordered,
+// or when the stream ends before its last chunk.
+func NewStreamReader(r io.Reader, key string) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	header := make([]byte, 1+streamSaltSize)
+	_, err := io.ReadFull(br, header)
+	if err != nil || header[0] != streamVersion {
+		return nil, ErrInvalidStream
+	}
+
+	aesGCM, err := streamCipher(key, header[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &streamReader{r: br, aesGCM: aesGCM, header: header}, nil
+}
+
+type streamWriter struct {
+	w       io.Writer
+	aesGCM  cipher.AEAD
+	header  []byte
+	buf     []byte
+	counter uint64
+	closed  bool
+}
+
+func (s *streamWriter) Write(p []byte) (int, error) {
+	if s.closed {
+		return 0, io.ErrClosedPipe
+	}
+
+	written := 0
+	for len(p) > 0 {
+		n := copy(s.buf[len(s.buf):cap(s.buf)], p)
+		s.buf = s.buf[:len(s.buf)+n]
+		p = p[n:]
+		written += n
+
+		// A full chunk is only written once more data follows, the last
+		// one has to be marked final
+		if len(s.buf) == cap(s.buf) && len(p) > 0 {
+			err := s.flush(false)
+			if err != nil {
+				return written, err
+			}
+		}
+	}
+
+	return written, nil
+}
+
+func (s *streamWriter) Close() error {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	return s.flush(true)
+}
+
+func (s *streamWriter) flush(final bool) error {
+	chunk := s.aesGCM.Seal(nil, streamNonce(s.aesGCM, s.counter, final), s.buf, s.header)
+	s.counter++
+	s.buf = s.buf[:0]
+
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(len(chunk)))
+	_, err := s.w.Write(size[:])
+	if err != nil {
+		return err
+	}
+
+	_, err = s.w.Write(chunk)
+	return err
+}
+
+type streamReader struct {
+	r       *bufio.Reader
+	aesGCM  cipher.AEAD
+	header  []byte
+	buf     []byte
+	counter uint64
+	done    bool
+}
+
+func (s *streamReader) Read(p []byte) (int, error) {
+	for len(s.buf) == 0 {
+		if s.done {
+			return 0, io.EOF
+		}
+
+		err := s.next()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, s.buf)
+	s.buf = s.buf[n:]
+	return n, nil
+}
+
+func (s *streamReader) next() error {
+	var size [4]byte
+	_, err := io.ReadFull(s.r, size[:])
+	if err != nil {
+		return ErrInvalidStream
+	}
+
+	length := binary.BigEndian.Uint32(size[:])
+	if length > streamChunkSize+uint32(s.aesGCM.Overhead()) {
+		return ErrInvalidStream
+	}
+
+	chunk := make([]byte, length)
+	_, err = io.ReadFull(s.r, chunk)
+	if err != nil {
+		return ErrInvalidStream
+	}
+
+	// Whether the chunk is the last one is told by what follows it, the
+	// final flag in its nonce then has to agree
+	_, err = s.r.Peek(1)
+	final := errors.Is(err, io.EOF)
+
+	s.buf, err = s.aesGCM.Open(chunk[:0], streamNonce(s.aesGCM, s.counter, final), chunk, s.header)
+	if err != nil {
+		return ErrInvalidStream
+	}
+
+	s.counter++
+	s.done = final
+	return nil
+}
+
+// streamCipher keys every stream differently through its salt, the counter
+// nonces can then start over for each one.
+func streamCipher(key string, salt []byte) (cipher.AEAD, error) {
+	rawKey, err := base64.StdEncoding.DecodeString(key)
+	if err != nil || len(rawKey) != 32 {
+		return nil, ErrInvalidStreamKey
+	}
+
+	aesKey, err := hkdf.Key(sha256.New, rawKey, salt, streamInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(aesKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func streamNonce(aesGCM cipher.AEAD, counter uint64, final bool) []byte {
+	nonce := make([]byte, aesGCM.NonceSize())
+	binary.BigEndian.PutUint64(nonce[len(nonce)-9:len(nonce)-1], counter)
+	if final {
+		nonce[len(nonce)-1] = streamFinal
+	}
+	return nonce
+}
@@ -0,0 +1,70 @@
+package cypher
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/openfort-xyz/shield/pkg/random"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStream(t *testing.T) {
+	key, err := random.GenerateRandomString(32)
+	require.NoError(t, err)
+	otherKey, err := random.GenerateRandomString(32)
+	require.NoError(t, err)
+
+	plaintext := bytes.Repeat([]byte("shield backup "), 3*streamChunkSize/14)
+
+	encrypt := func(t *testing.T, plaintext []byte) []byte {
+		var out bytes.Buffer
+		w, err := NewStreamWriter(&out, key)
+		require.NoError(t, err)
+		_, err = w.Write(plaintext)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		return out.Bytes()
+	}
+
+	decrypt := func(encrypted []byte, key string) ([]byte, error) {
+		r, err := NewStreamReader(bytes.NewReader(encrypted), key)
+		if err != nil {
+			return nil, err
+		}
+		return io.ReadAll(r)
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		for _, size := range []int{0, 1, streamChunkSize, len(plaintext)} {
+			decrypted, err := decrypt(encrypt(t, plaintext[:size]), key)
+			require.NoError(t, err)
+			assert.Equal(t, plaintext[:size], decrypted)
+		}
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		_, err := decrypt(encrypt(t, plaintext), otherKey)
+		assert.ErrorIs(t, err, ErrInvalidStream)
+	})
+
+	t.Run("truncated at a chunk boundary", func(t *testing.T) {
+		encrypted := encrypt(t, plaintext)
+		chunk := 4 + streamChunkSize + 16
+		_, err := decrypt(encrypted[:1+streamSaltSize+chunk], key)
+		assert.ErrorIs(t, err, ErrInvalidStream)
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		encrypted := encrypt(t, plaintext)
+		encrypted[len(encrypted)/2] ^= 1
+		_, err := decrypt(encrypted, key)
+		assert.ErrorIs(t, err, ErrInvalidStream)
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		_, err := NewStreamWriter(io.Discard, "not a key")
+		assert.ErrorIs(t, err, ErrInvalidStreamKey)
+	})
+}
@@ -0,0 +1,183 @@
+// Package lru is a bounded least recently used cache whose entries expire.
+//
+// Entries can be tagged with a group so they are dropped together, and every
+// invalidation bumps a generation so callers can refuse to cache a value
+// loaded while an invalidation was in flight.
+package lru
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type Cache[K comparable, V any] struct {
+	mu         sync.Mutex
+	size       int
+	order      *list.List
+	entries    map[K]*list.Element
+	groups     map[string]map[K]struct{}
+	generation uint64
+}
+
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	group     string
+	expiresAt time.Time
+}
+
+// New returns nil when size isn't positive, every method treats a nil cache
+// as always empty.
+func New[K comparable, V any](size int) *Cache[K, V] {
+	if size <= 0 {
+		return nil
+	}
+	return &Cache[K, V]{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[K]*list.Element),
+		groups:  make(map[string]map[K]struct{}),
+	}
+}
+
+// Get returns the value cached under key unless it expired by now, expired
+// entries are dropped on the way.
+func (c *Cache[K, V]) Get(key K, now time.Time) (V, bool) {
+	var zero V
+	if c == nil {
+		return zero, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return zero, false
+	}
+
+	e := elem.Value.(*entry[K, V])
+	if !now.Before(e.expiresAt) {
+		c.remove(elem)
+		return zero, false
+	}
+
+	c.order.MoveToFront(elem)
+	return e.value, true
+}
+
+// Generation is taken before a value is loaded and handed back to
+// SetIfGeneration.
+func (c *Cache[K, V]) Generation() uint64 {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+// Set caches value until expiresAt, evicting the least recently used entries
+// past the size. An empty group leaves the entry out of Invalidate.
+func (c *Cache[K, V]) Set(key K, value V, group string, expiresAt time.Time) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set(key, value, group, expiresAt)
+}
+
+// SetIfGeneration is Set, unless the cache was invalidated since generation
+// was taken.
+func (c *Cache[K, V]) SetIfGeneration(generation uint64, key K, value V, group string, expiresAt time.Time) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+	c.set(key, value, group, expiresAt)
+}
+
+// Invalidate drops every entry of the group.
+func (c *Cache[K, V]) Invalidate(group string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	for key := range c.groups[group] {
+		c.remove(c.entries[key])
+	}
+}
+
+// Purge drops every entry.
+func (c *Cache[K, V]) Purge() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.order.Init()
+	c.entries = make(map[K]*list.Element)
+	c.groups = make(map[string]map[K]struct{})
+}
+
+func (c *Cache[K, V]) Len() int {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// set expects c.mu to be held.
+func (c *Cache[K, V]) set(key K, value V, group string, expiresAt time.Time) {
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+
+	c.entries[key] = c.order.PushFront(&entry[K, V]{key: key, value: value, group: group, expiresAt: expiresAt})
+	if group != "" {
+		if c.groups[group] == nil {
+			c.groups[group] = make(map[K]struct{})
+		}
+		c.groups[group][key] = struct{}{}
+	}
+
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+// remove expects c.mu to be held.
+func (c *Cache[K, V]) remove(elem *list.Element) {
+	e := elem.Value.(*entry[K, V])
+	c.order.Remove(elem)
+	delete(c.entries, e.key)
+
+	if e.group == "" {
+		return
+	}
+	keys := c.groups[e.group]
+	delete(keys, e.key)
+	if len(keys) == 0 {
+		delete(c.groups, e.group)
+	}
+}
@@ -0,0 +1,74 @@
+package lru
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	now := time.Now()
+
+	t.Run("hit until expiry", func(t *testing.T) {
+		c := New[string, int](10)
+		c.Set("key", 1, "", now.Add(time.Minute))
+
+		value, ok := c.Get("key", now)
+		assert.True(t, ok)
+		assert.Equal(t, 1, value)
+
+		_, ok = c.Get("key", now.Add(time.Minute))
+		assert.False(t, ok)
+		assert.Equal(t, 0, c.Len())
+	})
+
+	t.Run("least recently used is evicted", func(t *testing.T) {
+		c := New[string, int](2)
+		c.Set("a", 1, "", now.Add(time.Minute))
+		c.Set("b", 2, "", now.Add(time.Minute))
+		c.Get("a", now)
+		c.Set("c", 3, "", now.Add(time.Minute))
+
+		_, ok := c.Get("b", now)
+		assert.False(t, ok)
+		_, ok = c.Get("a", now)
+		assert.True(t, ok)
+		assert.Equal(t, 2, c.Len())
+	})
+
+	t.Run("invalidation drops the group", func(t *testing.T) {
+		c := New[string, int](10)
+		c.Set("a", 1, "group", now.Add(time.Minute))
+		c.Set("b", 2, "group", now.Add(time.Minute))
+		c.Set("c", 3, "other_group", now.Add(time.Minute))
+
+		c.Invalidate("group")
+		assert.Equal(t, 1, c.Len())
+		_, ok := c.Get("c", now)
+		assert.True(t, ok)
+
+		c.Purge()
+		assert.Equal(t, 0, c.Len())
+	})
+
+	t.Run("set racing an invalidation is dropped", func(t *testing.T) {
+		c := New[string, int](10)
+		generation := c.Generation()
+		c.Invalidate("group")
+		c.SetIfGeneration(generation, "key", 1, "group", now.Add(time.Minute))
+
+		_, ok := c.Get("key", now)
+		assert.False(t, ok)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		c := New[string, int](0)
+		assert.Nil(t, c)
+
+		c.Set("key", 1, "", now.Add(time.Minute))
+		_, ok := c.Get("key", now)
+		assert.False(t, ok)
+		assert.Equal(t, 0, c.Len())
+	})
+}
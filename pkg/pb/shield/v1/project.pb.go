@@ -0,0 +1,2010 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: shield/v1/project.proto
+
+package shieldv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ClientCertMode int32
+
+const (
+	ClientCertMode_CLIENT_CERT_MODE_UNSPECIFIED ClientCertMode = 0
+	ClientCertMode_CLIENT_CERT_MODE_DISABLED    ClientCertMode = 1
+	ClientCertMode_CLIENT_CERT_MODE_ALTERNATIVE ClientCertMode = 2
+	ClientCertMode_CLIENT_CERT_MODE_REQUIRED    ClientCertMode = 3
+)
+
+// Enum value maps for ClientCertMode.
+var (
+	ClientCertMode_name = map[int32]string{
+		0: "CLIENT_CERT_MODE_UNSPECIFIED",
+		1: "CLIENT_CERT_MODE_DISABLED",
+		2: "CLIENT_CERT_MODE_ALTERNATIVE",
+		3: "CLIENT_CERT_MODE_REQUIRED",
+	}
+	ClientCertMode_value = map[string]int32{
+		"CLIENT_CERT_MODE_UNSPECIFIED": 0,
+		"CLIENT_CERT_MODE_DISABLED":    1,
+		"CLIENT_CERT_MODE_ALTERNATIVE": 2,
+		"CLIENT_CERT_MODE_REQUIRED":    3,
+	}
+)
+
+func (x ClientCertMode) Enum() *ClientCertMode {
+	p := new(ClientCertMode)
+	*p = x
+	return p
+}
+
+func (x ClientCertMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ClientCertMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_shield_v1_project_proto_enumTypes[0].Descriptor()
+}
+
+func (ClientCertMode) Type() protoreflect.EnumType {
+	return &file_shield_v1_project_proto_enumTypes[0]
+}
+
+func (x ClientCertMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ClientCertMode.Descriptor instead.
+func (ClientCertMode) EnumDescriptor() ([]byte, []int) {
+	return file_shield_v1_project_proto_rawDescGZIP(), []int{0}
+}
+
+type ProjectStatus int32
+
+const (
+	ProjectStatus_PROJECT_STATUS_UNSPECIFIED ProjectStatus = 0
+	ProjectStatus_PROJECT_STATUS_ACTIVE      ProjectStatus = 1
+	// Shares can be read but not changed.
+	ProjectStatus_PROJECT_STATUS_READ_ONLY ProjectStatus = 2
+	ProjectStatus_PROJECT_STATUS_SUSPENDED ProjectStatus = 3
+)
+
+// Enum value maps for ProjectStatus.
+var (
+	ProjectStatus_name = map[int32]string{
+		0: "PROJECT_STATUS_UNSPECIFIED",
+		1: "PROJECT_STATUS_ACTIVE",
+		2: "PROJECT_STATUS_READ_ONLY",
+		3: "PROJECT_STATUS_SUSPENDED",
+	}
+	ProjectStatus_value = map[string]int32{
+		"PROJECT_STATUS_UNSPECIFIED": 0,
+		"PROJECT_STATUS_ACTIVE":      1,
+		"PROJECT_STATUS_READ_ONLY":   2,
+		"PROJECT_STATUS_SUSPENDED":   3,
+	}
+)
+
+func (x ProjectStatus) Enum() *ProjectStatus {
+	p := new(ProjectStatus)
+	*p = x
+	return p
+}
+
+func (x ProjectStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProjectStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_shield_v1_project_proto_enumTypes[1].Descriptor()
+}
+
+func (ProjectStatus) Type() protoreflect.EnumType {
+	return &file_shield_v1_project_proto_enumTypes[1]
+}
+
+func (x ProjectStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProjectStatus.Descriptor instead.
+func (ProjectStatus) EnumDescriptor() ([]byte, []int) {
+	return file_shield_v1_project_proto_rawDescGZIP(), []int{1}
+}
+
+type ProviderType int32
+
+const (
+	ProviderType_PROVIDER_TYPE_UNSPECIFIED   ProviderType = 0
+	ProviderType_PROVIDER_TYPE_OPENFORT      ProviderType = 1
+	ProviderType_PROVIDER_TYPE_CUSTOM        ProviderType = 2
+	ProviderType_PROVIDER_TYPE_INTROSPECTION ProviderType = 3
+)
+
+// Enum value maps for ProviderType.
+var (
+	ProviderType_name = map[int32]string{
+		0: "PROVIDER_TYPE_UNSPECIFIED",
+		1: "PROVIDER_TYPE_OPENFORT",
+		2: "PROVIDER_TYPE_CUSTOM",
+		3: "PROVIDER_TYPE_INTROSPECTION",
+	}
+	ProviderType_value = map[string]int32{
+		"PROVIDER_TYPE_UNSPECIFIED":   0,
+		"PROVIDER_TYPE_OPENFORT":      1,
+		"PROVIDER_TYPE_CUSTOM":        2,
+		"PROVIDER_TYPE_INTROSPECTION": 3,
+	}
+)
+
+func (x ProviderType) Enum() *ProviderType {
+	p := new(ProviderType)
+	*p = x
+	return p
+}
+
+func (x ProviderType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProviderType) Descriptor() protoreflect.EnumDescriptor {
+	return file_shield_v1_project_proto_enumTypes[2].Descriptor()
+}
+
+func (ProviderType) Type() protoreflect.EnumType {
+	return &file_shield_v1_project_proto_enumTypes[2]
+}
+
+func (x ProviderType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProviderType.Descriptor instead.
+func (ProviderType) EnumDescriptor() ([]byte, []int) {
+	return file_shield_v1_project_proto_rawDescGZIP(), []int{2}
+}
+
+type KeyType int32
+
+const (
+	KeyType_KEY_TYPE_UNSPECIFIED KeyType = 0
+	KeyType_KEY_TYPE_RSA         KeyType = 1
+	KeyType_KEY_TYPE_ECDSA       KeyType = 2
+	KeyType_KEY_TYPE_ED25519     KeyType = 3
+	KeyType_KEY_TYPE_HMAC        KeyType = 4
+)
+
+// Enum value maps for KeyType.
+var (
+	KeyType_name = map[int32]string{
+		0: "KEY_TYPE_UNSPECIFIED",
+		1: "KEY_TYPE_RSA",
+		2: "KEY_TYPE_ECDSA",
+		3: "KEY_TYPE_ED25519",
+		4: "KEY_TYPE_HMAC",
+	}
+	KeyType_value = map[string]int32{
+		"KEY_TYPE_UNSPECIFIED": 0,
+		"KEY_TYPE_RSA":         1,
+		"KEY_TYPE_ECDSA":       2,
+		"KEY_TYPE_ED25519":     3,
+		"KEY_TYPE_HMAC":        4,
+	}
+)
+
+func (x KeyType) Enum() *KeyType {
+	p := new(KeyType)
+	*p = x
+	return p
+}
+
+func (x KeyType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (KeyType) Descriptor() protoreflect.EnumDescriptor {
+	return file_shield_v1_project_proto_enumTypes[3].Descriptor()
+}
+
+func (KeyType) Type() protoreflect.EnumType {
+	return &file_shield_v1_project_proto_enumTypes[3]
+}
+
+func (x KeyType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use KeyType.Descriptor instead.
+func (KeyType) EnumDescriptor() ([]byte, []int) {
+	return file_shield_v1_project_proto_rawDescGZIP(), []int{3}
+}
+
+type Project struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Id                     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                   string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Enabled_2Fa            bool                   `protobuf:"varint,3,opt,name=enabled_2fa,json=enabled2fa,proto3" json:"enabled_2fa,omitempty"`
+	ClientCertMode         ClientCertMode         `protobuf:"varint,4,opt,name=client_cert_mode,json=clientCertMode,proto3,enum=shield.v1.ClientCertMode" json:"client_cert_mode,omitempty"`
+	SignedRequestsRequired bool                   `protobuf:"varint,5,opt,name=signed_requests_required,json=signedRequestsRequired,proto3" json:"signed_requests_required,omitempty"`
+	Status                 ProjectStatus          `protobuf:"varint,6,opt,name=status,proto3,enum=shield.v1.ProjectStatus" json:"status,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *Project) Reset() {
+	*x = Project{}
+	mi := &file_shield_v1_project_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Project) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Project) ProtoMessage() {}
+
+func (x *Project) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_project_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Project.ProtoReflect.Descriptor instead.
+func (*Project) Descriptor() ([]byte, []int) {
+	return file_shield_v1_project_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Project) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Project) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Project) GetEnabled_2Fa() bool {
+	if x != nil {
+		return x.Enabled_2Fa
+	}
+	return false
+}
+
+func (x *Project) GetClientCertMode() ClientCertMode {
+	if x != nil {
+		return x.ClientCertMode
+	}
+	return ClientCertMode_CLIENT_CERT_MODE_UNSPECIFIED
+}
+
+func (x *Project) GetSignedRequestsRequired() bool {
+	if x != nil {
+		return x.SignedRequestsRequired
+	}
+	return false
+}
+
+func (x *Project) GetStatus() ProjectStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ProjectStatus_PROJECT_STATUS_UNSPECIFIED
+}
+
+type GetProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProjectRequest) Reset() {
+	*x = GetProjectRequest{}
+	mi := &file_shield_v1_project_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProjectRequest) ProtoMessage() {}
+
+func (x *GetProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_project_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProjectRequest.ProtoReflect.Descriptor instead.
+func (*GetProjectRequest) Descriptor() ([]byte, []int) {
+	return file_shield_v1_project_proto_rawDescGZIP(), []int{1}
+}
+
+type GetProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProjectResponse) Reset() {
+	*x = GetProjectResponse{}
+	mi := &file_shield_v1_project_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProjectResponse) ProtoMessage() {}
+
+func (x *GetProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_project_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProjectResponse.ProtoReflect.Descriptor instead.
+func (*GetProjectResponse) Descriptor() ([]byte, []int) {
+	return file_shield_v1_project_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetProjectResponse) GetProject() *Project {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+type Provider struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	Type          ProviderType           `protobuf:"varint,2,opt,name=type,proto3,enum=shield.v1.ProviderType" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Provider) Reset() {
+	*x = Provider{}
+	mi := &file_shield_v1_project_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Provider) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Provider) ProtoMessage() {}
+
+func (x *Provider) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_project_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Provider.ProtoReflect.Descriptor instead.
+func (*Provider) Descriptor() ([]byte, []int) {
+	return file_shield_v1_project_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Provider) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+func (x *Provider) GetType() ProviderType {
+	if x != nil {
+		return x.Type
+	}
+	return ProviderType_PROVIDER_TYPE_UNSPECIFIED
+}
+
+// ProviderKey is one of the PEM keys a custom provider verifies tokens with.
+type ProviderKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyId         string                 `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	Kid           string                 `protobuf:"bytes,2,opt,name=kid,proto3" json:"kid,omitempty"`
+	Pem           string                 `protobuf:"bytes,3,opt,name=pem,proto3" json:"pem,omitempty"`
+	KeyType       KeyType                `protobuf:"varint,4,opt,name=key_type,json=keyType,proto3,enum=shield.v1.KeyType" json:"key_type,omitempty"`
+	NotBefore     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	NotAfter      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProviderKey) Reset() {
+	*x = ProviderKey{}
+	mi := &file_shield_v1_project_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProviderKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderKey) ProtoMessage() {}
+
+func (x *ProviderKey) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_project_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderKey.ProtoReflect.Descriptor instead.
+func (*ProviderKey) Descriptor() ([]byte, []int) {
+	return file_shield_v1_project_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ProviderKey) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *ProviderKey) GetKid() string {
+	if x != nil {
+		return x.Kid
+	}
+	return ""
+}
+
+func (x *ProviderKey) GetPem() string {
+	if x != nil {
+		return x.Pem
+	}
+	return ""
+}
+
+func (x *ProviderKey) GetKeyType() KeyType {
+	if x != nil {
+		return x.KeyType
+	}
+	return KeyType_KEY_TYPE_UNSPECIFIED
+}
+
+func (x *ProviderKey) GetNotBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotBefore
+	}
+	return nil
+}
+
+func (x *ProviderKey) GetNotAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotAfter
+	}
+	return nil
+}
+
+// ProviderDetail holds the configuration of a provider, only the fields of
+// its type are set. Secrets are never returned.
+type ProviderDetail struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ProviderId      string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	Type            ProviderType           `protobuf:"varint,2,opt,name=type,proto3,enum=shield.v1.ProviderType" json:"type,omitempty"`
+	PublishableKey  string                 `protobuf:"bytes,3,opt,name=publishable_key,json=publishableKey,proto3" json:"publishable_key,omitempty"`
+	Jwk             string                 `protobuf:"bytes,4,opt,name=jwk,proto3" json:"jwk,omitempty"`
+	Pem             string                 `protobuf:"bytes,5,opt,name=pem,proto3" json:"pem,omitempty"`
+	CookieFieldName *string                `protobuf:"bytes,6,opt,name=cookie_field_name,json=cookieFieldName,proto3,oneof" json:"cookie_field_name,omitempty"`
+	KeyType         KeyType                `protobuf:"varint,7,opt,name=key_type,json=keyType,proto3,enum=shield.v1.KeyType" json:"key_type,omitempty"`
+	Endpoint        string                 `protobuf:"bytes,8,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	ClientId        string                 `protobuf:"bytes,9,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	UserIdClaim     string                 `protobuf:"bytes,10,opt,name=user_id_claim,json=userIdClaim,proto3" json:"user_id_claim,omitempty"`
+	Keys            []*ProviderKey         `protobuf:"bytes,11,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ProviderDetail) Reset() {
+	*x = ProviderDetail{}
+	mi := &file_shield_v1_project_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProviderDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderDetail) ProtoMessage() {}
+
+func (x *ProviderDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_project_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderDetail.ProtoReflect.Descriptor instead.
+func (*ProviderDetail) Descriptor() ([]byte, []int) {
+	return file_shield_v1_project_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ProviderDetail) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+func (x *ProviderDetail) GetType() ProviderType {
+	if x != nil {
+		return x.Type
+	}
+	return ProviderType_PROVIDER_TYPE_UNSPECIFIED
+}
+
+func (x *ProviderDetail) GetPublishableKey() string {
+	if x != nil {
+		return x.PublishableKey
+	}
+	return ""
+}
+
+func (x *ProviderDetail) GetJwk() string {
+	if x != nil {
+		return x.Jwk
+	}
+	return ""
+}
+
+func (x *ProviderDetail) GetPem() string {
+	if x != nil {
+		return x.Pem
+	}
+	return ""
+}
+
+func (x *ProviderDetail) GetCookieFieldName() string {
+	if x != nil && x.CookieFieldName != nil {
+		return *x.CookieFieldName
+	}
+	return ""
+}
+
+func (x *ProviderDetail) GetKeyType() KeyType {
+	if x != nil {
+		return x.KeyType
+	}
+	return KeyType_KEY_TYPE_UNSPECIFIED
+}
+
+func (x *ProviderDetail) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *ProviderDetail) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *ProviderDetail) GetUserIdClaim() string {
+	if x != nil {
+		return x.UserIdClaim
+	}
+	return ""
+}
+
+func (x *ProviderDetail) GetKeys() []*ProviderKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type OpenfortProvider struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PublishableKey string                 `protobuf:"bytes,1,opt,name=publishable_key,json=publishableKey,proto3" json:"publishable_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *OpenfortProvider) Reset() {
+	*x = OpenfortProvider{}
+	mi := &file_shield_v1_project_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenfortProvider) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenfortProvider) ProtoMessage() {}
+
+func (x *OpenfortProvider) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_project_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenfortProvider.ProtoReflect.Descriptor instead.
+func (*OpenfortProvider) Descriptor() ([]byte, []int) {
+	return file_shield_v1_project_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *OpenfortProvider) GetPublishableKey() string {
+	if x != nil {
+		return x.PublishableKey
+	}
+	return ""
+}
+
+// CustomProvider verifies tokens with exactly one of a JWK set URL, a PEM
+// key or an HMAC secret.
+type CustomProvider struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Jwk             string                 `protobuf:"bytes,1,opt,name=jwk,proto3" json:"jwk,omitempty"`
+	Pem             string                 `protobuf:"bytes,2,opt,name=pem,proto3" json:"pem,omitempty"`
+	Secret          string                 `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"`
+	CookieFieldName *string                `protobuf:"bytes,4,opt,name=cookie_field_name,json=cookieFieldName,proto3,oneof" json:"cookie_field_name,omitempty"`
+	// Required along with pem.
+	KeyType       KeyType `protobuf:"varint,5,opt,name=key_type,json=keyType,proto3,enum=shield.v1.KeyType" json:"key_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomProvider) Reset() {
+	*x = CustomProvider{}
+	mi := &file_shield_v1_project_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomProvider) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomProvider) ProtoMessage() {}
+
+func (x *CustomProvider) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_project_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomProvider.ProtoReflect.Descriptor instead.
+func (*CustomProvider) Descriptor() ([]byte, []int) {
+	return file_shield_v1_project_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CustomProvider) GetJwk() string {
+	if x != nil {
+		return x.Jwk
+	}
+	return ""
+}
+
+func (x *CustomProvider) GetPem() string {
+	if x != nil {
+		return x.Pem
+	}
+	return ""
+}
+
+func (x *CustomProvider) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *CustomProvider) GetCookieFieldName() string {
+	if x != nil && x.CookieFieldName != nil {
+		return *x.CookieFieldName
+	}
+	return ""
+}
+
+func (x *CustomProvider) GetKeyType() KeyType {
+	if x != nil {
+		return x.KeyType
+	}
+	return KeyType_KEY_TYPE_UNSPECIFIED
+}
+
+// IntrospectionProvider verifies opaque tokens through an RFC 7662
+// introspection endpoint.
+type IntrospectionProvider struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Endpoint      string                 `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	ClientId      string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ClientSecret  string                 `protobuf:"bytes,3,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"`
+	UserIdClaim   string                 `protobuf:"bytes,4,opt,name=user_id_claim,json=userIdClaim,proto3" json:"user_id_claim,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IntrospectionProvider) Reset() {
+	*x = IntrospectionProvider{}
+	mi := &file_shield_v1_project_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IntrospectionProvider) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntrospectionProvider) ProtoMessage() {}
+
+func (x *IntrospectionProvider) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_project_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntrospectionProvider.ProtoReflect.Descriptor instead.
+func (*IntrospectionProvider) Descriptor() ([]byte, []int) {
+	return file_shield_v1_project_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *IntrospectionProvider) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *IntrospectionProvider) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *IntrospectionProvider) GetClientSecret() string {
+	if x != nil {
+		return x.ClientSecret
+	}
+	return ""
+}
+
+func (x *IntrospectionProvider) GetUserIdClaim() string {
+	if x != nil {
+		return x.UserIdClaim
+	}
+	return ""
+}
+
+type ListProvidersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProvidersRequest) Reset() {
+	*x = ListProvidersRequest{}
+	mi := &file_shield_v1_project_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProvidersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProvidersRequest) ProtoMessage() {}
+
+func (x *ListProvidersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_project_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProvidersRequest.ProtoReflect.Descriptor instead.
+func (*ListProvidersRequest) Descriptor() ([]byte, []int) {
+	return file_shield_v1_project_proto_rawDescGZIP(), []int{9}
+}
+
+type ListProvidersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Providers     []*Provider            `protobuf:"bytes,1,rep,name=providers,proto3" json:"providers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProvidersResponse) Reset() {
+	*x = ListProvidersResponse{}
+	mi := &file_shield_v1_project_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProvidersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProvidersResponse) ProtoMessage() {}
+
+func (x *ListProvidersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_project_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProvidersResponse.ProtoReflect.Descriptor instead.
+func (*ListProvidersResponse) Descriptor() ([]byte, []int) {
+	return file_shield_v1_project_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListProvidersResponse) GetProviders() []*Provider {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+type GetProviderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProviderRequest) Reset() {
+	*x = GetProviderRequest{}
+	mi := &file_shield_v1_project_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProviderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProviderRequest) ProtoMessage() {}
+
+func (x *GetProviderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_project_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProviderRequest.ProtoReflect.Descriptor instead.
+func (*GetProviderRequest) Descriptor() ([]byte, []int) {
+	return file_shield_v1_project_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetProviderRequest) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+type GetProviderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      *ProviderDetail        `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProviderResponse) Reset() {
+	*x = GetProviderResponse{}
+	mi := &file_shield_v1_project_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProviderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProviderResponse) ProtoMessage() {}
+
+func (x *GetProviderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_project_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProviderResponse.ProtoReflect.Descriptor instead.
+func (*GetProviderResponse) Descriptor() ([]byte, []int) {
+	return file_shield_v1_project_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetProviderResponse) GetProvider() *ProviderDetail {
+	if x != nil {
+		return x.Provider
+	}
+	return nil
+}
+
+type AddProvidersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Openfort      *OpenfortProvider      `protobuf:"bytes,1,opt,name=openfort,proto3" json:"openfort,omitempty"`
+	Custom        *CustomProvider        `protobuf:"bytes,2,opt,name=custom,proto3" json:"custom,omitempty"`
+	Introspection *IntrospectionProvider `protobuf:"bytes,3,opt,name=introspection,proto3" json:"introspection,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddProvidersRequest) Reset() {
+	*x = AddProvidersRequest{}
+	mi := &file_shield_v1_project_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddProvidersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddProvidersRequest) ProtoMessage() {}
+
+func (x *AddProvidersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_project_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddProvidersRequest.ProtoReflect.Descriptor instead.
+func (*AddProvidersRequest) Descriptor() ([]byte, []int) {
+	return file_shield_v1_project_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AddProvidersRequest) GetOpenfort() *OpenfortProvider {
+	if x != nil {
+		return x.Openfort
+	}
+	return nil
+}
+
+func (x *AddProvidersRequest) GetCustom() *CustomProvider {
+	if x != nil {
+		return x.Custom
+	}
+	return nil
+}
+
+func (x *AddProvidersRequest) GetIntrospection() *IntrospectionProvider {
+	if x != nil {
+		return x.Introspection
+	}
+	return nil
+}
+
+type AddProvidersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Providers     []*Provider            `protobuf:"bytes,1,rep,name=providers,proto3" json:"providers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddProvidersResponse) Reset() {
+	*x = AddProvidersResponse{}
+	mi := &file_shield_v1_project_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddProvidersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddProvidersResponse) ProtoMessage() {}
+
+func (x *AddProvidersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_project_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddProvidersResponse.ProtoReflect.Descriptor instead.
+func (*AddProvidersResponse) Descriptor() ([]byte, []int) {
+	return file_shield_v1_project_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *AddProvidersResponse) GetProviders() []*Provider {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+// UpdateProviderRequest changes the fields that are set, they must belong to
+// the provider's type.
+type UpdateProviderRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ProviderId      string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	PublishableKey  string                 `protobuf:"bytes,2,opt,name=publishable_key,json=publishableKey,proto3" json:"publishable_key,omitempty"`
+	Jwk             string                 `protobuf:"bytes,3,opt,name=jwk,proto3" json:"jwk,omitempty"`
+	Pem             string                 `protobuf:"bytes,4,opt,name=pem,proto3" json:"pem,omitempty"`
+	Secret          string                 `protobuf:"bytes,5,opt,name=secret,proto3" json:"secret,omitempty"`
+	CookieFieldName *string                `protobuf:"bytes,6,opt,name=cookie_field_name,json=cookieFieldName,proto3,oneof" json:"cookie_field_name,omitempty"`
+	KeyType         KeyType                `protobuf:"varint,7,opt,name=key_type,json=keyType,proto3,enum=shield.v1.KeyType" json:"key_type,omitempty"`
+	Introspection   *IntrospectionProvider `protobuf:"bytes,8,opt,name=introspection,proto3" json:"introspection,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateProviderRequest) Reset() {
+	*x = UpdateProviderRequest{}
+	mi := &file_shield_v1_project_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProviderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProviderRequest) ProtoMessage() {}
+
+func (x *UpdateProviderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_project_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProviderRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProviderRequest) Descriptor() ([]byte, []int) {
+	return file_shield_v1_project_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UpdateProviderRequest) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+func (x *UpdateProviderRequest) GetPublishableKey() string {
+	if x != nil {
+		return x.PublishableKey
+	}
+	return ""
+}
+
+func (x *UpdateProviderRequest) GetJwk() string {
+	if x != nil {
+		return x.Jwk
+	}
+	return ""
+}
+
+func (x *UpdateProviderRequest) GetPem() string {
+	if x != nil {
+		return x.Pem
+	}
+	return ""
+}
+
+func (x *UpdateProviderRequest) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *UpdateProviderRequest) GetCookieFieldName() string {
+	if x != nil && x.CookieFieldName != nil {
+		return *x.CookieFieldName
+	}
+	return ""
+}
+
+func (x *UpdateProviderRequest) GetKeyType() KeyType {
+	if x != nil {
+		return x.KeyType
+	}
+	return KeyType_KEY_TYPE_UNSPECIFIED
+}
+
+func (x *UpdateProviderRequest) GetIntrospection() *IntrospectionProvider {
+	if x != nil {
+		return x.Introspection
+	}
+	return nil
+}
+
+type UpdateProviderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProviderResponse) Reset() {
+	*x = UpdateProviderResponse{}
+	mi := &file_shield_v1_project_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProviderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProviderResponse) ProtoMessage() {}
+
+func (x *UpdateProviderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_project_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProviderResponse.ProtoReflect.Descriptor instead.
+func (*UpdateProviderResponse) Descriptor() ([]byte, []int) {
+	return file_shield_v1_project_proto_rawDescGZIP(), []int{16}
+}
+
+type DeleteProviderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProviderId    string                 `protobuf:"bytes,1,opt,name=provider_id,json=providerId,proto3" json:"provider_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProviderRequest) Reset() {
+	*x = DeleteProviderRequest{}
+	mi := &file_shield_v1_project_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProviderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProviderRequest) ProtoMessage() {}
+
+func (x *DeleteProviderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_project_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProviderRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProviderRequest) Descriptor() ([]byte, []int) {
+	return file_shield_v1_project_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeleteProviderRequest) GetProviderId() string {
+	if x != nil {
+		return x.ProviderId
+	}
+	return ""
+}
+
+type DeleteProviderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProviderResponse) Reset() {
+	*x = DeleteProviderResponse{}
+	mi := &file_shield_v1_project_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProviderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProviderResponse) ProtoMessage() {}
+
+func (x *DeleteProviderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_project_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProviderResponse.ProtoReflect.Descriptor instead.
+func (*DeleteProviderResponse) Descriptor() ([]byte, []int) {
+	return file_shield_v1_project_proto_rawDescGZIP(), []int{18}
+}
+
+type RegisterEncryptionSessionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	EncryptionPart string                 `protobuf:"bytes,1,opt,name=encryption_part,json=encryptionPart,proto3" json:"encryption_part,omitempty"`
+	// The Shield user the session is for, required along with otp_code when
+	// the project has 2FA enabled.
+	UserId        string  `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OtpCode       *string `protobuf:"bytes,3,opt,name=otp_code,json=otpCode,proto3,oneof" json:"otp_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterEncryptionSessionRequest) Reset() {
+	*x = RegisterEncryptionSessionRequest{}
+	mi := &file_shield_v1_project_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterEncryptionSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterEncryptionSessionRequest) ProtoMessage() {}
+
+func (x *RegisterEncryptionSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_project_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterEncryptionSessionRequest.ProtoReflect.Descriptor instead.
+func (*RegisterEncryptionSessionRequest) Descriptor() ([]byte, []int) {
+	return file_shield_v1_project_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RegisterEncryptionSessionRequest) GetEncryptionPart() string {
+	if x != nil {
+		return x.EncryptionPart
+	}
+	return ""
+}
+
+func (x *RegisterEncryptionSessionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RegisterEncryptionSessionRequest) GetOtpCode() string {
+	if x != nil && x.OtpCode != nil {
+		return *x.OtpCode
+	}
+	return ""
+}
+
+type RegisterEncryptionSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterEncryptionSessionResponse) Reset() {
+	*x = RegisterEncryptionSessionResponse{}
+	mi := &file_shield_v1_project_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterEncryptionSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterEncryptionSessionResponse) ProtoMessage() {}
+
+func (x *RegisterEncryptionSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_project_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterEncryptionSessionResponse.ProtoReflect.Descriptor instead.
+func (*RegisterEncryptionSessionResponse) Descriptor() ([]byte, []int) {
+	return file_shield_v1_project_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RegisterEncryptionSessionResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+// EncryptionType describes how a share is encrypted, without its secret.
+type EncryptionType struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	Entropy       Entropy                `protobuf:"varint,2,opt,name=entropy,proto3,enum=shield.v1.Entropy" json:"entropy,omitempty"`
+	PasskeyId     *string                `protobuf:"bytes,3,opt,name=passkey_id,json=passkeyId,proto3,oneof" json:"passkey_id,omitempty"`
+	PasskeyEnv    *PasskeyEnv            `protobuf:"bytes,4,opt,name=passkey_env,json=passkeyEnv,proto3" json:"passkey_env,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EncryptionType) Reset() {
+	*x = EncryptionType{}
+	mi := &file_shield_v1_project_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EncryptionType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncryptionType) ProtoMessage() {}
+
+func (x *EncryptionType) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_project_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EncryptionType.ProtoReflect.Descriptor instead.
+func (*EncryptionType) Descriptor() ([]byte, []int) {
+	return file_shield_v1_project_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *EncryptionType) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *EncryptionType) GetEntropy() Entropy {
+	if x != nil {
+		return x.Entropy
+	}
+	return Entropy_ENTROPY_UNSPECIFIED
+}
+
+func (x *EncryptionType) GetPasskeyId() string {
+	if x != nil && x.PasskeyId != nil {
+		return *x.PasskeyId
+	}
+	return ""
+}
+
+func (x *EncryptionType) GetPasskeyEnv() *PasskeyEnv {
+	if x != nil {
+		return x.PasskeyEnv
+	}
+	return nil
+}
+
+func (x *EncryptionType) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type GetSharesEncryptionForReferencesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// At most 100 references.
+	References    []string `protobuf:"bytes,1,rep,name=references,proto3" json:"references,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSharesEncryptionForReferencesRequest) Reset() {
+	*x = GetSharesEncryptionForReferencesRequest{}
+	mi := &file_shield_v1_project_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSharesEncryptionForReferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSharesEncryptionForReferencesRequest) ProtoMessage() {}
+
+func (x *GetSharesEncryptionForReferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_project_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSharesEncryptionForReferencesRequest.ProtoReflect.Descriptor instead.
+func (*GetSharesEncryptionForReferencesRequest) Descriptor() ([]byte, []int) {
+	return file_shield_v1_project_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetSharesEncryptionForReferencesRequest) GetReferences() []string {
+	if x != nil {
+		return x.References
+	}
+	return nil
+}
+
+type GetSharesEncryptionForReferencesResponse struct {
+	state           protoimpl.MessageState     `protogen:"open.v1"`
+	EncryptionTypes map[string]*EncryptionType `protobuf:"bytes,1,rep,name=encryption_types,json=encryptionTypes,proto3" json:"encryption_types,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetSharesEncryptionForReferencesResponse) Reset() {
+	*x = GetSharesEncryptionForReferencesResponse{}
+	mi := &file_shield_v1_project_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSharesEncryptionForReferencesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSharesEncryptionForReferencesResponse) ProtoMessage() {}
+
+func (x *GetSharesEncryptionForReferencesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_project_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSharesEncryptionForReferencesResponse.ProtoReflect.Descriptor instead.
+func (*GetSharesEncryptionForReferencesResponse) Descriptor() ([]byte, []int) {
+	return file_shield_v1_project_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetSharesEncryptionForReferencesResponse) GetEncryptionTypes() map[string]*EncryptionType {
+	if x != nil {
+		return x.EncryptionTypes
+	}
+	return nil
+}
+
+type GetSharesEncryptionForUsersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// External user IDs, at most 100.
+	UserIds []string `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	// Picks the share of each user, the default share when not set.
+	Reference     *string `protobuf:"bytes,2,opt,name=reference,proto3,oneof" json:"reference,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSharesEncryptionForUsersRequest) Reset() {
+	*x = GetSharesEncryptionForUsersRequest{}
+	mi := &file_shield_v1_project_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSharesEncryptionForUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSharesEncryptionForUsersRequest) ProtoMessage() {}
+
+func (x *GetSharesEncryptionForUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_project_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSharesEncryptionForUsersRequest.ProtoReflect.Descriptor instead.
+func (*GetSharesEncryptionForUsersRequest) Descriptor() ([]byte, []int) {
+	return file_shield_v1_project_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetSharesEncryptionForUsersRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *GetSharesEncryptionForUsersRequest) GetReference() string {
+	if x != nil && x.Reference != nil {
+		return *x.Reference
+	}
+	return ""
+}
+
+type GetSharesEncryptionForUsersResponse struct {
+	state           protoimpl.MessageState     `protogen:"open.v1"`
+	EncryptionTypes map[string]*EncryptionType `protobuf:"bytes,1,rep,name=encryption_types,json=encryptionTypes,proto3" json:"encryption_types,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetSharesEncryptionForUsersResponse) Reset() {
+	*x = GetSharesEncryptionForUsersResponse{}
+	mi := &file_shield_v1_project_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSharesEncryptionForUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSharesEncryptionForUsersResponse) ProtoMessage() {}
+
+func (x *GetSharesEncryptionForUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_project_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSharesEncryptionForUsersResponse.ProtoReflect.Descriptor instead.
+func (*GetSharesEncryptionForUsersResponse) Descriptor() ([]byte, []int) {
+	return file_shield_v1_project_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetSharesEncryptionForUsersResponse) GetEncryptionTypes() map[string]*EncryptionType {
+	if x != nil {
+		return x.EncryptionTypes
+	}
+	return nil
+}
+
+var File_shield_v1_project_proto protoreflect.FileDescriptor
+
+const file_shield_v1_project_proto_rawDesc = "" +
+	"\n" +
+	"\x17shield/v1/project.proto\x12\tshield.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x15shield/v1/share.proto\"\xff\x01\n" +
+	"\aProject\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1f\n" +
+	"\venabled_2fa\x18\x03 \x01(\bR\n" +
+	"enabled2fa\x12C\n" +
+	"\x10client_cert_mode\x18\x04 \x01(\x0e2\x19.shield.v1.ClientCertModeR\x0eclientCertMode\x128\n" +
+	"\x18signed_requests_required\x18\x05 \x01(\bR\x16signedRequestsRequired\x120\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x18.shield.v1.ProjectStatusR\x06status\"\x13\n" +
+	"\x11GetProjectRequest\"B\n" +
+	"\x12GetProjectResponse\x12,\n" +
+	"\aproject\x18\x01 \x01(\v2\x12.shield.v1.ProjectR\aproject\"X\n" +
+	"\bProvider\x12\x1f\n" +
+	"\vprovider_id\x18\x01 \x01(\tR\n" +
+	"providerId\x12+\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x17.shield.v1.ProviderTypeR\x04type\"\xeb\x01\n" +
+	"\vProviderKey\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\tR\x05keyId\x12\x10\n" +
+	"\x03kid\x18\x02 \x01(\tR\x03kid\x12\x10\n" +
+	"\x03pem\x18\x03 \x01(\tR\x03pem\x12-\n" +
+	"\bkey_type\x18\x04 \x01(\x0e2\x12.shield.v1.KeyTypeR\akeyType\x129\n" +
+	"\n" +
+	"not_before\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tnotBefore\x127\n" +
+	"\tnot_after\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\bnotAfter\"\xaa\x03\n" +
+	"\x0eProviderDetail\x12\x1f\n" +
+	"\vprovider_id\x18\x01 \x01(\tR\n" +
+	"providerId\x12+\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x17.shield.v1.ProviderTypeR\x04type\x12'\n" +
+	"\x0fpublishable_key\x18\x03 \x01(\tR\x0epublishableKey\x12\x10\n" +
+	"\x03jwk\x18\x04 \x01(\tR\x03jwk\x12\x10\n" +
+	"\x03pem\x18\x05 \x01(\tR\x03pem\x12/\n" +
+	"\x11cookie_field_name\x18\x06 \x01(\tH\x00R\x0fcookieFieldName\x88\x01\x01\x12-\n" +
+	"\bkey_type\x18\a \x01(\x0e2\x12.shield.v1.KeyTypeR\akeyType\x12\x1a\n" +
+	"\bendpoint\x18\b \x01(\tR\bendpoint\x12\x1b\n" +
+	"\tclient_id\x18\t \x01(\tR\bclientId\x12\"\n" +
+	"\ruser_id_claim\x18\n" +
+	" \x01(\tR\vuserIdClaim\x12*\n" +
+	"\x04keys\x18\v \x03(\v2\x16.shield.v1.ProviderKeyR\x04keysB\x14\n" +
+	"\x12_cookie_field_name\";\n" +
+	"\x10OpenfortProvider\x12'\n" +
+	"\x0fpublishable_key\x18\x01 \x01(\tR\x0epublishableKey\"\xc2\x01\n" +
+	"\x0eCustomProvider\x12\x10\n" +
+	"\x03jwk\x18\x01 \x01(\tR\x03jwk\x12\x10\n" +
+	"\x03pem\x18\x02 \x01(\tR\x03pem\x12\x16\n" +
+	"\x06secret\x18\x03 \x01(\tR\x06secret\x12/\n" +
+	"\x11cookie_field_name\x18\x04 \x01(\tH\x00R\x0fcookieFieldName\x88\x01\x01\x12-\n" +
+	"\bkey_type\x18\x05 \x01(\x0e2\x12.shield.v1.KeyTypeR\akeyTypeB\x14\n" +
+	"\x12_cookie_field_name\"\x99\x01\n" +
+	"\x15IntrospectionProvider\x12\x1a\n" +
+	"\bendpoint\x18\x01 \x01(\tR\bendpoint\x12\x1b\n" +
+	"\tclient_id\x18\x02 \x01(\tR\bclientId\x12#\n" +
+	"\rclient_secret\x18\x03 \x01(\tR\fclientSecret\x12\"\n" +
+	"\ruser_id_claim\x18\x04 \x01(\tR\vuserIdClaim\"\x16\n" +
+	"\x14ListProvidersRequest\"J\n" +
+	"\x15ListProvidersResponse\x121\n" +
+	"\tproviders\x18\x01 \x03(\v2\x13.shield.v1.ProviderR\tproviders\"5\n" +
+	"\x12GetProviderRequest\x12\x1f\n" +
+	"\vprovider_id\x18\x01 \x01(\tR\n" +
+	"providerId\"L\n" +
+	"\x13GetProviderResponse\x125\n" +
+	"\bprovider\x18\x01 \x01(\v2\x19.shield.v1.ProviderDetailR\bprovider\"\xc9\x01\n" +
+	"\x13AddProvidersRequest\x127\n" +
+	"\bopenfort\x18\x01 \x01(\v2\x1b.shield.v1.OpenfortProviderR\bopenfort\x121\n" +
+	"\x06custom\x18\x02 \x01(\v2\x19.shield.v1.CustomProviderR\x06custom\x12F\n" +
+	"\rintrospection\x18\x03 \x01(\v2 .shield.v1.IntrospectionProviderR\rintrospection\"I\n" +
+	"\x14AddProvidersResponse\x121\n" +
+	"\tproviders\x18\x01 \x03(\v2\x13.shield.v1.ProviderR\tproviders\"\xdb\x02\n" +
+	"\x15UpdateProviderRequest\x12\x1f\n" +
+	"\vprovider_id\x18\x01 \x01(\tR\n" +
+	"providerId\x12'\n" +
+	"\x0fpublishable_key\x18\x02 \x01(\tR\x0epublishableKey\x12\x10\n" +
+	"\x03jwk\x18\x03 \x01(\tR\x03jwk\x12\x10\n" +
+	"\x03pem\x18\x04 \x01(\tR\x03pem\x12\x16\n" +
+	"\x06secret\x18\x05 \x01(\tR\x06secret\x12/\n" +
+	"\x11cookie_field_name\x18\x06 \x01(\tH\x00R\x0fcookieFieldName\x88\x01\x01\x12-\n" +
+	"\bkey_type\x18\a \x01(\x0e2\x12.shield.v1.KeyTypeR\akeyType\x12F\n" +
+	"\rintrospection\x18\b \x01(\v2 .shield.v1.IntrospectionProviderR\rintrospectionB\x14\n" +
+	"\x12_cookie_field_name\"\x18\n" +
+	"\x16UpdateProviderResponse\"8\n" +
+	"\x15DeleteProviderRequest\x12\x1f\n" +
+	"\vprovider_id\x18\x01 \x01(\tR\n" +
+	"providerId\"\x18\n" +
+	"\x16DeleteProviderResponse\"\x91\x01\n" +
+	" RegisterEncryptionSessionRequest\x12'\n" +
+	"\x0fencryption_part\x18\x01 \x01(\tR\x0eencryptionPart\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1e\n" +
+	"\botp_code\x18\x03 \x01(\tH\x00R\aotpCode\x88\x01\x01B\v\n" +
+	"\t_otp_code\"B\n" +
+	"!RegisterEncryptionSessionResponse\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\"\xc1\x02\n" +
+	"\x0eEncryptionType\x12\x14\n" +
+	"\x05found\x18\x01 \x01(\bR\x05found\x12,\n" +
+	"\aentropy\x18\x02 \x01(\x0e2\x12.shield.v1.EntropyR\aentropy\x12\"\n" +
+	"\n" +
+	"passkey_id\x18\x03 \x01(\tH\x00R\tpasskeyId\x88\x01\x01\x126\n" +
+	"\vpasskey_env\x18\x04 \x01(\v2\x15.shield.v1.PasskeyEnvR\n" +
+	"passkeyEnv\x12C\n" +
+	"\bmetadata\x18\x05 \x03(\v2'.shield.v1.EncryptionType.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\r\n" +
+	"\v_passkey_id\"I\n" +
+	"'GetSharesEncryptionForReferencesRequest\x12\x1e\n" +
+	"\n" +
+	"references\x18\x01 \x03(\tR\n" +
+	"references\"\xfe\x01\n" +
+	"(GetSharesEncryptionForReferencesResponse\x12s\n" +
+	"\x10encryption_types\x18\x01 \x03(\v2H.shield.v1.GetSharesEncryptionForReferencesResponse.EncryptionTypesEntryR\x0fencryptionTypes\x1a]\n" +
+	"\x14EncryptionTypesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12/\n" +
+	"\x05value\x18\x02 \x01(\v2\x19.shield.v1.EncryptionTypeR\x05value:\x028\x01\"p\n" +
+	"\"GetSharesEncryptionForUsersRequest\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds\x12!\n" +
+	"\treference\x18\x02 \x01(\tH\x00R\treference\x88\x01\x01B\f\n" +
+	"\n" +
+	"_reference\"\xf4\x01\n" +
+	"#GetSharesEncryptionForUsersResponse\x12n\n" +
+	"\x10encryption_types\x18\x01 \x03(\v2C.shield.v1.GetSharesEncryptionForUsersResponse.EncryptionTypesEntryR\x0fencryptionTypes\x1a]\n" +
+	"\x14EncryptionTypesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12/\n" +
+	"\x05value\x18\x02 \x01(\v2\x19.shield.v1.EncryptionTypeR\x05value:\x028\x01*\x92\x01\n" +
+	"\x0eClientCertMode\x12 \n" +
+	"\x1cCLIENT_CERT_MODE_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19CLIENT_CERT_MODE_DISABLED\x10\x01\x12 \n" +
+	"\x1cCLIENT_CERT_MODE_ALTERNATIVE\x10\x02\x12\x1d\n" +
+	"\x19CLIENT_CERT_MODE_REQUIRED\x10\x03*\x86\x01\n" +
+	"\rProjectStatus\x12\x1e\n" +
+	"\x1aPROJECT_STATUS_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15PROJECT_STATUS_ACTIVE\x10\x01\x12\x1c\n" +
+	"\x18PROJECT_STATUS_READ_ONLY\x10\x02\x12\x1c\n" +
+	"\x18PROJECT_STATUS_SUSPENDED\x10\x03*\x84\x01\n" +
+	"\fProviderType\x12\x1d\n" +
+	"\x19PROVIDER_TYPE_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16PROVIDER_TYPE_OPENFORT\x10\x01\x12\x18\n" +
+	"\x14PROVIDER_TYPE_CUSTOM\x10\x02\x12\x1f\n" +
+	"\x1bPROVIDER_TYPE_INTROSPECTION\x10\x03*r\n" +
+	"\aKeyType\x12\x18\n" +
+	"\x14KEY_TYPE_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fKEY_TYPE_RSA\x10\x01\x12\x12\n" +
+	"\x0eKEY_TYPE_ECDSA\x10\x02\x12\x14\n" +
+	"\x10KEY_TYPE_ED25519\x10\x03\x12\x11\n" +
+	"\rKEY_TYPE_HMAC\x10\x042\x80\a\n" +
+	"\x0eProjectService\x12I\n" +
+	"\n" +
+	"GetProject\x12\x1c.shield.v1.GetProjectRequest\x1a\x1d.shield.v1.GetProjectResponse\x12R\n" +
+	"\rListProviders\x12\x1f.shield.v1.ListProvidersRequest\x1a .shield.v1.ListProvidersResponse\x12L\n" +
+	"\vGetProvider\x12\x1d.shield.v1.GetProviderRequest\x1a\x1e.shield.v1.GetProviderResponse\x12O\n" +
+	"\fAddProviders\x12\x1e.shield.v1.AddProvidersRequest\x1a\x1f.shield.v1.AddProvidersResponse\x12U\n" +
+	"\x0eUpdateProvider\x12 .shield.v1.UpdateProviderRequest\x1a!.shield.v1.UpdateProviderResponse\x12U\n" +
+	"\x0eDeleteProvider\x12 .shield.v1.DeleteProviderRequest\x1a!.shield.v1.DeleteProviderResponse\x12v\n" +
+	"\x19RegisterEncryptionSession\x12+.shield.v1.RegisterEncryptionSessionRequest\x1a,.shield.v1.RegisterEncryptionSessionResponse\x12\x8b\x01\n" +
+	" GetSharesEncryptionForReferences\x122.shield.v1.GetSharesEncryptionForReferencesRequest\x1a3.shield.v1.GetSharesEncryptionForReferencesResponse\x12|\n" +
+	"\x1bGetSharesEncryptionForUsers\x12-.shield.v1.GetSharesEncryptionForUsersRequest\x1a..shield.v1.GetSharesEncryptionForUsersResponseB:Z8github.com/openfort-xyz/shield/pkg/pb/shield/v1;shieldv1b\x06proto3"
+
+var (
+	file_shield_v1_project_proto_rawDescOnce sync.Once
+	file_shield_v1_project_proto_rawDescData []byte
+)
+
+func file_shield_v1_project_proto_rawDescGZIP() []byte {
+	file_shield_v1_project_proto_rawDescOnce.Do(func() {
+		file_shield_v1_project_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_shield_v1_project_proto_rawDesc), len(file_shield_v1_project_proto_rawDesc)))
+	})
+	return file_shield_v1_project_proto_rawDescData
+}
+
+var file_shield_v1_project_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_shield_v1_project_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_shield_v1_project_proto_goTypes = []any{
+	(ClientCertMode)(0),                              // 0: shield.v1.ClientCertMode
+	(ProjectStatus)(0),                               // 1: shield.v1.ProjectStatus
+	(ProviderType)(0),                                // 2: shield.v1.ProviderType
+	(KeyType)(0),                                     // 3: shield.v1.KeyType
+	(*Project)(nil),                                  // 4: shield.v1.Project
+	(*GetProjectRequest)(nil),                        // 5: shield.v1.GetProjectRequest
+	(*GetProjectResponse)(nil),                       // 6: shield.v1.GetProjectResponse
+	(*Provider)(nil),                                 // 7: shield.v1.Provider
+	(*ProviderKey)(nil),                              // 8: shield.v1.ProviderKey
+	(*ProviderDetail)(nil),                           // 9: shield.v1.ProviderDetail
+	(*OpenfortProvider)(nil),                         // 10: shield.v1.OpenfortProvider
+	(*CustomProvider)(nil),                           // 11: shield.v1.CustomProvider
+	(*IntrospectionProvider)(nil),                    // 12: shield.v1.IntrospectionProvider
+	(*ListProvidersRequest)(nil),                     // 13: shield.v1.ListProvidersRequest
+	(*ListProvidersResponse)(nil),                    // 14: shield.v1.ListProvidersResponse
+	(*GetProviderRequest)(nil),                       // 15: shield.v1.GetProviderRequest
+	(*GetProviderResponse)(nil),                      // 16: shield.v1.GetProviderResponse
+	(*AddProvidersRequest)(nil),                      // 17: shield.v1.AddProvidersRequest
+	(*AddProvidersResponse)(nil),                     // 18: shield.v1.AddProvidersResponse
+	(*UpdateProviderRequest)(nil),                    // 19: shield.v1.UpdateProviderRequest
+	(*UpdateProviderResponse)(nil),                   // 20: shield.v1.UpdateProviderResponse
+	(*DeleteProviderRequest)(nil),                    // 21: shield.v1.DeleteProviderRequest
+	(*DeleteProviderResponse)(nil),                   // 22: shield.v1.DeleteProviderResponse
+	(*RegisterEncryptionSessionRequest)(nil),         // 23: shield.v1.RegisterEncryptionSessionRequest
+	(*RegisterEncryptionSessionResponse)(nil),        // 24: shield.v1.RegisterEncryptionSessionResponse
+	(*EncryptionType)(nil),                           // 25: shield.v1.EncryptionType
+	(*GetSharesEncryptionForReferencesRequest)(nil),  // 26: shield.v1.GetSharesEncryptionForReferencesRequest
+	(*GetSharesEncryptionForReferencesResponse)(nil), // 27: shield.v1.GetSharesEncryptionForReferencesResponse
+	(*GetSharesEncryptionForUsersRequest)(nil),       // 28: shield.v1.GetSharesEncryptionForUsersRequest
+	(*GetSharesEncryptionForUsersResponse)(nil),      // 29: shield.v1.GetSharesEncryptionForUsersResponse
+	nil,                           // 30: shield.v1.EncryptionType.MetadataEntry
+	nil,                           // 31: shield.v1.GetSharesEncryptionForReferencesResponse.EncryptionTypesEntry
+	nil,                           // 32: shield.v1.GetSharesEncryptionForUsersResponse.EncryptionTypesEntry
+	(*timestamppb.Timestamp)(nil), // 33: google.protobuf.Timestamp
+	(Entropy)(0),                  // 34: shield.v1.Entropy
+	(*PasskeyEnv)(nil),            // 35: shield.v1.PasskeyEnv
+}
+var file_shield_v1_project_proto_depIdxs = []int32{
+	0,  // 0: shield.v1.Project.client_cert_mode:type_name -> shield.v1.ClientCertMode
+	1,  // 1: shield.v1.Project.status:type_name -> shield.v1.ProjectStatus
+	4,  // 2: shield.v1.GetProjectResponse.project:type_name -> shield.v1.Project
+	2,  // 3: shield.v1.Provider.type:type_name -> shield.v1.ProviderType
+	3,  // 4: shield.v1.ProviderKey.key_type:type_name -> shield.v1.KeyType
+	33, // 5: shield.v1.ProviderKey.not_before:type_name -> google.protobuf.Timestamp
+	33, // 6: shield.v1.ProviderKey.not_after:type_name -> google.protobuf.Timestamp
+	2,  // 7: shield.v1.ProviderDetail.type:type_name -> shield.v1.ProviderType
+	3,  // 8: shield.v1.ProviderDetail.key_type:type_name -> shield.v1.KeyType
+	8,  // 9: shield.v1.ProviderDetail.keys:type_name -> shield.v1.ProviderKey
+	3,  // 10: shield.v1.CustomProvider.key_type:type_name -> shield.v1.KeyType
+	7,  // 11: shield.v1.ListProvidersResponse.providers:type_name -> shield.v1.Provider
+	9,  // 12: shield.v1.GetProviderResponse.provider:type_name -> shield.v1.ProviderDetail
+	10, // 13: shield.v1.AddProvidersRequest.openfort:type_name -> shield.v1.OpenfortProvider
+	11, // 14: shield.v1.AddProvidersRequest.custom:type_name -> shield.v1.CustomProvider
+	12, // 15: shield.v1.AddProvidersRequest.introspection:type_name -> shield.v1.IntrospectionProvider
+	7,  // 16: shield.v1.AddProvidersResponse.providers:type_name -> shield.v1.Provider
+	3,  // 17: shield.v1.UpdateProviderRequest.key_type:type_name -> shield.v1.KeyType
+	12, // 18: shield.v1.UpdateProviderRequest.introspection:type_name -> shield.v1.IntrospectionProvider
+	34, // 19: shield.v1.EncryptionType.entropy:type_name -> shield.v1.Entropy
+	35, // 20: shield.v1.EncryptionType.passkey_env:type_name -> shield.v1.PasskeyEnv
+	30, // 21: shield.v1.EncryptionType.metadata:type_name -> shield.v1.EncryptionType.MetadataEntry
+	31, // 22: shield.v1.GetSharesEncryptionForReferencesResponse.encryption_types:type_name -> shield.v1.GetSharesEncryptionForReferencesResponse.EncryptionTypesEntry
+	32, // 23: shield.v1.GetSharesEncryptionForUsersResponse.encryption_types:type_name -> shield.v1.GetSharesEncryptionForUsersResponse.EncryptionTypesEntry
+	25, // 24: shield.v1.GetSharesEncryptionForReferencesResponse.EncryptionTypesEntry.value:type_name -> shield.v1.EncryptionType
+	25, // 25: shield.v1.GetSharesEncryptionForUsersResponse.EncryptionTypesEntry.value:type_name -> shield.v1.EncryptionType
+	5,  // 26: shield.v1.ProjectService.GetProject:input_type -> shield.v1.GetProjectRequest
+	13, // 27: shield.v1.ProjectService.ListProviders:input_type -> shield.v1.ListProvidersRequest
+	15, // 28: shield.v1.ProjectService.GetProvider:input_type -> shield.v1.GetProviderRequest
+	17, // 29: shield.v1.ProjectService.AddProviders:input_type -> shield.v1.AddProvidersRequest
+	19, // 30: shield.v1.ProjectService.UpdateProvider:input_type -> shield.v1.UpdateProviderRequest
+	21, // 31: shield.v1.ProjectService.DeleteProvider:input_type -> shield.v1.DeleteProviderRequest
+	23, // 32: shield.v1.ProjectService.RegisterEncryptionSession:input_type -> shield.v1.RegisterEncryptionSessionRequest
+	26, // 33: shield.v1.ProjectService.GetSharesEncryptionForReferences:input_type -> shield.v1.GetSharesEncryptionForReferencesRequest
+	28, // 34: shield.v1.ProjectService.GetSharesEncryptionForUsers:input_type -> shield.v1.GetSharesEncryptionForUsersRequest
+	6,  // 35: shield.v1.ProjectService.GetProject:output_type -> shield.v1.GetProjectResponse
+	14, // 36: shield.v1.ProjectService.ListProviders:output_type -> shield.v1.ListProvidersResponse
+	16, // 37: shield.v1.ProjectService.GetProvider:output_type -> shield.v1.GetProviderResponse
+	18, // 38: shield.v1.ProjectService.AddProviders:output_type -> shield.v1.AddProvidersResponse
+	20, // 39: shield.v1.ProjectService.UpdateProvider:output_type -> shield.v1.UpdateProviderResponse
+	22, // 40: shield.v1.ProjectService.DeleteProvider:output_type -> shield.v1.DeleteProviderResponse
+	24, // 41: shield.v1.ProjectService.RegisterEncryptionSession:output_type -> shield.v1.RegisterEncryptionSessionResponse
+	27, // 42: shield.v1.ProjectService.GetSharesEncryptionForReferences:output_type -> shield.v1.GetSharesEncryptionForReferencesResponse
+	29, // 43: shield.v1.ProjectService.GetSharesEncryptionForUsers:output_type -> shield.v1.GetSharesEncryptionForUsersResponse
+	35, // [35:44] is the sub-list for method output_type
+	26, // [26:35] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
+}
+
+func init() { file_shield_v1_project_proto_init() }
+func file_shield_v1_project_proto_init() {
+	if File_shield_v1_project_proto != nil {
+		return
+	}
+	file_shield_v1_share_proto_init()
+	file_shield_v1_project_proto_msgTypes[5].OneofWrappers = []any{}
+	file_shield_v1_project_proto_msgTypes[7].OneofWrappers = []any{}
+	file_shield_v1_project_proto_msgTypes[15].OneofWrappers = []any{}
+	file_shield_v1_project_proto_msgTypes[19].OneofWrappers = []any{}
+	file_shield_v1_project_proto_msgTypes[21].OneofWrappers = []any{}
+	file_shield_v1_project_proto_msgTypes[24].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shield_v1_project_proto_rawDesc), len(file_shield_v1_project_proto_rawDesc)),
+			NumEnums:      4,
+			NumMessages:   29,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_shield_v1_project_proto_goTypes,
+		DependencyIndexes: file_shield_v1_project_proto_depIdxs,
+		EnumInfos:         file_shield_v1_project_proto_enumTypes,
+		MessageInfos:      file_shield_v1_project_proto_msgTypes,
+	}.Build()
+	File_shield_v1_project_proto = out.File
+	file_shield_v1_project_proto_goTypes = nil
+	file_shield_v1_project_proto_depIdxs = nil
+}
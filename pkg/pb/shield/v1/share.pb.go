@@ -0,0 +1,1444 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: shield/v1/share.proto
+
+package shieldv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Entropy is the source of the key a share's secret is encrypted with.
+type Entropy int32
+
+const (
+	Entropy_ENTROPY_UNSPECIFIED Entropy = 0
+	// The secret isn't encrypted by Shield.
+	Entropy_ENTROPY_NONE Entropy = 1
+	// The secret is encrypted with a key derived from a user password, as
+	// described by the share's encryption parameters.
+	Entropy_ENTROPY_USER Entropy = 2
+	// The secret is encrypted with the project's encryption key.
+	Entropy_ENTROPY_PROJECT Entropy = 3
+	// The secret is encrypted with a key held by one of the user's passkeys.
+	Entropy_ENTROPY_PASSKEY Entropy = 4
+)
+
+// Enum value maps for Entropy.
+var (
+	Entropy_name = map[int32]string{
+		0: "ENTROPY_UNSPECIFIED",
+		1: "ENTROPY_NONE",
+		2: "ENTROPY_USER",
+		3: "ENTROPY_PROJECT",
+		4: "ENTROPY_PASSKEY",
+	}
+	Entropy_value = map[string]int32{
+		"ENTROPY_UNSPECIFIED": 0,
+		"ENTROPY_NONE":        1,
+		"ENTROPY_USER":        2,
+		"ENTROPY_PROJECT":     3,
+		"ENTROPY_PASSKEY":     4,
+	}
+)
+
+func (x Entropy) Enum() *Entropy {
+	p := new(Entropy)
+	*p = x
+	return p
+}
+
+func (x Entropy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Entropy) Descriptor() protoreflect.EnumDescriptor {
+	return file_shield_v1_share_proto_enumTypes[0].Descriptor()
+}
+
+func (Entropy) Type() protoreflect.EnumType {
+	return &file_shield_v1_share_proto_enumTypes[0]
+}
+
+func (x Entropy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Entropy.Descriptor instead.
+func (Entropy) EnumDescriptor() ([]byte, []int) {
+	return file_shield_v1_share_proto_rawDescGZIP(), []int{0}
+}
+
+// StorageMethod is where the share is kept. Its values match the storage
+// method IDs of the REST API, so Shield is the default.
+type StorageMethod int32
+
+const (
+	StorageMethod_STORAGE_METHOD_SHIELD       StorageMethod = 0
+	StorageMethod_STORAGE_METHOD_GOOGLE_DRIVE StorageMethod = 1
+	StorageMethod_STORAGE_METHOD_ICLOUD       StorageMethod = 2
+)
+
+// Enum value maps for StorageMethod.
+var (
+	StorageMethod_name = map[int32]string{
+		0: "STORAGE_METHOD_SHIELD",
+		1: "STORAGE_METHOD_GOOGLE_DRIVE",
+		2: "STORAGE_METHOD_ICLOUD",
+	}
+	StorageMethod_value = map[string]int32{
+		"STORAGE_METHOD_SHIELD":       0,
+		"STORAGE_METHOD_GOOGLE_DRIVE": 1,
+		"STORAGE_METHOD_ICLOUD":       2,
+	}
+)
+
+func (x StorageMethod) Enum() *StorageMethod {
+	p := new(StorageMethod)
+	*p = x
+	return p
+}
+
+func (x StorageMethod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StorageMethod) Descriptor() protoreflect.EnumDescriptor {
+	return file_shield_v1_share_proto_enumTypes[1].Descriptor()
+}
+
+func (StorageMethod) Type() protoreflect.EnumType {
+	return &file_shield_v1_share_proto_enumTypes[1]
+}
+
+func (x StorageMethod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StorageMethod.Descriptor instead.
+func (StorageMethod) EnumDescriptor() ([]byte, []int) {
+	return file_shield_v1_share_proto_rawDescGZIP(), []int{1}
+}
+
+// EncryptionParameters describe how the key of a user entropy share is
+// derived from the user's password.
+type EncryptionParameters struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Salt          string                 `protobuf:"bytes,1,opt,name=salt,proto3" json:"salt,omitempty"`
+	Iterations    int32                  `protobuf:"varint,2,opt,name=iterations,proto3" json:"iterations,omitempty"`
+	Length        int32                  `protobuf:"varint,3,opt,name=length,proto3" json:"length,omitempty"`
+	Digest        string                 `protobuf:"bytes,4,opt,name=digest,proto3" json:"digest,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EncryptionParameters) Reset() {
+	*x = EncryptionParameters{}
+	mi := &file_shield_v1_share_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EncryptionParameters) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncryptionParameters) ProtoMessage() {}
+
+func (x *EncryptionParameters) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_share_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EncryptionParameters.ProtoReflect.Descriptor instead.
+func (*EncryptionParameters) Descriptor() ([]byte, []int) {
+	return file_shield_v1_share_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EncryptionParameters) GetSalt() string {
+	if x != nil {
+		return x.Salt
+	}
+	return ""
+}
+
+func (x *EncryptionParameters) GetIterations() int32 {
+	if x != nil {
+		return x.Iterations
+	}
+	return 0
+}
+
+func (x *EncryptionParameters) GetLength() int32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *EncryptionParameters) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+// PasskeyEnv describes the environment a passkey was created in.
+type PasskeyEnv struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          *string                `protobuf:"bytes,1,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Os            *string                `protobuf:"bytes,2,opt,name=os,proto3,oneof" json:"os,omitempty"`
+	OsVersion     *string                `protobuf:"bytes,3,opt,name=os_version,json=osVersion,proto3,oneof" json:"os_version,omitempty"`
+	Device        *string                `protobuf:"bytes,4,opt,name=device,proto3,oneof" json:"device,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PasskeyEnv) Reset() {
+	*x = PasskeyEnv{}
+	mi := &file_shield_v1_share_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PasskeyEnv) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PasskeyEnv) ProtoMessage() {}
+
+func (x *PasskeyEnv) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_share_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PasskeyEnv.ProtoReflect.Descriptor instead.
+func (*PasskeyEnv) Descriptor() ([]byte, []int) {
+	return file_shield_v1_share_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PasskeyEnv) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *PasskeyEnv) GetOs() string {
+	if x != nil && x.Os != nil {
+		return *x.Os
+	}
+	return ""
+}
+
+func (x *PasskeyEnv) GetOsVersion() string {
+	if x != nil && x.OsVersion != nil {
+		return *x.OsVersion
+	}
+	return ""
+}
+
+func (x *PasskeyEnv) GetDevice() string {
+	if x != nil && x.Device != nil {
+		return *x.Device
+	}
+	return ""
+}
+
+type PasskeyReference struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PasskeyId     string                 `protobuf:"bytes,1,opt,name=passkey_id,json=passkeyId,proto3" json:"passkey_id,omitempty"`
+	PasskeyEnv    *PasskeyEnv            `protobuf:"bytes,2,opt,name=passkey_env,json=passkeyEnv,proto3" json:"passkey_env,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PasskeyReference) Reset() {
+	*x = PasskeyReference{}
+	mi := &file_shield_v1_share_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PasskeyReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PasskeyReference) ProtoMessage() {}
+
+func (x *PasskeyReference) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_share_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PasskeyReference.ProtoReflect.Descriptor instead.
+func (*PasskeyReference) Descriptor() ([]byte, []int) {
+	return file_shield_v1_share_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PasskeyReference) GetPasskeyId() string {
+	if x != nil {
+		return x.PasskeyId
+	}
+	return ""
+}
+
+func (x *PasskeyReference) GetPasskeyEnv() *PasskeyEnv {
+	if x != nil {
+		return x.PasskeyEnv
+	}
+	return nil
+}
+
+type Share struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Secret  string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	Entropy Entropy                `protobuf:"varint,2,opt,name=entropy,proto3,enum=shield.v1.Entropy" json:"entropy,omitempty"`
+	// Required when entropy is user, and only then.
+	EncryptionParameters *EncryptionParameters `protobuf:"bytes,3,opt,name=encryption_parameters,json=encryptionParameters,proto3" json:"encryption_parameters,omitempty"`
+	Reference            string                `protobuf:"bytes,4,opt,name=reference,proto3" json:"reference,omitempty"`
+	StorageMethod        StorageMethod         `protobuf:"varint,5,opt,name=storage_method,json=storageMethod,proto3,enum=shield.v1.StorageMethod" json:"storage_method,omitempty"`
+	// Required when entropy is passkey, and only then.
+	PasskeyReference *PasskeyReference `protobuf:"bytes,6,opt,name=passkey_reference,json=passkeyReference,proto3" json:"passkey_reference,omitempty"`
+	KeychainId       string            `protobuf:"bytes,7,opt,name=keychain_id,json=keychainId,proto3" json:"keychain_id,omitempty"`
+	Metadata         map[string]string `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Share) Reset() {
+	*x = Share{}
+	mi := &file_shield_v1_share_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Share) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Share) ProtoMessage() {}
+
+func (x *Share) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_share_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Share.ProtoReflect.Descriptor instead.
+func (*Share) Descriptor() ([]byte, []int) {
+	return file_shield_v1_share_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Share) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *Share) GetEntropy() Entropy {
+	if x != nil {
+		return x.Entropy
+	}
+	return Entropy_ENTROPY_UNSPECIFIED
+}
+
+func (x *Share) GetEncryptionParameters() *EncryptionParameters {
+	if x != nil {
+		return x.EncryptionParameters
+	}
+	return nil
+}
+
+func (x *Share) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *Share) GetStorageMethod() StorageMethod {
+	if x != nil {
+		return x.StorageMethod
+	}
+	return StorageMethod_STORAGE_METHOD_SHIELD
+}
+
+func (x *Share) GetPasskeyReference() *PasskeyReference {
+	if x != nil {
+		return x.PasskeyReference
+	}
+	return nil
+}
+
+func (x *Share) GetKeychainId() string {
+	if x != nil {
+		return x.KeychainId
+	}
+	return ""
+}
+
+func (x *Share) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type GetShareRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Reference string                 `protobuf:"bytes,1,opt,name=reference,proto3" json:"reference,omitempty"`
+	// Decrypts a project entropy share, only one of encryption_part and
+	// encryption_session is needed.
+	EncryptionPart    string `protobuf:"bytes,2,opt,name=encryption_part,json=encryptionPart,proto3" json:"encryption_part,omitempty"`
+	EncryptionSession string `protobuf:"bytes,3,opt,name=encryption_session,json=encryptionSession,proto3" json:"encryption_session,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetShareRequest) Reset() {
+	*x = GetShareRequest{}
+	mi := &file_shield_v1_share_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShareRequest) ProtoMessage() {}
+
+func (x *GetShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_share_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShareRequest.ProtoReflect.Descriptor instead.
+func (*GetShareRequest) Descriptor() ([]byte, []int) {
+	return file_shield_v1_share_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetShareRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *GetShareRequest) GetEncryptionPart() string {
+	if x != nil {
+		return x.EncryptionPart
+	}
+	return ""
+}
+
+func (x *GetShareRequest) GetEncryptionSession() string {
+	if x != nil {
+		return x.EncryptionSession
+	}
+	return ""
+}
+
+type GetShareResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Share         *Share                 `protobuf:"bytes,1,opt,name=share,proto3" json:"share,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShareResponse) Reset() {
+	*x = GetShareResponse{}
+	mi := &file_shield_v1_share_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShareResponse) ProtoMessage() {}
+
+func (x *GetShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_share_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShareResponse.ProtoReflect.Descriptor instead.
+func (*GetShareResponse) Descriptor() ([]byte, []int) {
+	return file_shield_v1_share_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetShareResponse) GetShare() *Share {
+	if x != nil {
+		return x.Share
+	}
+	return nil
+}
+
+type RegisterShareRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Share *Share                 `protobuf:"bytes,1,opt,name=share,proto3" json:"share,omitempty"`
+	// Encrypts a project entropy share, only one of encryption_part and
+	// encryption_session is needed.
+	EncryptionPart    string `protobuf:"bytes,2,opt,name=encryption_part,json=encryptionPart,proto3" json:"encryption_part,omitempty"`
+	EncryptionSession string `protobuf:"bytes,3,opt,name=encryption_session,json=encryptionSession,proto3" json:"encryption_session,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RegisterShareRequest) Reset() {
+	*x = RegisterShareRequest{}
+	mi := &file_shield_v1_share_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterShareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterShareRequest) ProtoMessage() {}
+
+func (x *RegisterShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_share_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterShareRequest.ProtoReflect.Descriptor instead.
+func (*RegisterShareRequest) Descriptor() ([]byte, []int) {
+	return file_shield_v1_share_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RegisterShareRequest) GetShare() *Share {
+	if x != nil {
+		return x.Share
+	}
+	return nil
+}
+
+func (x *RegisterShareRequest) GetEncryptionPart() string {
+	if x != nil {
+		return x.EncryptionPart
+	}
+	return ""
+}
+
+func (x *RegisterShareRequest) GetEncryptionSession() string {
+	if x != nil {
+		return x.EncryptionSession
+	}
+	return ""
+}
+
+type RegisterShareResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterShareResponse) Reset() {
+	*x = RegisterShareResponse{}
+	mi := &file_shield_v1_share_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterShareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterShareResponse) ProtoMessage() {}
+
+func (x *RegisterShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_share_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterShareResponse.ProtoReflect.Descriptor instead.
+func (*RegisterShareResponse) Descriptor() ([]byte, []int) {
+	return file_shield_v1_share_proto_rawDescGZIP(), []int{7}
+}
+
+type UpdateShareRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The share's reference picks the share to replace.
+	Share             *Share `protobuf:"bytes,1,opt,name=share,proto3" json:"share,omitempty"`
+	EncryptionPart    string `protobuf:"bytes,2,opt,name=encryption_part,json=encryptionPart,proto3" json:"encryption_part,omitempty"`
+	EncryptionSession string `protobuf:"bytes,3,opt,name=encryption_session,json=encryptionSession,proto3" json:"encryption_session,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *UpdateShareRequest) Reset() {
+	*x = UpdateShareRequest{}
+	mi := &file_shield_v1_share_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateShareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateShareRequest) ProtoMessage() {}
+
+func (x *UpdateShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_share_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateShareRequest.ProtoReflect.Descriptor instead.
+func (*UpdateShareRequest) Descriptor() ([]byte, []int) {
+	return file_shield_v1_share_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateShareRequest) GetShare() *Share {
+	if x != nil {
+		return x.Share
+	}
+	return nil
+}
+
+func (x *UpdateShareRequest) GetEncryptionPart() string {
+	if x != nil {
+		return x.EncryptionPart
+	}
+	return ""
+}
+
+func (x *UpdateShareRequest) GetEncryptionSession() string {
+	if x != nil {
+		return x.EncryptionSession
+	}
+	return ""
+}
+
+type UpdateShareResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Share         *Share                 `protobuf:"bytes,1,opt,name=share,proto3" json:"share,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateShareResponse) Reset() {
+	*x = UpdateShareResponse{}
+	mi := &file_shield_v1_share_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateShareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateShareResponse) ProtoMessage() {}
+
+func (x *UpdateShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_share_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateShareResponse.ProtoReflect.Descriptor instead.
+func (*UpdateShareResponse) Descriptor() ([]byte, []int) {
+	return file_shield_v1_share_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateShareResponse) GetShare() *Share {
+	if x != nil {
+		return x.Share
+	}
+	return nil
+}
+
+type DeleteShareRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reference     string                 `protobuf:"bytes,1,opt,name=reference,proto3" json:"reference,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteShareRequest) Reset() {
+	*x = DeleteShareRequest{}
+	mi := &file_shield_v1_share_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteShareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteShareRequest) ProtoMessage() {}
+
+func (x *DeleteShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_share_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteShareRequest.ProtoReflect.Descriptor instead.
+func (*DeleteShareRequest) Descriptor() ([]byte, []int) {
+	return file_shield_v1_share_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteShareRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+type DeleteShareResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteShareResponse) Reset() {
+	*x = DeleteShareResponse{}
+	mi := &file_shield_v1_share_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteShareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteShareResponse) ProtoMessage() {}
+
+func (x *DeleteShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_share_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteShareResponse.ProtoReflect.Descriptor instead.
+func (*DeleteShareResponse) Descriptor() ([]byte, []int) {
+	return file_shield_v1_share_proto_rawDescGZIP(), []int{11}
+}
+
+type GetKeychainRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only returns the share with this reference when set.
+	Reference string `protobuf:"bytes,1,opt,name=reference,proto3" json:"reference,omitempty"`
+	// Only returns the shares holding every one of these metadata entries.
+	Metadata          map[string]string `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	EncryptionPart    string            `protobuf:"bytes,3,opt,name=encryption_part,json=encryptionPart,proto3" json:"encryption_part,omitempty"`
+	EncryptionSession string            `protobuf:"bytes,4,opt,name=encryption_session,json=encryptionSession,proto3" json:"encryption_session,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetKeychainRequest) Reset() {
+	*x = GetKeychainRequest{}
+	mi := &file_shield_v1_share_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeychainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeychainRequest) ProtoMessage() {}
+
+func (x *GetKeychainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_share_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeychainRequest.ProtoReflect.Descriptor instead.
+func (*GetKeychainRequest) Descriptor() ([]byte, []int) {
+	return file_shield_v1_share_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetKeychainRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *GetKeychainRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *GetKeychainRequest) GetEncryptionPart() string {
+	if x != nil {
+		return x.EncryptionPart
+	}
+	return ""
+}
+
+func (x *GetKeychainRequest) GetEncryptionSession() string {
+	if x != nil {
+		return x.EncryptionSession
+	}
+	return ""
+}
+
+type GetKeychainResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shares        []*Share               `protobuf:"bytes,1,rep,name=shares,proto3" json:"shares,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeychainResponse) Reset() {
+	*x = GetKeychainResponse{}
+	mi := &file_shield_v1_share_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeychainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeychainResponse) ProtoMessage() {}
+
+func (x *GetKeychainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_share_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeychainResponse.ProtoReflect.Descriptor instead.
+func (*GetKeychainResponse) Descriptor() ([]byte, []int) {
+	return file_shield_v1_share_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetKeychainResponse) GetShares() []*Share {
+	if x != nil {
+		return x.Shares
+	}
+	return nil
+}
+
+type GetKeychainMetadataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeychainMetadataRequest) Reset() {
+	*x = GetKeychainMetadataRequest{}
+	mi := &file_shield_v1_share_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeychainMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeychainMetadataRequest) ProtoMessage() {}
+
+func (x *GetKeychainMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_share_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeychainMetadataRequest.ProtoReflect.Descriptor instead.
+func (*GetKeychainMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_shield_v1_share_proto_rawDescGZIP(), []int{14}
+}
+
+type GetKeychainMetadataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	References    []*ReferenceMetadata   `protobuf:"bytes,4,rep,name=references,proto3" json:"references,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeychainMetadataResponse) Reset() {
+	*x = GetKeychainMetadataResponse{}
+	mi := &file_shield_v1_share_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeychainMetadataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeychainMetadataResponse) ProtoMessage() {}
+
+func (x *GetKeychainMetadataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_share_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeychainMetadataResponse.ProtoReflect.Descriptor instead.
+func (*GetKeychainMetadataResponse) Descriptor() ([]byte, []int) {
+	return file_shield_v1_share_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetKeychainMetadataResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetKeychainMetadataResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *GetKeychainMetadataResponse) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *GetKeychainMetadataResponse) GetReferences() []*ReferenceMetadata {
+	if x != nil {
+		return x.References
+	}
+	return nil
+}
+
+type ReferenceMetadata struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reference     string                 `protobuf:"bytes,1,opt,name=reference,proto3" json:"reference,omitempty"`
+	Entropy       Entropy                `protobuf:"varint,2,opt,name=entropy,proto3,enum=shield.v1.Entropy" json:"entropy,omitempty"`
+	StorageMethod StorageMethod          `protobuf:"varint,3,opt,name=storage_method,json=storageMethod,proto3,enum=shield.v1.StorageMethod" json:"storage_method,omitempty"`
+	PasskeyId     string                 `protobuf:"bytes,4,opt,name=passkey_id,json=passkeyId,proto3" json:"passkey_id,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReferenceMetadata) Reset() {
+	*x = ReferenceMetadata{}
+	mi := &file_shield_v1_share_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReferenceMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReferenceMetadata) ProtoMessage() {}
+
+func (x *ReferenceMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_share_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReferenceMetadata.ProtoReflect.Descriptor instead.
+func (*ReferenceMetadata) Descriptor() ([]byte, []int) {
+	return file_shield_v1_share_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ReferenceMetadata) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *ReferenceMetadata) GetEntropy() Entropy {
+	if x != nil {
+		return x.Entropy
+	}
+	return Entropy_ENTROPY_UNSPECIFIED
+}
+
+func (x *ReferenceMetadata) GetStorageMethod() StorageMethod {
+	if x != nil {
+		return x.StorageMethod
+	}
+	return StorageMethod_STORAGE_METHOD_SHIELD
+}
+
+func (x *ReferenceMetadata) GetPasskeyId() string {
+	if x != nil {
+		return x.PasskeyId
+	}
+	return ""
+}
+
+func (x *ReferenceMetadata) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ReferenceMetadata) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ReferenceMetadata) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type RenameReferenceRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Reference string                 `protobuf:"bytes,1,opt,name=reference,proto3" json:"reference,omitempty"`
+	// Must not be taken by any other share of the project.
+	NewReference  string `protobuf:"bytes,2,opt,name=new_reference,json=newReference,proto3" json:"new_reference,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameReferenceRequest) Reset() {
+	*x = RenameReferenceRequest{}
+	mi := &file_shield_v1_share_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameReferenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameReferenceRequest) ProtoMessage() {}
+
+func (x *RenameReferenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_share_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameReferenceRequest.ProtoReflect.Descriptor instead.
+func (*RenameReferenceRequest) Descriptor() ([]byte, []int) {
+	return file_shield_v1_share_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RenameReferenceRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *RenameReferenceRequest) GetNewReference() string {
+	if x != nil {
+		return x.NewReference
+	}
+	return ""
+}
+
+type RenameReferenceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameReferenceResponse) Reset() {
+	*x = RenameReferenceResponse{}
+	mi := &file_shield_v1_share_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameReferenceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameReferenceResponse) ProtoMessage() {}
+
+func (x *RenameReferenceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shield_v1_share_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameReferenceResponse.ProtoReflect.Descriptor instead.
+func (*RenameReferenceResponse) Descriptor() ([]byte, []int) {
+	return file_shield_v1_share_proto_rawDescGZIP(), []int{18}
+}
+
+var File_shield_v1_share_proto protoreflect.FileDescriptor
+
+const file_shield_v1_share_proto_rawDesc = "" +
+	"\n" +
+	"\x15shield/v1/share.proto\x12\tshield.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"z\n" +
+	"\x14EncryptionParameters\x12\x12\n" +
+	"\x04salt\x18\x01 \x01(\tR\x04salt\x12\x1e\n" +
+	"\n" +
+	"iterations\x18\x02 \x01(\x05R\n" +
+	"iterations\x12\x16\n" +
+	"\x06length\x18\x03 \x01(\x05R\x06length\x12\x16\n" +
+	"\x06digest\x18\x04 \x01(\tR\x06digest\"\xa5\x01\n" +
+	"\n" +
+	"PasskeyEnv\x12\x17\n" +
+	"\x04name\x18\x01 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x13\n" +
+	"\x02os\x18\x02 \x01(\tH\x01R\x02os\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"os_version\x18\x03 \x01(\tH\x02R\tosVersion\x88\x01\x01\x12\x1b\n" +
+	"\x06device\x18\x04 \x01(\tH\x03R\x06device\x88\x01\x01B\a\n" +
+	"\x05_nameB\x05\n" +
+	"\x03_osB\r\n" +
+	"\v_os_versionB\t\n" +
+	"\a_device\"i\n" +
+	"\x10PasskeyReference\x12\x1d\n" +
+	"\n" +
+	"passkey_id\x18\x01 \x01(\tR\tpasskeyId\x126\n" +
+	"\vpasskey_env\x18\x02 \x01(\v2\x15.shield.v1.PasskeyEnvR\n" +
+	"passkeyEnv\"\xe6\x03\n" +
+	"\x05Share\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x12,\n" +
+	"\aentropy\x18\x02 \x01(\x0e2\x12.shield.v1.EntropyR\aentropy\x12T\n" +
+	"\x15encryption_parameters\x18\x03 \x01(\v2\x1f.shield.v1.EncryptionParametersR\x14encryptionParameters\x12\x1c\n" +
+	"\treference\x18\x04 \x01(\tR\treference\x12?\n" +
+	"\x0estorage_method\x18\x05 \x01(\x0e2\x18.shield.v1.StorageMethodR\rstorageMethod\x12H\n" +
+	"\x11passkey_reference\x18\x06 \x01(\v2\x1b.shield.v1.PasskeyReferenceR\x10passkeyReference\x12\x1f\n" +
+	"\vkeychain_id\x18\a \x01(\tR\n" +
+	"keychainId\x12:\n" +
+	"\bmetadata\x18\b \x03(\v2\x1e.shield.v1.Share.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x87\x01\n" +
+	"\x0fGetShareRequest\x12\x1c\n" +
+	"\treference\x18\x01 \x01(\tR\treference\x12'\n" +
+	"\x0fencryption_part\x18\x02 \x01(\tR\x0eencryptionPart\x12-\n" +
+	"\x12encryption_session\x18\x03 \x01(\tR\x11encryptionSession\":\n" +
+	"\x10GetShareResponse\x12&\n" +
+	"\x05share\x18\x01 \x01(\v2\x10.shield.v1.ShareR\x05share\"\x96\x01\n" +
+	"\x14RegisterShareRequest\x12&\n" +
+	"\x05share\x18\x01 \x01(\v2\x10.shield.v1.ShareR\x05share\x12'\n" +
+	"\x0fencryption_part\x18\x02 \x01(\tR\x0eencryptionPart\x12-\n" +
+	"\x12encryption_session\x18\x03 \x01(\tR\x11encryptionSession\"\x17\n" +
+	"\x15RegisterShareResponse\"\x94\x01\n" +
+	"\x12UpdateShareRequest\x12&\n" +
+	"\x05share\x18\x01 \x01(\v2\x10.shield.v1.ShareR\x05share\x12'\n" +
+	"\x0fencryption_part\x18\x02 \x01(\tR\x0eencryptionPart\x12-\n" +
+	"\x12encryption_session\x18\x03 \x01(\tR\x11encryptionSession\"=\n" +
+	"\x13UpdateShareResponse\x12&\n" +
+	"\x05share\x18\x01 \x01(\v2\x10.shield.v1.ShareR\x05share\"2\n" +
+	"\x12DeleteShareRequest\x12\x1c\n" +
+	"\treference\x18\x01 \x01(\tR\treference\"\x15\n" +
+	"\x13DeleteShareResponse\"\x90\x02\n" +
+	"\x12GetKeychainRequest\x12\x1c\n" +
+	"\treference\x18\x01 \x01(\tR\treference\x12G\n" +
+	"\bmetadata\x18\x02 \x03(\v2+.shield.v1.GetKeychainRequest.MetadataEntryR\bmetadata\x12'\n" +
+	"\x0fencryption_part\x18\x03 \x01(\tR\x0eencryptionPart\x12-\n" +
+	"\x12encryption_session\x18\x04 \x01(\tR\x11encryptionSession\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"?\n" +
+	"\x13GetKeychainResponse\x12(\n" +
+	"\x06shares\x18\x01 \x03(\v2\x10.shield.v1.ShareR\x06shares\"\x1c\n" +
+	"\x1aGetKeychainMetadataRequest\"\xe1\x01\n" +
+	"\x1bGetKeychainMetadataResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12<\n" +
+	"\n" +
+	"references\x18\x04 \x03(\v2\x1c.shield.v1.ReferenceMetadataR\n" +
+	"references\"\xba\x03\n" +
+	"\x11ReferenceMetadata\x12\x1c\n" +
+	"\treference\x18\x01 \x01(\tR\treference\x12,\n" +
+	"\aentropy\x18\x02 \x01(\x0e2\x12.shield.v1.EntropyR\aentropy\x12?\n" +
+	"\x0estorage_method\x18\x03 \x01(\x0e2\x18.shield.v1.StorageMethodR\rstorageMethod\x12\x1d\n" +
+	"\n" +
+	"passkey_id\x18\x04 \x01(\tR\tpasskeyId\x12F\n" +
+	"\bmetadata\x18\x05 \x03(\v2*.shield.v1.ReferenceMetadata.MetadataEntryR\bmetadata\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"[\n" +
+	"\x16RenameReferenceRequest\x12\x1c\n" +
+	"\treference\x18\x01 \x01(\tR\treference\x12#\n" +
+	"\rnew_reference\x18\x02 \x01(\tR\fnewReference\"\x19\n" +
+	"\x17RenameReferenceResponse*p\n" +
+	"\aEntropy\x12\x17\n" +
+	"\x13ENTROPY_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fENTROPY_NONE\x10\x01\x12\x10\n" +
+	"\fENTROPY_USER\x10\x02\x12\x13\n" +
+	"\x0fENTROPY_PROJECT\x10\x03\x12\x13\n" +
+	"\x0fENTROPY_PASSKEY\x10\x04*f\n" +
+	"\rStorageMethod\x12\x19\n" +
+	"\x15STORAGE_METHOD_SHIELD\x10\x00\x12\x1f\n" +
+	"\x1bSTORAGE_METHOD_GOOGLE_DRIVE\x10\x01\x12\x19\n" +
+	"\x15STORAGE_METHOD_ICLOUD\x10\x022\xc3\x02\n" +
+	"\fShareService\x12C\n" +
+	"\bGetShare\x12\x1a.shield.v1.GetShareRequest\x1a\x1b.shield.v1.GetShareResponse\x12R\n" +
+	"\rRegisterShare\x12\x1f.shield.v1.RegisterShareRequest\x1a .shield.v1.RegisterShareResponse\x12L\n" +
+	"\vUpdateShare\x12\x1d.shield.v1.UpdateShareRequest\x1a\x1e.shield.v1.UpdateShareResponse\x12L\n" +
+	"\vDeleteShare\x12\x1d.shield.v1.DeleteShareRequest\x1a\x1e.shield.v1.DeleteShareResponse2\x9f\x02\n" +
+	"\x0fKeychainService\x12L\n" +
+	"\vGetKeychain\x12\x1d.shield.v1.GetKeychainRequest\x1a\x1e.shield.v1.GetKeychainResponse\x12d\n" +
+	"\x13GetKeychainMetadata\x12%.shield.v1.GetKeychainMetadataRequest\x1a&.shield.v1.GetKeychainMetadataResponse\x12X\n" +
+	"\x0fRenameReference\x12!.shield.v1.RenameReferenceRequest\x1a\".shield.v1.RenameReferenceResponseB:Z8github.com/openfort-xyz/shield/pkg/pb/shield/v1;shieldv1b\x06proto3"
+
+var (
+	file_shield_v1_share_proto_rawDescOnce sync.Once
+	file_shield_v1_share_proto_rawDescData []byte
+)
+
+func file_shield_v1_share_proto_rawDescGZIP() []byte {
+	file_shield_v1_share_proto_rawDescOnce.Do(func() {
+		file_shield_v1_share_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_shield_v1_share_proto_rawDesc), len(file_shield_v1_share_proto_rawDesc)))
+	})
+	return file_shield_v1_share_proto_rawDescData
+}
+
+var file_shield_v1_share_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_shield_v1_share_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_shield_v1_share_proto_goTypes = []any{
+	(Entropy)(0),                        // 0: shield.v1.Entropy
+	(StorageMethod)(0),                  // 1: shield.v1.StorageMethod
+	(*EncryptionParameters)(nil),        // 2: shield.v1.EncryptionParameters
+	(*PasskeyEnv)(nil),                  // 3: shield.v1.PasskeyEnv
+	(*PasskeyReference)(nil),            // 4: shield.v1.PasskeyReference
+	(*Share)(nil),                       // 5: shield.v1.Share
+	(*GetShareRequest)(nil),             // 6: shield.v1.GetShareRequest
+	(*GetShareResponse)(nil),            // 7: shield.v1.GetShareResponse
+	(*RegisterShareRequest)(nil),        // 8: shield.v1.RegisterShareRequest
+	(*RegisterShareResponse)(nil),       // 9: shield.v1.RegisterShareResponse
+	(*UpdateShareRequest)(nil),          // 10: shield.v1.UpdateShareRequest
+	(*UpdateShareResponse)(nil),         // 11: shield.v1.UpdateShareResponse
+	(*DeleteShareRequest)(nil),          // 12: shield.v1.DeleteShareRequest
+	(*DeleteShareResponse)(nil),         // 13: shield.v1.DeleteShareResponse
+	(*GetKeychainRequest)(nil),          // 14: shield.v1.GetKeychainRequest
+	(*GetKeychainResponse)(nil),         // 15: shield.v1.GetKeychainResponse
+	(*GetKeychainMetadataRequest)(nil),  // 16: shield.v1.GetKeychainMetadataRequest
+	(*GetKeychainMetadataResponse)(nil), // 17: shield.v1.GetKeychainMetadataResponse
+	(*ReferenceMetadata)(nil),           // 18: shield.v1.ReferenceMetadata
+	(*RenameReferenceRequest)(nil),      // 19: shield.v1.RenameReferenceRequest
+	(*RenameReferenceResponse)(nil),     // 20: shield.v1.RenameReferenceResponse
+	nil,                                 // 21: shield.v1.Share.MetadataEntry
+	nil,                                 // 22: shield.v1.GetKeychainRequest.MetadataEntry
+	nil,                                 // 23: shield.v1.ReferenceMetadata.MetadataEntry
+	(*timestamppb.Timestamp)(nil),       // 24: google.protobuf.Timestamp
+}
+var file_shield_v1_share_proto_depIdxs = []int32{
+	3,  // 0: shield.v1.PasskeyReference.passkey_env:type_name -> shield.v1.PasskeyEnv
+	0,  // 1: shield.v1.Share.entropy:type_name -> shield.v1.Entropy
+	2,  // 2: shield.v1.Share.encryption_parameters:type_name -> shield.v1.EncryptionParameters
+	1,  // 3: shield.v1.Share.storage_method:type_name -> shield.v1.StorageMethod
+	4,  // 4: shield.v1.Share.passkey_reference:type_name -> shield.v1.PasskeyReference
+	21, // 5: shield.v1.Share.metadata:type_name -> shield.v1.Share.MetadataEntry
+	5,  // 6: shield.v1.GetShareResponse.share:type_name -> shield.v1.Share
+	5,  // 7: shield.v1.RegisterShareRequest.share:type_name -> shield.v1.Share
+	5,  // 8: shield.v1.UpdateShareRequest.share:type_name -> shield.v1.Share
+	5,  // 9: shield.v1.UpdateShareResponse.share:type_name -> shield.v1.Share
+	22, // 10: shield.v1.GetKeychainRequest.metadata:type_name -> shield.v1.GetKeychainRequest.MetadataEntry
+	5,  // 11: shield.v1.GetKeychainResponse.shares:type_name -> shield.v1.Share
+	24, // 12: shield.v1.GetKeychainMetadataResponse.created_at:type_name -> google.protobuf.Timestamp
+	24, // 13: shield.v1.GetKeychainMetadataResponse.updated_at:type_name -> google.protobuf.Timestamp
+	18, // 14: shield.v1.GetKeychainMetadataResponse.references:type_name -> shield.v1.ReferenceMetadata
+	0,  // 15: shield.v1.ReferenceMetadata.entropy:type_name -> shield.v1.Entropy
+	1,  // 16: shield.v1.ReferenceMetadata.storage_method:type_name -> shield.v1.StorageMethod
+	23, // 17: shield.v1.ReferenceMetadata.metadata:type_name -> shield.v1.ReferenceMetadata.MetadataEntry
+	24, // 18: shield.v1.ReferenceMetadata.created_at:type_name -> google.protobuf.Timestamp
+	24, // 19: shield.v1.ReferenceMetadata.updated_at:type_name -> google.protobuf.Timestamp
+	6,  // 20: shield.v1.ShareService.GetShare:input_type -> shield.v1.GetShareRequest
+	8,  // 21: shield.v1.ShareService.RegisterShare:input_type -> shield.v1.RegisterShareRequest
+	10, // 22: shield.v1.ShareService.UpdateShare:input_type -> shield.v1.UpdateShareRequest
+	12, // 23: shield.v1.ShareService.DeleteShare:input_type -> shield.v1.DeleteShareRequest
+	14, // 24: shield.v1.KeychainService.GetKeychain:input_type -> shield.v1.GetKeychainRequest
+	16, // 25: shield.v1.KeychainService.GetKeychainMetadata:input_type -> shield.v1.GetKeychainMetadataRequest
+	19, // 26: shield.v1.KeychainService.RenameReference:input_type -> shield.v1.RenameReferenceRequest
+	7,  // 27: shield.v1.ShareService.GetShare:output_type -> shield.v1.GetShareResponse
+	9,  // 28: shield.v1.ShareService.RegisterShare:output_type -> shield.v1.RegisterShareResponse
+	11, // 29: shield.v1.ShareService.UpdateShare:output_type -> shield.v1.UpdateShareResponse
+	13, // 30: shield.v1.ShareService.DeleteShare:output_type -> shield.v1.DeleteShareResponse
+	15, // 31: shield.v1.KeychainService.GetKeychain:output_type -> shield.v1.GetKeychainResponse
+	17, // 32: shield.v1.KeychainService.GetKeychainMetadata:output_type -> shield.v1.GetKeychainMetadataResponse
+	20, // 33: shield.v1.KeychainService.RenameReference:output_type -> shield.v1.RenameReferenceResponse
+	27, // [27:34] is the sub-list for method output_type
+	20, // [20:27] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
+}
+
+func init() { file_shield_v1_share_proto_init() }
+func file_shield_v1_share_proto_init() {
+	if File_shield_v1_share_proto != nil {
+		return
+	}
+	file_shield_v1_share_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shield_v1_share_proto_rawDesc), len(file_shield_v1_share_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   22,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_shield_v1_share_proto_goTypes,
+		DependencyIndexes: file_shield_v1_share_proto_depIdxs,
+		EnumInfos:         file_shield_v1_share_proto_enumTypes,
+		MessageInfos:      file_shield_v1_share_proto_msgTypes,
+	}.Build()
+	File_shield_v1_share_proto = out.File
+	file_shield_v1_share_proto_goTypes = nil
+	file_shield_v1_share_proto_depIdxs = nil
+}
@@ -0,0 +1,449 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: shield/v1/share.proto
+
+package shieldv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ShareService_GetShare_FullMethodName      = "/shield.v1.ShareService/GetShare"
+	ShareService_RegisterShare_FullMethodName = "/shield.v1.ShareService/RegisterShare"
+	ShareService_UpdateShare_FullMethodName   = "/shield.v1.ShareService/UpdateShare"
+	ShareService_DeleteShare_FullMethodName   = "/shield.v1.ShareService/DeleteShare"
+)
+
+// ShareServiceClient is the client API for ShareService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ShareService manages the shares of the authenticated end user. Calls carry
+// the same credentials as the REST API in their metadata: x-api-key,
+// x-auth-provider, authorization and, for Openfort identities,
+// x-openfort-provider and x-openfort-token-type.
+type ShareServiceClient interface {
+	// GetShare returns the share with the given reference, or the default
+	// share when no reference is given.
+	GetShare(ctx context.Context, in *GetShareRequest, opts ...grpc.CallOption) (*GetShareResponse, error)
+	// RegisterShare stores a new share for the user.
+	RegisterShare(ctx context.Context, in *RegisterShareRequest, opts ...grpc.CallOption) (*RegisterShareResponse, error)
+	// UpdateShare replaces the share with the given reference.
+	UpdateShare(ctx context.Context, in *UpdateShareRequest, opts ...grpc.CallOption) (*UpdateShareResponse, error)
+	// DeleteShare deletes the share with the given reference, or the default
+	// share when no reference is given.
+	DeleteShare(ctx context.Context, in *DeleteShareRequest, opts ...grpc.CallOption) (*DeleteShareResponse, error)
+}
+
+type shareServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewShareServiceClient(cc grpc.ClientConnInterface) ShareServiceClient {
+	return &shareServiceClient{cc}
+}
+
+func (c *shareServiceClient) GetShare(ctx context.Context, in *GetShareRequest, opts ...grpc.CallOption) (*GetShareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetShareResponse)
+	err := c.cc.Invoke(ctx, ShareService_GetShare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shareServiceClient) RegisterShare(ctx context.Context, in *RegisterShareRequest, opts ...grpc.CallOption) (*RegisterShareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterShareResponse)
+	err := c.cc.Invoke(ctx, ShareService_RegisterShare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shareServiceClient) UpdateShare(ctx context.Context, in *UpdateShareRequest, opts ...grpc.CallOption) (*UpdateShareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateShareResponse)
+	err := c.cc.Invoke(ctx, ShareService_UpdateShare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shareServiceClient) DeleteShare(ctx context.Context, in *DeleteShareRequest, opts ...grpc.CallOption) (*DeleteShareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteShareResponse)
+	err := c.cc.Invoke(ctx, ShareService_DeleteShare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ShareServiceServer is the server API for ShareService service.
+// All implementations must embed UnimplementedShareServiceServer
+// for forward compatibility.
+//
+// ShareService manages the shares of the authenticated end user. Calls carry
+// the same credentials as the REST API in their metadata: x-api-key,
+// x-auth-provider, authorization and, for Openfort identities,
+// x-openfort-provider and x-openfort-token-type.
+type ShareServiceServer interface {
+	// GetShare returns the share with the given reference, or the default
+	// share when no reference is given.
+	GetShare(context.Context, *GetShareRequest) (*GetShareResponse, error)
+	// RegisterShare stores a new share for the user.
+	RegisterShare(context.Context, *RegisterShareRequest) (*RegisterShareResponse, error)
+	// UpdateShare replaces the share with the given reference.
+	UpdateShare(context.Context, *UpdateShareRequest) (*UpdateShareResponse, error)
+	// DeleteShare deletes the share with the given reference, or the default
+	// share when no reference is given.
+	DeleteShare(context.Context, *DeleteShareRequest) (*DeleteShareResponse, error)
+	mustEmbedUnimplementedShareServiceServer()
+}
+
+// UnimplementedShareServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedShareServiceServer struct{}
+
+func (UnimplementedShareServiceServer) GetShare(context.Context, *GetShareRequest) (*GetShareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShare not implemented")
+}
+func (UnimplementedShareServiceServer) RegisterShare(context.Context, *RegisterShareRequest) (*RegisterShareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterShare not implemented")
+}
+func (UnimplementedShareServiceServer) UpdateShare(context.Context, *UpdateShareRequest) (*UpdateShareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateShare not implemented")
+}
+func (UnimplementedShareServiceServer) DeleteShare(context.Context, *DeleteShareRequest) (*DeleteShareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteShare not implemented")
+}
+func (UnimplementedShareServiceServer) mustEmbedUnimplementedShareServiceServer() {}
+func (UnimplementedShareServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeShareServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ShareServiceServer will
+// result in compilation errors.
+type UnsafeShareServiceServer interface {
+	mustEmbedUnimplementedShareServiceServer()
+}
+
+func RegisterShareServiceServer(s grpc.ServiceRegistrar, srv ShareServiceServer) {
+	// If the following call pancis, it indicates UnimplementedShareServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ShareService_ServiceDesc, srv)
+}
+
+func _ShareService_GetShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShareServiceServer).GetShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShareService_GetShare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShareServiceServer).GetShare(ctx, req.(*GetShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShareService_RegisterShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShareServiceServer).RegisterShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShareService_RegisterShare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShareServiceServer).RegisterShare(ctx, req.(*RegisterShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShareService_UpdateShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShareServiceServer).UpdateShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShareService_UpdateShare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShareServiceServer).UpdateShare(ctx, req.(*UpdateShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShareService_DeleteShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShareServiceServer).DeleteShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShareService_DeleteShare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShareServiceServer).DeleteShare(ctx, req.(*DeleteShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ShareService_ServiceDesc is the grpc.ServiceDesc for ShareService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ShareService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "shield.v1.ShareService",
+	HandlerType: (*ShareServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetShare",
+			Handler:    _ShareService_GetShare_Handler,
+		},
+		{
+			MethodName: "RegisterShare",
+			Handler:    _ShareService_RegisterShare_Handler,
+		},
+		{
+			MethodName: "UpdateShare",
+			Handler:    _ShareService_UpdateShare_Handler,
+		},
+		{
+			MethodName: "DeleteShare",
+			Handler:    _ShareService_DeleteShare_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "shield/v1/share.proto",
+}
+
+const (
+	KeychainService_GetKeychain_FullMethodName         = "/shield.v1.KeychainService/GetKeychain"
+	KeychainService_GetKeychainMetadata_FullMethodName = "/shield.v1.KeychainService/GetKeychainMetadata"
+	KeychainService_RenameReference_FullMethodName     = "/shield.v1.KeychainService/RenameReference"
+)
+
+// KeychainServiceClient is the client API for KeychainService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// KeychainService reads and organizes the keychain of the authenticated end
+// user, it takes the same credentials as ShareService.
+type KeychainServiceClient interface {
+	// GetKeychain returns the shares of the user's keychain.
+	GetKeychain(ctx context.Context, in *GetKeychainRequest, opts ...grpc.CallOption) (*GetKeychainResponse, error)
+	// GetKeychainMetadata describes the keychain and its shares without their
+	// secrets.
+	GetKeychainMetadata(ctx context.Context, in *GetKeychainMetadataRequest, opts ...grpc.CallOption) (*GetKeychainMetadataResponse, error)
+	// RenameReference renames one of the references of the keychain.
+	RenameReference(ctx context.Context, in *RenameReferenceRequest, opts ...grpc.CallOption) (*RenameReferenceResponse, error)
+}
+
+type keychainServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewKeychainServiceClient(cc grpc.ClientConnInterface) KeychainServiceClient {
+	return &keychainServiceClient{cc}
+}
+
+func (c *keychainServiceClient) GetKeychain(ctx context.Context, in *GetKeychainRequest, opts ...grpc.CallOption) (*GetKeychainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetKeychainResponse)
+	err := c.cc.Invoke(ctx, KeychainService_GetKeychain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keychainServiceClient) GetKeychainMetadata(ctx context.Context, in *GetKeychainMetadataRequest, opts ...grpc.CallOption) (*GetKeychainMetadataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetKeychainMetadataResponse)
+	err := c.cc.Invoke(ctx, KeychainService_GetKeychainMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keychainServiceClient) RenameReference(ctx context.Context, in *RenameReferenceRequest, opts ...grpc.CallOption) (*RenameReferenceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenameReferenceResponse)
+	err := c.cc.Invoke(ctx, KeychainService_RenameReference_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// KeychainServiceServer is the server API for KeychainService service.
+// All implementations must embed UnimplementedKeychainServiceServer
+// for forward compatibility.
+//
+// KeychainService reads and organizes the keychain of the authenticated end
+// user, it takes the same credentials as ShareService.
+type KeychainServiceServer interface {
+	// GetKeychain returns the shares of the user's keychain.
+	GetKeychain(context.Context, *GetKeychainRequest) (*GetKeychainResponse, error)
+	// GetKeychainMetadata describes the keychain and its shares without their
+	// secrets.
+	GetKeychainMetadata(context.Context, *GetKeychainMetadataRequest) (*GetKeychainMetadataResponse, error)
+	// RenameReference renames one of the references of the keychain.
+	RenameReference(context.Context, *RenameReferenceRequest) (*RenameReferenceResponse, error)
+	mustEmbedUnimplementedKeychainServiceServer()
+}
+
+// UnimplementedKeychainServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedKeychainServiceServer struct{}
+
+func (UnimplementedKeychainServiceServer) GetKeychain(context.Context, *GetKeychainRequest) (*GetKeychainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKeychain not implemented")
+}
+func (UnimplementedKeychainServiceServer) GetKeychainMetadata(context.Context, *GetKeychainMetadataRequest) (*GetKeychainMetadataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKeychainMetadata not implemented")
+}
+func (UnimplementedKeychainServiceServer) RenameReference(context.Context, *RenameReferenceRequest) (*RenameReferenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameReference not implemented")
+}
+func (UnimplementedKeychainServiceServer) mustEmbedUnimplementedKeychainServiceServer() {}
+func (UnimplementedKeychainServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeKeychainServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to KeychainServiceServer will
+// result in compilation errors.
+type UnsafeKeychainServiceServer interface {
+	mustEmbedUnimplementedKeychainServiceServer()
+}
+
+func RegisterKeychainServiceServer(s grpc.ServiceRegistrar, srv KeychainServiceServer) {
+	// If the following call pancis, it indicates UnimplementedKeychainServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&KeychainService_ServiceDesc, srv)
+}
+
+func _KeychainService_GetKeychain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKeychainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeychainServiceServer).GetKeychain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeychainService_GetKeychain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeychainServiceServer).GetKeychain(ctx, req.(*GetKeychainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeychainService_GetKeychainMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKeychainMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeychainServiceServer).GetKeychainMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeychainService_GetKeychainMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeychainServiceServer).GetKeychainMetadata(ctx, req.(*GetKeychainMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeychainService_RenameReference_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameReferenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeychainServiceServer).RenameReference(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeychainService_RenameReference_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeychainServiceServer).RenameReference(ctx, req.(*RenameReferenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// KeychainService_ServiceDesc is the grpc.ServiceDesc for KeychainService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var KeychainService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "shield.v1.KeychainService",
+	HandlerType: (*KeychainServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetKeychain",
+			Handler:    _KeychainService_GetKeychain_Handler,
+		},
+		{
+			MethodName: "GetKeychainMetadata",
+			Handler:    _KeychainService_GetKeychainMetadata_Handler,
+		},
+		{
+			MethodName: "RenameReference",
+			Handler:    _KeychainService_RenameReference_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "shield/v1/share.proto",
+}
@@ -0,0 +1,26 @@
+package signing
+
+import "testing"
+
+func TestVerify(t *testing.T) {
+	key := []byte("signing_key")
+	bodyHash := BodyHash([]byte("{}"))
+	sig := Sign(key, "post", "/shares?reference=ref", bodyHash, 1760875200, "nonce")
+
+	if !Verify(key, sig, "POST", "/shares?reference=ref", bodyHash, 1760875200, "nonce") {
+		t.Fatal("expected signature to verify, method case shouldn't matter")
+	}
+
+	cases := map[string]bool{
+		"wrong key":  Verify([]byte("other"), sig, "POST", "/shares?reference=ref", bodyHash, 1760875200, "nonce"),
+		"other path": Verify(key, sig, "POST", "/shares?reference=other", bodyHash, 1760875200, "nonce"),
+		"other body": Verify(key, sig, "POST", "/shares?reference=ref", BodyHash(nil), 1760875200, "nonce"),
+		"other time": Verify(key, sig, "POST", "/shares?reference=ref", bodyHash, 1760875201, "nonce"),
+		"not hex":    Verify(key, "zz", "POST", "/shares?reference=ref", bodyHash, 1760875200, "nonce"),
+	}
+	for name, ok := range cases {
+		if ok {
+			t.Errorf("%s: expected signature to be rejected", name)
+		}
+	}
+}